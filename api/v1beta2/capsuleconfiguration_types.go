@@ -62,6 +62,13 @@ type CapsuleConfigurationSpec struct {
 	// Event (Audit) Configuration
 	// +kubebuilder:default={namespace:default}
 	Events EventsConfiguration `json:"events,omitempty"`
+	// Price table used to accumulate the costs of the tenants from their allocated and used resources.
+	// Costs are only accumulated when the price table is defined.
+	// +optional
+	Chargeback *ChargebackConfiguration `json:"chargeback,omitempty"`
+	// Restricts the locations charts are replicated from by TenantResources.
+	// +optional
+	Charts *ChartsConfiguration `json:"charts,omitempty"`
 
 	// Deprecated: use users property instead (https://projectcapsule.dev/docs/operating/setup/configuration/#users)
 	//
@@ -88,6 +95,16 @@ type RBACConfiguration struct {
 	ProvisionerClusterRole string `json:"provisioner,omitempty"`
 }
 
+type ChartsConfiguration struct {
+	// Helm repositories and OCI registries namespaced TenantResources are allowed to replicate charts from,
+	// with or without their scheme (eg. oci://registry.example.com/charts, https://charts.example.com).
+	// Locations below an allowed one are allowed as well, including the download URLs published by repository indexes
+	// and the redirects followed while downloading. Namespaced TenantResources cannot replicate charts when none is allowed,
+	// while GlobalTenantResources are not restricted.
+	// +optional
+	AllowedRepositories []string `json:"allowedRepositories,omitempty"`
+}
+
 type EventsConfiguration struct {
 	// Namespace where the events are logged for cluster scoped resources or deny events (default namespace)
 	// +kubebuilder:default=default
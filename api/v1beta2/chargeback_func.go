@@ -0,0 +1,55 @@
+// Copyright 2020-2026 Project Capsule Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta2
+
+import (
+	"math"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// Gets the price of the given resource, false if the resource has no price.
+func (in *ChargebackConfiguration) GetPrice(name corev1.ResourceName) (ChargebackPrice, bool) {
+	for _, price := range in.Prices {
+		if price.Resource == name {
+			return price, true
+		}
+	}
+
+	return ChargebackPrice{}, false
+}
+
+// Calculates the cost of the quantity over the given duration. Costs are rounded to nano units.
+func (in ChargebackPrice) Cost(quantity resource.Quantity, duration time.Duration) resource.Quantity {
+	unit := in.Unit.AsApproximateFloat64()
+	if unit <= 0 {
+		unit = 1
+	}
+
+	cost := quantity.AsApproximateFloat64() / unit * in.Price.AsApproximateFloat64() * duration.Hours()
+	if cost <= 0 {
+		return resource.Quantity{Format: resource.DecimalSI}
+	}
+
+	return *resource.NewScaledQuantity(int64(math.Round(cost*1e9)), resource.Nano)
+}
+
+// Calculates the costs of the sampled resources over the given duration. Resources without a price
+// are not charged.
+func (in *ChargebackConfiguration) Costs(sample corev1.ResourceList, duration time.Duration) corev1.ResourceList {
+	costs := corev1.ResourceList{}
+
+	for name, quantity := range sample {
+		price, ok := in.GetPrice(name)
+		if !ok {
+			continue
+		}
+
+		costs[name] = price.Cost(quantity, duration)
+	}
+
+	return costs
+}
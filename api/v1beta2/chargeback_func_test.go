@@ -0,0 +1,117 @@
+// Copyright 2020-2026 Project Capsule Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta2_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+)
+
+func TestChargebackCosts(t *testing.T) {
+	t.Parallel()
+
+	cfg := &capsulev1beta2.ChargebackConfiguration{
+		Currency: "USD",
+		Prices: []capsulev1beta2.ChargebackPrice{
+			{
+				Resource: corev1.ResourceRequestsCPU,
+				Price:    resource.MustParse("0.04"),
+			},
+			{
+				Resource: corev1.ResourceRequestsMemory,
+				Unit:     resource.MustParse("1Gi"),
+				Price:    resource.MustParse("0.01"),
+			},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		sample   corev1.ResourceList
+		duration time.Duration
+		expected map[corev1.ResourceName]string
+	}{
+		{
+			name: "Charges resources with a price",
+			sample: corev1.ResourceList{
+				corev1.ResourceRequestsCPU:    resource.MustParse("500m"),
+				corev1.ResourceRequestsMemory: resource.MustParse("4Gi"),
+			},
+			duration: 30 * time.Minute,
+			expected: map[corev1.ResourceName]string{
+				corev1.ResourceRequestsCPU:    "10m",
+				corev1.ResourceRequestsMemory: "20m",
+			},
+		},
+		{
+			name: "Ignores resources without a price",
+			sample: corev1.ResourceList{
+				corev1.ResourceLimitsCPU: resource.MustParse("2"),
+			},
+			duration: time.Hour,
+			expected: map[corev1.ResourceName]string{},
+		},
+		{
+			name: "Rounds to nano units",
+			sample: corev1.ResourceList{
+				corev1.ResourceRequestsCPU: resource.MustParse("1m"),
+			},
+			duration: time.Second,
+			expected: map[corev1.ResourceName]string{
+				corev1.ResourceRequestsCPU: "11n",
+			},
+		},
+		{
+			name: "No costs without duration",
+			sample: corev1.ResourceList{
+				corev1.ResourceRequestsCPU: resource.MustParse("2"),
+			},
+			duration: 0,
+			expected: map[corev1.ResourceName]string{
+				corev1.ResourceRequestsCPU: "0",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			costs := cfg.Costs(tt.sample, tt.duration)
+
+			assert.Len(t, costs, len(tt.expected))
+
+			for name, expected := range tt.expected {
+				cost := costs[name]
+				assert.Equal(t, expected, cost.String(), name)
+			}
+		})
+	}
+}
+
+func TestChargebackGetPrice(t *testing.T) {
+	t.Parallel()
+
+	cfg := &capsulev1beta2.ChargebackConfiguration{
+		Prices: []capsulev1beta2.ChargebackPrice{
+			{
+				Resource: corev1.ResourceRequestsStorage,
+				Price:    resource.MustParse("0.0001"),
+			},
+		},
+	}
+
+	price, ok := cfg.GetPrice(corev1.ResourceRequestsStorage)
+	assert.True(t, ok)
+	assert.Equal(t, "100u", price.Price.String())
+
+	_, ok = cfg.GetPrice(corev1.ResourceRequestsCPU)
+	assert.False(t, ok)
+}
@@ -0,0 +1,65 @@
+// Copyright 2020-2026 Project Capsule Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta2
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ChargebackConfiguration defines the price table used to accumulate the costs of the tenants.
+type ChargebackConfiguration struct {
+	// Currency the prices are expressed in. Only used for reporting.
+	// +kubebuilder:default=USD
+	Currency string `json:"currency,omitempty"`
+	// Interval in which the allocated and used resources of the tenants are sampled.
+	// Costs are accumulated between two samples.
+	// +kubebuilder:default="5m"
+	Interval metav1.Duration `json:"interval,omitzero"`
+	// Prices of the resources. Resources without a price are reported but not charged.
+	// +listType=map
+	// +listMapKey=resource
+	Prices []ChargebackPrice `json:"prices,omitempty"`
+}
+
+type ChargebackPrice struct {
+	// Name of the resource as used in resourcequotas (eg. requests.cpu, limits.memory, requests.storage).
+	Resource corev1.ResourceName `json:"resource"`
+	// Quantity of the resource the price refers to (eg. 1Gi for memory).
+	// +kubebuilder:default="1"
+	Unit resource.Quantity `json:"unit,omitzero"`
+	// Cost of one unit of the resource per hour.
+	Price resource.Quantity `json:"price"`
+}
+
+// TenantChargebackStatus summarizes the accumulated costs of a tenant.
+type TenantChargebackStatus struct {
+	// Currency the costs are expressed in.
+	Currency string `json:"currency,omitempty"`
+	// Time since when the costs are accumulated.
+	Since metav1.Time `json:"since,omitzero"`
+	// Time of the last sample.
+	LastSampleTime metav1.Time `json:"lastSampleTime,omitzero"`
+	// Resources allocated to the tenant at the last sample. Allocated resources are
+	// the bound resourcepool claims and the resourcequotas of the tenant.
+	// +optional
+	Allocated corev1.ResourceList `json:"allocated,omitempty"`
+	// Resources used by the tenant at the last sample, as observed by the resourcequotas
+	// and globalresourcequotas in the namespaces of the tenant.
+	// +optional
+	Used corev1.ResourceList `json:"used,omitempty"`
+	// Accumulated costs of the allocated resources per resource.
+	// +optional
+	AllocatedCost corev1.ResourceList `json:"allocatedCost,omitempty"`
+	// Accumulated costs of the used resources per resource.
+	// +optional
+	UsedCost corev1.ResourceList `json:"usedCost,omitempty"`
+	// Accumulated costs of all allocated resources.
+	// +optional
+	TotalAllocatedCost resource.Quantity `json:"totalAllocatedCost,omitzero"`
+	// Accumulated costs of all used resources.
+	// +optional
+	TotalUsedCost resource.Quantity `json:"totalUsedCost,omitzero"`
+}
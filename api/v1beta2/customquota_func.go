@@ -3,6 +3,51 @@
 
 package v1beta2
 
+import (
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// Returns true if the quota counts admissions within a rolling time window.
+func (c *CustomQuotaSpec) IsWindowed() bool {
+	return c.Window != nil && c.Window.Duration > 0
+}
+
+// Returns the rolling time window of the quota, zero if the quota is not windowed.
+func (c *CustomQuotaSpec) GetWindow() time.Duration {
+	if !c.IsWindowed() {
+		return 0
+	}
+
+	return c.Window.Duration
+}
+
+// Returns the usage from which admissions are warned about, nil if the quota has no valid threshold.
+func (c *CustomQuotaSpec) GetWarnAt() *resource.Quantity {
+	if c.WarnAt == "" {
+		return nil
+	}
+
+	warnAt, err := c.WarnAt.Resolve(c.Limit)
+	if err != nil {
+		return nil
+	}
+
+	return &warnAt
+}
+
+// Returns a copy of the soft limit, nil if the quota has no soft limit.
+func (c *CustomQuotaSpec) GetSoftLimit() *resource.Quantity {
+	if c.SoftLimit == nil {
+		return nil
+	}
+
+	softLimit := c.SoftLimit.DeepCopy()
+
+	return &softLimit
+}
+
 func (c *CustomQuotaSpec) CollectJSONPathExpressions() (expressions []string) {
 	set := map[string]struct{}{}
 
@@ -62,4 +62,7 @@ type CustomQuotaStatusUsage struct {
 	// Used is the current observed total available of the resource (limit - used).
 	// +optional
 	Available resource.Quantity `json:"available"`
+	// Values are the distinct values counted by quotas using the distinct operation.
+	// +optional
+	Values []string `json:"values,omitempty"`
 }
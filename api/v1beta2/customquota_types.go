@@ -7,6 +7,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	"github.com/projectcapsule/capsule/pkg/api"
 	"github.com/projectcapsule/capsule/pkg/api/runtime"
 	"github.com/projectcapsule/capsule/pkg/runtime/quota"
 	"github.com/projectcapsule/capsule/pkg/runtime/selectors"
@@ -18,8 +19,26 @@ type CustomQuotaSpec struct {
 	ScopeSelectors []metav1.LabelSelector `json:"scopeSelectors,omitempty"`
 	// Resource Quantity as limit
 	Limit resource.Quantity `json:"limit"`
+	// Soft limit below the limit. Admissions may overrun the soft limit, but each admission doing so
+	// is admitted with a warning and recorded as an event.
+	// +optional
+	SoftLimit *resource.Quantity `json:"softLimit,omitempty"`
+	// Threshold from which admissions are admitted with a warning and recorded as an event, either
+	// a percentage of the limit (eg. 80%) or a quantity (eg. 8Gi). The NearLimit condition reports
+	// whether the usage reached the threshold.
+	// +optional
+	WarnAt api.QuotaThreshold `json:"warnAt,omitempty"`
 	// Target resource
 	Sources []CustomQuotaSpecSource `json:"sources,omitzero"`
+	// Rolling time window in which admissions are counted (eg. 1h, 24h). When set, the usage of the quota is the
+	// usage of the objects created within the window instead of the usage of the existing objects. Updates and
+	// deletions of objects do not release usage, it's released once the admission leaves the window.
+	// Admissions are tracked in the QuantityLedger of the quota, which holds at most 1024 admissions, so every
+	// windowed quota admits at most 1024 admissions within the window and denies further admissions until earlier
+	// admissions leave the window, whatever the operation of its sources. Windowed quotas which only count objects
+	// can't have a limit above 1024.
+	// +optional
+	Window *metav1.Duration `json:"window,omitempty"`
 	// Additional Options for the CustomQuotaSpecification
 	// +kubebuilder:default:={emitMetricPerClaimUsage:false}
 	Options *CustomQuotaOptionsSpec `json:"options,omitzero"`
@@ -49,7 +68,7 @@ type CustomQuotaSpecSourceConfig struct {
 	// CEL expression evaluated against the source object.
 	// The object is available as "object".
 	// Must evaluate to kubernetes.Quantity or list<kubernetes.Quantity>.
-	// Mutually exclusive with path and must be empty when op is "count".
+	// Mutually exclusive with path and must be empty when op is "count" or "distinct".
 	// +kubebuilder:validation:MaxLength=4096
 	// +optional
 	CEL string `json:"cel,omitempty"`
@@ -54,6 +54,27 @@ func (q *GlobalResourceQuota) CalculateAvailable() {
 	q.Status.Total.Available = available
 }
 
+// Resolves the warning threshold against the hard limits of the quota, nil if the quota has no
+// valid threshold.
+func (q *GlobalResourceQuota) GetWarnAt() corev1.ResourceList {
+	if q.Spec.WarnAt == "" {
+		return nil
+	}
+
+	warnAt := make(corev1.ResourceList, len(q.Spec.Quota.Hard))
+
+	for name, hard := range q.Spec.Quota.Hard {
+		threshold, err := q.Spec.WarnAt.Resolve(hard)
+		if err != nil {
+			return nil
+		}
+
+		warnAt[name] = threshold
+	}
+
+	return warnAt
+}
+
 func ZeroResourceList(resources corev1.ResourceList) corev1.ResourceList {
 	out := make(corev1.ResourceList, len(resources))
 	for name := range resources {
@@ -7,6 +7,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	"github.com/projectcapsule/capsule/pkg/api"
 	"github.com/projectcapsule/capsule/pkg/runtime/selectors"
 )
 
@@ -21,6 +22,18 @@ type GlobalResourceQuotaSpec struct {
 	// Quota is the native Kubernetes ResourceQuota specification enforced
 	// across the selected namespaces.
 	Quota corev1.ResourceQuotaSpec `json:"quota"`
+
+	// Soft limits below the hard limits of the quota. Admissions may overrun a soft limit, but each
+	// admission doing so is admitted with a warning and recorded as an event.
+	// +optional
+	Soft corev1.ResourceList `json:"soft,omitempty"`
+
+	// Percentage of the hard limits (eg. 80%) from which admissions are admitted with a warning and
+	// recorded as an event. The NearLimit condition reports whether the usage of any resource reached
+	// the threshold.
+	// +kubebuilder:validation:Pattern=`^([1-9][0-9]?|100)%$`
+	// +optional
+	WarnAt api.QuotaThreshold `json:"warnAt,omitempty"`
 }
 
 // +kubebuilder:object:root=true
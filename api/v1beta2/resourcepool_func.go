@@ -5,7 +5,10 @@ package v1beta2
 
 import (
 	"errors"
+	"fmt"
+	"reflect"
 	"sort"
+	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -17,6 +20,43 @@ func (r *ResourcePool) GetQuotaName() string {
 	return meta.NameForManagedPoolResourceQuota(r.GetName())
 }
 
+// Gets the name of the resourcequota provisioned for the given scope. The empty scope refers to the
+// quota of the pool.
+func (r *ResourcePool) GetScopedQuotaName(scope string) string {
+	if scope == "" {
+		return r.GetQuotaName()
+	}
+
+	return meta.NameForManagedPoolScopedResourceQuota(r.GetName(), scope)
+}
+
+// Gets the scope with the given name, nil if the pool does not define the scope.
+func (r *ResourcePool) GetScope(name string) *ResourcePoolScope {
+	for i := range r.Spec.Scopes {
+		if r.Spec.Scopes[i].Name == name {
+			return &r.Spec.Scopes[i]
+		}
+	}
+
+	return nil
+}
+
+// Gets the scope matching the scope selector of the claim. Claims without scope selector claim from
+// the quota of the pool, in which case no scope is returned.
+func (r *ResourcePool) GetScopeForClaim(claim *ResourcePoolClaim) (*ResourcePoolScope, error) {
+	if claim.Spec.ScopeSelector == nil {
+		return nil, nil
+	}
+
+	for i := range r.Spec.Scopes {
+		if equalScopeSelectors(r.Spec.Scopes[i].ScopeSelector, *claim.Spec.ScopeSelector) {
+			return &r.Spec.Scopes[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("no scope of pool %s matches the scopeSelector of the claim", r.Name)
+}
+
 func (r *ResourcePool) AssignNamespaces(namespaces []corev1.Namespace) {
 	var l []string
 
@@ -83,7 +123,11 @@ func (r *ResourcePool) AddClaimToStatus(claim *ResourcePoolClaim) {
 			Name:      meta.RFC1123Name(claim.Name),
 			Namespace: meta.RFC1123SubdomainName(claim.Namespace),
 		},
-		Claims: claim.Spec.ResourceClaims,
+		Claims: claim.GetResourceClaims(),
+	}
+
+	if scope, _ := r.GetScopeForClaim(claim); scope != nil {
+		scl.Scope = scope.Name
 	}
 
 	// Try to update existing entry if UID matches
@@ -136,27 +180,68 @@ func (r *ResourcePool) CalculateClaimedResources() {
 		usage[res] = resource.MustParse("0")
 	}
 
+	scoped := make(map[string]corev1.ResourceList, len(r.Spec.Scopes))
+
+	for _, scope := range r.Spec.Scopes {
+		scoped[scope.Name] = corev1.ResourceList{}
+
+		for res := range scope.Hard {
+			scoped[scope.Name][res] = resource.MustParse("0")
+		}
+	}
+
 	for _, claims := range r.Status.Claims {
 		for _, claim := range claims {
+			target := usage
+
+			// Scoped claims are tracked against the budget of their scope
+			if claim.Scope != "" {
+				if _, ok := scoped[claim.Scope]; !ok {
+					scoped[claim.Scope] = corev1.ResourceList{}
+				}
+
+				target = scoped[claim.Scope]
+			}
+
 			for resourceName, qt := range claim.Claims {
-				amount, exists := usage[resourceName]
+				amount, exists := target[resourceName]
 				if !exists {
 					amount = resource.MustParse("0")
 				}
 
 				amount.Add(qt)
-				usage[resourceName] = amount
+				target[resourceName] = amount
 			}
 		}
 	}
 
 	r.Status.Allocation.Claimed = usage
 
+	r.Status.Scopes = nil
+
+	for name, claimed := range scoped {
+		if r.Status.Scopes == nil {
+			r.Status.Scopes = make(map[string]ResourcePoolQuotaStatus, len(scoped))
+		}
+
+		status := ResourcePoolQuotaStatus{
+			Claimed: claimed,
+		}
+
+		if scope := r.GetScope(name); scope != nil {
+			status.Hard = scope.Hard.DeepCopy()
+			status.Available = subtractResources(scope.Hard, claimed)
+		}
+
+		r.Status.Scopes[name] = status
+	}
+
 	r.CalculateAvailableResources()
 }
 
 func (r *ResourcePool) CalculateAvailableResources() {
 	available := corev1.ResourceList{}
+	borrowed := corev1.ResourceList{}
 
 	for res, qt := range r.Status.Allocation.Hard {
 		amount, exists := r.Status.Allocation.Claimed[res]
@@ -165,9 +250,78 @@ func (r *ResourcePool) CalculateAvailableResources() {
 		}
 
 		available[res] = qt
+
+		// Claims beyond the hard resources are borrowed from the cohort
+		if r.Spec.Cohort != "" && qt.Sign() < 0 {
+			amount := qt.DeepCopy()
+			amount.Neg()
+
+			borrowed[res] = amount
+		}
 	}
 
 	r.Status.Allocation.Available = available
+
+	r.Status.Borrowed = nil
+	if len(borrowed) != 0 {
+		r.Status.Borrowed = borrowed
+	}
+}
+
+// Gets the resources the pool contributes to its cohort. Unused resources are reduced by the resources
+// requested by exhausted claims of the pool, as the pool requires them itself. Borrowed resources are
+// negative.
+func (r *ResourcePool) GetLendableResources() corev1.ResourceList {
+	lendable := r.GetAvailableClaimableResources()
+
+	for resourceName, qt := range lendable {
+		ex, ok := r.Status.Exhaustions[resourceName.String()]
+		if !ok || qt.Sign() <= 0 {
+			continue
+		}
+
+		if ex.Requesting.Cmp(qt) >= 0 {
+			lendable[resourceName] = resource.MustParse("0")
+
+			continue
+		}
+
+		qt.Sub(ex.Requesting)
+		lendable[resourceName] = qt
+	}
+
+	return lendable
+}
+
+// Gets the resources which can be claimed from the pool, when the other pools of the cohort contribute the
+// given lendable resources. Resources beyond the available resources of the pool are limited by the
+// borrowing limit.
+func (r *ResourcePool) GetCohortClaimableResources(lendable corev1.ResourceList) corev1.ResourceList {
+	claimable := r.GetAvailableClaimableResources()
+
+	if r.Spec.Cohort == "" {
+		return claimable
+	}
+
+	for resourceName, own := range claimable {
+		balance := own.DeepCopy()
+		if qt, ok := lendable[resourceName]; ok {
+			balance.Add(qt)
+		}
+
+		limit := own.DeepCopy()
+		if qt, ok := r.Spec.BorrowingLimit[resourceName]; ok {
+			limit.Add(qt)
+		}
+
+		if balance.Cmp(limit) < 0 {
+			claimable[resourceName] = balance
+		} else {
+			claimable[resourceName] = limit
+		}
+	}
+
+	return claimable
 }
 
 func (r *ResourcePool) CanClaimFromPool(claim corev1.ResourceList) []error {
@@ -185,20 +339,53 @@ func (r *ResourcePool) CanClaimFromPool(claim corev1.ResourceList) []error {
 }
 
 func (r *ResourcePool) GetAvailableClaimableResources() corev1.ResourceList {
-	hard := r.Status.Allocation.Hard.DeepCopy()
+	return subtractResources(r.Status.Allocation.Hard, r.Status.Allocation.Claimed)
+}
+
+// Gets the resources which can be claimed for the given scope. The empty scope refers to the quota
+// of the pool.
+func (r *ResourcePool) GetAvailableClaimableScopeResources(scope string) corev1.ResourceList {
+	if scope == "" {
+		return r.GetAvailableClaimableResources()
+	}
+
+	budget := r.GetScope(scope)
+	if budget == nil {
+		return corev1.ResourceList{}
+	}
+
+	return subtractResources(budget.Hard, r.Status.Scopes[scope].Claimed)
+}
+
+// Gets the hard resources of the given scope. The empty scope refers to the quota of the pool.
+func (r *ResourcePool) GetScopeHardResources(scope string) corev1.ResourceList {
+	if scope == "" {
+		return r.Status.Allocation.Hard
+	}
 
-	for resourceName, qt := range hard {
-		claimed, exists := r.Status.Allocation.Claimed[resourceName]
+	if budget := r.GetScope(scope); budget != nil {
+		return budget.Hard
+	}
+
+	return nil
+}
+
+// Subtracts the used resources from the hard resources, for each resource of the hard resources.
+func subtractResources(hard corev1.ResourceList, used corev1.ResourceList) corev1.ResourceList {
+	available := hard.DeepCopy()
+
+	for resourceName, qt := range available {
+		claimed, exists := used[resourceName]
 		if !exists {
 			claimed = resource.MustParse("0")
 		}
 
 		qt.Sub(claimed)
 
-		hard[resourceName] = qt
+		available[resourceName] = qt
 	}
 
-	return hard
+	return available
 }
 
 // Gets the Hard specification for the resourcequotas
@@ -225,8 +412,37 @@ func (r *ResourcePool) GetResourceQuotaHardResources(namespace string) corev1.Re
 	return claimed
 }
 
+// Gets the Hard specification for the scoped resourcequota of the given scope. Resources of the scope
+// which are not claimed in the namespace are set to zero, so they can not be used without a claim.
+// The empty scope refers to the quota of the pool.
+func (r *ResourcePool) GetScopedResourceQuotaHardResources(namespace string, scope string) corev1.ResourceList {
+	if scope == "" {
+		return r.GetResourceQuotaHardResources(namespace)
+	}
+
+	_, claimed := r.GetNamespaceScopeClaims(namespace, scope)
+
+	if budget := r.GetScope(scope); budget != nil {
+		for resourceName := range budget.Hard {
+			if _, ok := claimed[resourceName]; !ok {
+				claimed[resourceName] = resource.MustParse("0")
+			}
+		}
+	}
+
+	return claimed
+}
+
 // Gets the total amount of claimed resources for a namespace.
 func (r *ResourcePool) GetNamespaceClaims(namespace string) (claims map[string]*ResourcePoolClaimsItem, claimedResources corev1.ResourceList) {
+	return r.GetNamespaceScopeClaims(namespace, "")
+}
+
+// Gets the total amount of claimed resources for a scope of a namespace.
+func (r *ResourcePool) GetNamespaceScopeClaims(
+	namespace string,
+	scope string,
+) (claims map[string]*ResourcePoolClaimsItem, claimedResources corev1.ResourceList) {
 	claimedResources = corev1.ResourceList{}
 	claims = map[string]*ResourcePoolClaimsItem{}
 
@@ -237,6 +453,10 @@ func (r *ResourcePool) GetNamespaceClaims(namespace string) (claims map[string]*
 		}
 
 		for _, claim := range cl {
+			if claim.Scope != scope {
+				continue
+			}
+
 			for resourceName, claimed := range claim.Claims {
 				usedValue, usedExists := claimedResources[resourceName]
 				if !usedExists {
@@ -279,3 +499,41 @@ func (r *ResourcePool) GetClaimedByNamespaceClaims() (claims map[string]corev1.R
 
 	return claims
 }
+
+// Compares scope selectors regardless of the order of their requirements and values.
+func equalScopeSelectors(a, b corev1.ScopeSelector) bool {
+	return reflect.DeepEqual(normalizeScopeSelector(a), normalizeScopeSelector(b))
+}
+
+func normalizeScopeSelector(selector corev1.ScopeSelector) []corev1.ScopedResourceSelectorRequirement {
+	requirements := make([]corev1.ScopedResourceSelectorRequirement, 0, len(selector.MatchExpressions))
+
+	for _, req := range selector.MatchExpressions {
+		values := append([]string{}, req.Values...)
+		sort.Strings(values)
+
+		if len(values) == 0 {
+			values = nil
+		}
+
+		requirements = append(requirements, corev1.ScopedResourceSelectorRequirement{
+			ScopeName: req.ScopeName,
+			Operator:  req.Operator,
+			Values:    values,
+		})
+	}
+
+	sort.Slice(requirements, func(i, j int) bool {
+		if requirements[i].ScopeName != requirements[j].ScopeName {
+			return requirements[i].ScopeName < requirements[j].ScopeName
+		}
+
+		if requirements[i].Operator != requirements[j].Operator {
+			return requirements[i].Operator < requirements[j].Operator
+		}
+
+		return strings.Join(requirements[i].Values, ",") < strings.Join(requirements[j].Values, ",")
+	})
+
+	return requirements
+}
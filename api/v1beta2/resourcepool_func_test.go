@@ -13,6 +13,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 
 	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	"github.com/projectcapsule/capsule/pkg/api"
 	"github.com/projectcapsule/capsule/pkg/api/meta"
 )
 
@@ -189,6 +190,181 @@ func TestCanClaimFromPool(t *testing.T) {
 	assert.Len(t, errs, 0)
 }
 
+func TestCohortResources(t *testing.T) {
+	gpu := corev1.ResourceName("requests.nvidia.com/gpu")
+
+	borrower := &capsulev1beta2.ResourcePool{
+		Spec: capsulev1beta2.ResourcePoolSpec{
+			Cohort: "department",
+			BorrowingLimit: corev1.ResourceList{
+				gpu: resource.MustParse("4"),
+			},
+		},
+		Status: capsulev1beta2.ResourcePoolStatus{
+			Allocation: capsulev1beta2.ResourcePoolQuotaStatus{
+				Hard: corev1.ResourceList{
+					gpu:                      resource.MustParse("2"),
+					corev1.ResourceLimitsCPU: resource.MustParse("8"),
+				},
+			},
+			Claims: capsulev1beta2.ResourcePoolNamespaceClaimsStatus{
+				"ns": {
+					&capsulev1beta2.ResourcePoolClaimsItem{
+						Claims: corev1.ResourceList{
+							gpu: resource.MustParse("3"),
+						},
+					},
+				},
+			},
+		},
+	}
+
+	borrower.CalculateClaimedResources()
+
+	borrowed := borrower.Status.Borrowed[gpu]
+	assert.Equal(t, 0, (&borrowed).Cmp(resource.MustParse("1")))
+	assert.NotContains(t, borrower.Status.Borrowed, corev1.ResourceLimitsCPU)
+
+	lendable := borrower.GetLendableResources()
+	borrowedLendable := lendable[gpu]
+	assert.Equal(t, 0, (&borrowedLendable).Cmp(resource.MustParse("-1")))
+
+	lender := &capsulev1beta2.ResourcePool{
+		Spec: capsulev1beta2.ResourcePoolSpec{
+			Cohort: "department",
+		},
+		Status: capsulev1beta2.ResourcePoolStatus{
+			Allocation: capsulev1beta2.ResourcePoolQuotaStatus{
+				Hard: corev1.ResourceList{
+					gpu: resource.MustParse("8"),
+				},
+				Claimed: corev1.ResourceList{
+					gpu: resource.MustParse("2"),
+				},
+			},
+			Exhaustions: map[string]api.PoolExhaustionResource{
+				gpu.String(): {Requesting: resource.MustParse("1")},
+			},
+		},
+	}
+
+	// Unused resources requested by exhausted claims are not lent
+	lenderLendable := lender.GetLendableResources()[gpu]
+	assert.Equal(t, 0, (&lenderLendable).Cmp(resource.MustParse("5")))
+
+	// Borrowing is limited by the borrowing limit
+	claimable := borrower.GetCohortClaimableResources(corev1.ResourceList{gpu: resource.MustParse("5")})[gpu]
+	assert.Equal(t, 0, (&claimable).Cmp(resource.MustParse("3")))
+
+	// Borrowing is limited by the resources lent by the cohort
+	claimable = borrower.GetCohortClaimableResources(corev1.ResourceList{gpu: resource.MustParse("2")})[gpu]
+	assert.Equal(t, 0, (&claimable).Cmp(resource.MustParse("1")))
+
+	// Resources which can not be borrowed
+	claimable = borrower.GetCohortClaimableResources(corev1.ResourceList{corev1.ResourceLimitsCPU: resource.MustParse("4")})[corev1.ResourceLimitsCPU]
+	assert.Equal(t, 0, (&claimable).Cmp(resource.MustParse("8")))
+
+	// Pools without cohort don't borrow
+	lender.Spec.Cohort = ""
+	claimable = lender.GetCohortClaimableResources(corev1.ResourceList{gpu: resource.MustParse("5")})[gpu]
+	assert.Equal(t, 0, (&claimable).Cmp(resource.MustParse("6")))
+}
+
+func TestScopedResources(t *testing.T) {
+	high := corev1.ScopeSelector{
+		MatchExpressions: []corev1.ScopedResourceSelectorRequirement{
+			{ScopeName: corev1.ResourceQuotaScopePriorityClass, Operator: corev1.ScopeSelectorOpIn, Values: []string{"high", "critical"}},
+		},
+	}
+
+	pool := &capsulev1beta2.ResourcePool{
+		ObjectMeta: metav1.ObjectMeta{Name: "pool"},
+		Spec: capsulev1beta2.ResourcePoolSpec{
+			Scopes: []capsulev1beta2.ResourcePoolScope{
+				{
+					Name:          "high",
+					ScopeSelector: high,
+					Hard: corev1.ResourceList{
+						corev1.ResourceRequestsCPU:    resource.MustParse("4"),
+						corev1.ResourceRequestsMemory: resource.MustParse("8Gi"),
+					},
+				},
+			},
+		},
+		Status: capsulev1beta2.ResourcePoolStatus{
+			Allocation: capsulev1beta2.ResourcePoolQuotaStatus{
+				Hard: corev1.ResourceList{corev1.ResourceRequestsCPU: resource.MustParse("8")},
+			},
+		},
+	}
+
+	unscoped := &capsulev1beta2.ResourcePoolClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: "unscoped", Namespace: "ns", UID: "unscoped"},
+		Spec: capsulev1beta2.ResourcePoolClaimSpec{
+			ResourceClaims: corev1.ResourceList{corev1.ResourceRequestsCPU: resource.MustParse("2")},
+		},
+	}
+
+	scoped := &capsulev1beta2.ResourcePoolClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: "scoped", Namespace: "ns", UID: "scoped"},
+		Spec: capsulev1beta2.ResourcePoolClaimSpec{
+			// Same selector with values in a different order
+			ScopeSelector: &corev1.ScopeSelector{
+				MatchExpressions: []corev1.ScopedResourceSelectorRequirement{
+					{ScopeName: corev1.ResourceQuotaScopePriorityClass, Operator: corev1.ScopeSelectorOpIn, Values: []string{"critical", "high"}},
+				},
+			},
+			ResourceClaims: corev1.ResourceList{corev1.ResourceRequestsCPU: resource.MustParse("3")},
+		},
+	}
+
+	mismatch := scoped.DeepCopy()
+	mismatch.Spec.ScopeSelector.MatchExpressions[0].Values = []string{"low"}
+
+	scope, err := pool.GetScopeForClaim(unscoped)
+	assert.NoError(t, err)
+	assert.Nil(t, scope)
+
+	scope, err = pool.GetScopeForClaim(scoped)
+	assert.NoError(t, err)
+
+	if assert.NotNil(t, scope) {
+		assert.Equal(t, "high", scope.Name)
+	}
+
+	_, err = pool.GetScopeForClaim(mismatch)
+	assert.Error(t, err)
+
+	pool.AddClaimToStatus(unscoped)
+	pool.AddClaimToStatus(scoped)
+
+	assert.Equal(t, "high", pool.GetClaimFromStatus(scoped).Scope)
+	assert.Empty(t, pool.GetClaimFromStatus(unscoped).Scope)
+
+	// Scoped claims are tracked against the budget of their scope
+	claimed := pool.Status.Allocation.Claimed[corev1.ResourceRequestsCPU]
+	assert.Equal(t, 0, (&claimed).Cmp(resource.MustParse("2")))
+
+	scopeClaimed := pool.Status.Scopes["high"].Claimed[corev1.ResourceRequestsCPU]
+	assert.Equal(t, 0, (&scopeClaimed).Cmp(resource.MustParse("3")))
+
+	available := pool.GetAvailableClaimableScopeResources("high")[corev1.ResourceRequestsCPU]
+	assert.Equal(t, 0, (&available).Cmp(resource.MustParse("1")))
+
+	// Unclaimed resources of the scope are provisioned as zero
+	hard := pool.GetScopedResourceQuotaHardResources("ns", "high")
+	cpu, memory := hard[corev1.ResourceRequestsCPU], hard[corev1.ResourceRequestsMemory]
+	assert.Equal(t, 0, (&cpu).Cmp(resource.MustParse("3")))
+	assert.True(t, memory.IsZero())
+
+	hard = pool.GetScopedResourceQuotaHardResources("ns", "")
+	cpu = hard[corev1.ResourceRequestsCPU]
+	assert.Equal(t, 0, (&cpu).Cmp(resource.MustParse("2")))
+
+	assert.Equal(t, "capsule-pool-pool", pool.GetScopedQuotaName(""))
+	assert.Equal(t, "capsule-pool-pool-high", pool.GetScopedQuotaName("high"))
+}
+
 func TestGetResourceQuotaHardResources(t *testing.T) {
 	pool := &capsulev1beta2.ResourcePool{
 		Spec: capsulev1beta2.ResourcePoolSpec{
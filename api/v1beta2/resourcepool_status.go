@@ -30,6 +30,12 @@ type ResourcePoolStatus struct {
 	// Tracks the Usage from Claimed against what has been granted from the pool
 	// +optional
 	Allocation ResourcePoolQuotaStatus `json:"allocation,omitzero"`
+	// Tracks the claimed resources of each scope against the budget of the scope
+	// +optional
+	Scopes map[string]ResourcePoolQuotaStatus `json:"scopes,omitempty"`
+	// Resources claimed beyond the hard resources of the pool, which are borrowed from the cohort
+	// +optional
+	Borrowed corev1.ResourceList `json:"borrowed,omitempty"`
 	// Exhaustions from claims associated with the pool
 	Exhaustions map[string]api.PoolExhaustionResource `json:"exhaustions,omitempty"`
 	// Conditions for the resource claim
@@ -70,4 +76,8 @@ type ResourcePoolClaimsItem struct {
 
 	// Claimed resources
 	Claims corev1.ResourceList `json:"claims,omitempty"`
+
+	// Scope of the pool the resources are claimed for
+	// +optional
+	Scope string `json:"scope,omitempty"`
 }
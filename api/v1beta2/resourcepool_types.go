@@ -11,6 +11,7 @@ import (
 )
 
 // ResourcePoolSpec.
+// +kubebuilder:validation:XValidation:rule="!has(self.borrowingLimit) || (has(self.cohort) && size(self.cohort) > 0)",message="borrowingLimit requires a cohort"
 type ResourcePoolSpec struct {
 	// Selector to match the namespaces that should be managed by the GlobalResourceQuota
 	Selectors []selectors.NamespaceSelector `json:"selectors,omitempty"`
@@ -20,12 +21,39 @@ type ResourcePoolSpec struct {
 	// When you use claims it's recommended to provision Defaults as the prevent the scheduling of any resources
 	// +optional
 	Defaults corev1.ResourceList `json:"defaults,omitzero"`
+	// Scopes with a dedicated budget. Claims with a matching scope selector claim from the budget of the scope,
+	// which is provisioned as an additional scoped resourcequota in the namespaces. Scoped budgets are tracked
+	// separately from the quota of the resourcepool. As Kubernetes enforces all matching resourcequotas, the
+	// scope selector of the quota should exclude the scopes.
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Scopes []ResourcePoolScope `json:"scopes,omitempty"`
+	// Resourcepools within the same cohort lend their unused resources to each other. Borrowed resources are
+	// reclaimed, when the lending resourcepool requires them for its own claims.
+	// +optional
+	Cohort string `json:"cohort,omitempty"`
+	// The maximum amount of resources the resourcepool may borrow from its cohort. Resources which are not
+	// listed can not be borrowed. Requires a cohort.
+	// +optional
+	BorrowingLimit corev1.ResourceList `json:"borrowingLimit,omitempty"`
 	// Additional Configuration
 	//+kubebuilder:default:={}
 	// +optional
 	Config ResourcePoolSpecConfiguration `json:"config,omitzero"`
 }
 
+type ResourcePoolScope struct {
+	// Name of the scope, used to name the scoped resourcequota
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// +kubebuilder:validation:MaxLength=63
+	Name string `json:"name"`
+	// Scope selector of the scoped resourcequota
+	ScopeSelector corev1.ScopeSelector `json:"scopeSelector"`
+	// Hard resources which can be claimed for the scope
+	Hard corev1.ResourceList `json:"hard"`
+}
+
 type ResourcePoolSpecConfiguration struct {
 	// With this option all resources which can be allocated are set to 0 for the resourcequota defaults. (Default false)
 	// +kubebuilder:default=false
@@ -42,6 +70,14 @@ type ResourcePoolSpecConfiguration struct {
 	// By Enabling this option, the resourceclaims will be deleted when the resourcepool is deleted, if they are in bound state. (Default false)
 	// +kubebuilder:default=false
 	DeleteBoundResources *bool `json:"deleteBoundResources,omitempty"`
+	// When the pool is exhausted, a claim may preempt bound claims with a lower priority. Preempted claims are
+	// disassociated from the resourcepool and queued again. (Default false)
+	// +kubebuilder:default=false
+	Preemption *bool `json:"preemption,omitempty"`
+	// Expired claims remain bound for this period, as long as the usage of their namespace exceeds the quota
+	// remaining without the claim. Once the period elapsed, expired claims are released regardless of the usage. (Default 0s)
+	// +optional
+	ExpiryGracePeriod *metav1.Duration `json:"expiryGracePeriod,omitempty"`
 }
 
 // +kubebuilder:object:root=true
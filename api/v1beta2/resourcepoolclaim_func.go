@@ -4,6 +4,9 @@
 package v1beta2
 
 import (
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/projectcapsule/capsule/pkg/api/meta"
@@ -59,3 +62,50 @@ func (r *ResourcePoolClaim) GetPool() string {
 
 	return r.Spec.Pool
 }
+
+// Returns the claimed resources. With autoscaling, the resources scaled by the pool replace the
+// claimed resources of the spec.
+func (r *ResourcePoolClaim) GetResourceClaims() corev1.ResourceList {
+	if r.Spec.Autoscaling == nil || r.Status.Autoscaling == nil || len(r.Status.Autoscaling.Claims) == 0 {
+		return r.Spec.ResourceClaims
+	}
+
+	claims := make(corev1.ResourceList, len(r.Spec.ResourceClaims))
+
+	for resourceName, qt := range r.Spec.ResourceClaims {
+		if scaled, ok := r.Status.Autoscaling.Claims[resourceName]; ok {
+			claims[resourceName] = scaled
+
+			continue
+		}
+
+		claims[resourceName] = qt
+	}
+
+	return claims
+}
+
+// Returns the point in time the claim expires, nil if the claim does not expire.
+func (r *ResourcePoolClaim) GetExpiry() *metav1.Time {
+	if r.Spec.ExpiresAt != nil {
+		return r.Spec.ExpiresAt.DeepCopy()
+	}
+
+	if r.Spec.TTL != nil {
+		expiry := metav1.NewTime(r.CreationTimestamp.Add(r.Spec.TTL.Duration))
+
+		return &expiry
+	}
+
+	return nil
+}
+
+// Indicate the claim is expired at the given point in time.
+func (r *ResourcePoolClaim) IsExpired(now time.Time) bool {
+	expiry := r.GetExpiry()
+	if expiry == nil {
+		return false
+	}
+
+	return !now.Before(expiry.Time)
+}
@@ -5,8 +5,11 @@ package v1beta2_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
@@ -167,3 +170,106 @@ func TestGetPool(t *testing.T) {
 		})
 	}
 }
+
+func TestResourcePoolClaimExpiry(t *testing.T) {
+	created := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	expiresAt := metav1.NewTime(created.Add(2 * time.Hour))
+
+	tests := []struct {
+		name      string
+		spec      capsulev1beta2.ResourcePoolClaimSpec
+		now       time.Time
+		expected  *metav1.Time
+		isExpired bool
+	}{
+		{
+			name: "no expiry",
+			now:  created.Add(24 * time.Hour),
+		},
+		{
+			name:     "ttl counts from creation",
+			spec:     capsulev1beta2.ResourcePoolClaimSpec{TTL: &metav1.Duration{Duration: time.Hour}},
+			now:      created.Add(30 * time.Minute),
+			expected: &metav1.Time{Time: created.Add(time.Hour)},
+		},
+		{
+			name:      "ttl passed",
+			spec:      capsulev1beta2.ResourcePoolClaimSpec{TTL: &metav1.Duration{Duration: time.Hour}},
+			now:       created.Add(time.Hour),
+			expected:  &metav1.Time{Time: created.Add(time.Hour)},
+			isExpired: true,
+		},
+		{
+			name:     "expiresAt before deadline",
+			spec:     capsulev1beta2.ResourcePoolClaimSpec{ExpiresAt: &expiresAt},
+			now:      created.Add(time.Hour),
+			expected: &expiresAt,
+		},
+		{
+			name:      "expiresAt passed",
+			spec:      capsulev1beta2.ResourcePoolClaimSpec{ExpiresAt: &expiresAt},
+			now:       created.Add(3 * time.Hour),
+			expected:  &expiresAt,
+			isExpired: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claim := capsulev1beta2.ResourcePoolClaim{
+				ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.NewTime(created)},
+				Spec:       tt.spec,
+			}
+
+			expiry := claim.GetExpiry()
+			if tt.expected == nil {
+				assert.Nil(t, expiry)
+			} else if assert.NotNil(t, expiry) {
+				assert.True(t, tt.expected.Equal(expiry))
+			}
+
+			assert.Equal(t, tt.isExpired, claim.IsExpired(tt.now))
+		})
+	}
+}
+
+func TestResourcePoolClaimGetResourceClaims(t *testing.T) {
+	spec := corev1.ResourceList{
+		corev1.ResourceRequestsCPU:    resource.MustParse("2"),
+		corev1.ResourceRequestsMemory: resource.MustParse("1Gi"),
+	}
+	scaled := &capsulev1beta2.ResourcePoolClaimAutoscalingStatus{
+		Claims: corev1.ResourceList{corev1.ResourceRequestsCPU: resource.MustParse("4")},
+	}
+
+	tests := []struct {
+		name        string
+		autoscaling *capsulev1beta2.ResourcePoolClaimAutoscaling
+		status      *capsulev1beta2.ResourcePoolClaimAutoscalingStatus
+		expectedCPU string
+	}{
+		{name: "without autoscaling", status: scaled, expectedCPU: "2"},
+		{name: "not scaled yet", autoscaling: &capsulev1beta2.ResourcePoolClaimAutoscaling{}, expectedCPU: "2"},
+		{name: "scaled", autoscaling: &capsulev1beta2.ResourcePoolClaimAutoscaling{}, status: scaled, expectedCPU: "4"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claim := capsulev1beta2.ResourcePoolClaim{
+				Spec: capsulev1beta2.ResourcePoolClaimSpec{
+					ResourceClaims: spec,
+					Autoscaling:    tt.autoscaling,
+				},
+				Status: capsulev1beta2.ResourcePoolClaimStatus{Autoscaling: tt.status},
+			}
+
+			claims := claim.GetResourceClaims()
+
+			assert.Len(t, claims, 2)
+			cpu, memory := claims[corev1.ResourceRequestsCPU], claims[corev1.ResourceRequestsMemory]
+
+			assert.True(t, cpu.Equal(resource.MustParse(tt.expectedCPU)))
+			assert.True(t, memory.Equal(resource.MustParse("1Gi")))
+		})
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/projectcapsule/capsule/pkg/api/meta"
 )
 
+// +kubebuilder:validation:XValidation:rule="!(has(self.ttl) && has(self.expiresAt))",message="ttl and expiresAt are mutually exclusive"
 type ResourcePoolClaimSpec struct {
 	// If there's the possability to claim from multiple global Quotas
 	// You must be specific about which one you want to claim resources from
@@ -17,6 +18,65 @@ type ResourcePoolClaimSpec struct {
 	Pool string `json:"pool"`
 	// Amount which should be claimed for the resourcequota
 	ResourceClaims corev1.ResourceList `json:"claim"`
+	// Claim the resources for a scope of the ResourcePool. The scope selector must equal the scope selector of a
+	// scope defined by the ResourcePool. Once bound to a ResourcePool, this field is immutable
+	// +optional
+	ScopeSelector *corev1.ScopeSelector `json:"scopeSelector,omitempty"`
+	// Priority of the claim within the ResourcePool. Claims with a higher priority are allocated first
+	// and may preempt bound claims with a lower priority, if the ResourcePool enables preemption. (Default 0)
+	// +kubebuilder:default=0
+	// +optional
+	Priority int32 `json:"priority,omitempty"`
+	// Duration since the creation of the claim, after which the claim expires. Expired claims are released
+	// from the ResourcePool and no longer bound again. Mutually exclusive with expiresAt.
+	// +optional
+	TTL *metav1.Duration `json:"ttl,omitempty"`
+	// Point in time, after which the claim expires. Expired claims are released from the ResourcePool and
+	// no longer bound again. Mutually exclusive with ttl.
+	// +optional
+	ExpiresAt *metav1.Time `json:"expiresAt,omitempty"`
+	// Resize the claim based on the usage of the namespace resourcequota. The claimed resources act as
+	// initial amount, which is grown and shrunk by the pool.
+	// +optional
+	Autoscaling *ResourcePoolClaimAutoscaling `json:"autoscaling,omitempty"`
+}
+
+// +kubebuilder:validation:XValidation:rule="self.lowWatermark < self.highWatermark",message="lowWatermark must be lower than highWatermark"
+type ResourcePoolClaimAutoscaling struct {
+	// Percentage of the namespace resourcequota usage, above which the claim is grown. (Default 90)
+	// +kubebuilder:default=90
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	HighWatermark int32 `json:"highWatermark,omitempty"`
+	// Percentage of the namespace resourcequota usage, below which the claim is shrunk. (Default 50)
+	// +kubebuilder:default=50
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=99
+	// +optional
+	LowWatermark int32 `json:"lowWatermark,omitempty"`
+	// Duration the usage must remain below the low watermark, before the claim is shrunk. (Default 10m)
+	// +kubebuilder:default="10m"
+	// +optional
+	ScaleDownStabilization *metav1.Duration `json:"scaleDownStabilization,omitempty"`
+	// Minimum amount of resources the claim is shrunk to.
+	// +optional
+	Min corev1.ResourceList `json:"min,omitempty"`
+	// Maximum amount of resources the claim is grown to.
+	// +optional
+	Max corev1.ResourceList `json:"max,omitempty"`
+}
+
+type ResourcePoolClaimAutoscalingStatus struct {
+	// Resources claimed after autoscaling, these replace the claimed resources of the spec
+	// +optional
+	Claims corev1.ResourceList `json:"claims,omitempty"`
+	// Point in time since when the usage of a resource is below the low watermark
+	// +optional
+	LowUsageSince map[corev1.ResourceName]metav1.Time `json:"lowUsageSince,omitempty"`
+	// Last time the claim was resized
+	// +optional
+	LastScaleTime *metav1.Time `json:"lastScaleTime,omitempty"`
 }
 
 // ResourceQuotaClaimStatus defines the observed state of ResourceQuotaClaim.
@@ -36,6 +96,12 @@ type ResourcePoolClaimStatus struct {
 	// Tracks the Usage from Claimed from this claim and available resources
 	// +optional
 	Allocation ResourcePoolQuotaStatus `json:"allocation,omitzero"`
+	// Point in time, after which the claim expires. Derived from ttl or expiresAt.
+	// +optional
+	ExpiresAt *metav1.Time `json:"expiresAt,omitempty"`
+	// Autoscaling state of the claim, managed by the pool
+	// +optional
+	Autoscaling *ResourcePoolClaimAutoscalingStatus `json:"autoscaling,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -45,6 +111,7 @@ type ResourcePoolClaimStatus struct {
 // +kubebuilder:printcolumn:name="Message",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].message",description="Ready Message"
 // +kubebuilder:printcolumn:name="Bound",type="string",JSONPath=".status.conditions[?(@.type==\"Bound\")].status",description="Bound Status"
 // +kubebuilder:printcolumn:name="Reason",type="string",JSONPath=".status.conditions[?(@.type==\"Bound\")].message",description="Bound Message"
+// +kubebuilder:printcolumn:name="Expires",type="date",JSONPath=".status.expiresAt",description="The point in time the claim expires",priority=1
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description=""
 // ResourcePoolClaim is the Schema for the resourcepoolclaims API.
 type ResourcePoolClaim struct {
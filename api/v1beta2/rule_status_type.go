@@ -24,10 +24,72 @@ type RuleStatusStatus struct {
 	// Order is preserved from the originating Tenant rules.
 	// +optional
 	Rules []*rules.NamespaceRuleBodyNamespace `json:"rules,omitempty"`
+	// DryRun reports violations of existing objects against rules marked as
+	// dry-run. Those rules are not enforced at admission.
+	// +optional
+	DryRun *RuleEvaluationStatus `json:"dryRun,omitempty"`
+	// Audit reports existing objects which match audit rules or which would be
+	// denied by the enforced rules. It is refreshed periodically.
+	// +optional
+	Audit *RuleEvaluationStatus `json:"audit,omitempty"`
 	// Conditions
 	Conditions meta.ConditionList `json:"conditions"`
 }
 
+// RuleEvaluationStatus summarizes the evaluation of namespace rules against
+// objects which already exist in the namespace.
+// +kubebuilder:object:generate=true
+type RuleEvaluationStatus struct {
+	// LastEvaluationTime is the time the objects were last evaluated.
+	// +optional
+	LastEvaluationTime metav1.Time `json:"lastEvaluationTime,omitzero"`
+	// ObservedGeneration is the generation of the RuleStatus the objects were
+	// evaluated against.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Evaluated is the number of objects evaluated.
+	// +optional
+	Evaluated int32 `json:"evaluated,omitempty"`
+	// ViolationCount is the total number of violations found, including the
+	// ones omitted from Violations.
+	// +optional
+	ViolationCount int32 `json:"violationCount,omitempty"`
+	// Violations lists the violations found, truncated to a bounded size.
+	// +optional
+	Violations []RuleViolation `json:"violations,omitempty"`
+	// Failed is the number of objects which could not be evaluated. They are
+	// counted neither as evaluated nor as violating.
+	// +optional
+	Failed int32 `json:"failed,omitempty"`
+	// Failures lists the evaluation errors, truncated to a bounded size.
+	// +optional
+	Failures []string `json:"failures,omitempty"`
+	// NotEvaluable lists the rules which were not evaluated, since they depend
+	// on the admission request (the previous object or the requesting user).
+	// +optional
+	NotEvaluable []string `json:"notEvaluable,omitempty"`
+}
+
+// RuleViolation describes an existing object which does not comply with a rule.
+// +kubebuilder:object:generate=true
+type RuleViolation struct {
+	// APIVersion of the violating object.
+	APIVersion string `json:"apiVersion"`
+	// Kind of the violating object.
+	Kind string `json:"kind"`
+	// Name of the violating object.
+	Name string `json:"name"`
+	// Action of the rule reporting the violation.
+	// +optional
+	Action rules.ActionType `json:"action,omitempty"`
+	// Reason is the event reason admission would report for the denial.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+	// Message is the message admission would deny the object with.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
 // +kubebuilder:object:root=true
 // +kubebuilder:storageversion
 // +kubebuilder:subresource:status
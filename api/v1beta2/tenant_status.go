@@ -42,6 +42,9 @@ type TenantStatus struct {
 	Namespaces []string `json:"namespaces,omitempty"`
 	// Tracks state for the namespaces associated with this tenant
 	Spaces []*TenantStatusNamespaceItem `json:"spaces,omitempty"`
+	// Accumulated costs of the Tenant, only reported when a price table is configured
+	// +optional
+	Chargeback *TenantChargebackStatus `json:"chargeback,omitempty"`
 	// Tenant Condition
 	Conditions meta.ConditionList `json:"conditions"`
 }
@@ -4,8 +4,11 @@
 package v1beta2
 
 import (
+	"github.com/fluxcd/pkg/apis/kustomize"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/projectcapsule/capsule/pkg/api"
 	"github.com/projectcapsule/capsule/pkg/api/meta"
@@ -35,6 +38,17 @@ func (s *TenantResourceCommonStatus) UpdateStats() {
 	s.Size = uint(len(s.ProcessedItems))
 }
 
+// UpdateItemConditions updates the conditions aggregated from the processed items.
+func (s *TenantResourceCommonStatus) UpdateItemConditions(obj client.Object) {
+	s.Conditions.UpdateConditionByType(s.ProcessedItems.HealthyCondition(obj))
+
+	if drifted := s.ProcessedItems.DriftedCondition(obj); drifted != nil {
+		s.Conditions.UpdateConditionByType(*drifted)
+	} else {
+		s.Conditions.RemoveConditionByType(meta.DriftedCondition)
+	}
+}
+
 type TenantResourceCommonSpec struct {
 	// Provide additional settings
 	// +kubebuilder:default={}
@@ -44,6 +58,12 @@ type TenantResourceCommonSpec struct {
 	// TenantResource can be reconciled.
 	// +optional
 	DependsOn []meta.LocalRFC1123ObjectReference `json:"dependsOn,omitempty"`
+	// Condition the dependencies must report before this TenantResource is reconciled.
+	// Ready waits for the dependencies to be applied, Healthy additionally waits for
+	// their replicated objects to be healthy.
+	// +kubebuilder:default=Ready
+	// +optional
+	DependsOnCondition DependencyCondition `json:"dependsOnCondition,omitempty"`
 	// Define the period of time upon a second reconciliation must be invoked.
 	// Keep in mind that any change to the manifests will trigger a new reconciliation.
 	// +kubebuilder:default="60s"
@@ -66,6 +86,23 @@ func (s *TenantResourceCommonSpec) IsCordoned() bool {
 	return s.Cordoned != nil && *s.Cordoned
 }
 
+// DependsOnConditions returns the condition types the dependencies must report as true.
+func (s *TenantResourceCommonSpec) DependsOnConditions() []string {
+	if s.DependsOnCondition == DependencyConditionHealthy {
+		return []string{meta.ReadyCondition, meta.HealthyCondition}
+	}
+
+	return []string{meta.ReadyCondition}
+}
+
+// +kubebuilder:validation:Enum=Ready;Healthy
+type DependencyCondition string
+
+const (
+	DependencyConditionReady   DependencyCondition = "Ready"
+	DependencyConditionHealthy DependencyCondition = "Healthy"
+)
+
 type TenantResourceCommonSpecSettings struct {
 	// Enabling this allows TenanResources to interact with objects which were not created by a TenantResource. In this case on prune no deletion of the entire object is made.
 	// +kubebuilder:default=false
@@ -89,10 +126,27 @@ type ResourceSpec struct {
 	AdditionalMetadata *api.AdditionalMetadataSpec `json:"additionalMetadata,omitempty"`
 	// Templates for advanced use cases
 	Generators []TemplateItemSpec `json:"generators,omitempty"`
+	// Secrets assembled from the keys of existing Secrets.
+	// +optional
+	SecretSync []SecretSyncItemSpec `json:"secretSync,omitempty"`
+	// Helm chart rendered for each target, its manifests are replicated along with the other items.
+	// +optional
+	Chart *ChartItemSpec `json:"chart,omitempty"`
 	// Provide additional template context, which can be used throughout all
 	// the declared items for the replication
 	// +optional
 	Context *tpl.TemplateContext `json:"context,omitempty"`
+	// Strategic merge and JSON6902 patches applied to the collected resources before they are
+	// replicated. Patch documents may refer to the {{tenant.name}} and {{namespace}} templates.
+	// Patches without a target apply to the resources matching the kind and name of their document.
+	// +optional
+	Patches []kustomize.Patch `json:"patches,omitempty"`
+	// Defines how changes made to the replicated resources outside of Capsule are handled.
+	// Ignore overwrites them on the next resync, Report watches the resources and reports
+	// the drift without touching them, Correct watches the resources and restores them immediately.
+	// +kubebuilder:default=Ignore
+	// +optional
+	DriftPolicy meta.DriftPolicy `json:"driftPolicy,omitempty"`
 }
 
 // +kubebuilder:validation:XPreserveUnknownFields
@@ -108,3 +162,77 @@ type TemplateItemSpec struct {
 	// +kubebuilder:default=zero
 	MissingKey tpl.MissingKeyOption `json:"missingKey,omitempty"`
 }
+
+type ChartItemSpec struct {
+	// URL of the repository holding the chart: either a Helm repository serving an index
+	// (http:// or https://), or an OCI registry (oci://). TenantResources can only use the
+	// repositories allowed by the Capsule configuration.
+	// +kubebuilder:validation:Pattern=`^(https?|oci)://.+`
+	Repository string `json:"repository"`
+	// Name of the chart within the repository.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+	// Version or semver constraint of the chart, the latest stable version is used when empty.
+	// +optional
+	Version string `json:"version,omitempty"`
+	// Name of the release the chart is rendered for, defaults to the chart name.
+	// Supports the {{tenant.name}} and {{namespace}} templates.
+	// +optional
+	ReleaseName string `json:"releaseName,omitempty"`
+	// Values of the chart, as yaml. They are templated with the same context as the generators.
+	// +optional
+	Values string `json:"values,omitempty"`
+	// Missing Key Option for templating the values
+	// +kubebuilder:default=zero
+	MissingKey tpl.MissingKeyOption `json:"missingKey,omitempty"`
+	// Connect to the OCI registry over plain HTTP.
+	// +optional
+	PlainHTTP bool `json:"plainHTTP,omitempty"`
+}
+
+type SecretSyncItemSpec struct {
+	// Name of the replicated Secret.
+	// Supports the {{tenant.name}} and {{namespace}} templates.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+	// Type of the replicated Secret. The keys of the sources are converted when required:
+	// kubernetes.io/dockerconfigjson Secrets are built from the server, username, password and
+	// email keys, or from the .dockercfg key, and the other way around for kubernetes.io/dockercfg.
+	// +kubebuilder:default=Opaque
+	// +optional
+	Type corev1.SecretType `json:"type,omitempty"`
+	// Secrets the keys are taken from. Sources are merged in order, a key of a source overriding
+	// the same key of the previous ones, except for the registries of .dockerconfigjson keys
+	// which are merged together.
+	// +kubebuilder:validation:MinItems=1
+	Sources []SecretSyncSource `json:"sources"`
+}
+
+type SecretSyncSource struct {
+	// Name of the source Secret.
+	// Supports the {{tenant.name}} and {{namespace}} templates.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+	// Namespace of the source Secret, defaults to the targeted Namespace.
+	// Supports the {{tenant.name}} and {{namespace}} templates.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+	// Keys taken from the source Secret, all of them are taken when empty.
+	// +optional
+	Keys []SecretSyncKey `json:"keys,omitempty"`
+	// If the source Secret does not exist, it is skipped instead of failing the replication.
+	// +optional
+	Optional bool `json:"optional,omitempty"`
+}
+
+type SecretSyncKey struct {
+	// Key of the source Secret.
+	// +kubebuilder:validation:MinLength=1
+	Key string `json:"key"`
+	// Key of the replicated Secret, defaults to the key of the source.
+	// +optional
+	As string `json:"as,omitempty"`
+	// If the key is missing from the source Secret, it is skipped instead of failing the replication.
+	// +optional
+	Optional bool `json:"optional,omitempty"`
+}
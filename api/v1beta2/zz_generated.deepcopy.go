@@ -8,6 +8,7 @@
 package v1beta2
 
 import (
+	"github.com/fluxcd/pkg/apis/kustomize"
 	"github.com/projectcapsule/capsule/pkg/api"
 	"github.com/projectcapsule/capsule/pkg/api/meta"
 	"github.com/projectcapsule/capsule/pkg/api/rbac"
@@ -133,6 +134,16 @@ func (in *CapsuleConfigurationSpec) DeepCopyInto(out *CapsuleConfigurationSpec)
 	out.CacheInvalidation = in.CacheInvalidation
 	out.Impersonation = in.Impersonation
 	out.Events = in.Events
+	if in.Chargeback != nil {
+		in, out := &in.Chargeback, &out.Chargeback
+		*out = new(ChargebackConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Charts != nil {
+		in, out := &in.Charts, &out.Charts
+		*out = new(ChartsConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.UserNames != nil {
 		in, out := &in.UserNames, &out.UserNames
 		*out = make([]string, len(*in))
@@ -202,6 +213,81 @@ func (in *CapsuleResources) DeepCopy() *CapsuleResources {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ChargebackConfiguration) DeepCopyInto(out *ChargebackConfiguration) {
+	*out = *in
+	out.Interval = in.Interval
+	if in.Prices != nil {
+		in, out := &in.Prices, &out.Prices
+		*out = make([]ChargebackPrice, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChargebackConfiguration.
+func (in *ChargebackConfiguration) DeepCopy() *ChargebackConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(ChargebackConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ChargebackPrice) DeepCopyInto(out *ChargebackPrice) {
+	*out = *in
+	out.Unit = in.Unit.DeepCopy()
+	out.Price = in.Price.DeepCopy()
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChargebackPrice.
+func (in *ChargebackPrice) DeepCopy() *ChargebackPrice {
+	if in == nil {
+		return nil
+	}
+	out := new(ChargebackPrice)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ChartItemSpec) DeepCopyInto(out *ChartItemSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChartItemSpec.
+func (in *ChartItemSpec) DeepCopy() *ChartItemSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ChartItemSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ChartsConfiguration) DeepCopyInto(out *ChartsConfiguration) {
+	*out = *in
+	if in.AllowedRepositories != nil {
+		in, out := &in.AllowedRepositories, &out.AllowedRepositories
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChartsConfiguration.
+func (in *ChartsConfiguration) DeepCopy() *ChartsConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(ChartsConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CustomQuota) DeepCopyInto(out *CustomQuota) {
 	*out = *in
@@ -305,6 +391,11 @@ func (in *CustomQuotaSpec) DeepCopyInto(out *CustomQuotaSpec) {
 		}
 	}
 	out.Limit = in.Limit.DeepCopy()
+	if in.SoftLimit != nil {
+		in, out := &in.SoftLimit, &out.SoftLimit
+		x := (*in).DeepCopy()
+		*out = &x
+	}
 	if in.Sources != nil {
 		in, out := &in.Sources, &out.Sources
 		*out = make([]CustomQuotaSpecSource, len(*in))
@@ -312,6 +403,11 @@ func (in *CustomQuotaSpec) DeepCopyInto(out *CustomQuotaSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Window != nil {
+		in, out := &in.Window, &out.Window
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 	if in.Options != nil {
 		in, out := &in.Options, &out.Options
 		*out = new(CustomQuotaOptionsSpec)
@@ -427,6 +523,11 @@ func (in *CustomQuotaStatusUsage) DeepCopyInto(out *CustomQuotaStatusUsage) {
 	*out = *in
 	out.Used = in.Used.DeepCopy()
 	out.Available = in.Available.DeepCopy()
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustomQuotaStatusUsage.
@@ -769,6 +870,13 @@ func (in *GlobalResourceQuotaSpec) DeepCopyInto(out *GlobalResourceQuotaSpec) {
 		}
 	}
 	in.Quota.DeepCopyInto(&out.Quota)
+	if in.Soft != nil {
+		in, out := &in.Soft, &out.Soft
+		*out = make(corev1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GlobalResourceQuotaSpec.
@@ -1477,6 +1585,73 @@ func (in *ResourcePoolClaim) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourcePoolClaimAutoscaling) DeepCopyInto(out *ResourcePoolClaimAutoscaling) {
+	*out = *in
+	if in.ScaleDownStabilization != nil {
+		in, out := &in.ScaleDownStabilization, &out.ScaleDownStabilization
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Min != nil {
+		in, out := &in.Min, &out.Min
+		*out = make(corev1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	if in.Max != nil {
+		in, out := &in.Max, &out.Max
+		*out = make(corev1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourcePoolClaimAutoscaling.
+func (in *ResourcePoolClaimAutoscaling) DeepCopy() *ResourcePoolClaimAutoscaling {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourcePoolClaimAutoscaling)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourcePoolClaimAutoscalingStatus) DeepCopyInto(out *ResourcePoolClaimAutoscalingStatus) {
+	*out = *in
+	if in.Claims != nil {
+		in, out := &in.Claims, &out.Claims
+		*out = make(corev1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	if in.LowUsageSince != nil {
+		in, out := &in.LowUsageSince, &out.LowUsageSince
+		*out = make(map[corev1.ResourceName]metav1.Time, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.LastScaleTime != nil {
+		in, out := &in.LastScaleTime, &out.LastScaleTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourcePoolClaimAutoscalingStatus.
+func (in *ResourcePoolClaimAutoscalingStatus) DeepCopy() *ResourcePoolClaimAutoscalingStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourcePoolClaimAutoscalingStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ResourcePoolClaimList) DeepCopyInto(out *ResourcePoolClaimList) {
 	*out = *in
@@ -1519,6 +1694,25 @@ func (in *ResourcePoolClaimSpec) DeepCopyInto(out *ResourcePoolClaimSpec) {
 			(*out)[key] = val.DeepCopy()
 		}
 	}
+	if in.ScopeSelector != nil {
+		in, out := &in.ScopeSelector, &out.ScopeSelector
+		*out = new(corev1.ScopeSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TTL != nil {
+		in, out := &in.TTL, &out.TTL
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.ExpiresAt != nil {
+		in, out := &in.ExpiresAt, &out.ExpiresAt
+		*out = (*in).DeepCopy()
+	}
+	if in.Autoscaling != nil {
+		in, out := &in.Autoscaling, &out.Autoscaling
+		*out = new(ResourcePoolClaimAutoscaling)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourcePoolClaimSpec.
@@ -1544,6 +1738,15 @@ func (in *ResourcePoolClaimStatus) DeepCopyInto(out *ResourcePoolClaimStatus) {
 		}
 	}
 	in.Allocation.DeepCopyInto(&out.Allocation)
+	if in.ExpiresAt != nil {
+		in, out := &in.ExpiresAt, &out.ExpiresAt
+		*out = (*in).DeepCopy()
+	}
+	if in.Autoscaling != nil {
+		in, out := &in.Autoscaling, &out.Autoscaling
+		*out = new(ResourcePoolClaimAutoscalingStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourcePoolClaimStatus.
@@ -1708,6 +1911,29 @@ func (in *ResourcePoolQuotaStatus) DeepCopy() *ResourcePoolQuotaStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourcePoolScope) DeepCopyInto(out *ResourcePoolScope) {
+	*out = *in
+	in.ScopeSelector.DeepCopyInto(&out.ScopeSelector)
+	if in.Hard != nil {
+		in, out := &in.Hard, &out.Hard
+		*out = make(corev1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourcePoolScope.
+func (in *ResourcePoolScope) DeepCopy() *ResourcePoolScope {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourcePoolScope)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ResourcePoolSpec) DeepCopyInto(out *ResourcePoolSpec) {
 	*out = *in
@@ -1726,6 +1952,20 @@ func (in *ResourcePoolSpec) DeepCopyInto(out *ResourcePoolSpec) {
 			(*out)[key] = val.DeepCopy()
 		}
 	}
+	if in.Scopes != nil {
+		in, out := &in.Scopes, &out.Scopes
+		*out = make([]ResourcePoolScope, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.BorrowingLimit != nil {
+		in, out := &in.BorrowingLimit, &out.BorrowingLimit
+		*out = make(corev1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
 	in.Config.DeepCopyInto(&out.Config)
 }
 
@@ -1757,6 +1997,16 @@ func (in *ResourcePoolSpecConfiguration) DeepCopyInto(out *ResourcePoolSpecConfi
 		*out = new(bool)
 		**out = **in
 	}
+	if in.Preemption != nil {
+		in, out := &in.Preemption, &out.Preemption
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ExpiryGracePeriod != nil {
+		in, out := &in.ExpiryGracePeriod, &out.ExpiryGracePeriod
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourcePoolSpecConfiguration.
@@ -1800,6 +2050,20 @@ func (in *ResourcePoolStatus) DeepCopyInto(out *ResourcePoolStatus) {
 		}
 	}
 	in.Allocation.DeepCopyInto(&out.Allocation)
+	if in.Scopes != nil {
+		in, out := &in.Scopes, &out.Scopes
+		*out = make(map[string]ResourcePoolQuotaStatus, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.Borrowed != nil {
+		in, out := &in.Borrowed, &out.Borrowed
+		*out = make(corev1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
 	if in.Exhaustions != nil {
 		in, out := &in.Exhaustions, &out.Exhaustions
 		*out = make(map[string]api.PoolExhaustionResource, len(*in))
@@ -1858,11 +2122,30 @@ func (in *ResourceSpec) DeepCopyInto(out *ResourceSpec) {
 		*out = make([]TemplateItemSpec, len(*in))
 		copy(*out, *in)
 	}
+	if in.SecretSync != nil {
+		in, out := &in.SecretSync, &out.SecretSync
+		*out = make([]SecretSyncItemSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Chart != nil {
+		in, out := &in.Chart, &out.Chart
+		*out = new(ChartItemSpec)
+		**out = **in
+	}
 	if in.Context != nil {
 		in, out := &in.Context, &out.Context
 		*out = new(template.TemplateContext)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Patches != nil {
+		in, out := &in.Patches, &out.Patches
+		*out = make([]kustomize.Patch, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceSpec.
@@ -1875,6 +2158,37 @@ func (in *ResourceSpec) DeepCopy() *ResourceSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RuleEvaluationStatus) DeepCopyInto(out *RuleEvaluationStatus) {
+	*out = *in
+	in.LastEvaluationTime.DeepCopyInto(&out.LastEvaluationTime)
+	if in.Violations != nil {
+		in, out := &in.Violations, &out.Violations
+		*out = make([]RuleViolation, len(*in))
+		copy(*out, *in)
+	}
+	if in.Failures != nil {
+		in, out := &in.Failures, &out.Failures
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NotEvaluable != nil {
+		in, out := &in.NotEvaluable, &out.NotEvaluable
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RuleEvaluationStatus.
+func (in *RuleEvaluationStatus) DeepCopy() *RuleEvaluationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RuleEvaluationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RuleStatus) DeepCopyInto(out *RuleStatus) {
 	*out = *in
@@ -1959,6 +2273,16 @@ func (in *RuleStatusStatus) DeepCopyInto(out *RuleStatusStatus) {
 			}
 		}
 	}
+	if in.DryRun != nil {
+		in, out := &in.DryRun, &out.DryRun
+		*out = new(RuleEvaluationStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Audit != nil {
+		in, out := &in.Audit, &out.Audit
+		*out = new(RuleEvaluationStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make(meta.ConditionList, len(*in))
@@ -1978,6 +2302,78 @@ func (in *RuleStatusStatus) DeepCopy() *RuleStatusStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RuleViolation) DeepCopyInto(out *RuleViolation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RuleViolation.
+func (in *RuleViolation) DeepCopy() *RuleViolation {
+	if in == nil {
+		return nil
+	}
+	out := new(RuleViolation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretSyncItemSpec) DeepCopyInto(out *SecretSyncItemSpec) {
+	*out = *in
+	if in.Sources != nil {
+		in, out := &in.Sources, &out.Sources
+		*out = make([]SecretSyncSource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretSyncItemSpec.
+func (in *SecretSyncItemSpec) DeepCopy() *SecretSyncItemSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretSyncItemSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretSyncKey) DeepCopyInto(out *SecretSyncKey) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretSyncKey.
+func (in *SecretSyncKey) DeepCopy() *SecretSyncKey {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretSyncKey)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretSyncSource) DeepCopyInto(out *SecretSyncSource) {
+	*out = *in
+	if in.Keys != nil {
+		in, out := &in.Keys, &out.Keys
+		*out = make([]SecretSyncKey, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretSyncSource.
+func (in *SecretSyncSource) DeepCopy() *SecretSyncSource {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretSyncSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ServiceAccountClient) DeepCopyInto(out *ServiceAccountClient) {
 	*out = *in
@@ -2091,6 +2487,53 @@ func (in *TenantAvailableStatus) DeepCopy() *TenantAvailableStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TenantChargebackStatus) DeepCopyInto(out *TenantChargebackStatus) {
+	*out = *in
+	in.Since.DeepCopyInto(&out.Since)
+	in.LastSampleTime.DeepCopyInto(&out.LastSampleTime)
+	if in.Allocated != nil {
+		in, out := &in.Allocated, &out.Allocated
+		*out = make(corev1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	if in.Used != nil {
+		in, out := &in.Used, &out.Used
+		*out = make(corev1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	if in.AllocatedCost != nil {
+		in, out := &in.AllocatedCost, &out.AllocatedCost
+		*out = make(corev1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	if in.UsedCost != nil {
+		in, out := &in.UsedCost, &out.UsedCost
+		*out = make(corev1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	out.TotalAllocatedCost = in.TotalAllocatedCost.DeepCopy()
+	out.TotalUsedCost = in.TotalUsedCost.DeepCopy()
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantChargebackStatus.
+func (in *TenantChargebackStatus) DeepCopy() *TenantChargebackStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TenantChargebackStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TenantList) DeepCopyInto(out *TenantList) {
 	*out = *in
@@ -2562,6 +3005,11 @@ func (in *TenantStatus) DeepCopyInto(out *TenantStatus) {
 			}
 		}
 	}
+	if in.Chargeback != nil {
+		in, out := &in.Chargeback, &out.Chargeback
+		*out = new(TenantChargebackStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make(meta.ConditionList, len(*in))
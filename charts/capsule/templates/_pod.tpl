@@ -70,6 +70,12 @@ spec:
         {{- with .Values.manager.options.cacheSyncTimeout }}
         - --cache-sync-timeout={{ . }}
         {{- end }}
+        {{- with .Values.manager.options.ruleAuditInterval }}
+        - --rule-audit-interval={{ . }}
+        {{- end }}
+        {{- with .Values.manager.options.ledgerAuditInterval }}
+        - --ledger-audit-interval={{ . }}
+        {{- end }}
         - --enable-leader-election={{ .Values.manager.options.leaderElection.enabled }}
         {{- with .Values.manager.options.leaderElection.leaseDuration }}
         - --leader-election-lease-duration={{ . }}
@@ -45,6 +45,7 @@ import (
 	"github.com/projectcapsule/capsule/internal/controllers/admission"
 	cacheinvalidator "github.com/projectcapsule/capsule/internal/controllers/cfg/invalidator"
 	configcontroller "github.com/projectcapsule/capsule/internal/controllers/cfg/status"
+	chargebackcontroller "github.com/projectcapsule/capsule/internal/controllers/chargeback"
 	customquotacontroller "github.com/projectcapsule/capsule/internal/controllers/customquotas"
 	globalresourcequotacontroller "github.com/projectcapsule/capsule/internal/controllers/globalresourcequotas"
 	podlabelscontroller "github.com/projectcapsule/capsule/internal/controllers/pod"
@@ -85,6 +86,8 @@ import (
 	"github.com/projectcapsule/capsule/internal/webhook/serviceaccounts"
 	tenantmutation "github.com/projectcapsule/capsule/internal/webhook/tenant/mutation"
 	tenantvalidation "github.com/projectcapsule/capsule/internal/webhook/tenant/validation"
+	tenantresourcevalidation "github.com/projectcapsule/capsule/internal/webhook/tenantresource"
+	"github.com/projectcapsule/capsule/pkg/ruleengine"
 	"github.com/projectcapsule/capsule/pkg/runtime/configuration"
 	evt "github.com/projectcapsule/capsule/pkg/runtime/events"
 	"github.com/projectcapsule/capsule/pkg/runtime/handlers"
@@ -148,6 +151,10 @@ func main() {
 
 		cacheSyncTimeout time.Duration
 
+		ruleAuditInterval time.Duration
+
+		ledgerAuditInterval time.Duration
+
 		leaderElectionLeaseDuration time.Duration
 		leaderElectionRenewDeadline time.Duration
 		leaderElectionRetryPeriod   time.Duration
@@ -248,6 +255,18 @@ func main() {
 		0,
 		"The timeout used when waiting for controller cache synchronization. If unset or 0, the controller-runtime default is used.",
 	)
+	flag.DurationVar(
+		&ruleAuditInterval,
+		"rule-audit-interval",
+		10*time.Minute,
+		"The interval at which existing objects are audited against namespace rules. If 0, existing objects are not audited.",
+	)
+	flag.DurationVar(
+		&ledgerAuditInterval,
+		"ledger-audit-interval",
+		10*time.Minute,
+		"The interval at which QuantityLedgers are audited against the live usage and repaired. If 0, QuantityLedgers are not audited.",
+	)
 	flag.DurationVar(
 		&leaderElectionLeaseDuration,
 		"leader-election-lease-duration",
@@ -708,6 +727,7 @@ func main() {
 		rulesgenericmutation.Register(cfg),
 		rulesgenericvalidation.Register(
 			regexCache,
+			celCache,
 			cfg,
 			rulesgenericvalidation.ForKind(
 				corev1.SchemeGroupVersion.WithKind("Pod").GroupKind(),
@@ -823,6 +843,7 @@ func main() {
 			celCache,
 		)),
 		route.GlobalResourceQuotaCalculation(globalresourcequotavalidation.Handler()),
+		route.TenantResourceValidation(tenantresourcevalidation.ChartsHandler(cfg)),
 		route.CalculationCustomQuotas(
 			customquotavalidation.ObjectCalculationHandler(
 				targetsCache,
@@ -932,6 +953,12 @@ func main() {
 		RESTConfig: manager.GetConfig(),
 		Log:        ctrl.Log.WithName("capsule.ctrl").WithName("ruleset"),
 		Metrics:    metrics.MustMakeRuleStatusRecorder(),
+		Evaluator: ruleengine.ObjectEvaluators{
+			rulesgenericvalidation.Evaluator(regexCache, celCache),
+			podrules.PodEvaluator(regexCache, registryCache),
+			servicerules.ServiceEvaluator(regexCache),
+		},
+		AuditInterval: ruleAuditInterval,
 	}).SetupWithManager(manager, controllerConfig); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "RuleSet")
 		os.Exit(1)
@@ -962,6 +989,7 @@ func main() {
 		cfg,
 		controllerConfig,
 		impersonationCache,
+		manager.GetEventRecorder("tenantresources-ctrl"),
 	); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "tenantresources")
 		os.Exit(1)
@@ -988,16 +1016,30 @@ func main() {
 		os.Exit(1)
 	}
 
+	ledgerRecorder := metrics.MustMakeQuantityLedgerRecorder()
+
 	if err := globalresourcequotacontroller.Add(
 		ctrl.Log.WithName("capsule.ctrl").WithName("globalresourcequotas"),
 		manager,
 		manager.GetEventRecorder("globalresourcequotas-ctrl"),
 		controllerConfig,
+		ledgerAuditInterval,
+		ledgerRecorder,
 	); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "globalresourcequotas")
 		os.Exit(1)
 	}
 
+	if err := chargebackcontroller.Add(
+		ctrl.Log.WithName("capsule.ctrl").WithName("chargeback"),
+		manager,
+		cfg,
+		controllerConfig,
+	); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "chargeback")
+		os.Exit(1)
+	}
+
 	if err = customquotacontroller.Add(ctrl.Log.WithName("controllers").WithName("CustomQuotas"),
 		manager,
 		manager.GetEventRecorder("customquotas-ctrl"),
@@ -1005,6 +1047,8 @@ func main() {
 		jsonPathCache,
 		celCache,
 		targetsCache,
+		ledgerAuditInterval,
+		ledgerRecorder,
 	); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "customquotas")
 		os.Exit(1)
@@ -50,6 +50,13 @@ func (c *CELCache) GetOrCompileQuantity(
 	return c.getOrCompile(expression, celruntime.ResultTypeQuantity, mode)
 }
 
+func (c *CELCache) GetOrCompileRule(
+	expression string,
+	mode environment.Type,
+) (*celruntime.CompiledExpression, error) {
+	return c.getOrCompile(expression, celruntime.ResultTypeRule, mode)
+}
+
 func (c *CELCache) DeleteMany(expressions ...string) int {
 	if c == nil {
 		return 0
@@ -123,6 +130,8 @@ func (c *CELCache) getOrCompile(
 		compiled, err = c.compiler.CompileBoolean(expression, mode)
 	case celruntime.ResultTypeQuantity:
 		compiled, err = c.compiler.CompileQuantity(expression, mode)
+	case celruntime.ResultTypeRule:
+		compiled, err = c.compiler.CompileRule(expression, mode)
 	default:
 		err = fmt.Errorf("unsupported CEL result type %q", resultType)
 	}
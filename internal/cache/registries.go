@@ -29,6 +29,17 @@ type CompiledRule struct {
 	RegexID string
 
 	AllowedPolicy map[corev1.PullPolicy]struct{} // nil/empty => allow any
+
+	// Reference constraints, enforced once the registry rule matched.
+	RequireDigest bool
+	ForbiddenTags []runtime.ExpressionMatch
+	Digests       []rules.OCIDigest
+}
+
+// HasReferenceConstraints reports whether the rule constrains tags or digests
+// of matching references.
+func (r *CompiledRule) HasReferenceConstraints() bool {
+	return r.RequireDigest || len(r.ForbiddenTags) > 0 || len(r.Digests) > 0
 }
 
 func (r *CompiledRule) AllowsPullPolicy(pullPolicy corev1.PullPolicy) bool {
@@ -232,6 +243,7 @@ func (c *RegistryRuleSetCache) HashRules(specRules []rules.OCIRegistry) string {
 		sepRule  = "\n"
 		sepField = "\x1f"
 		sepList  = "\x1e"
+		sepGroup = "\x1d"
 	)
 
 	for _, r := range specRules {
@@ -247,6 +259,28 @@ func (c *RegistryRuleSetCache) HashRules(specRules []rules.OCIRegistry) string {
 
 		sort.Strings(policies)
 
+		forbiddenTags := make([]string, 0, len(r.ForbiddenTags))
+		for _, tag := range r.ForbiddenTags {
+			tagExact := append([]string(nil), tag.Exact...)
+			sort.Strings(tagExact)
+
+			forbiddenTags = append(forbiddenTags, fmt.Sprintf(
+				"%s%s%s%s%t",
+				strings.Join(tagExact, sepList),
+				sepField,
+				strings.TrimSpace(tag.Expression),
+				sepField,
+				tag.Negate,
+			))
+		}
+
+		digests := make([]string, 0, len(r.Digests))
+		for _, digest := range r.Digests {
+			digests = append(digests, strings.TrimSpace(digest.Digest)+"@"+strings.TrimSpace(digest.Tag))
+		}
+
+		sort.Strings(digests)
+
 		b.WriteString("exact")
 		b.WriteString(sepField)
 
@@ -285,6 +319,26 @@ func (c *RegistryRuleSetCache) HashRules(specRules []rules.OCIRegistry) string {
 			b.WriteString(p)
 		}
 
+		b.WriteString(sepField)
+		b.WriteString("requireDigest")
+		b.WriteString(sepField)
+
+		if r.RequireDigest {
+			b.WriteString("1")
+		} else {
+			b.WriteString("0")
+		}
+
+		b.WriteString(sepField)
+		b.WriteString("forbiddenTags")
+		b.WriteString(sepField)
+		b.WriteString(strings.Join(forbiddenTags, sepGroup))
+
+		b.WriteString(sepField)
+		b.WriteString("digests")
+		b.WriteString(sepField)
+		b.WriteString(strings.Join(digests, sepList))
+
 		b.WriteString(sepRule)
 	}
 
@@ -347,7 +401,10 @@ func (c *RegistryRuleSetCache) buildRuleSet(id string, specRules []rules.OCIRegi
 		}
 
 		cr := CompiledRule{
-			Match: match,
+			Match:         match,
+			RequireDigest: r.RequireDigest,
+			ForbiddenTags: r.ForbiddenTags,
+			Digests:       r.Digests,
 		}
 
 		if strings.TrimSpace(match.Expression) != "" {
@@ -359,6 +416,20 @@ func (c *RegistryRuleSetCache) buildRuleSet(id string, specRules []rules.OCIRegi
 			cr.RegexID = compiled.ID
 		}
 
+		for _, tag := range r.ForbiddenTags {
+			if len(tag.Exact) == 0 && strings.TrimSpace(tag.Expression) == "" {
+				return nil, fmt.Errorf("forbidden tag rule must define at least one of exact or exp")
+			}
+
+			if strings.TrimSpace(tag.Expression) == "" {
+				continue
+			}
+
+			if _, _, err := c.regexCache.GetOrCompile(tag.ExpressionRegex); err != nil {
+				return nil, err
+			}
+		}
+
 		if len(r.Policy) > 0 {
 			cr.AllowedPolicy = make(map[corev1.PullPolicy]struct{}, len(r.Policy))
 
@@ -750,6 +750,33 @@ func TestRegistryRuleSetCacheHashRules(t *testing.T) {
 		}
 	})
 
+	t.Run("reference constraints produce different hash", func(t *testing.T) {
+		t.Parallel()
+
+		c := NewRegistryRuleSetCache(nil)
+
+		base := registry("harbor/.*")
+
+		requireDigest := registry("harbor/.*")
+		requireDigest.RequireDigest = true
+
+		forbiddenTags := registry("harbor/.*")
+		forbiddenTags.ForbiddenTags = []runtime.ExpressionMatch{{Exact: []string{"latest"}}}
+
+		digests := registry("harbor/.*")
+		digests.Digests = []rules.OCIDigest{{Digest: "sha256:" + strings.Repeat("a", 64)}}
+
+		seen := map[string]struct{}{}
+		for _, specRule := range []rules.OCIRegistry{base, requireDigest, forbiddenTags, digests} {
+			hash := c.HashRules([]rules.OCIRegistry{specRule})
+			if _, ok := seen[hash]; ok {
+				t.Fatalf("expected distinct hashes, got duplicate %q", hash)
+			}
+
+			seen[hash] = struct{}{}
+		}
+	})
+
 	t.Run("rule order affects hash", func(t *testing.T) {
 		t.Parallel()
 
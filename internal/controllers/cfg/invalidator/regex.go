@@ -73,6 +73,8 @@ func collectRegexExpressionsFromNamespaceRule(
 	}
 
 	for _, registry := range rule.Enforce.Workloads.Registries {
+		collectRegexExpressionsFromMatches(set, registry.ForbiddenTags)
+
 		expr := registry.ExpressionRegex
 		if expr.Expression == "" {
 			continue
@@ -90,6 +92,24 @@ func collectRegexExpressionsFromNamespaceRule(
 		set[cache.HashRegex(expr)] = expr
 	}
 
+	if sc := rule.Enforce.Workloads.SecurityContext; sc != nil {
+		collectRegexExpressionsFromMatches(set, sc.Capabilities, sc.SeccompProfiles, sc.AppArmorProfiles)
+	}
+
+	if scheduling := rule.Enforce.Workloads.Scheduling; scheduling != nil {
+		for _, toleration := range scheduling.Tolerations {
+			for _, match := range []*runtime.ExpressionMatch{toleration.Key, toleration.Effect} {
+				if match != nil {
+					collectRegexExpressionsFromMatches(set, []runtime.ExpressionMatch{*match})
+				}
+			}
+		}
+	}
+
+	if volumes := rule.Enforce.Workloads.Volumes; volumes != nil {
+		collectRegexExpressionsFromMatches(set, volumes.Types, volumes.CSIDrivers)
+	}
+
 	for _, metadataRule := range rule.Enforce.Metadata {
 		for selector := range metadataRule.Labels {
 			expr := rules.MetadataKeyExpression(selector)
@@ -102,3 +122,19 @@ func collectRegexExpressionsFromNamespaceRule(
 		}
 	}
 }
+
+func collectRegexExpressionsFromMatches(
+	set map[string]runtime.ExpressionRegex,
+	matches ...[]runtime.ExpressionMatch,
+) {
+	for _, list := range matches {
+		for _, match := range list {
+			expr := match.ExpressionRegex
+			if expr.Expression == "" {
+				continue
+			}
+
+			set[cache.HashRegex(expr)] = expr
+		}
+	}
+}
@@ -0,0 +1,225 @@
+// Copyright 2020-2026 Project Capsule Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package chargeback
+
+import (
+	"context"
+	"reflect"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	ctrlutils "github.com/projectcapsule/capsule/internal/controllers/utils"
+	"github.com/projectcapsule/capsule/internal/metrics"
+	"github.com/projectcapsule/capsule/pkg/runtime/configuration"
+)
+
+type Controller struct {
+	client.Client
+
+	reader        client.Reader
+	log           logr.Logger
+	configuration configuration.Configuration
+	metrics       *metrics.ChargebackRecorder
+}
+
+func (r *Controller) SetupWithManager(mgr ctrl.Manager, options ctrlutils.ControllerOptions) error {
+	r.reader = mgr.GetAPIReader()
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("capsule/chargeback").
+		For(
+			&capsulev1beta2.Tenant{},
+			builder.WithPredicates(predicate.GenerationChangedPredicate{}),
+		).
+		Watches(
+			&capsulev1beta2.CapsuleConfiguration{},
+			handler.EnqueueRequestsFromMapFunc(r.enqueueAllTenants),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{}),
+		).
+		WithOptions(options.Runtime.ToControllerOptions()).
+		Complete(r)
+}
+
+func (r *Controller) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.Result, error) {
+	log := r.log.WithValues("Request.Name", request.Name)
+
+	instance := &capsulev1beta2.Tenant{}
+	if err := r.Get(ctx, request.NamespacedName, instance); err != nil {
+		if apierrors.IsNotFound(err) {
+			r.metrics.DeleteAllMetricsForTenant(request.Name)
+
+			return ctrl.Result{}, nil
+		}
+
+		return ctrl.Result{}, err
+	}
+
+	cfg := r.configuration.Chargeback()
+	if cfg == nil {
+		log.V(5).Info("chargeback is not configured")
+
+		r.metrics.DeleteAllMetricsForTenant(instance.Name)
+
+		return ctrl.Result{}, r.updateStatus(ctx, instance, func(latest *capsulev1beta2.Tenant) {
+			latest.Status.Chargeback = nil
+		})
+	}
+
+	allocated, err := r.allocatedResources(ctx, instance)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	used, err := r.usedResources(ctx, instance)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	var allocatedCosts, usedCosts corev1.ResourceList
+
+	err = r.updateStatus(ctx, instance, func(latest *capsulev1beta2.Tenant) {
+		allocatedCosts, usedCosts = sample(cfg, latest, allocated, used, time.Now())
+	})
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	r.metrics.Record(instance.Name, metrics.ChargebackTypeAllocated, allocated, allocatedCosts)
+	r.metrics.Record(instance.Name, metrics.ChargebackTypeUsed, used, usedCosts)
+
+	log.V(5).Info("sampled chargeback", "allocated", allocated, "used", used)
+
+	return ctrl.Result{RequeueAfter: cfg.Interval.Duration}, nil
+}
+
+// Updates the chargeback status on the latest version of the tenant. The status of the tenant
+// is owned by the tenant controller, therefore only the chargeback status is written.
+func (r *Controller) updateStatus(
+	ctx context.Context,
+	instance *capsulev1beta2.Tenant,
+	mutate func(*capsulev1beta2.Tenant),
+) error {
+	return retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		latest := &capsulev1beta2.Tenant{}
+		if err := r.reader.Get(ctx, types.NamespacedName{Name: instance.GetName()}, latest); err != nil {
+			if apierrors.IsNotFound(err) {
+				return nil
+			}
+
+			return err
+		}
+
+		original := latest.Status.Chargeback.DeepCopy()
+
+		mutate(latest)
+
+		if reflect.DeepEqual(original, latest.Status.Chargeback) {
+			return nil
+		}
+
+		return r.Client.Status().Update(ctx, latest)
+	})
+}
+
+// Samples the allocated and used resources of the tenant. The costs since the previous sample
+// are accumulated based on the resources of the previous sample, as these resources were allocated
+// and used until now. Returns the costs which were accumulated per resource.
+func sample(
+	cfg *capsulev1beta2.ChargebackConfiguration,
+	tnt *capsulev1beta2.Tenant,
+	allocated corev1.ResourceList,
+	used corev1.ResourceList,
+	now time.Time,
+) (allocatedCosts corev1.ResourceList, usedCosts corev1.ResourceList) {
+	now = now.Truncate(time.Second)
+
+	status := tnt.Status.Chargeback
+
+	// Costs are not comparable when the currency changed, the accumulation starts again.
+	if status == nil || status.Currency != cfg.Currency {
+		status = &capsulev1beta2.TenantChargebackStatus{
+			Currency: cfg.Currency,
+			Since:    metav1.NewTime(now),
+		}
+	}
+
+	allocatedCosts = corev1.ResourceList{}
+	usedCosts = corev1.ResourceList{}
+
+	if !status.LastSampleTime.IsZero() && now.After(status.LastSampleTime.Time) {
+		elapsed := now.Sub(status.LastSampleTime.Time)
+
+		allocatedCosts = cfg.Costs(status.Allocated, elapsed)
+		usedCosts = cfg.Costs(status.Used, elapsed)
+	}
+
+	status.AllocatedCost = addCosts(status.AllocatedCost, &status.TotalAllocatedCost, allocatedCosts)
+	status.UsedCost = addCosts(status.UsedCost, &status.TotalUsedCost, usedCosts)
+	status.Allocated = allocated
+	status.Used = used
+	status.LastSampleTime = metav1.NewTime(now)
+
+	tnt.Status.Chargeback = status
+
+	return allocatedCosts, usedCosts
+}
+
+// Adds the costs to the accumulated costs per resource and to the total.
+func addCosts(accumulated corev1.ResourceList, total *resource.Quantity, costs corev1.ResourceList) corev1.ResourceList {
+	if accumulated == nil {
+		accumulated = corev1.ResourceList{}
+	}
+
+	for name, cost := range costs {
+		current, ok := accumulated[name]
+		if !ok {
+			current = resource.Quantity{Format: resource.DecimalSI}
+		}
+
+		current.Add(cost)
+		accumulated[name] = current
+
+		total.Add(cost)
+	}
+
+	if len(accumulated) == 0 {
+		return nil
+	}
+
+	return accumulated
+}
+
+func (r *Controller) enqueueAllTenants(ctx context.Context, _ client.Object) []reconcile.Request {
+	var tenants capsulev1beta2.TenantList
+	if err := r.List(ctx, &tenants); err != nil {
+		r.log.Error(err, "failed to list Tenants")
+
+		return nil
+	}
+
+	reqs := make([]reconcile.Request, 0, len(tenants.Items))
+	for i := range tenants.Items {
+		reqs = append(reqs, reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name: tenants.Items[i].Name,
+			},
+		})
+	}
+
+	return reqs
+}
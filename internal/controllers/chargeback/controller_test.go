@@ -0,0 +1,345 @@
+// Copyright 2020-2026 Project Capsule Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package chargeback
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	"github.com/projectcapsule/capsule/internal/metrics"
+	"github.com/projectcapsule/capsule/pkg/api"
+)
+
+func chargebackConfiguration() *capsulev1beta2.ChargebackConfiguration {
+	return &capsulev1beta2.ChargebackConfiguration{
+		Currency: "USD",
+		Interval: metav1.Duration{Duration: 5 * time.Minute},
+		Prices: []capsulev1beta2.ChargebackPrice{
+			{
+				Resource: corev1.ResourceRequestsCPU,
+				Price:    resource.MustParse("0.06"),
+			},
+		},
+	}
+}
+
+func TestSample(t *testing.T) {
+	t.Parallel()
+
+	cfg := chargebackConfiguration()
+	start := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name              string
+		status            *capsulev1beta2.TenantChargebackStatus
+		now               time.Time
+		expectedCost      string
+		expectedTotal     string
+		expectedSince     time.Time
+		expectedIncrement string
+	}{
+		{
+			name:              "First sample does not accumulate costs",
+			now:               start,
+			expectedCost:      "",
+			expectedTotal:     "0",
+			expectedSince:     start,
+			expectedIncrement: "",
+		},
+		{
+			name: "Accumulates the previous sample over the elapsed time",
+			status: &capsulev1beta2.TenantChargebackStatus{
+				Currency:       "USD",
+				Since:          metav1.NewTime(start),
+				LastSampleTime: metav1.NewTime(start),
+				Allocated: corev1.ResourceList{
+					corev1.ResourceRequestsCPU: resource.MustParse("2"),
+				},
+				AllocatedCost: corev1.ResourceList{
+					corev1.ResourceRequestsCPU: resource.MustParse("1"),
+				},
+				TotalAllocatedCost: resource.MustParse("1"),
+			},
+			now:               start.Add(30 * time.Minute),
+			expectedCost:      "1060m",
+			expectedTotal:     "1060m",
+			expectedSince:     start,
+			expectedIncrement: "60m",
+		},
+		{
+			name: "Restarts accumulation when the currency changed",
+			status: &capsulev1beta2.TenantChargebackStatus{
+				Currency:       "EUR",
+				Since:          metav1.NewTime(start),
+				LastSampleTime: metav1.NewTime(start),
+				Allocated: corev1.ResourceList{
+					corev1.ResourceRequestsCPU: resource.MustParse("2"),
+				},
+				AllocatedCost: corev1.ResourceList{
+					corev1.ResourceRequestsCPU: resource.MustParse("1"),
+				},
+				TotalAllocatedCost: resource.MustParse("1"),
+			},
+			now:               start.Add(time.Hour),
+			expectedCost:      "",
+			expectedTotal:     "0",
+			expectedSince:     start.Add(time.Hour),
+			expectedIncrement: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tnt := &capsulev1beta2.Tenant{}
+			tnt.Status.Chargeback = tt.status
+
+			allocated := corev1.ResourceList{
+				corev1.ResourceRequestsCPU: resource.MustParse("4"),
+			}
+
+			allocatedCosts, _ := sample(cfg, tnt, allocated, corev1.ResourceList{}, tt.now)
+
+			status := tnt.Status.Chargeback
+			if status == nil {
+				t.Fatal("expected chargeback status")
+			}
+
+			if status.Currency != cfg.Currency {
+				t.Fatalf("currency = %q, want %q", status.Currency, cfg.Currency)
+			}
+
+			if !status.Since.Time.Equal(tt.expectedSince) {
+				t.Fatalf("since = %s, want %s", status.Since.Time, tt.expectedSince)
+			}
+
+			if !status.LastSampleTime.Time.Equal(tt.now) {
+				t.Fatalf("last sample time = %s, want %s", status.LastSampleTime.Time, tt.now)
+			}
+
+			cpu := status.Allocated[corev1.ResourceRequestsCPU]
+			if cpu.String() != "4" {
+				t.Fatalf("allocated cpu = %s, want 4", cpu.String())
+			}
+
+			cost, ok := status.AllocatedCost[corev1.ResourceRequestsCPU]
+			if tt.expectedCost == "" {
+				if ok {
+					t.Fatalf("unexpected allocated cost %s", cost.String())
+				}
+			} else if cost.String() != tt.expectedCost {
+				t.Fatalf("allocated cost = %s, want %s", cost.String(), tt.expectedCost)
+			}
+
+			if status.TotalAllocatedCost.String() != tt.expectedTotal {
+				t.Fatalf("total allocated cost = %s, want %s", status.TotalAllocatedCost.String(), tt.expectedTotal)
+			}
+
+			increment, ok := allocatedCosts[corev1.ResourceRequestsCPU]
+			if tt.expectedIncrement == "" {
+				if ok {
+					t.Fatalf("unexpected cost increment %s", increment.String())
+				}
+			} else if increment.String() != tt.expectedIncrement {
+				t.Fatalf("cost increment = %s, want %s", increment.String(), tt.expectedIncrement)
+			}
+		})
+	}
+}
+
+func chargebackClient(t *testing.T, objects ...client.Object) client.Client {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatalf("add client-go scheme: %v", err)
+	}
+
+	if err := capsulev1beta2.AddToScheme(scheme); err != nil {
+		t.Fatalf("add Capsule scheme: %v", err)
+	}
+
+	return fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithStatusSubresource(&capsulev1beta2.Tenant{}).
+		WithObjects(objects...).
+		Build()
+}
+
+func TestCollectResources(t *testing.T) {
+	t.Parallel()
+
+	tnt := &capsulev1beta2.Tenant{
+		ObjectMeta: metav1.ObjectMeta{Name: "solar"},
+		Spec: capsulev1beta2.TenantSpec{
+			ResourceQuota: api.ResourceQuotaSpec{
+				Scope: api.ResourceQuotaScopeNamespace,
+				Items: []corev1.ResourceQuotaSpec{
+					{
+						Hard: corev1.ResourceList{
+							corev1.ResourceRequestsMemory: resource.MustParse("1Gi"),
+						},
+					},
+				},
+			},
+		},
+		Status: capsulev1beta2.TenantStatus{
+			Namespaces: []string{"solar-dev", "solar-prod"},
+		},
+	}
+
+	pool := &capsulev1beta2.ResourcePool{
+		ObjectMeta: metav1.ObjectMeta{Name: "pool"},
+		Status: capsulev1beta2.ResourcePoolStatus{
+			Claims: capsulev1beta2.ResourcePoolNamespaceClaimsStatus{
+				"solar-dev": {
+					{Claims: corev1.ResourceList{corev1.ResourceRequestsCPU: resource.MustParse("1")}},
+					{Claims: corev1.ResourceList{corev1.ResourceRequestsCPU: resource.MustParse("500m")}},
+				},
+				"wind-dev": {
+					{Claims: corev1.ResourceList{corev1.ResourceRequestsCPU: resource.MustParse("8")}},
+				},
+			},
+		},
+	}
+
+	quotaStatus := func(namespace, name string, used corev1.ResourceList) *corev1.ResourceQuota {
+		return &corev1.ResourceQuota{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Status:     corev1.ResourceQuotaStatus{Used: used},
+		}
+	}
+
+	globalQuota := &capsulev1beta2.GlobalResourceQuota{
+		ObjectMeta: metav1.ObjectMeta{Name: "global"},
+		Status: capsulev1beta2.GlobalResourceQuotaStatus{
+			NamespaceUsage: capsulev1beta2.GlobalResourceQuotaNamespaceUsage{
+				"solar-prod": {
+					Used: corev1.ResourceList{corev1.ResourceRequestsCPU: resource.MustParse("3")},
+				},
+			},
+		},
+	}
+
+	c := chargebackClient(t,
+		tnt,
+		pool,
+		globalQuota,
+		quotaStatus("solar-dev", "capsule-solar-0", corev1.ResourceList{
+			corev1.ResourceRequestsCPU: resource.MustParse("1"),
+		}),
+		quotaStatus("solar-dev", "capsule-pool-pool", corev1.ResourceList{
+			corev1.ResourceRequestsCPU: resource.MustParse("1200m"),
+		}),
+		quotaStatus("solar-prod", "capsule-solar-0", corev1.ResourceList{
+			corev1.ResourceRequestsCPU:    resource.MustParse("2"),
+			corev1.ResourceRequestsMemory: resource.MustParse("512Mi"),
+		}),
+		quotaStatus("wind-dev", "capsule-wind-0", corev1.ResourceList{
+			corev1.ResourceRequestsCPU: resource.MustParse("16"),
+		}),
+	)
+
+	r := &Controller{Client: c, reader: c, log: logr.Discard()}
+
+	allocated, err := r.allocatedResources(context.Background(), tnt)
+	if err != nil {
+		t.Fatalf("allocated resources: %v", err)
+	}
+
+	used, err := r.usedResources(context.Background(), tnt)
+	if err != nil {
+		t.Fatalf("used resources: %v", err)
+	}
+
+	expect := func(kind string, list corev1.ResourceList, name corev1.ResourceName, expected string) {
+		t.Helper()
+
+		quantity := list[name]
+		if quantity.Cmp(resource.MustParse(expected)) != 0 {
+			t.Fatalf("%s %s = %s, want %s", kind, name, quantity.String(), expected)
+		}
+	}
+
+	expect("allocated", allocated, corev1.ResourceRequestsCPU, "1500m")
+	expect("allocated", allocated, corev1.ResourceRequestsMemory, "2Gi")
+	expect("used", used, corev1.ResourceRequestsCPU, "4200m")
+	expect("used", used, corev1.ResourceRequestsMemory, "512Mi")
+
+	tnt.Spec.ResourceQuota.Scope = api.ResourceQuotaScopeTenant
+
+	allocated, err = r.allocatedResources(context.Background(), tnt)
+	if err != nil {
+		t.Fatalf("allocated resources: %v", err)
+	}
+
+	expect("allocated", allocated, corev1.ResourceRequestsMemory, "1Gi")
+}
+
+func TestUpdateStatusWritesChargebackOnly(t *testing.T) {
+	t.Parallel()
+
+	stored := &capsulev1beta2.Tenant{
+		ObjectMeta: metav1.ObjectMeta{Name: "solar"},
+		Status: capsulev1beta2.TenantStatus{
+			State: capsulev1beta2.TenantStateActive,
+			Size:  2,
+		},
+	}
+
+	c := chargebackClient(t, stored)
+	r := &Controller{Client: c, reader: c, log: logr.Discard(), metrics: metrics.NewChargebackRecorder()}
+
+	instance := stored.DeepCopy()
+	instance.Status.Size = 5
+
+	allocated := corev1.ResourceList{corev1.ResourceRequestsCPU: resource.MustParse("1")}
+
+	err := r.updateStatus(context.Background(), instance, func(latest *capsulev1beta2.Tenant) {
+		sample(chargebackConfiguration(), latest, allocated, nil, time.Now())
+	})
+	if err != nil {
+		t.Fatalf("update status: %v", err)
+	}
+
+	updated := &capsulev1beta2.Tenant{}
+	if err := c.Get(context.Background(), client.ObjectKey{Name: stored.Name}, updated); err != nil {
+		t.Fatalf("get tenant: %v", err)
+	}
+
+	if updated.Status.Chargeback == nil {
+		t.Fatal("expected chargeback status")
+	}
+
+	if updated.Status.Size != stored.Status.Size {
+		t.Fatalf("size = %d, want %d", updated.Status.Size, stored.Status.Size)
+	}
+
+	err = r.updateStatus(context.Background(), instance, func(latest *capsulev1beta2.Tenant) {
+		latest.Status.Chargeback = nil
+	})
+	if err != nil {
+		t.Fatalf("update status: %v", err)
+	}
+
+	if err := c.Get(context.Background(), client.ObjectKey{Name: stored.Name}, updated); err != nil {
+		t.Fatalf("get tenant: %v", err)
+	}
+
+	if updated.Status.Chargeback != nil {
+		t.Fatal("expected chargeback status to be removed")
+	}
+}
@@ -0,0 +1,34 @@
+// Copyright 2020-2026 Project Capsule Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package chargeback
+
+import (
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	"github.com/projectcapsule/capsule/internal/controllers/utils"
+	"github.com/projectcapsule/capsule/internal/metrics"
+	"github.com/projectcapsule/capsule/pkg/runtime/configuration"
+)
+
+func Add(
+	log logr.Logger,
+	mgr manager.Manager,
+	cfg configuration.Configuration,
+	ctrlConfig utils.ControllerOptions,
+) error {
+	controller := &Controller{
+		Client:        mgr.GetClient(),
+		log:           log,
+		configuration: cfg,
+		metrics:       metrics.MustMakeChargebackRecorder(),
+	}
+	if err := controller.SetupWithManager(mgr, ctrlConfig); err != nil {
+		return fmt.Errorf("unable to create Chargeback controller: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,104 @@
+// Copyright 2020-2026 Project Capsule Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package chargeback
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	"github.com/projectcapsule/capsule/pkg/api"
+)
+
+// Collects the resources allocated to the tenant. Allocated are the resources claimed by bound
+// resourcepool claims in the namespaces of the tenant and the hard resources of the resourcequotas
+// of the tenant. Resourcequotas with the namespace scope are allocated for each namespace.
+func (r *Controller) allocatedResources(ctx context.Context, tnt *capsulev1beta2.Tenant) (corev1.ResourceList, error) {
+	allocated := corev1.ResourceList{}
+
+	pools := &capsulev1beta2.ResourcePoolList{}
+	if err := r.List(ctx, pools); err != nil {
+		return nil, fmt.Errorf("list resourcepools: %w", err)
+	}
+
+	for _, pool := range pools.Items {
+		for _, namespace := range tnt.Status.Namespaces {
+			for _, claim := range pool.Status.Claims[namespace] {
+				addResources(allocated, claim.Claims, 1)
+			}
+		}
+	}
+
+	multiplier := int64(len(tnt.Status.Namespaces))
+	if tnt.Spec.ResourceQuota.Scope == api.ResourceQuotaScopeTenant {
+		multiplier = 1
+	}
+
+	for _, item := range tnt.Spec.ResourceQuota.Items {
+		addResources(allocated, item.Hard, multiplier)
+	}
+
+	return allocated, nil
+}
+
+// Collects the resources used by the tenant. For each namespace of the tenant the highest usage of
+// a resource observed by any resourcequota or globalresourcequota is used, as these quotas observe
+// the same objects.
+func (r *Controller) usedResources(ctx context.Context, tnt *capsulev1beta2.Tenant) (corev1.ResourceList, error) {
+	used := corev1.ResourceList{}
+
+	globalQuotas := &capsulev1beta2.GlobalResourceQuotaList{}
+	if err := r.List(ctx, globalQuotas); err != nil {
+		return nil, fmt.Errorf("list globalresourcequotas: %w", err)
+	}
+
+	for _, namespace := range tnt.Status.Namespaces {
+		quotas := &corev1.ResourceQuotaList{}
+		if err := r.List(ctx, quotas, client.InNamespace(namespace)); err != nil {
+			return nil, fmt.Errorf("list resourcequotas in namespace %s: %w", namespace, err)
+		}
+
+		namespaceUsed := corev1.ResourceList{}
+
+		for _, quota := range quotas.Items {
+			maxResources(namespaceUsed, quota.Status.Used)
+		}
+
+		for _, quota := range globalQuotas.Items {
+			if usage, ok := quota.Status.NamespaceUsage[namespace]; ok {
+				maxResources(namespaceUsed, usage.Used)
+			}
+		}
+
+		addResources(used, namespaceUsed, 1)
+	}
+
+	return used, nil
+}
+
+// Adds the resources multiplied by the multiplier to the list.
+func addResources(list corev1.ResourceList, resources corev1.ResourceList, multiplier int64) {
+	for name, quantity := range resources {
+		scaled := quantity.DeepCopy()
+		scaled.Mul(multiplier)
+
+		if current, ok := list[name]; ok {
+			scaled.Add(current)
+		}
+
+		list[name] = scaled
+	}
+}
+
+// Raises the resources of the list to the given resources.
+func maxResources(list corev1.ResourceList, resources corev1.ResourceList) {
+	for name, quantity := range resources {
+		if current, ok := list[name]; !ok || quantity.Cmp(current) > 0 {
+			list[name] = quantity.DeepCopy()
+		}
+	}
+}
@@ -49,6 +49,9 @@ type quotaUsageReconcileInput struct {
 	// Used for compiled target cache.
 	CacheKey     string
 	TargetsCache *cache.CompiledTargetsCache[string]
+
+	// Windowed quotas account admissions from their QuantityLedger, only the targets are resolved.
+	Windowed bool
 }
 
 type quotaUsageReconcileResult struct {
@@ -105,10 +108,15 @@ func reconcileQuotaUsage(
 		in.TargetsCache.Set(in.CacheKey, targets)
 	}
 
+	if in.Windowed {
+		return out, nil
+	}
+
 	var errs []error
 
 	itemsByGVK := make(map[schema.GroupVersionKind][]unstructured.Unstructured, len(out.Targets))
 	claimsByKey := make(map[quotaClaimKey]capsulev1beta2.CustomQuotaClaimItem)
+	distinctValues := make(map[string]struct{})
 
 	for _, target := range targets {
 		gvk := schema.GroupVersionKind{
@@ -161,7 +169,16 @@ func reconcileQuotaUsage(
 				continue
 			}
 
-			rawUsage, err := usageForTarget(ctx, item, target)
+			var rawUsage resource.Quantity
+
+			if target.Operation == quota.OpDistinct {
+				// Objects are sorted oldest first, a distinct value is claimed by the
+				// oldest object holding it.
+				rawUsage, err = introducedValuesForTarget(item, target, distinctValues)
+			} else {
+				rawUsage, err = usageForTarget(ctx, item, target)
+			}
+
 			if err != nil {
 				errs = append(errs, err)
 
@@ -176,9 +193,18 @@ func reconcileQuotaUsage(
 				out.Usage.Used.Add(accountingUsage)
 				quota.ClampQuantityToZero(&out.Usage.Used)
 
-			case quota.OpAdd, quota.OpCount:
+			case quota.OpAdd, quota.OpCount, quota.OpDistinct:
 				out.Usage.Used.Add(accountingUsage)
 
+			case quota.OpMax, quota.OpPerObject:
+				// Capping operations are enforced on each object by admission,
+				// there are no reservations which have to be materialized by claims.
+				if accountingUsage.Cmp(out.Usage.Used) > 0 {
+					out.Usage.Used = accountingUsage
+				}
+
+				continue
+
 			default:
 				errs = append(errs, fmt.Errorf(
 					"unsupported operation %q for %s/%s (%s)",
@@ -235,6 +261,15 @@ func reconcileQuotaUsage(
 
 	quota.ClampQuantityToZero(&out.Usage.Used)
 
+	if len(distinctValues) > 0 {
+		out.Usage.Values = make([]string, 0, len(distinctValues))
+		for value := range distinctValues {
+			out.Usage.Values = append(out.Usage.Values, value)
+		}
+
+		sort.Strings(out.Usage.Values)
+	}
+
 	out.Usage.Available = limit.DeepCopy()
 	out.Usage.Available.Sub(out.Usage.Used)
 	quota.ClampQuantityToZero(&out.Usage.Available)
@@ -272,21 +307,8 @@ func usageForTarget(
 	case quota.OpCount:
 		return *resource.NewQuantity(1, resource.DecimalSI), nil
 
-	case quota.OpAdd, quota.OpSub:
-		var (
-			usage resource.Quantity
-			err   error
-		)
-
-		switch {
-		case target.CompiledCEL != nil:
-			usage, err = target.CompiledCEL.EvaluateQuantity(ctx, item)
-		case target.CompiledPath != nil:
-			usage, err = quota.ParseQuantityFromUnstructured(item, target.CompiledPath)
-		default:
-			err = fmt.Errorf("compiled usage expression is missing")
-		}
-
+	case quota.OpAdd, quota.OpSub, quota.OpMax, quota.OpPerObject:
+		usage, err := quota.EvaluateQuantity(ctx, item, target.Operation, target.CompiledPath, target.CompiledCEL)
 		if err != nil {
 			return resource.Quantity{}, fmt.Errorf(
 				"get usage from %s/%s (%s) path %q cel %q op %q: %w",
@@ -312,3 +334,36 @@ func usageForTarget(
 		)
 	}
 }
+
+// Counts the distinct values of the item which are not part of the already observed values.
+// The introduced values are added to the observed values.
+func introducedValuesForTarget(
+	item unstructured.Unstructured,
+	target cache.CompiledTarget,
+	observed map[string]struct{},
+) (resource.Quantity, error) {
+	values, err := quota.EvaluateDistinctValues(item, target.CompiledPath)
+	if err != nil {
+		return resource.Quantity{}, fmt.Errorf(
+			"get distinct values from %s/%s (%s) path %q: %w",
+			item.GetNamespace(),
+			item.GetName(),
+			item.GetObjectKind().GroupVersionKind().String(),
+			target.Path,
+			err,
+		)
+	}
+
+	introduced := int64(0)
+
+	for _, value := range values {
+		if _, ok := observed[value]; ok {
+			continue
+		}
+
+		observed[value] = struct{}{}
+		introduced++
+	}
+
+	return *resource.NewQuantity(introduced, resource.DecimalSI), nil
+}
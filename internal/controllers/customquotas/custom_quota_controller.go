@@ -189,12 +189,18 @@ func (r *customQuotaClaimController) reconcile(
 
 		CacheKey:     MakeCustomQuotaCacheKey(instance.GetNamespace(), instance.GetName()),
 		TargetsCache: r.targetsCache,
+
+		Windowed: instance.Spec.IsWindowed(),
 	}, instance.Spec.Limit)
 
 	instance.Status.Targets = result.Targets
-	instance.Status.Usage = result.Usage
 	instance.Status.Claims = result.Claims
 
+	// The usage of windowed quotas is accounted from the QuantityLedger.
+	if !instance.Spec.IsWindowed() {
+		instance.Status.Usage = result.Usage
+	}
+
 	return err
 }
 
@@ -208,6 +214,23 @@ func (r *customQuotaClaimController) reconcileLedger(
 		Namespace: instance.GetNamespace(),
 	}
 
+	if instance.Spec.IsWindowed() {
+		usage, requeueAfter, err := reconcileWindowedQuantityLedger(
+			ctx,
+			r.Client,
+			r.reader,
+			log,
+			key,
+			instance.Spec.GetWindow(),
+			instance.Spec.Limit,
+		)
+		if err == nil {
+			instance.Status.Usage = usage
+		}
+
+		return requeueAfter, err
+	}
+
 	return reconcileQuantityLedgerAllocation(
 		ctx,
 		r.Client,
@@ -334,6 +357,8 @@ func (r *customQuotaClaimController) updateStatus(
 
 		latest.Status.Conditions.UpdateConditionByType(readyCondition)
 
+		updateNearLimitCondition(instance, &instance.Spec, &latest.Status)
+
 		if reflect.DeepEqual(*originalStatus, latest.Status) {
 			return nil
 		}
@@ -254,21 +254,9 @@ func (r *clusterCustomQuotaClaimController) reconcile(
 	log logr.Logger,
 	instance *capsulev1beta2.GlobalCustomQuota,
 ) error {
-	var namespaces []string
-
-	var err error
-
-	if len(instance.Spec.NamespaceSelectors) > 0 {
-		namespaces, err = selectors.GetNamespacesMatchingSelectorsStrings(
-			ctx,
-			r.Client,
-			instance.Spec.NamespaceSelectors,
-		)
-		if err != nil {
-			return err
-		}
-	} else {
-		namespaces = []string{"*"}
+	namespaces, err := globalCustomQuotaNamespaces(ctx, r.Client, instance)
+	if err != nil {
+		return err
 	}
 
 	instance.Status.Namespaces = namespaces
@@ -291,15 +279,34 @@ func (r *clusterCustomQuotaClaimController) reconcile(
 
 		CacheKey:     MakeGlobalCustomQuotaCacheKey(instance.GetName()),
 		TargetsCache: r.targetsCache,
+
+		Windowed: instance.Spec.IsWindowed(),
 	}, instance.Spec.Limit)
 
 	instance.Status.Targets = result.Targets
-	instance.Status.Usage = result.Usage
 	instance.Status.Claims = result.Claims
 
+	// The usage of windowed quotas is accounted from the QuantityLedger.
+	if !instance.Spec.IsWindowed() {
+		instance.Status.Usage = result.Usage
+	}
+
 	return err
 }
 
+// Gets the namespaces selected by the quota, all namespaces if the quota has no namespace selectors.
+func globalCustomQuotaNamespaces(
+	ctx context.Context,
+	c client.Client,
+	instance *capsulev1beta2.GlobalCustomQuota,
+) ([]string, error) {
+	if len(instance.Spec.NamespaceSelectors) == 0 {
+		return []string{"*"}, nil
+	}
+
+	return selectors.GetNamespacesMatchingSelectorsStrings(ctx, c, instance.Spec.NamespaceSelectors)
+}
+
 func (r *clusterCustomQuotaClaimController) reconcileLedger(
 	ctx context.Context,
 	log logr.Logger,
@@ -310,6 +317,23 @@ func (r *clusterCustomQuotaClaimController) reconcileLedger(
 		Namespace: configuration.ControllerNamespace(),
 	}
 
+	if instance.Spec.IsWindowed() {
+		usage, requeueAfter, err := reconcileWindowedQuantityLedger(
+			ctx,
+			r.Client,
+			r.reader,
+			log,
+			key,
+			instance.Spec.GetWindow(),
+			instance.Spec.Limit,
+		)
+		if err == nil {
+			instance.Status.Usage = usage
+		}
+
+		return requeueAfter, err
+	}
+
 	return reconcileQuantityLedgerAllocation(
 		ctx,
 		r.Client,
@@ -434,6 +458,8 @@ func (r *clusterCustomQuotaClaimController) updateStatus(
 
 		latest.Status.Conditions.UpdateConditionByType(readyCondition)
 
+		updateNearLimitCondition(instance, &instance.Spec.CustomQuotaSpec, &latest.Status.CustomQuotaStatus)
+
 		if reflect.DeepEqual(*originalStatus, latest.Status) {
 			return nil
 		}
@@ -0,0 +1,331 @@
+// Copyright 2020-2026 Project Capsule Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package customquotas
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	k8smeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	"github.com/projectcapsule/capsule/internal/cache"
+	cutils "github.com/projectcapsule/capsule/internal/controllers/utils"
+	"github.com/projectcapsule/capsule/internal/metrics"
+	"github.com/projectcapsule/capsule/pkg/api/meta"
+	"github.com/projectcapsule/capsule/pkg/runtime/quota"
+)
+
+// Resource name under which the drift of custom quota ledgers is recorded.
+const ledgerDriftResource corev1.ResourceName = "usage"
+
+// Periodically audits the QuantityLedgers of CustomQuotas and GlobalCustomQuotas. Reservations and
+// pending deletes can outlive their admission when webhooks time out or objects are force-deleted,
+// the audit recomputes the usage from the live objects and repairs the ledger against it.
+type quantityLedgerAuditor struct {
+	client.Client
+
+	reader client.Reader
+
+	log      logr.Logger
+	metrics  *metrics.QuantityLedgerRecorder
+	mapper   k8smeta.RESTMapper
+	interval time.Duration
+
+	jsonPathCache *cache.JSONPathCache
+	celCache      *cache.CELCache
+	targetsCache  *cache.CompiledTargetsCache[string]
+}
+
+func (r *quantityLedgerAuditor) SetupWithManager(mgr ctrl.Manager, ctrlConfig cutils.ControllerOptions) error {
+	r.mapper = mgr.GetRESTMapper()
+	r.reader = mgr.GetAPIReader()
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("capsule/customquota-ledger-audit").
+		For(
+			&capsulev1beta2.QuantityLedger{},
+			builder.WithPredicates(
+				predicate.GenerationChangedPredicate{},
+				predicate.NewPredicateFuncs(func(object client.Object) bool {
+					ledger, ok := object.(*capsulev1beta2.QuantityLedger)
+
+					return ok && isCustomQuotaLedger(ledger)
+				}),
+			),
+		).
+		WithOptions(ctrlConfig.Runtime.ToControllerOptions()).
+		Complete(r)
+}
+
+func (r *quantityLedgerAuditor) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.Result, error) {
+	log := r.log.WithValues("Request.Name", request.Name, "Request.Namespace", request.Namespace)
+
+	ledger := &capsulev1beta2.QuantityLedger{}
+	if err := r.Get(ctx, request.NamespacedName, ledger); err != nil {
+		if apierrors.IsNotFound(err) {
+			r.metrics.DeleteAllMetricsForLedger(request.Name, request.Namespace)
+
+			return reconcile.Result{}, nil
+		}
+
+		return reconcile.Result{}, err
+	}
+
+	observed, err := r.observeUsage(ctx, log, ledger)
+	if err != nil {
+		return reconcile.Result{}, fmt.Errorf("observe usage: %w", err)
+	}
+
+	if observed == nil {
+		return ctrl.Result{RequeueAfter: r.interval}, nil
+	}
+
+	drift, err := r.repairLedger(ctx, log, request.NamespacedName, *observed)
+	if err != nil {
+		return reconcile.Result{}, fmt.Errorf("repair QuantityLedger: %w", err)
+	}
+
+	if !drift.consistent() {
+		log.Info("repaired QuantityLedger drift", "drift", drift.message())
+	}
+
+	r.recordAudit(ledger, drift)
+
+	return ctrl.Result{RequeueAfter: r.interval}, nil
+}
+
+// Recomputes the usage of the quota of the ledger from the live objects. Returns nil if the ledger
+// can not be audited: the quota is gone or windowed, as windowed quotas are accounted from the
+// ledger itself.
+func (r *quantityLedgerAuditor) observeUsage(
+	ctx context.Context,
+	log logr.Logger,
+	ledger *capsulev1beta2.QuantityLedger,
+) (*quotaUsageReconcileResult, error) {
+	var in quotaUsageReconcileInput
+
+	var limit resource.Quantity
+
+	switch ledger.Spec.TargetRef.Kind {
+	case "CustomQuota":
+		instance := &capsulev1beta2.CustomQuota{}
+		if err := r.Get(ctx, types.NamespacedName{Namespace: ledger.Namespace, Name: ledger.Spec.TargetRef.Name}, instance); err != nil {
+			return nil, client.IgnoreNotFound(err)
+		}
+
+		if instance.Spec.IsWindowed() {
+			return nil, nil
+		}
+
+		in = quotaUsageReconcileInput{
+			Sources:                  instance.Spec.Sources,
+			ScopeSelectors:           instance.Spec.ScopeSelectors,
+			Namespaces:               []string{instance.Namespace},
+			RequireNamespacedTargets: true,
+			CacheKey:                 MakeCustomQuotaCacheKey(instance.GetNamespace(), instance.GetName()),
+		}
+		limit = instance.Spec.Limit
+	case "GlobalCustomQuota":
+		instance := &capsulev1beta2.GlobalCustomQuota{}
+		if err := r.Get(ctx, types.NamespacedName{Name: ledger.Spec.TargetRef.Name}, instance); err != nil {
+			return nil, client.IgnoreNotFound(err)
+		}
+
+		if instance.Spec.IsWindowed() {
+			return nil, nil
+		}
+
+		namespaces, err := globalCustomQuotaNamespaces(ctx, r.Client, instance)
+		if err != nil {
+			return nil, err
+		}
+
+		in = quotaUsageReconcileInput{
+			Sources:        instance.Spec.Sources,
+			ScopeSelectors: instance.Spec.ScopeSelectors,
+			Namespaces:     namespaces,
+			CacheKey:       MakeGlobalCustomQuotaCacheKey(instance.GetName()),
+		}
+		limit = instance.Spec.Limit
+	default:
+		return nil, nil
+	}
+
+	in.Log = log
+	in.Client = r.Client
+	in.Mapper = r.mapper
+	in.JSONPathCache = r.jsonPathCache
+	in.CELCache = r.celCache
+	in.TargetsCache = r.targetsCache
+
+	result, err := reconcileQuotaUsage(ctx, in, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// Diffs the ledger against the observed usage and repairs it. Reservations which materialized,
+// transitioned or expired and stale pending deletes are released, the reserved and allocated
+// quantities are recomputed and the LedgerConsistent condition reports the drift found.
+func (r *quantityLedgerAuditor) repairLedger(
+	ctx context.Context,
+	log logr.Logger,
+	key types.NamespacedName,
+	observed quotaUsageReconcileResult,
+) (drift quantityLedgerDrift, err error) {
+	err = retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		ledger := &capsulev1beta2.QuantityLedger{}
+		if err := r.reader.Get(ctx, key, ledger); err != nil {
+			return client.IgnoreNotFound(err)
+		}
+
+		now := metav1.Now()
+		originalStatus := ledger.Status.DeepCopy()
+
+		drift = diffQuantityLedgerStatus(ledger.Status, observed.Usage.Used, now)
+
+		allocateQuantityLedgerStatus(log, key, &ledger.Status, observed.Usage.Used, observed.Claims, now)
+		ledger.Status.Conditions.UpdateConditionByType(drift.condition(ledger))
+
+		if reflect.DeepEqual(*originalStatus, ledger.Status) {
+			return nil
+		}
+
+		return r.Status().Update(ctx, ledger)
+	})
+
+	return drift, err
+}
+
+func (r *quantityLedgerAuditor) recordAudit(ledger *capsulev1beta2.QuantityLedger, drift quantityLedgerDrift) {
+	kind := ledger.Spec.TargetRef.Kind
+
+	r.metrics.RecordAudit(
+		ledger.Name,
+		ledger.Namespace,
+		kind,
+		corev1.ResourceList{ledgerDriftResource: drift.used},
+		corev1.ResourceList{ledgerDriftResource: drift.reserved},
+		drift.consistent(),
+	)
+
+	r.metrics.RecordRepair(ledger.Name, ledger.Namespace, kind, metrics.QuantityLedgerRepairExpiredReservation, drift.expiredReservations)
+	r.metrics.RecordRepair(ledger.Name, ledger.Namespace, kind, metrics.QuantityLedgerRepairStalePendingDelete, drift.stalePendingDeletes)
+
+	if !drift.used.IsZero() {
+		r.metrics.RecordRepair(ledger.Name, ledger.Namespace, kind, metrics.QuantityLedgerRepairUsed, 1)
+	}
+
+	if !drift.reserved.IsZero() {
+		r.metrics.RecordRepair(ledger.Name, ledger.Namespace, kind, metrics.QuantityLedgerRepairReserved, 1)
+	}
+}
+
+// Drift of a QuantityLedger found by its audit.
+type quantityLedgerDrift struct {
+	// Allocated quantity of the ledger minus the live usage and the reserved quantity.
+	used resource.Quantity
+	// Reserved quantity of the ledger minus the deltas of its reservations.
+	reserved resource.Quantity
+	// Reservations past their expiry.
+	expiredReservations int
+	// Pending deletes past their TTL.
+	stalePendingDeletes int
+}
+
+func diffQuantityLedgerStatus(
+	status capsulev1beta2.QuantityLedgerStatus,
+	liveUsed resource.Quantity,
+	now metav1.Time,
+) quantityLedgerDrift {
+	drift := quantityLedgerDrift{
+		reserved: status.Reserved.DeepCopy(),
+	}
+
+	for _, res := range status.Reservations {
+		drift.reserved.Sub(quantityLedgerReservationDelta(res))
+
+		if res.ExpiresAt != nil && res.ExpiresAt.Before(&now) {
+			drift.expiredReservations++
+		}
+	}
+
+	for _, pd := range status.PendingDeletes {
+		if now.Sub(pd.CreatedAt.Time) >= pendingDeleteTTL {
+			drift.stalePendingDeletes++
+		}
+	}
+
+	// Allocated is clamped to zero, so it is compared with the expected allocation rather than
+	// deriving the used quantity from it.
+	expected := liveUsed.DeepCopy()
+	expected.Add(status.Reserved)
+	quota.ClampQuantityToZero(&expected)
+
+	drift.used = status.Allocated.DeepCopy()
+	drift.used.Sub(expected)
+
+	return drift
+}
+
+func (d quantityLedgerDrift) consistent() bool {
+	return d.used.IsZero() && d.reserved.IsZero() && d.expiredReservations == 0 && d.stalePendingDeletes == 0
+}
+
+func (d quantityLedgerDrift) message() string {
+	parts := make([]string, 0, 4)
+
+	if !d.used.IsZero() {
+		parts = append(parts, "used drifted by "+d.used.String())
+	}
+
+	if !d.reserved.IsZero() {
+		parts = append(parts, "reserved drifted by "+d.reserved.String())
+	}
+
+	if d.expiredReservations > 0 {
+		parts = append(parts, fmt.Sprintf("%d expired reservations", d.expiredReservations))
+	}
+
+	if d.stalePendingDeletes > 0 {
+		parts = append(parts, fmt.Sprintf("%d stale pending deletes", d.stalePendingDeletes))
+	}
+
+	return "repaired " + strings.Join(parts, ", ")
+}
+
+func (d quantityLedgerDrift) condition(obj client.Object) meta.Condition {
+	condition := meta.NewLedgerConsistentCondition(obj)
+	if d.consistent() {
+		return condition
+	}
+
+	condition.Status = metav1.ConditionFalse
+	condition.Reason = meta.DriftRepairedReason
+	condition.Message = d.message()
+
+	return condition
+}
+
+func isCustomQuotaLedger(ledger *capsulev1beta2.QuantityLedger) bool {
+	return ledger.Spec.TargetRef.Kind == "CustomQuota" || ledger.Spec.TargetRef.Kind == "GlobalCustomQuota"
+}
@@ -0,0 +1,247 @@
+// Copyright 2020-2026 Project Capsule Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package customquotas
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	capsulemeta "github.com/projectcapsule/capsule/pkg/api/meta"
+)
+
+func TestDiffQuantityLedgerStatus(t *testing.T) {
+	t.Parallel()
+
+	now := metav1.Now()
+	past := metav1.NewTime(now.Add(-time.Minute))
+	future := metav1.NewTime(now.Add(time.Minute))
+	delta := resource.MustParse("-2")
+
+	tests := []struct {
+		name                string
+		status              capsulev1beta2.QuantityLedgerStatus
+		live                string
+		wantUsed            string
+		wantReserved        string
+		wantExpired         int
+		wantStale           int
+		wantConsistent      bool
+		wantConditionReason string
+	}{
+		{
+			name: "consistent ledger",
+			status: capsulev1beta2.QuantityLedgerStatus{
+				Reserved:  resource.MustParse("1"),
+				Allocated: resource.MustParse("6"),
+				Reservations: []capsulev1beta2.QuantityLedgerReservation{
+					{ID: "a", Usage: resource.MustParse("1"), ExpiresAt: &future},
+				},
+			},
+			live:                "5",
+			wantUsed:            "0",
+			wantReserved:        "0",
+			wantConsistent:      true,
+			wantConditionReason: capsulemeta.ConsistentReason,
+		},
+		{
+			name: "allocated diverged from live usage",
+			status: capsulev1beta2.QuantityLedgerStatus{
+				Reserved:  resource.MustParse("0"),
+				Allocated: resource.MustParse("8"),
+			},
+			live:                "5",
+			wantUsed:            "3",
+			wantReserved:        "0",
+			wantConditionReason: capsulemeta.DriftRepairedReason,
+		},
+		{
+			name: "reserved diverged from reservations",
+			status: capsulev1beta2.QuantityLedgerStatus{
+				Reserved:  resource.MustParse("4"),
+				Allocated: resource.MustParse("9"),
+				Reservations: []capsulev1beta2.QuantityLedgerReservation{
+					{ID: "a", Usage: resource.MustParse("1"), ExpiresAt: &future},
+				},
+			},
+			live:                "5",
+			wantUsed:            "0",
+			wantReserved:        "3",
+			wantConditionReason: capsulemeta.DriftRepairedReason,
+		},
+		{
+			name: "negative reservations clamp allocated to zero",
+			status: capsulev1beta2.QuantityLedgerStatus{
+				Reserved:  resource.MustParse("-2"),
+				Allocated: resource.MustParse("0"),
+				Reservations: []capsulev1beta2.QuantityLedgerReservation{
+					{ID: "a", Usage: resource.MustParse("0"), Delta: &delta, ExpiresAt: &future},
+				},
+			},
+			live:                "1",
+			wantUsed:            "0",
+			wantReserved:        "0",
+			wantConsistent:      true,
+			wantConditionReason: capsulemeta.ConsistentReason,
+		},
+		{
+			name: "expired reservations and stale pending deletes",
+			status: capsulev1beta2.QuantityLedgerStatus{
+				Reserved:  resource.MustParse("1"),
+				Allocated: resource.MustParse("6"),
+				Reservations: []capsulev1beta2.QuantityLedgerReservation{
+					{ID: "a", Usage: resource.MustParse("1"), ExpiresAt: &past},
+				},
+				PendingDeletes: []capsulev1beta2.QuantityLedgerPendingDelete{
+					{ID: "b", CreatedAt: past},
+					{ID: "c", CreatedAt: now},
+				},
+			},
+			live:                "5",
+			wantUsed:            "0",
+			wantReserved:        "0",
+			wantExpired:         1,
+			wantStale:           1,
+			wantConditionReason: capsulemeta.DriftRepairedReason,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			drift := diffQuantityLedgerStatus(tt.status, resource.MustParse(tt.live), now)
+
+			if drift.used.Cmp(resource.MustParse(tt.wantUsed)) != 0 {
+				t.Fatalf("used drift = %s, want %s", drift.used.String(), tt.wantUsed)
+			}
+			if drift.reserved.Cmp(resource.MustParse(tt.wantReserved)) != 0 {
+				t.Fatalf("reserved drift = %s, want %s", drift.reserved.String(), tt.wantReserved)
+			}
+			if drift.expiredReservations != tt.wantExpired {
+				t.Fatalf("expired reservations = %d, want %d", drift.expiredReservations, tt.wantExpired)
+			}
+			if drift.stalePendingDeletes != tt.wantStale {
+				t.Fatalf("stale pending deletes = %d, want %d", drift.stalePendingDeletes, tt.wantStale)
+			}
+			if drift.consistent() != tt.wantConsistent {
+				t.Fatalf("consistent = %t, want %t", drift.consistent(), tt.wantConsistent)
+			}
+
+			condition := drift.condition(&capsulev1beta2.QuantityLedger{})
+			if condition.Reason != tt.wantConditionReason {
+				t.Fatalf("condition reason = %s, want %s", condition.Reason, tt.wantConditionReason)
+			}
+		})
+	}
+}
+
+func TestQuantityLedgerAuditorRepairLedger(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	if err := capsulev1beta2.AddToScheme(scheme); err != nil {
+		t.Fatalf("add Capsule scheme: %v", err)
+	}
+
+	now := time.Now()
+	past := metav1.NewTime(now.Add(-time.Minute))
+	future := metav1.NewTime(now.Add(time.Minute))
+
+	key := types.NamespacedName{Namespace: "tenant-a", Name: "pods"}
+	ledger := &capsulev1beta2.QuantityLedger{
+		ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace},
+		Spec: capsulev1beta2.QuantityLedgerSpec{
+			TargetRef: capsulev1beta2.QuantityLedgerTargetRef{Kind: "CustomQuota", Name: key.Name},
+		},
+		Status: capsulev1beta2.QuantityLedgerStatus{
+			Reserved:  resource.MustParse("3"),
+			Allocated: resource.MustParse("10"),
+			Reservations: []capsulev1beta2.QuantityLedgerReservation{
+				{ID: "expired", Usage: resource.MustParse("2"), ObjectRef: ledgerPodRef("a"), CreatedAt: past, UpdatedAt: past, ExpiresAt: &past},
+				{ID: "active", Usage: resource.MustParse("1"), ObjectRef: ledgerPodRef("b"), CreatedAt: past, UpdatedAt: past, ExpiresAt: &future},
+			},
+			PendingDeletes: []capsulev1beta2.QuantityLedgerPendingDelete{
+				{ID: "stale", ObjectRef: ledgerPodRef("c"), CreatedAt: past},
+			},
+		},
+	}
+
+	cl := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithStatusSubresource(&capsulev1beta2.QuantityLedger{}).
+		WithObjects(ledger).
+		Build()
+	auditor := &quantityLedgerAuditor{Client: cl, reader: cl}
+	observed := quotaUsageReconcileResult{
+		Usage: capsulev1beta2.CustomQuotaStatusUsage{Used: resource.MustParse("5")},
+	}
+
+	drift, err := auditor.repairLedger(context.Background(), logr.Discard(), key, observed)
+	if err != nil {
+		t.Fatalf("repairLedger() error = %v", err)
+	}
+	if drift.consistent() || drift.expiredReservations != 1 || drift.stalePendingDeletes != 1 {
+		t.Fatalf("drift = %+v, want one expired reservation and one stale pending delete", drift)
+	}
+	if drift.used.Cmp(resource.MustParse("2")) != 0 {
+		t.Fatalf("used drift = %s, want 2", drift.used.String())
+	}
+
+	got := &capsulev1beta2.QuantityLedger{}
+	if err := cl.Get(context.Background(), key, got); err != nil {
+		t.Fatalf("get ledger: %v", err)
+	}
+	if len(got.Status.Reservations) != 1 || got.Status.Reservations[0].ID != "active" {
+		t.Fatalf("reservations = %#v, want only the active reservation", got.Status.Reservations)
+	}
+	if len(got.Status.PendingDeletes) != 0 {
+		t.Fatalf("pending deletes = %#v, want none", got.Status.PendingDeletes)
+	}
+	if got.Status.Reserved.Cmp(resource.MustParse("1")) != 0 {
+		t.Fatalf("reserved = %s, want 1", got.Status.Reserved.String())
+	}
+	if got.Status.Allocated.Cmp(resource.MustParse("6")) != 0 {
+		t.Fatalf("allocated = %s, want 6", got.Status.Allocated.String())
+	}
+
+	condition := got.Status.Conditions.GetConditionByType(capsulemeta.LedgerConsistentCondition)
+	if condition == nil || condition.Status != metav1.ConditionFalse || condition.Reason != capsulemeta.DriftRepairedReason {
+		t.Fatalf("condition = %#v, want DriftRepaired", condition)
+	}
+
+	drift, err = auditor.repairLedger(context.Background(), logr.Discard(), key, observed)
+	if err != nil {
+		t.Fatalf("repairLedger() error = %v", err)
+	}
+	if !drift.consistent() {
+		t.Fatalf("drift after repair = %s, want consistent", drift.message())
+	}
+
+	if err := cl.Get(context.Background(), key, got); err != nil {
+		t.Fatalf("get ledger: %v", err)
+	}
+
+	condition = got.Status.Conditions.GetConditionByType(capsulemeta.LedgerConsistentCondition)
+	if condition == nil || condition.Status != metav1.ConditionTrue || condition.Reason != capsulemeta.ConsistentReason {
+		t.Fatalf("condition = %#v, want Consistent", condition)
+	}
+}
+
+func ledgerPodRef(name string) capsulev1beta2.QuantityLedgerObjectRef {
+	return capsulev1beta2.QuantityLedgerObjectRef{
+		APIVersion: "v1",
+		Kind:       "Pod",
+		Namespace:  "tenant-a",
+		Name:       name,
+	}
+}
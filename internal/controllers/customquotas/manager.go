@@ -5,6 +5,7 @@ package customquotas
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/go-logr/logr"
 	"k8s.io/client-go/tools/events"
@@ -23,6 +24,8 @@ func Add(
 	jsonPathCache *cache.JSONPathCache,
 	celCache *cache.CELCache,
 	targetsCache *cache.CompiledTargetsCache[string],
+	ledgerAuditInterval time.Duration,
+	ledgerMetrics *metrics.QuantityLedgerRecorder,
 ) (err error) {
 	if err = (&customQuotaClaimController{
 		Client:        mgr.GetClient(),
@@ -48,5 +51,21 @@ func Add(
 		return fmt.Errorf("unable to create cluster custom quota controller: %w", err)
 	}
 
+	if ledgerAuditInterval <= 0 {
+		return nil
+	}
+
+	if err = (&quantityLedgerAuditor{
+		Client:        mgr.GetClient(),
+		log:           log.WithName("QuantityLedgerAudit"),
+		metrics:       ledgerMetrics,
+		interval:      ledgerAuditInterval,
+		jsonPathCache: jsonPathCache,
+		celCache:      celCache,
+		targetsCache:  targetsCache,
+	}).SetupWithManager(mgr, cfg); err != nil {
+		return fmt.Errorf("unable to create custom quota ledger audit controller: %w", err)
+	}
+
 	return nil
 }
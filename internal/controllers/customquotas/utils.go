@@ -27,6 +27,7 @@ import (
 
 	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
 	"github.com/projectcapsule/capsule/internal/cache"
+	"github.com/projectcapsule/capsule/pkg/api/meta"
 	celruntime "github.com/projectcapsule/capsule/pkg/runtime/cel"
 	"github.com/projectcapsule/capsule/pkg/runtime/jsonpath"
 	"github.com/projectcapsule/capsule/pkg/runtime/quota"
@@ -57,6 +58,37 @@ type CompiledTarget struct {
 	CompiledSelectors []selectors.CompiledSelectorWithFields
 }
 
+// Reports whether the usage of the quota reached the warning threshold or exceeded the soft limit. The
+// condition is removed from quotas without warning threshold and soft limit.
+func updateNearLimitCondition(
+	obj client.Object,
+	spec *capsulev1beta2.CustomQuotaSpec,
+	status *capsulev1beta2.CustomQuotaStatus,
+) {
+	warnAt, softLimit := spec.GetWarnAt(), spec.GetSoftLimit()
+	if warnAt == nil && softLimit == nil {
+		status.Conditions.RemoveConditionByType(meta.NearLimitCondition)
+
+		return
+	}
+
+	condition := meta.NewNearLimitCondition(obj)
+
+	switch quota.PassedThreshold(status.Usage.Used, warnAt, softLimit) {
+	case quota.ThresholdSoftLimit:
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = meta.SoftLimitExceededReason
+		condition.Message = fmt.Sprintf("usage %s exceeds soft limit %s", status.Usage.Used.String(), softLimit.String())
+	case quota.ThresholdWarnAt:
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = meta.ThresholdReachedReason
+		condition.Message = fmt.Sprintf("usage %s reached warning threshold %s", status.Usage.Used.String(), warnAt.String())
+	case quota.ThresholdNone:
+	}
+
+	status.Conditions.UpdateConditionByType(condition)
+}
+
 func CompileTargets(
 	jcache *cache.JSONPathCache,
 	ccache *cache.CELCache,
@@ -73,8 +105,15 @@ func CompileTargets(
 		case quota.OpCount:
 			// no usage expression needed
 
-		case quota.OpAdd, quota.OpSub:
+		case quota.OpAdd, quota.OpSub, quota.OpMax, quota.OpPerObject, quota.OpDistinct:
 			switch {
+			case target.Operation == quota.OpDistinct && target.CEL != "":
+				return nil, fmt.Errorf(
+					"cel is not supported for %s %q, distinct values are evaluated from path",
+					target.String(),
+					target.Operation,
+				)
+
 			case target.Path != "" && target.CEL == "":
 				compiledPath, err := jcache.GetOrCompile(target.Path)
 				if err != nil {
@@ -493,6 +532,9 @@ func pendingDeleteStillPresent(
 	return false
 }
 
+// Pending deletes are admission hints which expire after this duration.
+const pendingDeleteTTL = 30 * time.Second
+
 const (
 	unresolvedReservationInitialRequeue = 2 * time.Second
 	unresolvedReservationRequeue        = 5 * time.Second
@@ -611,128 +653,142 @@ func reconcileQuantityLedgerAllocation(
 			return err
 		}
 
-		now := metav1.Now()
-		pendingDeleteTTL := 30 * time.Second
+		originalStatus := ledger.Status.DeepCopy()
 
-		pendingDeletePresent := make([]bool, len(ledger.Status.PendingDeletes))
-		confirmedTransitions := make(map[string][]capsulev1beta2.QuantityLedgerObjectRef)
+		requeueAfter = allocateQuantityLedgerStatus(log, key, &ledger.Status, observedUsed, claims, metav1.Now())
 
-		for i, pendingDelete := range ledger.Status.PendingDeletes {
-			pendingDeletePresent[i] = pendingDeleteStillPresent(pendingDelete, claims)
-			if pendingDeletePresent[i] {
-				continue
-			}
+		if reflect.DeepEqual(*originalStatus, ledger.Status) {
+			return nil
+		}
 
-			key := ledgerReservationObjectKey(pendingDelete.ObjectRef)
-			confirmedTransitions[key] = append(confirmedTransitions[key], pendingDelete.ObjectRef)
-		}
-
-		activeReservations := make([]capsulev1beta2.QuantityLedgerReservation, 0, len(ledger.Status.Reservations))
-		materializedThrough := materializedReservationPositions(ledger.Status.Reservations, claims)
-
-		for i, res := range ledger.Status.Reservations {
-			materialized := materializedThrough[ledgerReservationObjectKey(res.ObjectRef)] > i
-			transitioned := reservationHasConfirmedTransition(res, confirmedTransitions)
-			expired := res.ExpiresAt != nil && res.ExpiresAt.Before(&now)
-
-			log.V(5).Info("evaluating ledger reservation",
-				"ledger", key.String(),
-				"reservationID", res.ID,
-				"usage", res.Usage.String(),
-				"uid", string(res.ObjectRef.UID),
-				"group", res.ObjectRef.APIGroup,
-				"version", res.ObjectRef.APIVersion,
-				"kind", res.ObjectRef.Kind,
-				"namespace", res.ObjectRef.Namespace,
-				"name", res.ObjectRef.Name,
-				"materialized", materialized,
-				"transitioned", transitioned,
-				"expired", expired,
-			)
+		return c.Status().Update(ctx, ledger)
+	})
+	if err != nil {
+		return nil, err
+	}
 
-			switch {
-			case materialized:
-				continue
+	return requeueAfter, nil
+}
 
-			case transitioned:
-				// A persisted update/delete moved this object out of the
-				// matching claims before reconciliation observed its earlier
-				// state. Its pending-delete hint confirms that the admission
-				// operation materialized, so older reservations for the same
-				// object can be released without waiting for their TTL.
-				continue
+// Releases the reservations of the ledger which materialized in the observed claims, were
+// transitioned or expired, and the pending deletes which are no longer present or expired. The
+// reserved and allocated quantities are recomputed from the remaining reservations. Returns the
+// duration after which remaining reservations must be evaluated again.
+func allocateQuantityLedgerStatus(
+	log logr.Logger,
+	key types.NamespacedName,
+	status *capsulev1beta2.QuantityLedgerStatus,
+	observedUsed resource.Quantity,
+	claims []capsulev1beta2.CustomQuotaClaimItem,
+	now metav1.Time,
+) (requeueAfter *time.Duration) {
+	pendingDeletePresent := make([]bool, len(status.PendingDeletes))
+	confirmedTransitions := make(map[string][]capsulev1beta2.QuantityLedgerObjectRef)
 
-			case expired:
-				continue
+	for i, pendingDelete := range status.PendingDeletes {
+		pendingDeletePresent[i] = pendingDeleteStillPresent(pendingDelete, claims)
+		if pendingDeletePresent[i] {
+			continue
+		}
 
-			default:
-				activeReservations = append(activeReservations, res)
+		key := ledgerReservationObjectKey(pendingDelete.ObjectRef)
+		confirmedTransitions[key] = append(confirmedTransitions[key], pendingDelete.ObjectRef)
+	}
 
-				requeueAfter = minDurationPtr(
-					requeueAfter,
-					nextReservationMaterializationRequeue(now, res),
-				)
-			}
-		}
+	activeReservations := make([]capsulev1beta2.QuantityLedgerReservation, 0, len(status.Reservations))
+	materializedThrough := materializedReservationPositions(status.Reservations, claims)
 
-		activeDeletes := make([]capsulev1beta2.QuantityLedgerPendingDelete, 0, len(ledger.Status.PendingDeletes))
+	for i, res := range status.Reservations {
+		materialized := materializedThrough[ledgerReservationObjectKey(res.ObjectRef)] > i
+		transitioned := reservationHasConfirmedTransition(res, confirmedTransitions)
+		expired := res.ExpiresAt != nil && res.ExpiresAt.Before(&now)
 
-		for i, pd := range ledger.Status.PendingDeletes {
-			stillPresent := pendingDeletePresent[i]
-			expired := now.Sub(pd.CreatedAt.Time) >= pendingDeleteTTL
+		log.V(5).Info("evaluating ledger reservation",
+			"ledger", key.String(),
+			"reservationID", res.ID,
+			"usage", res.Usage.String(),
+			"uid", string(res.ObjectRef.UID),
+			"group", res.ObjectRef.APIGroup,
+			"version", res.ObjectRef.APIVersion,
+			"kind", res.ObjectRef.Kind,
+			"namespace", res.ObjectRef.Namespace,
+			"name", res.ObjectRef.Name,
+			"materialized", materialized,
+			"transitioned", transitioned,
+			"expired", expired,
+		)
 
-			log.V(5).Info("evaluating pending delete",
-				"ledger", key.String(),
-				"uid", string(pd.ObjectRef.UID),
-				"group", pd.ObjectRef.APIGroup,
-				"version", pd.ObjectRef.APIVersion,
-				"kind", pd.ObjectRef.Kind,
-				"namespace", pd.ObjectRef.Namespace,
-				"name", pd.ObjectRef.Name,
-				"stillPresent", stillPresent,
-				"expired", expired,
-			)
+		switch {
+		case materialized:
+			continue
 
-			// Pending deletes are admission hints, not durable desired state.
-			// The admitted update/delete may fail after the webhook returns,
-			// and a transient policy snapshot must not leave a hint that
-			// requeues this quota forever. Once the hint expires, the current
-			// observed claims are authoritative.
-			if !stillPresent || expired {
-				continue
-			}
+		case transitioned:
+			// A persisted update/delete moved this object out of the
+			// matching claims before reconciliation observed its earlier
+			// state. Its pending-delete hint confirms that the admission
+			// operation materialized, so older reservations for the same
+			// object can be released without waiting for their TTL.
+			continue
 
-			activeDeletes = append(activeDeletes, pd)
-			requeueAfter = minDurationPtr(requeueAfter, immediatePendingDeleteRequeue)
-		}
+		case expired:
+			continue
 
-		reserved := resource.MustParse("0")
-		for _, res := range activeReservations {
-			reserved.Add(quantityLedgerReservationDelta(res))
+		default:
+			activeReservations = append(activeReservations, res)
+
+			requeueAfter = minDurationPtr(
+				requeueAfter,
+				nextReservationMaterializationRequeue(now, res),
+			)
 		}
+	}
 
-		allocated := observedUsed.DeepCopy()
-		allocated.Add(reserved)
-		quota.ClampQuantityToZero(&allocated)
+	activeDeletes := make([]capsulev1beta2.QuantityLedgerPendingDelete, 0, len(status.PendingDeletes))
 
-		originalStatus := ledger.Status.DeepCopy()
+	for i, pd := range status.PendingDeletes {
+		stillPresent := pendingDeletePresent[i]
+		expired := now.Sub(pd.CreatedAt.Time) >= pendingDeleteTTL
 
-		ledger.Status.Reservations = activeReservations
-		ledger.Status.PendingDeletes = activeDeletes
-		ledger.Status.Reserved = reserved
-		ledger.Status.Allocated = allocated
+		log.V(5).Info("evaluating pending delete",
+			"ledger", key.String(),
+			"uid", string(pd.ObjectRef.UID),
+			"group", pd.ObjectRef.APIGroup,
+			"version", pd.ObjectRef.APIVersion,
+			"kind", pd.ObjectRef.Kind,
+			"namespace", pd.ObjectRef.Namespace,
+			"name", pd.ObjectRef.Name,
+			"stillPresent", stillPresent,
+			"expired", expired,
+		)
 
-		if reflect.DeepEqual(*originalStatus, ledger.Status) {
-			return nil
+		// Pending deletes are admission hints, not durable desired state.
+		// The admitted update/delete may fail after the webhook returns,
+		// and a transient policy snapshot must not leave a hint that
+		// requeues this quota forever. Once the hint expires, the current
+		// observed claims are authoritative.
+		if !stillPresent || expired {
+			continue
 		}
 
-		return c.Status().Update(ctx, ledger)
-	})
-	if err != nil {
-		return nil, err
+		activeDeletes = append(activeDeletes, pd)
+		requeueAfter = minDurationPtr(requeueAfter, immediatePendingDeleteRequeue)
 	}
 
-	return requeueAfter, nil
+	reserved := resource.MustParse("0")
+	for _, res := range activeReservations {
+		reserved.Add(quantityLedgerReservationDelta(res))
+	}
+
+	allocated := observedUsed.DeepCopy()
+	allocated.Add(reserved)
+	quota.ClampQuantityToZero(&allocated)
+
+	status.Reservations = activeReservations
+	status.PendingDeletes = activeDeletes
+	status.Reserved = reserved
+	status.Allocated = allocated
+
+	return requeueAfter
 }
 
 func reservationHasConfirmedTransition(
@@ -6,10 +6,13 @@ package customquotas
 import (
 	"context"
 	"errors"
+	"reflect"
 	"testing"
 	"time"
 
 	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	k8smeta "k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -21,6 +24,7 @@ import (
 	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
 	"github.com/projectcapsule/capsule/internal/cache"
 	capsulemeta "github.com/projectcapsule/capsule/pkg/api/meta"
+	capsuleruntime "github.com/projectcapsule/capsule/pkg/api/runtime"
 	"github.com/projectcapsule/capsule/pkg/runtime/quota"
 	"github.com/projectcapsule/capsule/pkg/runtime/selectors"
 )
@@ -573,3 +577,277 @@ func (c *rejectingGetClient) Get(
 ) error {
 	return errors.New("cached Get must not be used for ledger conflict retries")
 }
+
+func TestReconcileWindowedQuantityLedger(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	if err := capsulev1beta2.AddToScheme(scheme); err != nil {
+		t.Fatalf("add Capsule scheme: %v", err)
+	}
+
+	now := time.Now()
+	key := types.NamespacedName{Namespace: "tenant-a", Name: "pods"}
+	ledger := &capsulev1beta2.QuantityLedger{
+		ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace},
+		Status: capsulev1beta2.QuantityLedgerStatus{
+			Reservations: []capsulev1beta2.QuantityLedgerReservation{
+				{
+					ID:        "expired",
+					Usage:     resource.MustParse("3"),
+					CreatedAt: metav1.NewTime(now.Add(-2 * time.Hour)),
+					ExpiresAt: &metav1.Time{Time: now.Add(-time.Hour)},
+				},
+				{
+					ID:        "active",
+					Usage:     resource.MustParse("2"),
+					CreatedAt: metav1.NewTime(now.Add(-30 * time.Minute)),
+					ExpiresAt: &metav1.Time{Time: now.Add(30 * time.Minute)},
+				},
+				{
+					ID:        "legacy",
+					Usage:     resource.MustParse("1"),
+					CreatedAt: metav1.NewTime(now.Add(-50 * time.Minute)),
+				},
+			},
+			PendingDeletes: []capsulev1beta2.QuantityLedgerPendingDelete{
+				{ID: "delete", CreatedAt: metav1.NewTime(now)},
+			},
+		},
+	}
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithStatusSubresource(&capsulev1beta2.QuantityLedger{}).
+		WithObjects(ledger).
+		Build()
+
+	usage, requeueAfter, err := reconcileWindowedQuantityLedger(
+		context.Background(),
+		c,
+		c,
+		logr.Discard(),
+		key,
+		time.Hour,
+		resource.MustParse("5"),
+	)
+	if err != nil {
+		t.Fatalf("reconcileWindowedQuantityLedger() error = %v", err)
+	}
+
+	if usage.Used.Cmp(resource.MustParse("3")) != 0 {
+		t.Fatalf("used = %s, want 3", usage.Used.String())
+	}
+	if usage.Available.Cmp(resource.MustParse("2")) != 0 {
+		t.Fatalf("available = %s, want 2", usage.Available.String())
+	}
+	if requeueAfter == nil || *requeueAfter <= 0 || *requeueAfter > 10*time.Minute {
+		t.Fatalf("requeueAfter = %v, want the legacy admission leaving the window within 10m", requeueAfter)
+	}
+
+	got := &capsulev1beta2.QuantityLedger{}
+	if err := c.Get(context.Background(), key, got); err != nil {
+		t.Fatalf("get ledger: %v", err)
+	}
+	if len(got.Status.Reservations) != 2 {
+		t.Fatalf("reservations = %d, want 2", len(got.Status.Reservations))
+	}
+	if len(got.Status.PendingDeletes) != 0 {
+		t.Fatalf("pending deletes = %d, want 0", len(got.Status.PendingDeletes))
+	}
+	if got.Status.Reserved.Cmp(resource.MustParse("3")) != 0 || got.Status.Allocated.Cmp(resource.MustParse("3")) != 0 {
+		t.Fatalf("reserved/allocated = %s/%s, want 3/3", got.Status.Reserved.String(), got.Status.Allocated.String())
+	}
+}
+
+func TestReconcileQuotaUsageNonAdditiveOperations(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	if err := corev1.AddToScheme(scheme); err != nil {
+		t.Fatalf("add core scheme: %v", err)
+	}
+
+	now := time.Now()
+	pod := func(name string, created time.Time, containers ...corev1.Container) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Namespace:         "tenant-a",
+				UID:               types.UID(name),
+				CreationTimestamp: metav1.NewTime(created),
+			},
+			Spec: corev1.PodSpec{Containers: containers},
+		}
+	}
+	container := func(image string, cpu string) corev1.Container {
+		return corev1.Container{
+			Image: image,
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(cpu)},
+			},
+		}
+	}
+
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(
+			pod("first", now.Add(-time.Hour), container("nginx:1.27", "250m"), container("busybox:1.36", "1")),
+			pod("second", now, container("nginx:1.27", "500m"), container("redis:7", "250m")),
+		).
+		Build()
+
+	mapper := k8smeta.NewDefaultRESTMapper(nil)
+	mapper.Add(corev1.SchemeGroupVersion.WithKind("Pod"), k8smeta.RESTScopeNamespace)
+
+	celCache, err := cache.NewCELCache()
+	if err != nil {
+		t.Fatalf("NewCELCache() error = %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		source     capsulev1beta2.CustomQuotaSpecSourceConfig
+		wantUsed   string
+		wantValues []string
+		wantClaims map[string]string
+	}{
+		{
+			name: "distinct",
+			source: capsulev1beta2.CustomQuotaSpecSourceConfig{
+				Operation: quota.OpDistinct,
+				Path:      ".spec.containers[*].image",
+			},
+			wantUsed:   "3",
+			wantValues: []string{"busybox:1.36", "nginx:1.27", "redis:7"},
+			wantClaims: map[string]string{"first": "2", "second": "1"},
+		},
+		{
+			name: "max",
+			source: capsulev1beta2.CustomQuotaSpecSourceConfig{
+				Operation: quota.OpMax,
+				Path:      ".spec.containers[*].resources.requests.cpu",
+			},
+			wantUsed:   "1",
+			wantClaims: map[string]string{},
+		},
+		{
+			name: "perObject",
+			source: capsulev1beta2.CustomQuotaSpecSourceConfig{
+				Operation: quota.OpPerObject,
+				Path:      ".spec.containers[*].resources.requests.cpu",
+			},
+			wantUsed:   "1250m",
+			wantClaims: map[string]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := reconcileQuotaUsage(context.Background(), quotaUsageReconcileInput{
+				Log:           logr.Discard(),
+				Client:        c,
+				Mapper:        mapper,
+				JSONPathCache: cache.NewJSONPathCache(),
+				CELCache:      celCache,
+				Sources: []capsulev1beta2.CustomQuotaSpecSource{
+					{
+						VersionKind: capsuleruntime.VersionKind{
+							APIVersion: "v1",
+							Kind:       "Pod",
+						},
+						CustomQuotaSpecSourceConfig: tt.source,
+					},
+				},
+				Namespaces:               []string{"tenant-a"},
+				RequireNamespacedTargets: true,
+			}, resource.MustParse("10"))
+			if err != nil {
+				t.Fatalf("reconcileQuotaUsage() error = %v", err)
+			}
+
+			if result.Usage.Used.Cmp(resource.MustParse(tt.wantUsed)) != 0 {
+				t.Fatalf("used = %s, want %s", result.Usage.Used.String(), tt.wantUsed)
+			}
+			if !reflect.DeepEqual(result.Usage.Values, tt.wantValues) {
+				t.Fatalf("values = %v, want %v", result.Usage.Values, tt.wantValues)
+			}
+
+			claims := make(map[string]string, len(result.Claims))
+			for _, claim := range result.Claims {
+				claims[claim.Name] = claim.Usage.String()
+			}
+			if !reflect.DeepEqual(claims, tt.wantClaims) {
+				t.Fatalf("claims = %v, want %v", claims, tt.wantClaims)
+			}
+		})
+	}
+}
+
+func TestUpdateNearLimitCondition(t *testing.T) {
+	t.Parallel()
+
+	softLimit := resource.MustParse("9")
+
+	tests := []struct {
+		name       string
+		spec       capsulev1beta2.CustomQuotaSpec
+		used       string
+		wantStatus metav1.ConditionStatus
+		wantReason string
+	}{
+		{
+			name: "without thresholds",
+			spec: capsulev1beta2.CustomQuotaSpec{Limit: resource.MustParse("10")},
+			used: "10",
+		},
+		{
+			name:       "below threshold",
+			spec:       capsulev1beta2.CustomQuotaSpec{Limit: resource.MustParse("10"), WarnAt: "80%"},
+			used:       "7",
+			wantStatus: metav1.ConditionFalse,
+			wantReason: capsulemeta.BelowThresholdReason,
+		},
+		{
+			name:       "reached threshold",
+			spec:       capsulev1beta2.CustomQuotaSpec{Limit: resource.MustParse("10"), WarnAt: "8"},
+			used:       "8",
+			wantStatus: metav1.ConditionTrue,
+			wantReason: capsulemeta.ThresholdReachedReason,
+		},
+		{
+			name:       "exceeded soft limit",
+			spec:       capsulev1beta2.CustomQuotaSpec{Limit: resource.MustParse("10"), WarnAt: "80%", SoftLimit: &softLimit},
+			used:       "10",
+			wantStatus: metav1.ConditionTrue,
+			wantReason: capsulemeta.SoftLimitExceededReason,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			instance := &capsulev1beta2.CustomQuota{ObjectMeta: metav1.ObjectMeta{Name: "quota", Generation: 3}}
+			status := &capsulev1beta2.CustomQuotaStatus{}
+			status.Usage.Used = resource.MustParse(tt.used)
+			status.Conditions.UpdateConditionByType(capsulemeta.NewNearLimitCondition(instance))
+
+			updateNearLimitCondition(instance, &tt.spec, status)
+
+			condition := status.Conditions.GetConditionByType(capsulemeta.NearLimitCondition)
+			if tt.wantReason == "" {
+				if condition != nil {
+					t.Fatalf("NearLimit condition = %#v, want none", condition)
+				}
+
+				return
+			}
+
+			if condition == nil || condition.Status != tt.wantStatus || condition.Reason != tt.wantReason {
+				t.Fatalf("NearLimit condition = %#v, want status %s and reason %s", condition, tt.wantStatus, tt.wantReason)
+			}
+		})
+	}
+}
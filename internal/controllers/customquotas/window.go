@@ -0,0 +1,114 @@
+// Copyright 2020-2026 Project Capsule Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package customquotas
+
+import (
+	"context"
+	"reflect"
+	"time"
+
+	"github.com/go-logr/logr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	"github.com/projectcapsule/capsule/pkg/runtime/quota"
+)
+
+// Reconciles the QuantityLedger of a windowed quota. Each reservation is an admission which is counted
+// until it leaves the window, reservations which left the window are garbage collected. Returns the usage
+// within the window and the duration until the next admission leaves the window.
+func reconcileWindowedQuantityLedger(
+	ctx context.Context,
+	c client.Client,
+	reader client.Reader,
+	log logr.Logger,
+	key types.NamespacedName,
+	window time.Duration,
+	limit resource.Quantity,
+) (capsulev1beta2.CustomQuotaStatusUsage, *time.Duration, error) {
+	var (
+		used         resource.Quantity
+		requeueAfter *time.Duration
+	)
+
+	err := retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		used = resource.MustParse("0")
+		requeueAfter = nil
+
+		ledger := &capsulev1beta2.QuantityLedger{}
+		if err := reader.Get(ctx, key, ledger); err != nil {
+			if apierrors.IsNotFound(err) {
+				return nil
+			}
+
+			return err
+		}
+
+		now := metav1.Now()
+
+		active := make([]capsulev1beta2.QuantityLedgerReservation, 0, len(ledger.Status.Reservations))
+
+		for _, res := range ledger.Status.Reservations {
+			expiresAt := windowedReservationExpiry(res, window)
+			if !now.Time.Before(expiresAt) {
+				log.V(5).Info("admission left the window",
+					"ledger", key.String(),
+					"reservationID", res.ID,
+					"kind", res.ObjectRef.Kind,
+					"namespace", res.ObjectRef.Namespace,
+					"name", res.ObjectRef.Name,
+				)
+
+				continue
+			}
+
+			active = append(active, res)
+			used.Add(quantityLedgerReservationDelta(res))
+
+			requeueAfter = minDurationPtr(requeueAfter, expiresAt.Sub(now.Time))
+		}
+
+		originalStatus := ledger.Status.DeepCopy()
+
+		// Updates and deletions do not release usage of windowed quotas.
+		ledger.Status.Reservations = active
+		ledger.Status.PendingDeletes = nil
+		ledger.Status.Reserved = used.DeepCopy()
+		ledger.Status.Allocated = used.DeepCopy()
+
+		if reflect.DeepEqual(*originalStatus, ledger.Status) {
+			return nil
+		}
+
+		return c.Status().Update(ctx, ledger)
+	})
+	if err != nil {
+		return capsulev1beta2.CustomQuotaStatusUsage{}, nil, err
+	}
+
+	usage := capsulev1beta2.CustomQuotaStatusUsage{
+		Used:      used,
+		Available: limit.DeepCopy(),
+	}
+
+	usage.Available.Sub(used)
+	quota.ClampQuantityToZero(&usage.Available)
+
+	return usage, requeueAfter, nil
+}
+
+// Gets the time an admission leaves the window. Reservations written before the quota was windowed
+// leave the window relative to their creation.
+func windowedReservationExpiry(res capsulev1beta2.QuantityLedgerReservation, window time.Duration) time.Time {
+	if res.ExpiresAt != nil {
+		return res.ExpiresAt.Time
+	}
+
+	return res.CreatedAt.Add(window)
+}
@@ -8,10 +8,12 @@ import (
 	"fmt"
 	"reflect"
 	"slices"
+	"strings"
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
@@ -30,6 +32,7 @@ import (
 	"github.com/projectcapsule/capsule/internal/metrics"
 	"github.com/projectcapsule/capsule/pkg/api/meta"
 	"github.com/projectcapsule/capsule/pkg/runtime/predicates"
+	runtimequota "github.com/projectcapsule/capsule/pkg/runtime/quota"
 	"github.com/projectcapsule/capsule/pkg/runtime/selectors"
 )
 
@@ -103,6 +106,7 @@ func (r *Controller) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.
 	}
 
 	status.Conditions.UpdateConditionByType(ready)
+	updateNearLimitCondition(instance, status)
 	status.ObservedGeneration = instance.Generation
 
 	if updateErr := r.updateStatus(ctx, instance, *status); updateErr != nil {
@@ -385,3 +389,59 @@ func resourceQuotaStatusReady(quota *corev1.ResourceQuota, hard corev1.ResourceL
 
 	return true
 }
+
+// Reports whether the usage of any resource reached the warning threshold or exceeded the soft limit.
+// The condition is removed from quotas without warning threshold and soft limits.
+func updateNearLimitCondition(
+	instance *capsulev1beta2.GlobalResourceQuota,
+	status *capsulev1beta2.GlobalResourceQuotaStatus,
+) {
+	warnAt := instance.GetWarnAt()
+	if len(warnAt) == 0 && len(instance.Spec.Soft) == 0 {
+		status.Conditions.RemoveConditionByType(meta.NearLimitCondition)
+
+		return
+	}
+
+	var reached, exceeded []string
+
+	for name := range instance.Spec.Quota.Hard {
+		used := status.Total.Used[name]
+
+		var threshold, soft *resource.Quantity
+
+		if quantity, ok := warnAt[name]; ok {
+			threshold = &quantity
+		}
+
+		if quantity, ok := instance.Spec.Soft[name]; ok {
+			soft = &quantity
+		}
+
+		switch runtimequota.PassedThreshold(used, threshold, soft) {
+		case runtimequota.ThresholdSoftLimit:
+			exceeded = append(exceeded, string(name))
+		case runtimequota.ThresholdWarnAt:
+			reached = append(reached, string(name))
+		case runtimequota.ThresholdNone:
+		}
+	}
+
+	slices.Sort(reached)
+	slices.Sort(exceeded)
+
+	condition := meta.NewNearLimitCondition(instance)
+
+	switch {
+	case len(exceeded) > 0:
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = meta.SoftLimitExceededReason
+		condition.Message = "usage exceeds soft limit of " + strings.Join(exceeded, ", ")
+	case len(reached) > 0:
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = meta.ThresholdReachedReason
+		condition.Message = "usage reached warning threshold of " + strings.Join(reached, ", ")
+	}
+
+	status.Conditions.UpdateConditionByType(condition)
+}
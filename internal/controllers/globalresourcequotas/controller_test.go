@@ -16,6 +16,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	"github.com/projectcapsule/capsule/pkg/api/meta"
 	"github.com/projectcapsule/capsule/pkg/runtime/selectors"
 )
 
@@ -220,3 +221,58 @@ func assertResource(
 		t.Fatalf("%s = %s, want %s", name, got.String(), want)
 	}
 }
+
+func TestUpdateNearLimitCondition(t *testing.T) {
+	t.Parallel()
+
+	quota := &capsulev1beta2.GlobalResourceQuota{
+		ObjectMeta: metav1.ObjectMeta{Name: "shared", Generation: 2},
+		Spec: capsulev1beta2.GlobalResourceQuotaSpec{
+			Quota: corev1.ResourceQuotaSpec{Hard: corev1.ResourceList{
+				corev1.ResourceRequestsCPU:    resource.MustParse("10"),
+				corev1.ResourceRequestsMemory: resource.MustParse("10Gi"),
+			}},
+		},
+	}
+	status := &capsulev1beta2.GlobalResourceQuotaStatus{}
+	status.Total.Used = corev1.ResourceList{
+		corev1.ResourceRequestsCPU:    resource.MustParse("8"),
+		corev1.ResourceRequestsMemory: resource.MustParse("9Gi"),
+	}
+
+	updateNearLimitCondition(quota, status)
+
+	if condition := status.Conditions.GetConditionByType(meta.NearLimitCondition); condition != nil {
+		t.Fatalf("NearLimit condition = %#v, want none without thresholds", condition)
+	}
+
+	quota.Spec.WarnAt = "80%"
+	updateNearLimitCondition(quota, status)
+
+	condition := status.Conditions.GetConditionByType(meta.NearLimitCondition)
+	if condition == nil ||
+		condition.Status != metav1.ConditionTrue ||
+		condition.Reason != meta.ThresholdReachedReason ||
+		condition.Message != "usage reached warning threshold of requests.cpu, requests.memory" ||
+		condition.ObservedGeneration != 2 {
+		t.Fatalf("NearLimit condition = %#v, want reached warning threshold", condition)
+	}
+
+	quota.Spec.Soft = corev1.ResourceList{corev1.ResourceRequestsMemory: resource.MustParse("8Gi")}
+	updateNearLimitCondition(quota, status)
+
+	condition = status.Conditions.GetConditionByType(meta.NearLimitCondition)
+	if condition == nil ||
+		condition.Reason != meta.SoftLimitExceededReason ||
+		condition.Message != "usage exceeds soft limit of requests.memory" {
+		t.Fatalf("NearLimit condition = %#v, want exceeded soft limit", condition)
+	}
+
+	status.Total.Used = corev1.ResourceList{}
+	updateNearLimitCondition(quota, status)
+
+	condition = status.Conditions.GetConditionByType(meta.NearLimitCondition)
+	if condition == nil || condition.Status != metav1.ConditionFalse || condition.Reason != meta.BelowThresholdReason {
+		t.Fatalf("NearLimit condition = %#v, want below threshold", condition)
+	}
+}
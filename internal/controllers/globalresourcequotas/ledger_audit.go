@@ -0,0 +1,286 @@
+// Copyright 2020-2026 Project Capsule Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package globalresourcequotas
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	ctrlutils "github.com/projectcapsule/capsule/internal/controllers/utils"
+	"github.com/projectcapsule/capsule/internal/metrics"
+	"github.com/projectcapsule/capsule/pkg/api/meta"
+	"github.com/projectcapsule/capsule/pkg/runtime/selectors"
+)
+
+// Periodically audits the QuantityLedgers of GlobalResourceQuotas. Reservations can outlive their
+// admission when webhooks time out, the audit observes the live usage of the ResourceQuotas and
+// repairs the ledger against it.
+type ledgerAuditor struct {
+	client.Client
+
+	quotas   *Controller
+	log      logr.Logger
+	metrics  *metrics.QuantityLedgerRecorder
+	interval time.Duration
+}
+
+func (r *ledgerAuditor) SetupWithManager(mgr ctrl.Manager, options ctrlutils.ControllerOptions) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("capsule/global-resource-quota-ledger-audit").
+		For(
+			&capsulev1beta2.QuantityLedger{},
+			builder.WithPredicates(
+				predicate.GenerationChangedPredicate{},
+				predicate.NewPredicateFuncs(func(object client.Object) bool {
+					ledger, ok := object.(*capsulev1beta2.QuantityLedger)
+
+					return ok && ledger.Spec.TargetRef.Kind == "GlobalResourceQuota"
+				}),
+			),
+		).
+		WithOptions(options.Runtime.ToControllerOptions()).
+		Complete(r)
+}
+
+func (r *ledgerAuditor) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.Result, error) {
+	log := r.log.WithValues("Request.Name", request.Name, "Request.Namespace", request.Namespace)
+
+	ledger := &capsulev1beta2.QuantityLedger{}
+	if err := r.Get(ctx, request.NamespacedName, ledger); err != nil {
+		if apierrors.IsNotFound(err) {
+			r.metrics.DeleteAllMetricsForLedger(request.Name, request.Namespace)
+
+			return ctrl.Result{}, nil
+		}
+
+		return ctrl.Result{}, err
+	}
+
+	instance := &capsulev1beta2.GlobalResourceQuota{}
+	if err := r.Get(ctx, types.NamespacedName{Name: ledger.Spec.TargetRef.Name}, instance); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+
+		return ctrl.Result{}, err
+	}
+
+	namespaces, err := selectors.GetNamespacesMatchingSelectors(ctx, r.quotas.reader, instance.Spec.NamespaceSelectors)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("select namespaces: %w", err)
+	}
+
+	status, initialized, err := r.quotas.observeUsage(ctx, instance, namespaces)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("observe usage: %w", err)
+	}
+
+	// The usage of ResourceQuotas which are not initialized is not authoritative.
+	if !initialized {
+		return ctrl.Result{RequeueAfter: r.interval}, nil
+	}
+
+	drift, err := r.repairLedger(ctx, request.NamespacedName, instance.Generation, status.Namespaces, status.Total.Used)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("repair QuantityLedger: %w", err)
+	}
+
+	if !drift.consistent() {
+		log.Info("repaired QuantityLedger drift", "drift", drift.message())
+	}
+
+	r.recordAudit(ledger, drift)
+
+	return ctrl.Result{RequeueAfter: r.interval}, nil
+}
+
+// Diffs the ledger against the observed usage and repairs it. Expired reservations are released,
+// the used, reserved and allocated resources are recomputed and the LedgerConsistent condition
+// reports the drift found.
+func (r *ledgerAuditor) repairLedger(
+	ctx context.Context,
+	key types.NamespacedName,
+	generation int64,
+	namespaces []string,
+	used corev1.ResourceList,
+) (drift ledgerDrift, err error) {
+	err = retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		current := &capsulev1beta2.QuantityLedger{}
+		if err := r.quotas.reader.Get(ctx, key, current); err != nil {
+			return client.IgnoreNotFound(err)
+		}
+
+		now := metav1.Now()
+		originalStatus := current.Status.DeepCopy()
+
+		drift = diffLedgerStatus(current.Status.ResourceQuota, used, now)
+
+		current.Status.ResourceQuota = reconcileLedgerStatus(
+			current.Status.ResourceQuota,
+			generation,
+			namespaces,
+			used,
+			true,
+			now,
+		)
+		current.Status.Conditions.UpdateConditionByType(drift.condition(current))
+
+		if reflect.DeepEqual(*originalStatus, current.Status) {
+			return nil
+		}
+
+		return r.Status().Update(ctx, current)
+	})
+
+	return drift, err
+}
+
+func (r *ledgerAuditor) recordAudit(ledger *capsulev1beta2.QuantityLedger, drift ledgerDrift) {
+	kind := ledger.Spec.TargetRef.Kind
+
+	r.metrics.RecordAudit(ledger.Name, ledger.Namespace, kind, drift.used, drift.reserved, drift.consistent())
+	r.metrics.RecordRepair(ledger.Name, ledger.Namespace, kind, metrics.QuantityLedgerRepairExpiredReservation, drift.expiredReservations)
+
+	if !resourceListZero(drift.used) {
+		r.metrics.RecordRepair(ledger.Name, ledger.Namespace, kind, metrics.QuantityLedgerRepairUsed, 1)
+	}
+
+	if !resourceListZero(drift.reserved) {
+		r.metrics.RecordRepair(ledger.Name, ledger.Namespace, kind, metrics.QuantityLedgerRepairReserved, 1)
+	}
+}
+
+// Drift of a QuantityLedger found by its audit.
+type ledgerDrift struct {
+	// Used resources of the ledger minus the live usage.
+	used corev1.ResourceList
+	// Reserved resources of the ledger minus the deltas of its reservations.
+	reserved corev1.ResourceList
+	// Reservations past their expiry.
+	expiredReservations int
+}
+
+func diffLedgerStatus(
+	current *capsulev1beta2.QuantityLedgerResourceQuotaStatus,
+	used corev1.ResourceList,
+	now metav1.Time,
+) ledgerDrift {
+	if current == nil {
+		current = &capsulev1beta2.QuantityLedgerResourceQuotaStatus{}
+	}
+
+	drift := ledgerDrift{
+		used:     current.Used.DeepCopy(),
+		reserved: current.Reserved.DeepCopy(),
+	}
+
+	if drift.used == nil {
+		drift.used = corev1.ResourceList{}
+	}
+
+	if drift.reserved == nil {
+		drift.reserved = corev1.ResourceList{}
+	}
+
+	for name, quantity := range used {
+		current := drift.used[name]
+		current.Sub(quantity)
+		drift.used[name] = current
+	}
+
+	for _, reservation := range current.Reservations {
+		for name, quantity := range reservation.Delta {
+			current := drift.reserved[name]
+			current.Sub(quantity)
+			drift.reserved[name] = current
+		}
+
+		if reservation.ExpiresAt != nil && reservation.ExpiresAt.Before(&now) {
+			drift.expiredReservations++
+		}
+	}
+
+	return drift
+}
+
+func (d ledgerDrift) consistent() bool {
+	return resourceListZero(d.used) && resourceListZero(d.reserved) && d.expiredReservations == 0
+}
+
+func (d ledgerDrift) message() string {
+	parts := make([]string, 0, 3)
+
+	if drifted := driftedResources(d.used); drifted != "" {
+		parts = append(parts, "used drifted by "+drifted)
+	}
+
+	if drifted := driftedResources(d.reserved); drifted != "" {
+		parts = append(parts, "reserved drifted by "+drifted)
+	}
+
+	if d.expiredReservations > 0 {
+		parts = append(parts, fmt.Sprintf("%d expired reservations", d.expiredReservations))
+	}
+
+	return "repaired " + strings.Join(parts, ", ")
+}
+
+func (d ledgerDrift) condition(obj client.Object) meta.Condition {
+	condition := meta.NewLedgerConsistentCondition(obj)
+	if d.consistent() {
+		return condition
+	}
+
+	condition.Status = metav1.ConditionFalse
+	condition.Reason = meta.DriftRepairedReason
+	condition.Message = d.message()
+
+	return condition
+}
+
+// Formats the resources which drifted, sorted by name.
+func driftedResources(drift corev1.ResourceList) string {
+	names := make([]string, 0, len(drift))
+
+	for name, quantity := range drift {
+		if !quantity.IsZero() {
+			names = append(names, name.String())
+		}
+	}
+
+	slices.Sort(names)
+
+	for i, name := range names {
+		quantity := drift[corev1.ResourceName(name)]
+		names[i] = name + "=" + quantity.String()
+	}
+
+	return strings.Join(names, " ")
+}
+
+func resourceListZero(resources corev1.ResourceList) bool {
+	for _, quantity := range resources {
+		if !quantity.IsZero() {
+			return false
+		}
+	}
+
+	return true
+}
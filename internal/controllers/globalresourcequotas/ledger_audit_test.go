@@ -0,0 +1,182 @@
+// Copyright 2020-2026 Project Capsule Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package globalresourcequotas
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	"github.com/projectcapsule/capsule/pkg/api/meta"
+)
+
+func TestDiffLedgerStatus(t *testing.T) {
+	t.Parallel()
+
+	now := metav1.Now()
+	past := metav1.NewTime(now.Add(-time.Minute))
+	future := metav1.NewTime(now.Add(time.Minute))
+
+	tests := []struct {
+		name           string
+		current        *capsulev1beta2.QuantityLedgerResourceQuotaStatus
+		used           corev1.ResourceList
+		wantMessage    string
+		wantConsistent bool
+	}{
+		{
+			name: "consistent ledger",
+			current: &capsulev1beta2.QuantityLedgerResourceQuotaStatus{
+				Used:     corev1.ResourceList{corev1.ResourcePods: resource.MustParse("4")},
+				Reserved: corev1.ResourceList{corev1.ResourcePods: resource.MustParse("1")},
+				Reservations: []capsulev1beta2.QuantityLedgerResourceQuotaReservation{
+					{ID: "a", Delta: corev1.ResourceList{corev1.ResourcePods: resource.MustParse("1")}, ExpiresAt: &future},
+				},
+			},
+			used:           corev1.ResourceList{corev1.ResourcePods: resource.MustParse("4")},
+			wantConsistent: true,
+		},
+		{
+			name:           "missing ledger status",
+			used:           corev1.ResourceList{corev1.ResourcePods: resource.MustParse("2")},
+			wantMessage:    "repaired used drifted by pods=-2",
+			wantConsistent: false,
+		},
+		{
+			name: "used and reserved drifted",
+			current: &capsulev1beta2.QuantityLedgerResourceQuotaStatus{
+				Used: corev1.ResourceList{
+					corev1.ResourcePods:        resource.MustParse("6"),
+					corev1.ResourceRequestsCPU: resource.MustParse("1"),
+				},
+				Reserved: corev1.ResourceList{corev1.ResourcePods: resource.MustParse("3")},
+				Reservations: []capsulev1beta2.QuantityLedgerResourceQuotaReservation{
+					{ID: "a", Delta: corev1.ResourceList{corev1.ResourcePods: resource.MustParse("1")}, ExpiresAt: &future},
+				},
+			},
+			used: corev1.ResourceList{
+				corev1.ResourcePods:        resource.MustParse("4"),
+				corev1.ResourceRequestsCPU: resource.MustParse("1"),
+			},
+			wantMessage:    "repaired used drifted by pods=2, reserved drifted by pods=2",
+			wantConsistent: false,
+		},
+		{
+			name: "expired reservations",
+			current: &capsulev1beta2.QuantityLedgerResourceQuotaStatus{
+				Used:     corev1.ResourceList{corev1.ResourcePods: resource.MustParse("4")},
+				Reserved: corev1.ResourceList{corev1.ResourcePods: resource.MustParse("1")},
+				Reservations: []capsulev1beta2.QuantityLedgerResourceQuotaReservation{
+					{ID: "a", Delta: corev1.ResourceList{corev1.ResourcePods: resource.MustParse("1")}, ExpiresAt: &past},
+				},
+			},
+			used:           corev1.ResourceList{corev1.ResourcePods: resource.MustParse("4")},
+			wantMessage:    "repaired 1 expired reservations",
+			wantConsistent: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			drift := diffLedgerStatus(tt.current, tt.used, now)
+			if drift.consistent() != tt.wantConsistent {
+				t.Fatalf("consistent = %t, want %t (%s)", drift.consistent(), tt.wantConsistent, drift.message())
+			}
+
+			if tt.wantConsistent {
+				return
+			}
+
+			if got := drift.message(); got != tt.wantMessage {
+				t.Fatalf("message = %q, want %q", got, tt.wantMessage)
+			}
+		})
+	}
+}
+
+func TestLedgerAuditorRepairLedger(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	if err := capsulev1beta2.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now()
+	past := metav1.NewTime(now.Add(-time.Minute))
+	future := metav1.NewTime(now.Add(time.Minute))
+
+	key := types.NamespacedName{Namespace: "capsule-system", Name: "shared"}
+	ledger := &capsulev1beta2.QuantityLedger{
+		ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace},
+		Spec: capsulev1beta2.QuantityLedgerSpec{
+			TargetRef: capsulev1beta2.QuantityLedgerTargetRef{Kind: "GlobalResourceQuota", Name: "shared"},
+		},
+		Status: capsulev1beta2.QuantityLedgerStatus{
+			ResourceQuota: &capsulev1beta2.QuantityLedgerResourceQuotaStatus{
+				Used:     corev1.ResourceList{corev1.ResourcePods: resource.MustParse("4")},
+				Reserved: corev1.ResourceList{corev1.ResourcePods: resource.MustParse("3")},
+				Reservations: []capsulev1beta2.QuantityLedgerResourceQuotaReservation{
+					{ID: "expired", Delta: corev1.ResourceList{corev1.ResourcePods: resource.MustParse("2")}, ExpiresAt: &past},
+					{ID: "active", Delta: corev1.ResourceList{corev1.ResourcePods: resource.MustParse("1")}, ExpiresAt: &future},
+				},
+			},
+		},
+	}
+
+	cl := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithStatusSubresource(&capsulev1beta2.QuantityLedger{}).
+		WithObjects(ledger).
+		Build()
+	auditor := &ledgerAuditor{Client: cl, quotas: &Controller{Client: cl, reader: cl}}
+	used := corev1.ResourceList{corev1.ResourcePods: resource.MustParse("4")}
+
+	drift, err := auditor.repairLedger(context.Background(), key, 1, []string{"a"}, used)
+	if err != nil {
+		t.Fatalf("repairLedger() error = %v", err)
+	}
+	if drift.consistent() || drift.expiredReservations != 1 {
+		t.Fatalf("drift = %s, want one expired reservation", drift.message())
+	}
+
+	got := &capsulev1beta2.QuantityLedger{}
+	if err := cl.Get(context.Background(), key, got); err != nil {
+		t.Fatal(err)
+	}
+
+	status := got.Status.ResourceQuota
+	if len(status.Reservations) != 1 || status.Reservations[0].ID != "active" {
+		t.Fatalf("reservations = %#v, want only the active reservation", status.Reservations)
+	}
+	if reserved := status.Reserved[corev1.ResourcePods]; reserved.Cmp(resource.MustParse("1")) != 0 {
+		t.Fatalf("reserved = %s, want 1", reserved.String())
+	}
+	if allocated := status.Allocated[corev1.ResourcePods]; allocated.Cmp(resource.MustParse("5")) != 0 {
+		t.Fatalf("allocated = %s, want 5", allocated.String())
+	}
+
+	condition := got.Status.Conditions.GetConditionByType(meta.LedgerConsistentCondition)
+	if condition == nil || condition.Status != metav1.ConditionFalse || condition.Reason != meta.DriftRepairedReason {
+		t.Fatalf("condition = %#v, want DriftRepaired", condition)
+	}
+
+	drift, err = auditor.repairLedger(context.Background(), key, 1, []string{"a"}, used)
+	if err != nil {
+		t.Fatalf("repairLedger() error = %v", err)
+	}
+	if !drift.consistent() {
+		t.Fatalf("drift after repair = %s, want consistent", drift.message())
+	}
+}
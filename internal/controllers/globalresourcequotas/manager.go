@@ -5,6 +5,7 @@ package globalresourcequotas
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/go-logr/logr"
 	"k8s.io/client-go/tools/events"
@@ -19,6 +20,8 @@ func Add(
 	mgr manager.Manager,
 	recorder events.EventRecorder,
 	cfg utils.ControllerOptions,
+	ledgerAuditInterval time.Duration,
+	ledgerMetrics *metrics.QuantityLedgerRecorder,
 ) error {
 	controller := &Controller{
 		Client:   mgr.GetClient(),
@@ -30,5 +33,19 @@ func Add(
 		return fmt.Errorf("unable to create GlobalResourceQuota controller: %w", err)
 	}
 
+	if ledgerAuditInterval <= 0 {
+		return nil
+	}
+
+	if err := (&ledgerAuditor{
+		Client:   mgr.GetClient(),
+		quotas:   controller,
+		log:      log.WithName("QuantityLedgerAudit"),
+		metrics:  ledgerMetrics,
+		interval: ledgerAuditInterval,
+	}).SetupWithManager(mgr, cfg); err != nil {
+		return fmt.Errorf("unable to create GlobalResourceQuota ledger audit controller: %w", err)
+	}
+
 	return nil
 }
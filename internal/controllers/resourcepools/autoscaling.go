@@ -0,0 +1,315 @@
+// Copyright 2020-2026 Project Capsule Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package resourcepools
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	evt "github.com/projectcapsule/capsule/pkg/runtime/events"
+)
+
+const (
+	defaultAutoscalingHighWatermark          int32 = 90
+	defaultAutoscalingScaleDownStabilization       = 10 * time.Minute
+)
+
+// Resizes bound claims with autoscaling based on the usage of their namespace resourcequota. Within the
+// resourcequota of a namespace and scope, each resource is scaled on the oldest claim with autoscaling,
+// which claims the resource.
+// Resized claims are updated in the pool status, growth is limited by the claimable resources of the pool.
+// Returns the duration after which a scale down must be evaluated again.
+func (r *resourcePoolController) handleClaimAutoscaling(
+	ctx context.Context,
+	log logr.Logger,
+	pool *capsulev1beta2.ResourcePool,
+	claims []capsulev1beta2.ResourcePoolClaim,
+	cohort *poolCohort,
+	now time.Time,
+) (requeueAfter time.Duration, err error) {
+	claimsByQuota := make(map[types.NamespacedName][]*capsulev1beta2.ResourcePoolClaim)
+	scopes := make(map[types.NamespacedName]string)
+
+	for i := range claims {
+		claim := &claims[i]
+
+		item := pool.GetClaimFromStatus(claim)
+		if claim.Spec.Autoscaling == nil || item == nil {
+			continue
+		}
+
+		key := types.NamespacedName{Name: pool.GetScopedQuotaName(item.Scope), Namespace: claim.Namespace}
+
+		claimsByQuota[key] = append(claimsByQuota[key], claim)
+		scopes[key] = item.Scope
+	}
+
+	quotas := make([]types.NamespacedName, 0, len(claimsByQuota))
+	for key := range claimsByQuota {
+		quotas = append(quotas, key)
+	}
+
+	sort.Slice(quotas, func(i, j int) bool {
+		return quotas[i].String() < quotas[j].String()
+	})
+
+	for _, key := range quotas {
+		rq := &corev1.ResourceQuota{}
+		if err := r.reader.Get(ctx, key, rq); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+
+			return requeueAfter, err
+		}
+
+		scope := scopes[key]
+		hard := pool.GetScopedResourceQuotaHardResources(key.Namespace, scope)
+		scaledResources := make(map[corev1.ResourceName]struct{})
+
+		for _, claim := range claimsByQuota[key] {
+			next, err := r.autoscaleClaim(ctx, log, pool, claim, cohort, scope, hard, rq.Status.Used, scaledResources, now)
+			if err != nil {
+				return requeueAfter, fmt.Errorf("autoscale claim %s/%s: %w", claim.Namespace, claim.Name, err)
+			}
+
+			requeueAfter = earliestRequeue(requeueAfter, next)
+		}
+	}
+
+	return requeueAfter, nil
+}
+
+func (r *resourcePoolController) autoscaleClaim(
+	ctx context.Context,
+	log logr.Logger,
+	pool *capsulev1beta2.ResourcePool,
+	claim *capsulev1beta2.ResourcePoolClaim,
+	cohort *poolCohort,
+	scope string,
+	hard corev1.ResourceList,
+	used corev1.ResourceList,
+	scaledResources map[corev1.ResourceName]struct{},
+	now time.Time,
+) (requeueAfter time.Duration, err error) {
+	policy := claim.Spec.Autoscaling
+
+	status := &capsulev1beta2.ResourcePoolClaimAutoscalingStatus{}
+	if claim.Status.Autoscaling != nil {
+		status = claim.Status.Autoscaling.DeepCopy()
+	}
+
+	original := status.DeepCopy()
+	current := claim.GetResourceClaims()
+	scaled := current.DeepCopy()
+	lowUsageSince := make(map[corev1.ResourceName]metav1.Time)
+	changes := make([]string, 0)
+
+	resourceNames := make([]string, 0, len(current))
+	for resourceName := range current {
+		resourceNames = append(resourceNames, resourceName.String())
+	}
+
+	sort.Strings(resourceNames)
+
+	for _, name := range resourceNames {
+		resourceName := corev1.ResourceName(name)
+
+		if _, ok := scaledResources[resourceName]; ok {
+			continue
+		}
+
+		scaledResources[resourceName] = struct{}{}
+
+		var since *metav1.Time
+		if s, ok := status.LowUsageSince[resourceName]; ok {
+			since = &s
+		}
+
+		qt := current[resourceName]
+
+		desired, since, requeueAt := autoscaleResource(policy, qt, hard[resourceName], used[resourceName], since, now)
+		if since != nil {
+			lowUsageSince[resourceName] = *since
+		}
+
+		if !requeueAt.IsZero() {
+			requeueAfter = earliestRequeue(requeueAfter, requeueAt.Sub(now))
+		}
+
+		claimable := cohort.claimableInScope(pool, scope)
+
+		desired = clampAutoscaledResource(policy, resourceName, claim.Spec.ResourceClaims[resourceName], qt, desired, claimable[resourceName])
+		if desired.Cmp(qt) == 0 {
+			continue
+		}
+
+		scaled[resourceName] = desired
+
+		changes = append(changes, fmt.Sprintf("%s from %s to %s", resourceName, qt.String(), desired.String()))
+	}
+
+	status.LowUsageSince = nil
+	if len(lowUsageSince) != 0 {
+		status.LowUsageSince = lowUsageSince
+	}
+
+	if len(changes) != 0 {
+		log.V(4).Info("autoscaling claim", "claim", claim.Name, "namespace", claim.Namespace, "changes", changes)
+
+		status.Claims = scaled
+		status.LastScaleTime = &metav1.Time{Time: now}
+	}
+
+	if reflect.DeepEqual(original, status) {
+		return requeueAfter, nil
+	}
+
+	err = retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		latest := &capsulev1beta2.ResourcePoolClaim{}
+		if err := r.reader.Get(ctx, client.ObjectKeyFromObject(claim), latest); err != nil {
+			return fmt.Errorf("failed to refetch instance before update: %w", err)
+		}
+
+		latest.Status.Autoscaling = status
+
+		return r.Status().Update(ctx, latest)
+	})
+	if err != nil {
+		return requeueAfter, err
+	}
+
+	claim.Status.Autoscaling = status
+
+	if len(changes) != 0 {
+		pool.AddClaimToStatus(claim)
+
+		r.recorder.Eventf(
+			claim,
+			pool,
+			corev1.EventTypeNormal,
+			evt.ReasonScaled,
+			evt.ActionReconciled,
+			"scaled "+strings.Join(changes, ", "),
+		)
+	}
+
+	return requeueAfter, nil
+}
+
+// Calculates the desired amount of a claimed resource. Usage above the high watermark grows the claim
+// immediately, usage below the low watermark shrinks the claim once the usage remained below the low
+// watermark for the stabilization window. In both cases, the claim is resized so the usage of the
+// namespace resourcequota settles between both watermarks. Returns the point in time since when the
+// usage is below the low watermark and when the scale down must be evaluated again.
+func autoscaleResource(
+	policy *capsulev1beta2.ResourcePoolClaimAutoscaling,
+	current resource.Quantity,
+	hard resource.Quantity,
+	used resource.Quantity,
+	lowUsageSince *metav1.Time,
+	now time.Time,
+) (desired resource.Quantity, since *metav1.Time, requeueAt time.Time) {
+	if hard.IsZero() {
+		return current, nil, requeueAt
+	}
+
+	high := policy.HighWatermark
+	if high == 0 {
+		high = defaultAutoscalingHighWatermark
+	}
+
+	window := defaultAutoscalingScaleDownStabilization
+	if policy.ScaleDownStabilization != nil {
+		window = policy.ScaleDownStabilization.Duration
+	}
+
+	utilization := float64(used.MilliValue()) * 100 / float64(hard.MilliValue())
+	target := float64(high+policy.LowWatermark) / 2
+	targetHard := int64(math.Ceil(float64(used.MilliValue()) * 100 / target))
+
+	switch {
+	case utilization >= float64(high):
+		return milliQuantity(current.MilliValue()+targetHard-hard.MilliValue(), current), nil, requeueAt
+	case utilization <= float64(policy.LowWatermark):
+		if lowUsageSince == nil {
+			lowUsageSince = &metav1.Time{Time: now}
+		}
+
+		if stabilized := lowUsageSince.Add(window); now.Before(stabilized) {
+			return current, lowUsageSince, stabilized
+		}
+
+		return milliQuantity(current.MilliValue()-(hard.MilliValue()-targetHard), current), nil, requeueAt
+	default:
+		return current, nil, requeueAt
+	}
+}
+
+// Limits the desired amount of a claimed resource by the autoscaling boundaries and the claimable
+// resources of the pool. Claims are not shrunk below the claimed resources of the spec, unless a
+// lower minimum is given.
+func clampAutoscaledResource(
+	policy *capsulev1beta2.ResourcePoolClaimAutoscaling,
+	resourceName corev1.ResourceName,
+	requested resource.Quantity,
+	current resource.Quantity,
+	desired resource.Quantity,
+	claimable resource.Quantity,
+) resource.Quantity {
+	minimum := requested
+	if qt, ok := policy.Min[resourceName]; ok {
+		minimum = qt
+	}
+
+	if desired.Cmp(minimum) < 0 {
+		desired = minimum.DeepCopy()
+	}
+
+	if qt, ok := policy.Max[resourceName]; ok && desired.Cmp(qt) > 0 {
+		desired = qt.DeepCopy()
+	}
+
+	if desired.Cmp(current) > 0 {
+		limit := current.DeepCopy()
+		if claimable.Sign() > 0 {
+			limit.Add(claimable)
+		}
+
+		if desired.Cmp(limit) > 0 {
+			desired = limit
+		}
+	}
+
+	return desired
+}
+
+// Creates a quantity in the format of the given reference. Quantities of whole units are rounded up to
+// whole units.
+func milliQuantity(milli int64, ref resource.Quantity) resource.Quantity {
+	if milli < 0 {
+		milli = 0
+	}
+
+	if ref.MilliValue()%1000 == 0 {
+		return *resource.NewQuantity(int64(math.Ceil(float64(milli)/1000)), ref.Format)
+	}
+
+	return *resource.NewMilliQuantity(milli, ref.Format)
+}
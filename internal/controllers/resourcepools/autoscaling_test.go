@@ -0,0 +1,167 @@
+// Copyright 2020-2026 Project Capsule Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package resourcepools
+
+import (
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+)
+
+func TestAutoscaleResource(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	policy := &capsulev1beta2.ResourcePoolClaimAutoscaling{
+		HighWatermark:          90,
+		LowWatermark:           50,
+		ScaleDownStabilization: &metav1.Duration{Duration: 10 * time.Minute},
+	}
+
+	tests := []struct {
+		name          string
+		hard          string
+		used          string
+		lowUsageSince *metav1.Time
+		expected      string
+		expectSince   bool
+		expectRequeue time.Time
+	}{
+		{name: "usage above high watermark grows", hard: "4", used: "3800m", expected: "6"},
+		{name: "usage between watermarks", hard: "4", used: "3", expected: "4"},
+		{name: "no hard resources", hard: "0", used: "3", expected: "4"},
+		{
+			name:          "usage below low watermark starts window",
+			hard:          "4",
+			used:          "1",
+			expected:      "4",
+			expectSince:   true,
+			expectRequeue: now.Add(10 * time.Minute),
+		},
+		{
+			name:          "usage below low watermark within window",
+			hard:          "4",
+			used:          "1",
+			lowUsageSince: &metav1.Time{Time: now.Add(-5 * time.Minute)},
+			expected:      "4",
+			expectSince:   true,
+			expectRequeue: now.Add(5 * time.Minute),
+		},
+		{
+			name:          "usage below low watermark after window shrinks",
+			hard:          "4",
+			used:          "1",
+			lowUsageSince: &metav1.Time{Time: now.Add(-11 * time.Minute)},
+			expected:      "2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			desired, since, requeueAt := autoscaleResource(policy, q("4"), q(tt.hard), q(tt.used), tt.lowUsageSince, now)
+
+			if desired.Cmp(q(tt.expected)) != 0 {
+				t.Fatalf("desired = %s, want %s", desired.String(), tt.expected)
+			}
+
+			if (since != nil) != tt.expectSince {
+				t.Fatalf("lowUsageSince = %v, want set %v", since, tt.expectSince)
+			}
+
+			if !requeueAt.Equal(tt.expectRequeue) {
+				t.Fatalf("requeueAt = %s, want %s", requeueAt, tt.expectRequeue)
+			}
+		})
+	}
+}
+
+func TestClampAutoscaledResource(t *testing.T) {
+	t.Parallel()
+
+	policy := &capsulev1beta2.ResourcePoolClaimAutoscaling{
+		Min: rl(map[corev1.ResourceName]string{corev1.ResourceRequestsMemory: "1Gi"}),
+		Max: rl(map[corev1.ResourceName]string{corev1.ResourceRequestsCPU: "8"}),
+	}
+
+	tests := []struct {
+		name         string
+		resourceName corev1.ResourceName
+		requested    string
+		current      string
+		desired      string
+		claimable    string
+		expected     string
+	}{
+		{name: "within boundaries", resourceName: corev1.ResourceRequestsCPU, requested: "2", current: "4", desired: "6", claimable: "10", expected: "6"},
+		{name: "not below requested", resourceName: corev1.ResourceRequestsCPU, requested: "2", current: "4", desired: "1", claimable: "10", expected: "2"},
+		{name: "not above maximum", resourceName: corev1.ResourceRequestsCPU, requested: "2", current: "4", desired: "10", claimable: "10", expected: "8"},
+		{name: "growth limited by pool", resourceName: corev1.ResourceRequestsCPU, requested: "2", current: "4", desired: "8", claimable: "1", expected: "5"},
+		{name: "no growth on exhausted pool", resourceName: corev1.ResourceRequestsCPU, requested: "2", current: "4", desired: "8", claimable: "-1", expected: "4"},
+		{name: "minimum below requested", resourceName: corev1.ResourceRequestsMemory, requested: "4Gi", current: "4Gi", desired: "512Mi", claimable: "0", expected: "1Gi"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := clampAutoscaledResource(policy, tt.resourceName, q(tt.requested), q(tt.current), q(tt.desired), q(tt.claimable))
+			if got.Cmp(q(tt.expected)) != 0 {
+				t.Fatalf("clampAutoscaledResource() = %s, want %s", got.String(), tt.expected)
+			}
+		})
+	}
+}
+
+func TestMilliQuantity(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		milli    int64
+		ref      string
+		expected string
+	}{
+		{name: "negative amount", milli: -500, ref: "2", expected: "0"},
+		{name: "whole units round up", milli: 2100, ref: "2", expected: "3"},
+		{name: "milli units are kept", milli: 2100, ref: "1500m", expected: "2100m"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := milliQuantity(tt.milli, q(tt.ref))
+			if got.Cmp(q(tt.expected)) != 0 {
+				t.Fatalf("milliQuantity() = %s, want %s", got.String(), tt.expected)
+			}
+		})
+	}
+}
+
+func TestEarliestRequeue(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		a, b     time.Duration
+		expected time.Duration
+	}{
+		{a: 0, b: 0, expected: 0},
+		{a: time.Minute, b: 0, expected: time.Minute},
+		{a: 0, b: time.Minute, expected: time.Minute},
+		{a: time.Hour, b: time.Minute, expected: time.Minute},
+		{a: time.Minute, b: time.Hour, expected: time.Minute},
+	}
+
+	for _, tt := range tests {
+		if got := earliestRequeue(tt.a, tt.b); got != tt.expected {
+			t.Fatalf("earliestRequeue(%s, %s) = %s, want %s", tt.a, tt.b, got, tt.expected)
+		}
+	}
+}
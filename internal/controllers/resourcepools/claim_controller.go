@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"time"
 
 	"github.com/go-logr/logr"
 	gherrors "github.com/pkg/errors"
@@ -49,7 +50,10 @@ func (r *resourceClaimController) SetupWithManager(mgr ctrl.Manager, ctrlConfig
 		For(
 			&capsulev1beta2.ResourcePoolClaim{},
 			builder.WithPredicates(
-				predicate.GenerationChangedPredicate{},
+				predicate.Or(
+					predicate.GenerationChangedPredicate{},
+					predicates.ResourcePoolClaimUnassignedPredicate{},
+				),
 			),
 		).
 		Watches(
@@ -110,7 +114,12 @@ func (r resourceClaimController) Reconcile(ctx context.Context, request ctrl.Req
 
 	err = r.reconcile(ctx, log, instance)
 
-	return ctrl.Result{}, err
+	// Requeue at the expiry, to mark claims which are not assigned to a pool as expired.
+	if expiry := instance.GetExpiry(); expiry != nil && !instance.IsExpired(time.Now()) {
+		result.RequeueAfter = time.Until(expiry.Time)
+	}
+
+	return result, err
 }
 
 // Trigger claims from a namespace, which are not yet allocated.
@@ -153,6 +162,12 @@ func (r resourceClaimController) reconcile(
 	log logr.Logger,
 	claim *capsulev1beta2.ResourcePoolClaim,
 ) (err error) {
+	if r.handleClaimExpiry(claim, time.Now()) {
+		log.V(5).Info("claim is expired", "expiry", claim.Status.ExpiresAt)
+
+		return nil
+	}
+
 	pool, err := r.evaluateResourcePool(ctx, claim)
 	if err != nil {
 		return err
@@ -161,6 +176,34 @@ func (r resourceClaimController) reconcile(
 	return r.allocateResourcePool(ctx, log, claim, pool)
 }
 
+// Tracks the expiry of the claim. Returns true, if the claim is expired and not assigned to a pool,
+// in which case it must not be assigned again. Assigned claims are released by the pool.
+func (r resourceClaimController) handleClaimExpiry(
+	claim *capsulev1beta2.ResourcePoolClaim,
+	now time.Time,
+) bool {
+	claim.Status.ExpiresAt = claim.GetExpiry()
+
+	if !claim.IsExpired(now) {
+		claim.Status.Conditions.RemoveConditionByType(meta.ExpiredCondition)
+
+		return false
+	}
+
+	if claim.Status.Pool.UID != "" {
+		return false
+	}
+
+	if cond := claim.Status.Conditions.GetConditionByType(meta.ExpiredCondition); cond == nil || cond.Reason != meta.ExpiredReason {
+		expired := meta.NewExpiredCondition(claim)
+		expired.Message = "claim expired at " + claim.Status.ExpiresAt.UTC().Format(time.RFC3339)
+
+		claim.Status.Conditions.UpdateConditionByType(expired)
+	}
+
+	return true
+}
+
 // Verify a Pool can be allocated.
 func (r resourceClaimController) evaluateResourcePool(
 	ctx context.Context,
@@ -203,9 +246,16 @@ func (r resourceClaimController) evaluateResourcePool(
 		)
 	}
 
+	scope, err := pool.GetScopeForClaim(claim)
+	if err != nil {
+		return nil, err
+	}
+
+	hard := pool.GetScopeHardResources(claimScopeName(scope))
+
 	// Validates if Resources can be allocated in the first place
 	for resourceName := range claim.Spec.ResourceClaims {
-		_, exists := pool.Status.Allocation.Hard[resourceName]
+		_, exists := hard[resourceName]
 		if !exists {
 			return nil, fmt.Errorf(
 				"resource %s is not available in pool %s",
@@ -283,8 +333,12 @@ func (r *resourceClaimController) updateStatus(
 
 		originalStatus := latest.Status.DeepCopy()
 
+		// The autoscaling state is managed by the pool.
+		autoscaling := latest.Status.Autoscaling
+
 		latest.Status = instance.Status
 		latest.Status.ObservedGeneration = instance.GetGeneration()
+		latest.Status.Autoscaling = autoscaling
 
 		readyCondition := meta.NewReadyCondition(latest)
 		if reconcileError != nil {
@@ -0,0 +1,236 @@
+// Copyright 2020-2026 Project Capsule Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package resourcepools
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	"github.com/projectcapsule/capsule/pkg/api/meta"
+)
+
+// Tracks the resources lent to a pool by the other pools of its cohort.
+type poolCohort struct {
+	name     string
+	lendable corev1.ResourceList
+}
+
+// Returns nil if the pool is not part of a cohort.
+func (r *resourcePoolController) gatherCohort(
+	ctx context.Context,
+	pool *capsulev1beta2.ResourcePool,
+) (*poolCohort, error) {
+	if pool.Spec.Cohort == "" || !pool.DeletionTimestamp.IsZero() {
+		return nil, nil
+	}
+
+	poolList := &capsulev1beta2.ResourcePoolList{}
+	if err := r.List(ctx, poolList, client.MatchingFields{".spec.cohort": pool.Spec.Cohort}); err != nil {
+		return nil, fmt.Errorf("list pools of cohort %s: %w", pool.Spec.Cohort, err)
+	}
+
+	cohort := &poolCohort{
+		name:     pool.Spec.Cohort,
+		lendable: corev1.ResourceList{},
+	}
+
+	for i := range poolList.Items {
+		lender := &poolList.Items[i]
+
+		if lender.UID == pool.UID || !lender.DeletionTimestamp.IsZero() {
+			continue
+		}
+
+		for resourceName, qt := range lender.GetLendableResources() {
+			if _, ok := pool.Status.Allocation.Hard[resourceName]; !ok {
+				continue
+			}
+
+			amount := cohort.lendable[resourceName]
+			amount.Add(qt)
+			cohort.lendable[resourceName] = amount
+		}
+	}
+
+	return cohort, nil
+}
+
+// Returns the resources which can be claimed from the pool, including resources borrowed from the cohort.
+func (c *poolCohort) claimable(pool *capsulev1beta2.ResourcePool) corev1.ResourceList {
+	if c == nil {
+		return pool.GetAvailableClaimableResources()
+	}
+
+	return pool.GetCohortClaimableResources(c.lendable)
+}
+
+// Returns the resources which can be claimed for the given scope of the pool. Scopes don't borrow from the cohort.
+func (c *poolCohort) claimableInScope(pool *capsulev1beta2.ResourcePool, scope string) corev1.ResourceList {
+	if scope != "" {
+		return pool.GetAvailableClaimableScopeResources(scope)
+	}
+
+	return c.claimable(pool)
+}
+
+// Returns the borrowed resources the pool must return, because the cohort no longer lends them.
+func (c *poolCohort) reclaimable(pool *capsulev1beta2.ResourcePool) corev1.ResourceList {
+	reclaim := corev1.ResourceList{}
+
+	if c == nil {
+		return reclaim
+	}
+
+	available := pool.GetAvailableClaimableResources()
+
+	for resourceName, borrowed := range pool.Status.Borrowed {
+		balance := available[resourceName].DeepCopy()
+		balance.Add(c.lendable[resourceName])
+
+		if balance.Sign() >= 0 {
+			continue
+		}
+
+		balance.Neg()
+
+		if balance.Cmp(borrowed) > 0 {
+			balance = borrowed.DeepCopy()
+		}
+
+		reclaim[resourceName] = balance
+	}
+
+	return reclaim
+}
+
+// Releases bound claims, until the resources reclaimed by the cohort are returned. Claims are released in
+// the same order as for preemption. Returns the claims which remain.
+func (r *resourcePoolController) handleCohortReclaim(
+	ctx context.Context,
+	log logr.Logger,
+	pool *capsulev1beta2.ResourcePool,
+	claims []capsulev1beta2.ResourcePoolClaim,
+	cohort *poolCohort,
+) ([]capsulev1beta2.ResourcePoolClaim, error) {
+	missing := cohort.reclaimable(pool)
+	if len(missing) == 0 {
+		return claims, nil
+	}
+
+	byUID := make(map[types.UID]*capsulev1beta2.ResourcePoolClaim, len(claims))
+	for i := range claims {
+		byUID[claims[i].UID] = &claims[i]
+	}
+
+	type candidate struct {
+		item  *capsulev1beta2.ResourcePoolClaimsItem
+		claim *capsulev1beta2.ResourcePoolClaim
+	}
+
+	candidates := make([]candidate, 0)
+
+	for _, items := range pool.Status.Claims {
+		for _, item := range items {
+			if bound, ok := byUID[item.UID]; ok && item.Scope == "" {
+				candidates = append(candidates, candidate{item: item, claim: bound})
+			}
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return releasedBefore(candidates[i].claim, candidates[j].claim)
+	})
+
+	released := make(map[types.UID]struct{})
+
+	for _, c := range candidates {
+		if resolved(missing) {
+			break
+		}
+
+		if !holdsMissingResources(c.item, missing) {
+			continue
+		}
+
+		log.V(4).Info("releasing claim reclaimed by cohort", "claim", c.item.Name, "namespace", c.item.Namespace, "cohort", cohort.name)
+
+		cond := meta.NewPreemptionCondition(c.claim)
+		cond.Reason = meta.ReclaimedReason
+		cond.Message = fmt.Sprintf("resources borrowed from cohort %s are reclaimed", cohort.name)
+
+		if err := r.handleClaimDisassociation(ctx, log, pool, c.item, &cond); err != nil {
+			return nil, fmt.Errorf("release reclaimed claim %s/%s: %w", c.item.Namespace, c.item.Name, err)
+		}
+
+		released[c.item.UID] = struct{}{}
+
+		for resourceName, qt := range c.item.Claims {
+			if deficit, ok := missing[resourceName]; ok {
+				deficit.Sub(qt)
+				missing[resourceName] = deficit
+			}
+		}
+	}
+
+	pool.CalculateClaimedResources()
+
+	remaining := make([]capsulev1beta2.ResourcePoolClaim, 0, len(claims))
+
+	for i := range claims {
+		if _, ok := released[claims[i].UID]; !ok {
+			remaining = append(remaining, claims[i])
+		}
+	}
+
+	return remaining, nil
+}
+
+func holdsMissingResources(item *capsulev1beta2.ResourcePoolClaimsItem, missing corev1.ResourceList) bool {
+	for resourceName, deficit := range missing {
+		if deficit.Sign() <= 0 {
+			continue
+		}
+
+		if qt, ok := item.Claims[resourceName]; ok && !qt.IsZero() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Trigger the other pools of a cohort, when the resources lent by a pool change.
+func (r *resourcePoolController) resourcePoolsForCohort(ctx context.Context, obj client.Object) []reconcile.Request {
+	pool, ok := obj.(*capsulev1beta2.ResourcePool)
+	if !ok || pool.Spec.Cohort == "" {
+		return nil
+	}
+
+	poolList := &capsulev1beta2.ResourcePoolList{}
+	if err := r.List(ctx, poolList, client.MatchingFields{".spec.cohort": pool.Spec.Cohort}); err != nil {
+		r.log.Error(err, "failed to list ResourcePools for cohort event", "cohort", pool.Spec.Cohort)
+
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(poolList.Items))
+
+	for i := range poolList.Items {
+		if poolList.Items[i].UID == pool.UID {
+			continue
+		}
+
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&poolList.Items[i])})
+	}
+
+	return requests
+}
@@ -0,0 +1,174 @@
+// Copyright 2020-2026 Project Capsule Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package resourcepools
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	"github.com/projectcapsule/capsule/pkg/api"
+	"github.com/projectcapsule/capsule/pkg/api/meta"
+	"github.com/projectcapsule/capsule/pkg/runtime/indexers/resourcepool"
+)
+
+const gpu = corev1.ResourceName("requests.nvidia.com/gpu")
+
+func gpus(v string) corev1.ResourceList {
+	return rl(map[corev1.ResourceName]string{gpu: v})
+}
+
+func cohortPool(name string, hard corev1.ResourceList, claims ...capsulev1beta2.ResourcePoolClaim) *capsulev1beta2.ResourcePool {
+	pool := poolWithClaims(hard, claims...)
+	pool.Name = name
+	pool.UID = types.UID("uid-" + name)
+	pool.Spec.Cohort = "department"
+	pool.Spec.Config.DeleteBoundResources = ptr.To(false)
+	pool.CalculateClaimedResources()
+
+	return pool
+}
+
+func cohortClient(t *testing.T, objs ...client.Object) client.Client {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	if err := capsulev1beta2.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	cohort := resourcepool.CohortReference{Obj: &capsulev1beta2.ResourcePool{}}
+
+	return fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithStatusSubresource(&capsulev1beta2.ResourcePoolClaim{}, &capsulev1beta2.ResourcePool{}).
+		WithIndex(cohort.Object(), cohort.Field(), cohort.Func()).
+		WithObjects(objs...).
+		Build()
+}
+
+func TestPoolCohortClaimable(t *testing.T) {
+	t.Parallel()
+
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	lender := cohortPool("lender", gpus("8"), claim(t, "lender-a", "team-a", "a", base, gpus("2")))
+	borrower := cohortPool("borrower", gpus("2"))
+	borrower.Spec.BorrowingLimit = gpus("4")
+
+	standalone := poolWithClaims(gpus("8"))
+	standalone.Name = "standalone"
+	standalone.UID = "uid-standalone"
+
+	c := cohortClient(t, lender, borrower, standalone)
+	r := &resourcePoolController{Client: c, reader: c, log: logr.Discard()}
+
+	cohort, err := r.gatherCohort(context.Background(), borrower)
+	if err != nil {
+		t.Fatalf("gatherCohort() error = %v", err)
+	}
+
+	claimable := cohort.claimable(borrower)[gpu]
+	if want := q("6"); claimable.Cmp(want) != 0 {
+		t.Fatalf("claimable = %s, want %s", claimable.String(), want.String())
+	}
+
+	noCohort, err := r.gatherCohort(context.Background(), standalone)
+	if err != nil {
+		t.Fatalf("gatherCohort() error = %v", err)
+	}
+
+	if noCohort != nil {
+		t.Fatal("expected no cohort for standalone pool")
+	}
+
+	claimable = noCohort.claimable(standalone)[gpu]
+	if want := q("8"); claimable.Cmp(want) != 0 {
+		t.Fatalf("claimable = %s, want %s", claimable.String(), want.String())
+	}
+}
+
+func TestHandleCohortReclaim(t *testing.T) {
+	t.Parallel()
+
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	older := claim(t, "borrower-old", "team-b", "old", base, gpus("2"))
+	newer := claim(t, "borrower-new", "team-b", "new", base.Add(time.Hour), gpus("2"))
+	own := claim(t, "borrower-own", "team-b", "own", base.Add(-time.Hour), gpus("1"))
+	own.Spec.Priority = 10
+
+	for _, cl := range []*capsulev1beta2.ResourcePoolClaim{&older, &newer, &own} {
+		cl.Status.Pool = meta.LocalRFC1123ObjectReferenceWithUID{Name: "borrower", UID: "uid-borrower"}
+	}
+
+	// The borrower claims 5 of 2 resources, borrowing 3 from the lender.
+	borrower := cohortPool("borrower", gpus("2"), older, newer, own)
+	borrower.Spec.BorrowingLimit = gpus("4")
+
+	// The lender claims 3 of 6 resources, 3 are lent, but 2 are requested by exhausted claims.
+	lender := cohortPool("lender", gpus("6"), claim(t, "lender-a", "team-a", "a", base, gpus("3")))
+	lender.Status.Exhaustions = map[string]api.PoolExhaustionResource{
+		gpu.String(): {Available: q("0"), Requesting: q("2")},
+	}
+
+	c := cohortClient(t, lender, borrower.DeepCopy(), older.DeepCopy(), newer.DeepCopy(), own.DeepCopy())
+	r := &resourcePoolController{Client: c, reader: c, log: logr.Discard(), recorder: events.NewFakeRecorder(10)}
+
+	cohort, err := r.gatherCohort(context.Background(), borrower)
+	if err != nil {
+		t.Fatalf("gatherCohort() error = %v", err)
+	}
+
+	reclaim := cohort.reclaimable(borrower)[gpu]
+	if want := q("2"); reclaim.Cmp(want) != 0 {
+		t.Fatalf("reclaimable = %s, want %s", reclaim.String(), want.String())
+	}
+
+	remaining, err := r.handleCohortReclaim(context.Background(), logr.Discard(), borrower, []capsulev1beta2.ResourcePoolClaim{older, newer, own}, cohort)
+	if err != nil {
+		t.Fatalf("handleCohortReclaim() error = %v", err)
+	}
+
+	if len(remaining) != 2 || remaining[0].UID != older.UID || remaining[1].UID != own.UID {
+		t.Fatalf("remaining claims = %v", remaining)
+	}
+
+	if borrower.GetClaimFromStatus(&newer) != nil {
+		t.Fatal("reclaimed claim is still bound to the pool")
+	}
+
+	borrowed := borrower.Status.Borrowed[gpu]
+	if want := q("1"); borrowed.Cmp(want) != 0 {
+		t.Fatalf("borrowed = %s, want %s", borrowed.String(), want.String())
+	}
+
+	current := &capsulev1beta2.ResourcePoolClaim{}
+	if err := c.Get(context.Background(), client.ObjectKeyFromObject(&newer), current); err != nil {
+		t.Fatal(err)
+	}
+
+	if cond := current.Status.Conditions.GetConditionByType(meta.PreemptionCondition); cond == nil || cond.Reason != meta.ReclaimedReason {
+		t.Fatalf("reclaimed claim condition = %#v", cond)
+	}
+
+	if current.Status.Pool.UID != "" {
+		t.Fatal("reclaimed claim is still assigned to the pool")
+	}
+
+	// Once reclaimed, the cohort no longer requires resources from the borrower.
+	if reclaim := cohort.reclaimable(borrower); len(reclaim) != 0 {
+		t.Fatalf("reclaimable after reclaim = %v", reclaim)
+	}
+}
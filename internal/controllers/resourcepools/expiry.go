@@ -0,0 +1,190 @@
+// Copyright 2020-2026 Project Capsule Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package resourcepools
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	"github.com/projectcapsule/capsule/pkg/api/meta"
+)
+
+// Tracks the next point in time a claim of the pool expires or its expiry grace period elapses.
+type poolExpiry struct {
+	now   time.Time
+	grace time.Duration
+	next  time.Time
+}
+
+func newPoolExpiry(pool *capsulev1beta2.ResourcePool, now time.Time) *poolExpiry {
+	e := &poolExpiry{now: now}
+
+	if pool.Spec.Config.ExpiryGracePeriod != nil {
+		e.grace = pool.Spec.Config.ExpiryGracePeriod.Duration
+	}
+
+	return e
+}
+
+func (e *poolExpiry) observe(t time.Time) {
+	if !t.After(e.now) {
+		return
+	}
+
+	if e.next.IsZero() || t.Before(e.next) {
+		e.next = t
+	}
+}
+
+// Returns the duration until the next expiry related change, zero if there is none.
+func (e *poolExpiry) requeueAfter() time.Duration {
+	if e.next.IsZero() {
+		return 0
+	}
+
+	return e.next.Sub(e.now)
+}
+
+// Releases expired claims from the pool and returns the claims which remain. Expired claims
+// stay bound during the grace period of the pool, as long as the usage of their namespace
+// exceeds the quota remaining without the claim.
+func (r *resourcePoolController) handleExpiredClaims(
+	ctx context.Context,
+	log logr.Logger,
+	pool *capsulev1beta2.ResourcePool,
+	claims []capsulev1beta2.ResourcePoolClaim,
+	expiry *poolExpiry,
+) ([]capsulev1beta2.ResourcePoolClaim, error) {
+	active := make([]capsulev1beta2.ResourcePoolClaim, 0, len(claims))
+
+	for i := range claims {
+		claim := &claims[i]
+
+		released, err := r.handleClaimExpiry(ctx, log, pool, claim, expiry)
+		if err != nil {
+			return nil, fmt.Errorf("claim %s/%s: %w", claim.Namespace, claim.Name, err)
+		}
+
+		if !released {
+			active = append(active, *claim)
+		}
+	}
+
+	pool.CalculateClaimedResources()
+
+	return active, nil
+}
+
+func (r *resourcePoolController) handleClaimExpiry(
+	ctx context.Context,
+	log logr.Logger,
+	pool *capsulev1beta2.ResourcePool,
+	claim *capsulev1beta2.ResourcePoolClaim,
+	expiry *poolExpiry,
+) (released bool, err error) {
+	deadline := claim.GetExpiry()
+
+	if deadline == nil {
+		return false, nil
+	}
+
+	if !claim.IsExpired(expiry.now) {
+		expiry.observe(deadline.Time)
+
+		return false, nil
+	}
+
+	item := pool.GetClaimFromStatus(claim)
+
+	if item != nil {
+		graceEnd := deadline.Add(expiry.grace)
+
+		if expiry.now.Before(graceEnd) {
+			exceeds, err := r.usageExceedsRemainingQuota(ctx, pool, claim.Namespace, item.Scope, item.Claims)
+			if err != nil {
+				return false, err
+			}
+
+			if exceeds {
+				expiry.observe(graceEnd)
+
+				cond := meta.NewExpiredCondition(claim)
+				cond.Reason = meta.ExpiryGracePeriodReason
+				cond.Message = fmt.Sprintf(
+					"usage exceeds the quota remaining without the claim, releasing at %s",
+					graceEnd.UTC().Format(time.RFC3339),
+				)
+
+				return false, updateStatusAndEmitEvent(ctx, r.Client, r.recorder, claim, pool, cond)
+			}
+		}
+	} else {
+		item = &capsulev1beta2.ResourcePoolClaimsItem{
+			NamespacedRFC1123ObjectReferenceWithNamespaceWithUID: meta.NamespacedRFC1123ObjectReferenceWithNamespaceWithUID{
+				UID:       claim.UID,
+				Name:      meta.RFC1123Name(claim.Name),
+				Namespace: meta.RFC1123SubdomainName(claim.Namespace),
+			},
+		}
+	}
+
+	log.V(4).Info("releasing expired claim", "claim", claim.Name, "namespace", claim.Namespace, "expiry", deadline)
+
+	cond := meta.NewExpiredCondition(claim)
+	cond.Message = "claim expired at " + deadline.UTC().Format(time.RFC3339)
+
+	if err := r.handleClaimDisassociation(ctx, log, pool, item, &cond); err != nil {
+		return false, fmt.Errorf("release expired claim: %w", err)
+	}
+
+	return true, nil
+}
+
+// Verifies if the usage of the namespace exceeds the hard resources of the namespace quota of the
+// given scope, when the given claimed resources are removed.
+func (r *resourcePoolController) usageExceedsRemainingQuota(
+	ctx context.Context,
+	pool *capsulev1beta2.ResourcePool,
+	namespace string,
+	scope string,
+	claimed corev1.ResourceList,
+) (bool, error) {
+	rq := &corev1.ResourceQuota{}
+	if err := r.reader.Get(ctx, types.NamespacedName{
+		Name: pool.GetScopedQuotaName(scope), Namespace: namespace,
+	}, rq); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	return exceedsRemainingQuota(rq.Status.Used, pool.GetScopedResourceQuotaHardResources(namespace, scope), claimed), nil
+}
+
+func exceedsRemainingQuota(used, hard, claimed corev1.ResourceList) bool {
+	for resourceName, qt := range claimed {
+		usage, ok := used[resourceName]
+		if !ok || usage.IsZero() {
+			continue
+		}
+
+		remaining := hard[resourceName].DeepCopy()
+		remaining.Sub(qt)
+
+		if usage.Cmp(remaining) > 0 {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,219 @@
+// Copyright 2020-2026 Project Capsule Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package resourcepools
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/events"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	"github.com/projectcapsule/capsule/pkg/api/meta"
+)
+
+func TestExceedsRemainingQuota(t *testing.T) {
+	t.Parallel()
+
+	cpu := func(v string) corev1.ResourceList {
+		return rl(map[corev1.ResourceName]string{corev1.ResourceRequestsCPU: v})
+	}
+
+	tests := []struct {
+		name     string
+		used     corev1.ResourceList
+		hard     corev1.ResourceList
+		claimed  corev1.ResourceList
+		expected bool
+	}{
+		{name: "no usage", used: corev1.ResourceList{}, hard: cpu("4"), claimed: cpu("2")},
+		{name: "usage within remaining quota", used: cpu("2"), hard: cpu("4"), claimed: cpu("2")},
+		{name: "usage exceeds remaining quota", used: cpu("3"), hard: cpu("4"), claimed: cpu("2"), expected: true},
+		{name: "only claim in namespace", used: cpu("1"), hard: cpu("2"), claimed: cpu("2"), expected: true},
+		{
+			name:    "unclaimed resources are ignored",
+			used:    rl(map[corev1.ResourceName]string{corev1.ResourceRequestsMemory: "4Gi"}),
+			hard:    cpu("2"),
+			claimed: cpu("2"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := exceedsRemainingQuota(tt.used, tt.hard, tt.claimed); got != tt.expected {
+				t.Fatalf("exceedsRemainingQuota() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestPoolExpiryRequeueAfter(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	expiry := newPoolExpiry(&capsulev1beta2.ResourcePool{}, now)
+
+	if expiry.requeueAfter() != 0 {
+		t.Fatal("expected no requeue without observed expiries")
+	}
+
+	expiry.observe(now.Add(-time.Minute))
+	expiry.observe(now.Add(time.Hour))
+	expiry.observe(now.Add(time.Minute))
+
+	if got := expiry.requeueAfter(); got != time.Minute {
+		t.Fatalf("requeueAfter() = %s, want %s", got, time.Minute)
+	}
+}
+
+func TestHandleClaimExpiry(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	cpu := rl(map[corev1.ResourceName]string{corev1.ResourceRequestsCPU: "2"})
+
+	tests := []struct {
+		name         string
+		ttl          time.Duration
+		grace        time.Duration
+		used         string
+		wantReleased bool
+		wantReason   string
+		wantRequeue  time.Duration
+	}{
+		{
+			name:        "not yet expired",
+			ttl:         13 * time.Hour,
+			wantRequeue: time.Hour,
+		},
+		{
+			name:         "expired without grace period",
+			ttl:          time.Hour,
+			used:         "2",
+			wantReleased: true,
+			wantReason:   meta.ExpiredReason,
+		},
+		{
+			name:        "expired within grace period while usage exceeds remaining quota",
+			ttl:         12 * time.Hour,
+			grace:       10 * time.Minute,
+			used:        "2",
+			wantReason:  meta.ExpiryGracePeriodReason,
+			wantRequeue: 10 * time.Minute,
+		},
+		{
+			name:         "expired within grace period without usage",
+			ttl:          12 * time.Hour,
+			grace:        10 * time.Minute,
+			used:         "0",
+			wantReleased: true,
+			wantReason:   meta.ExpiredReason,
+		},
+		{
+			name:         "grace period elapsed",
+			ttl:          11 * time.Hour,
+			grace:        10 * time.Minute,
+			used:         "2",
+			wantReleased: true,
+			wantReason:   meta.ExpiredReason,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cl := claim(t, "ci", "ci", "burst", now.Add(-12*time.Hour), cpu)
+			cl.Spec.TTL = &metav1.Duration{Duration: tt.ttl}
+			cl.Status.Pool = meta.LocalRFC1123ObjectReferenceWithUID{Name: "pool", UID: "pool-uid"}
+
+			pool := poolWithClaims(cpu, cl)
+			pool.Name = "pool"
+			pool.UID = "pool-uid"
+			pool.Spec.Config.DeleteBoundResources = ptr.To(false)
+			pool.Spec.Config.ExpiryGracePeriod = &metav1.Duration{Duration: tt.grace}
+
+			rq := &corev1.ResourceQuota{
+				ObjectMeta: metav1.ObjectMeta{Name: pool.GetQuotaName(), Namespace: "ci"},
+			}
+			if tt.used != "" {
+				rq.Status.Used = rl(map[corev1.ResourceName]string{corev1.ResourceRequestsCPU: tt.used})
+			}
+
+			scheme := runtime.NewScheme()
+			if err := capsulev1beta2.AddToScheme(scheme); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := corev1.AddToScheme(scheme); err != nil {
+				t.Fatal(err)
+			}
+
+			c := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithStatusSubresource(&capsulev1beta2.ResourcePoolClaim{}).
+				WithObjects(cl.DeepCopy(), rq).
+				Build()
+
+			r := &resourcePoolController{
+				Client:   c,
+				reader:   c,
+				log:      logr.Discard(),
+				recorder: events.NewFakeRecorder(10),
+			}
+
+			expiry := newPoolExpiry(pool, now)
+
+			released, err := r.handleClaimExpiry(context.Background(), logr.Discard(), pool, &cl, expiry)
+			if err != nil {
+				t.Fatalf("handleClaimExpiry() error = %v", err)
+			}
+
+			if released != tt.wantReleased {
+				t.Fatalf("handleClaimExpiry() released = %v, want %v", released, tt.wantReleased)
+			}
+
+			if got := expiry.requeueAfter(); got != tt.wantRequeue {
+				t.Fatalf("requeueAfter() = %s, want %s", got, tt.wantRequeue)
+			}
+
+			if released == (pool.GetClaimFromStatus(&cl) != nil) {
+				t.Fatalf("claim bound to pool = %v, want %v", !released, !tt.wantReleased)
+			}
+
+			current := &capsulev1beta2.ResourcePoolClaim{}
+			if err := c.Get(context.Background(), client.ObjectKeyFromObject(&cl), current); err != nil {
+				t.Fatal(err)
+			}
+
+			cond := current.Status.Conditions.GetConditionByType(meta.ExpiredCondition)
+
+			if tt.wantReason == "" {
+				if cond != nil {
+					t.Fatalf("unexpected expired condition %#v", cond)
+				}
+
+				return
+			}
+
+			if cond == nil || cond.Reason != tt.wantReason {
+				t.Fatalf("expired condition = %#v, want reason %s", cond, tt.wantReason)
+			}
+
+			if released && current.Status.Pool.UID != "" {
+				t.Fatal("released claim is still assigned to the pool")
+			}
+		})
+	}
+}
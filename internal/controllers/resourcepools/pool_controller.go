@@ -11,6 +11,7 @@ import (
 	"slices"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
 	gherrors "github.com/pkg/errors"
@@ -81,6 +82,10 @@ func (r *resourcePoolController) SetupWithManager(mgr ctrl.Manager, ctrlConfig c
 			handler.EnqueueRequestsFromMapFunc(r.resourcePoolsForNamespace),
 			builder.WithPredicates(predicates.UpdatedMetadataPredicate{}),
 		).
+		Watches(&capsulev1beta2.ResourcePool{},
+			handler.EnqueueRequestsFromMapFunc(r.resourcePoolsForCohort),
+			builder.WithPredicates(predicates.ResourcePoolCohortChangedPredicate{}),
+		).
 		WithOptions(ctrlConfig.Runtime.ToControllerOptions()).
 		Complete(r)
 }
@@ -140,9 +145,9 @@ func (r resourcePoolController) Reconcile(ctx context.Context, request ctrl.Requ
 		err = nil
 	}()
 
-	err = r.reconcile(ctx, log, instance)
+	requeueAfter, err := r.reconcile(ctx, log, instance)
 
-	return ctrl.Result{}, err
+	return ctrl.Result{RequeueAfter: requeueAfter}, err
 }
 
 func (r *resourcePoolController) resourcePoolsForNamespace(ctx context.Context, obj client.Object) []reconcile.Request {
@@ -202,16 +207,18 @@ func (r *resourcePoolController) finalize(
 	}
 }
 
+// Reconciles the pool and returns the duration after which the pool must be reconciled again, to
+// release expiring claims.
 func (r *resourcePoolController) reconcile(
 	ctx context.Context,
 	log logr.Logger,
 	pool *capsulev1beta2.ResourcePool,
-) (err error) {
+) (requeueAfter time.Duration, err error) {
 	r.handlePoolHardResources(pool)
 
 	namespaces, err := r.gatherMatchingNamespaces(ctx, log, pool)
 	if err != nil {
-		return err
+		return requeueAfter, err
 	}
 
 	currentNamespaces := make(map[string]struct{}, len(namespaces))
@@ -221,46 +228,85 @@ func (r *resourcePoolController) reconcile(
 
 	claims, err := r.gatherMatchingClaims(ctx, log, pool, currentNamespaces)
 	if err != nil {
-		return err
+		return requeueAfter, err
 	}
 
 	log.V(5).Info("Collected assigned claims", "count", len(claims))
 
 	if err := r.garbageCollection(ctx, log, pool, claims, currentNamespaces); err != nil {
-		return err
+		return requeueAfter, err
+	}
+
+	claims, err = r.handleClaimScopes(ctx, log, pool, claims)
+	if err != nil {
+		return requeueAfter, err
+	}
+
+	expiry := newPoolExpiry(pool, time.Now())
+
+	claims, err = r.handleExpiredClaims(ctx, log, pool, claims, expiry)
+	if err != nil {
+		return requeueAfter, err
+	}
+
+	requeueAfter = expiry.requeueAfter()
+
+	cohort, err := r.gatherCohort(ctx, pool)
+	if err != nil {
+		return requeueAfter, err
+	}
+
+	claims, err = r.handleCohortReclaim(ctx, log, pool, claims, cohort)
+	if err != nil {
+		return requeueAfter, err
+	}
+
+	autoscalingRequeue, err := r.handleClaimAutoscaling(ctx, log, pool, claims, cohort, time.Now())
+	if err != nil {
+		return requeueAfter, err
 	}
 
+	requeueAfter = earliestRequeue(requeueAfter, autoscalingRequeue)
+
 	pool.AssignNamespaces(namespaces)
 
-	// Sort by creation timestamp (oldest first)
-	sort.Slice(claims, func(i, j int) bool {
-		return claims[i].CreationTimestamp.Before(&claims[j].CreationTimestamp)
+	// Sort by priority (highest first), claims are already sorted by creation
+	// timestamp (oldest first) within the same priority.
+	sort.SliceStable(claims, func(i, j int) bool {
+		return claims[i].Spec.Priority > claims[j].Spec.Priority
 	})
 
 	// Keeps track of resources which are exhausted by previous resource
 	// This is only required when Ordered is active
 	exhaustions := make(map[string]api.PoolExhaustionResource)
 
+	preemption := newPoolPreemption(pool, claims)
+
 	// Soft-fail step: reconcile each claim, collect errors, continue
 	var errs []error
 
 	for i := range claims {
 		claim := &claims[i]
 
+		// Preempted claims are no longer assigned to the pool.
+		if preemption.isPreempted(claim) {
+			continue
+		}
+
 		log.V(5).Info("Found claim",
 			"name", claim.Name,
 			"namespace", claim.Namespace,
 			"created", claim.CreationTimestamp,
 		)
 
-		if err := r.reconcileResourceClaim(ctx, log.WithValues("Claim", claim.Name), pool, claim, exhaustions); err != nil {
+		if err := r.reconcileResourceClaim(ctx, log.WithValues("Claim", claim.Name), pool, claim, exhaustions, preemption, cohort); err != nil {
 			log.Error(err, "Failed to reconcile ResourceQuotaClaim", "claim", claim.Name, "namespace", claim.Namespace)
 			errs = append(errs, fmt.Errorf("claim %s/%s: %w", claim.Namespace, claim.Name, err))
 		}
 	}
 
 	if err := errors.Join(errs...); err != nil {
-		return err
+		return requeueAfter, err
 	}
 
 	log.V(7).Info("finalized reconciling claims", "exhaustions", exhaustions)
@@ -272,7 +318,7 @@ func (r *resourcePoolController) reconcile(
 	pool.AssignClaims()
 
 	if err := r.syncResourceQuotas(ctx, r.Client, r.reader, pool, namespaces); err != nil {
-		return fmt.Errorf("sync resourcequotas: %w", err)
+		return requeueAfter, fmt.Errorf("sync resourcequotas: %w", err)
 	}
 
 	claimsByNS := make(map[string][]capsulev1beta2.ResourcePoolClaim, 16)
@@ -280,6 +326,10 @@ func (r *resourcePoolController) reconcile(
 	for i := range claims {
 		cl := &claims[i]
 
+		if preemption.isPreempted(cl) {
+			continue
+		}
+
 		exhausted := cl.Status.Conditions.GetConditionByType(meta.ExhaustedCondition)
 		if exhausted == nil || exhausted.Status != metav1.ConditionTrue {
 			claimsByNS[cl.Namespace] = append(claimsByNS[cl.Namespace], *cl)
@@ -301,7 +351,7 @@ func (r *resourcePoolController) reconcile(
 		errs = append(errs, fmt.Errorf("reconcile claims in use: %w", err))
 	}
 
-	return errors.Join(errs...)
+	return requeueAfter, errors.Join(errs...)
 }
 
 func (r *resourcePoolController) reconcileClaimsInUse(
@@ -331,11 +381,36 @@ func (r *resourcePoolController) reconcileClaimsInUseForNamespace(
 	pool *capsulev1beta2.ResourcePool,
 	namespace string,
 	claims []capsulev1beta2.ResourcePoolClaim,
+) error {
+	// Claims of a scope are used by the scoped resourcequota
+	claimsByScope := make(map[string][]capsulev1beta2.ResourcePoolClaim)
+
+	for i := range claims {
+		scope := scopeOfClaim(pool, &claims[i])
+		claimsByScope[scope] = append(claimsByScope[scope], claims[i])
+	}
+
+	for scope, scopeClaims := range claimsByScope {
+		if err := r.reconcileClaimsInUseForScope(ctx, log, pool, namespace, scope, scopeClaims); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *resourcePoolController) reconcileClaimsInUseForScope(
+	ctx context.Context,
+	log logr.Logger,
+	pool *capsulev1beta2.ResourcePool,
+	namespace string,
+	scope string,
+	claims []capsulev1beta2.ResourcePoolClaim,
 ) error {
 	// Fetch the quota we manage for this namespace
 	rq := &corev1.ResourceQuota{}
 	if err := r.reader.Get(ctx, types.NamespacedName{
-		Name: pool.GetQuotaName(), Namespace: namespace,
+		Name: pool.GetScopedQuotaName(scope), Namespace: namespace,
 	}, rq); err != nil {
 		if apierrors.IsNotFound(err) {
 			return nil
@@ -349,8 +424,8 @@ func (r *resourcePoolController) reconcileClaimsInUseForNamespace(
 		used = corev1.ResourceList{}
 	}
 
-	// Consider only resources the pool manages (pool.Spec.Quota.Hard keys).
-	used = filterResourceListByKeys(used, pool.Spec.Quota.Hard)
+	// Consider only resources the pool manages (pool.Spec.Quota.Hard keys or the hard resources of the scope).
+	used = filterResourceListByKeys(used, pool.GetScopeHardResources(scope))
 
 	// Compute selected claims (by UID) needed to cover used.
 	selected := selectClaimsCoveringUsageGreedy(used, claims)
@@ -397,14 +472,16 @@ func (r *resourcePoolController) reconcileResourceClaim(
 	pool *capsulev1beta2.ResourcePool,
 	claim *capsulev1beta2.ResourcePoolClaim,
 	exhaustion map[string]api.PoolExhaustionResource,
+	preemption *poolPreemption,
+	cohort *poolCohort,
 ) (err error) {
 	t := pool.GetClaimFromStatus(claim)
 	if t != nil {
-		if reflect.DeepEqual(t.Claims, claim.Spec.ResourceClaims) {
+		if reflect.DeepEqual(t.Claims, claim.GetResourceClaims()) {
 			return r.handleClaimToPoolBinding(ctx, pool, claim)
 		}
 
-		exhaustions := canClaimWithinPoolExcludingClaim(log, pool, claim, t)
+		exhaustions := canClaimWithinPoolExcludingClaim(log, pool, claim, t, cohort)
 		if len(exhaustions) != 0 {
 			log.V(5).Info("resized claim exhausts resources", "amount", len(exhaustions))
 
@@ -445,7 +522,20 @@ func (r *resourcePoolController) reconcileResourceClaim(
 	}
 
 	// Check if Resources can be Assigned (Enough Resources to claim)
-	exhaustions := r.canClaimWithinNamespace(log, pool, claim)
+	exhaustions := r.canClaimWithinNamespace(log, pool, claim, cohort)
+	if len(exhaustions) != 0 && preemption != nil {
+		var preempted bool
+
+		preempted, err = r.handleClaimPreemption(ctx, log, pool, claim, exhaustions, preemption)
+		if err != nil {
+			return err
+		}
+
+		if preempted {
+			exhaustions = r.canClaimWithinNamespace(log, pool, claim, cohort)
+		}
+	}
+
 	if len(exhaustions) != 0 {
 		log.V(5).Info("exhausting resources", "amount", len(exhaustions))
 
@@ -465,8 +555,9 @@ func (r *resourcePoolController) canClaimWithinNamespace(
 	log logr.Logger,
 	pool *capsulev1beta2.ResourcePool,
 	claim *capsulev1beta2.ResourcePoolClaim,
+	cohort *poolCohort,
 ) (res map[string]api.PoolExhaustionResource) {
-	return canClaimWithinPoolExcludingClaim(log, pool, claim, nil)
+	return canClaimWithinPoolExcludingClaim(log, pool, claim, nil, cohort)
 }
 
 func canClaimWithinPoolExcludingClaim(
@@ -474,8 +565,10 @@ func canClaimWithinPoolExcludingClaim(
 	pool *capsulev1beta2.ResourcePool,
 	claim *capsulev1beta2.ResourcePoolClaim,
 	excluded *capsulev1beta2.ResourcePoolClaimsItem,
+	cohort *poolCohort,
 ) (res map[string]api.PoolExhaustionResource) {
-	claimable := pool.GetAvailableClaimableResources()
+	scope := scopeOfClaim(pool, claim)
+	claimable := cohort.claimableInScope(pool, scope)
 
 	if excluded != nil {
 		for resourceName, qt := range excluded.Claims {
@@ -487,18 +580,18 @@ func canClaimWithinPoolExcludingClaim(
 
 	log.V(5).Info("claimable resources", "claimable", claimable)
 
-	_, namespaceClaimed := pool.GetNamespaceClaims(claim.Namespace)
-	log.V(5).Info("namespace claimed resources", "claimed", namespaceClaimed)
+	_, namespaceClaimed := pool.GetNamespaceScopeClaims(claim.Namespace, scope)
+	log.V(5).Info("namespace claimed resources", "claimed", namespaceClaimed, "scope", scope)
 
 	res = make(map[string]api.PoolExhaustionResource)
 
-	for resourceName, req := range claim.Spec.ResourceClaims {
+	for resourceName, req := range claim.GetResourceClaims() {
 		// Verify if total Quota is available
 		available, exists := claimable[resourceName]
 		if !exists || available.IsZero() || available.Cmp(req) < 0 {
 			log.V(5).Info("not enough resources available", "available", available, "requesting", req)
 
-			res[resourceName.String()] = api.PoolExhaustionResource{
+			res[exhaustionKey(scope, resourceName)] = api.PoolExhaustionResource{
 				Available:  available,
 				Requesting: req,
 			}
@@ -518,9 +611,10 @@ func (r *resourcePoolController) handleClaimOrderedExhaustion(
 	exhaustions map[string]api.PoolExhaustionResource,
 ) (queued bool, err error) {
 	status := make([]string, 0)
+	scope := scopeOfClaim(pool, claim)
 
-	for resourceName, qt := range claim.Spec.ResourceClaims {
-		req, ok := exhaustions[resourceName.String()]
+	for resourceName, qt := range claim.GetResourceClaims() {
+		req, ok := exhaustions[exhaustionKey(scope, resourceName)]
 		if !ok {
 			continue
 		}
@@ -609,6 +703,12 @@ func (r *resourcePoolController) handleClaimToPoolBinding(
 	cond.Reason = meta.NoExhaustionsReason
 	cond.Message = "resource claimable from pool"
 
+	// A preempted or reclaimed claim is no longer preempted once it's bound again.
+	if preempted := claim.Status.Conditions.GetConditionByType(meta.PreemptionCondition); preempted != nil &&
+		(preempted.Reason == meta.PreemptedReason || preempted.Reason == meta.ReclaimedReason) {
+		claim.Status.Conditions.RemoveConditionByType(meta.PreemptionCondition)
+	}
+
 	if err = updateStatusAndEmitEvent(ctx, r.Client, r.recorder, claim, pool, cond); err != nil {
 		return err
 	}
@@ -618,12 +718,14 @@ func (r *resourcePoolController) handleClaimToPoolBinding(
 	return err
 }
 
-// Attempts to garbage collect a ResourceQuota resource.
+// Disassociates a claim from the pool. The optional condition is recorded on
+// the claim and reported with the event.
 func (r *resourcePoolController) handleClaimDisassociation(
 	ctx context.Context,
 	log logr.Logger,
 	pool *capsulev1beta2.ResourcePool,
 	claim *capsulev1beta2.ResourcePoolClaimsItem,
+	condition *meta.Condition,
 ) error {
 	current := &capsulev1beta2.ResourcePoolClaim{
 		ObjectMeta: metav1.ObjectMeta{
@@ -649,6 +751,11 @@ func (r *resourcePoolController) handleClaimDisassociation(
 		current.Status.Pool = meta.LocalRFC1123ObjectReferenceWithUID{}
 		current.Status.Conditions.RemoveConditionByType(meta.BoundCondition)
 		current.Status.Conditions.RemoveConditionByType(meta.ExhaustedCondition)
+		current.Status.Autoscaling = nil
+
+		if condition != nil {
+			current.Status.Conditions.UpdateConditionByType(*condition)
+		}
 
 		if err := r.Client.Status().Update(ctx, current); err != nil {
 			return fmt.Errorf("failed to update claim status: %w", err)
@@ -664,6 +771,19 @@ func (r *resourcePoolController) handleClaimDisassociation(
 			}
 		}
 
+		if condition != nil {
+			r.recorder.Eventf(
+				current,
+				pool,
+				corev1.EventTypeWarning,
+				condition.Reason,
+				evt.ActionDisassociating,
+				condition.Message,
+			)
+
+			return nil
+		}
+
 		r.recorder.Eventf(
 			current,
 			pool,
@@ -707,13 +827,40 @@ func (r *resourcePoolController) syncResourceQuotas(
 	return group.Wait()
 }
 
-// Synchronize a single resourcequota.
+// Synchronize the resourcequotas of a single namespace, one for the pool and one for each scope.
 func (r *resourcePoolController) syncResourceQuota(
 	ctx context.Context,
 	c client.Client,
 	reader client.Reader,
 	pool *capsulev1beta2.ResourcePool,
 	namespace corev1.Namespace,
+) (err error) {
+	keep := map[string]struct{}{pool.GetQuotaName(): {}}
+
+	if err := r.syncScopedResourceQuota(ctx, c, reader, pool, namespace, ""); err != nil {
+		return err
+	}
+
+	for _, scope := range pool.Spec.Scopes {
+		keep[pool.GetScopedQuotaName(scope.Name)] = struct{}{}
+
+		if err := r.syncScopedResourceQuota(ctx, c, reader, pool, namespace, scope.Name); err != nil {
+			return fmt.Errorf("scope %s: %w", scope.Name, err)
+		}
+	}
+
+	// Remove the resourcequotas of scopes which were removed from the pool
+	return r.deleteResourceQuotas(ctx, c, reader, pool, namespace.GetName(), keep)
+}
+
+// Synchronize a single resourcequota. The empty scope refers to the quota of the pool.
+func (r *resourcePoolController) syncScopedResourceQuota(
+	ctx context.Context,
+	c client.Client,
+	reader client.Reader,
+	pool *capsulev1beta2.ResourcePool,
+	namespace corev1.Namespace,
+	scope string,
 ) (err error) {
 	// getting ResourceQuota labels for the mutateFn
 	var quotaLabel string
@@ -724,7 +871,7 @@ func (r *resourcePoolController) syncResourceQuota(
 
 	target := &corev1.ResourceQuota{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      pool.GetQuotaName(),
+			Name:      pool.GetScopedQuotaName(scope),
 			Namespace: namespace.GetName(),
 		},
 	}
@@ -747,8 +894,13 @@ func (r *resourcePoolController) syncResourceQuota(
 			target.Spec.Scopes = pool.Spec.Quota.Scopes
 			target.Spec.ScopeSelector = pool.Spec.Quota.ScopeSelector
 
+			if budget := pool.GetScope(scope); budget != nil {
+				target.Spec.Scopes = nil
+				target.Spec.ScopeSelector = budget.ScopeSelector.DeepCopy()
+			}
+
 			// Assign to resourcequota all the claims + defaults
-			target.Spec.Hard = pool.GetResourceQuotaHardResources(namespace.GetName())
+			target.Spec.Hard = pool.GetScopedResourceQuotaHardResources(namespace.GetName(), scope)
 
 			return controllerutil.SetControllerReference(pool, target, c.Scheme())
 		})
@@ -913,7 +1065,7 @@ func (r *resourcePoolController) garbageCollection(
 				log.V(5).Info("Disassociating claim", "claim", cl.Name, "namespace", ns, "uid", cl.UID, "nsGC", nsMarked, "claimGC", claimActive)
 
 				cl.Namespace = meta.RFC1123SubdomainName(ns)
-				if err := r.handleClaimDisassociation(ctx, log, pool, cl); err != nil {
+				if err := r.handleClaimDisassociation(ctx, log, pool, cl, nil); err != nil {
 					r.log.Error(err, "Failed to disassociate claim", "namespace", ns, "uid", cl.UID)
 
 					return err
@@ -953,30 +1105,45 @@ func (r *resourcePoolController) garbageCollectNamespace(
 		return fmt.Errorf("failed to check namespace existence: %w", err)
 	}
 
-	name := pool.GetQuotaName()
+	return r.deleteResourceQuotas(ctx, r.Client, r.reader, pool, namespace, nil)
+}
 
-	// Attempt to delete the ResourceQuota
-	target := &corev1.ResourceQuota{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      name,
-			Namespace: namespace,
-		},
+// Deletes the resourcequotas of the pool in the given namespace, except the ones to keep.
+func (r *resourcePoolController) deleteResourceQuotas(
+	ctx context.Context,
+	c client.Client,
+	reader client.Reader,
+	pool *capsulev1beta2.ResourcePool,
+	namespace string,
+	keep map[string]struct{},
+) error {
+	quotaLabel, err := utils.GetTypeLabel(&capsulev1beta2.ResourcePool{})
+	if err != nil {
+		return err
 	}
 
-	err := r.reader.Get(ctx, types.NamespacedName{Namespace: namespace, Name: target.GetName()}, target)
-	if err != nil {
-		if apierrors.IsNotFound(err) {
-			r.log.V(5).Info("ResourceQuota already deleted", "namespace", namespace, "name", name)
+	quotas := &corev1.ResourceQuotaList{}
+	if err := reader.List(ctx, quotas, client.InNamespace(namespace), client.MatchingLabels{quotaLabel: pool.Name}); err != nil {
+		return fmt.Errorf("failed to list ResourceQuotas in namespace %s: %w", namespace, err)
+	}
 
-			return nil
+	for i := range quotas.Items {
+		target := &quotas.Items[i]
+
+		if _, ok := keep[target.Name]; ok {
+			continue
 		}
 
-		return err
-	}
+		// Delete the ResourceQuota
+		if err := c.Delete(ctx, target); err != nil {
+			if apierrors.IsNotFound(err) {
+				r.log.V(5).Info("ResourceQuota already deleted", "namespace", namespace, "name", target.Name)
+
+				continue
+			}
 
-	// Delete the ResourceQuota
-	if err := r.Delete(ctx, target); err != nil {
-		return fmt.Errorf("failed to delete ResourceQuota %s in namespace %s: %w", name, namespace, err)
+			return fmt.Errorf("failed to delete ResourceQuota %s in namespace %s: %w", target.Name, namespace, err)
+		}
 	}
 
 	return nil
@@ -0,0 +1,210 @@
+// Copyright 2020-2026 Project Capsule Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package resourcepools
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	"github.com/projectcapsule/capsule/pkg/api"
+	"github.com/projectcapsule/capsule/pkg/api/meta"
+)
+
+// Tracks the claims of a pool reconciliation which may be preempted.
+type poolPreemption struct {
+	claims    map[types.UID]*capsulev1beta2.ResourcePoolClaim
+	preempted map[types.UID]struct{}
+}
+
+// Returns nil if the pool does not enable preemption.
+func newPoolPreemption(
+	pool *capsulev1beta2.ResourcePool,
+	claims []capsulev1beta2.ResourcePoolClaim,
+) *poolPreemption {
+	if !ptr.Deref(pool.Spec.Config.Preemption, false) {
+		return nil
+	}
+
+	p := &poolPreemption{
+		claims:    make(map[types.UID]*capsulev1beta2.ResourcePoolClaim, len(claims)),
+		preempted: make(map[types.UID]struct{}),
+	}
+
+	for i := range claims {
+		p.claims[claims[i].UID] = &claims[i]
+	}
+
+	return p
+}
+
+func (p *poolPreemption) isPreempted(claim *capsulev1beta2.ResourcePoolClaim) bool {
+	if p == nil {
+		return false
+	}
+
+	_, ok := p.preempted[claim.UID]
+
+	return ok
+}
+
+// Selects the bound claims with a lower priority, which must be unbound to resolve the exhaustions
+// of the given claim. Claims with the lowest priority are preempted first, unused claims before used
+// ones and newer claims before older ones. Nil is returned if the exhaustions can not be resolved.
+func (p *poolPreemption) selectVictims(
+	pool *capsulev1beta2.ResourcePool,
+	claim *capsulev1beta2.ResourcePoolClaim,
+	exhaustions map[string]api.PoolExhaustionResource,
+) []*capsulev1beta2.ResourcePoolClaimsItem {
+	scope := scopeOfClaim(pool, claim)
+	hard := pool.GetScopeHardResources(scope)
+	missing := corev1.ResourceList{}
+
+	for resourceName := range claim.GetResourceClaims() {
+		ex, ok := exhaustions[exhaustionKey(scope, resourceName)]
+		if !ok {
+			continue
+		}
+
+		if _, ok := hard[resourceName]; !ok {
+			return nil
+		}
+
+		deficit := ex.Requesting.DeepCopy()
+		deficit.Sub(ex.Available)
+		missing[resourceName] = deficit
+	}
+
+	type candidate struct {
+		item  *capsulev1beta2.ResourcePoolClaimsItem
+		claim *capsulev1beta2.ResourcePoolClaim
+	}
+
+	candidates := make([]candidate, 0)
+
+	for _, items := range pool.Status.Claims {
+		for _, item := range items {
+			bound, ok := p.claims[item.UID]
+			if !ok || bound.UID == claim.UID || bound.Spec.Priority >= claim.Spec.Priority || item.Scope != scope {
+				continue
+			}
+
+			for resourceName := range missing {
+				if qt, ok := item.Claims[resourceName]; ok && !qt.IsZero() {
+					candidates = append(candidates, candidate{item: item, claim: bound})
+
+					break
+				}
+			}
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return releasedBefore(candidates[i].claim, candidates[j].claim)
+	})
+
+	victims := make([]*capsulev1beta2.ResourcePoolClaimsItem, 0)
+
+	for _, c := range candidates {
+		if resolved(missing) {
+			break
+		}
+
+		victims = append(victims, c.item)
+
+		for resourceName, qt := range c.item.Claims {
+			if deficit, ok := missing[resourceName]; ok {
+				deficit.Sub(qt)
+				missing[resourceName] = deficit
+			}
+		}
+	}
+
+	if !resolved(missing) {
+		return nil
+	}
+
+	return victims
+}
+
+// Orders claims which are released from a pool. Claims with the lowest priority are released first,
+// unused claims before used ones and newer claims before older ones.
+func releasedBefore(a, b *capsulev1beta2.ResourcePoolClaim) bool {
+	if a.Spec.Priority != b.Spec.Priority {
+		return a.Spec.Priority < b.Spec.Priority
+	}
+
+	if aUsed, bUsed := a.IsBoundInResourcePool(), b.IsBoundInResourcePool(); aUsed != bUsed {
+		return !aUsed
+	}
+
+	if !a.CreationTimestamp.Equal(&b.CreationTimestamp) {
+		return b.CreationTimestamp.Before(&a.CreationTimestamp)
+	}
+
+	if a.Namespace != b.Namespace {
+		return a.Namespace < b.Namespace
+	}
+
+	return a.Name < b.Name
+}
+
+func resolved(missing corev1.ResourceList) bool {
+	for _, qt := range missing {
+		if qt.Sign() > 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Unbinds bound claims with a lower priority to resolve the exhaustions of the given claim. The
+// preemption is recorded in the conditions and events of all involved claims.
+func (r *resourcePoolController) handleClaimPreemption(
+	ctx context.Context,
+	log logr.Logger,
+	pool *capsulev1beta2.ResourcePool,
+	claim *capsulev1beta2.ResourcePoolClaim,
+	exhaustions map[string]api.PoolExhaustionResource,
+	preemption *poolPreemption,
+) (preempted bool, err error) {
+	victims := preemption.selectVictims(pool, claim, exhaustions)
+	if len(victims) == 0 {
+		return false, nil
+	}
+
+	names := make([]string, 0, len(victims))
+
+	for _, victim := range victims {
+		log.V(4).Info("preempting claim", "claim", victim.Name, "namespace", victim.Namespace, "priority", preemption.claims[victim.UID].Spec.Priority)
+
+		cond := meta.NewPreemptionCondition(claim)
+		cond.Reason = meta.PreemptedReason
+		cond.Message = fmt.Sprintf("preempted by claim %s/%s with priority %d", claim.Namespace, claim.Name, claim.Spec.Priority)
+
+		if err := r.handleClaimDisassociation(ctx, log, pool, victim, &cond); err != nil {
+			return false, fmt.Errorf("preempt claim %s/%s: %w", victim.Namespace, victim.Name, err)
+		}
+
+		preemption.preempted[victim.UID] = struct{}{}
+
+		names = append(names, fmt.Sprintf("%s/%s", victim.Namespace, victim.Name))
+	}
+
+	pool.CalculateClaimedResources()
+
+	cond := meta.NewPreemptionCondition(claim)
+	cond.Reason = meta.PreemptingReason
+	cond.Message = "preempted claims " + strings.Join(names, ", ")
+
+	return true, updateStatusAndEmitEvent(ctx, r.Client, r.recorder, claim, pool, cond)
+}
@@ -0,0 +1,200 @@
+// Copyright 2020-2026 Project Capsule Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package resourcepools
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/events"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	"github.com/projectcapsule/capsule/pkg/api"
+	"github.com/projectcapsule/capsule/pkg/api/meta"
+)
+
+func prioritized(c capsulev1beta2.ResourcePoolClaim, priority int32) capsulev1beta2.ResourcePoolClaim {
+	c.Spec.Priority = priority
+
+	return c
+}
+
+func TestPoolPreemptionSelectVictims(t *testing.T) {
+	t.Parallel()
+
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	hard := rl(map[corev1.ResourceName]string{corev1.ResourceRequestsCPU: "4"})
+	cpu := func(v string) corev1.ResourceList {
+		return rl(map[corev1.ResourceName]string{corev1.ResourceRequestsCPU: v})
+	}
+
+	tests := []struct {
+		name        string
+		bound       []capsulev1beta2.ResourcePoolClaim
+		claim       capsulev1beta2.ResourcePoolClaim
+		wantVictims []string
+	}{
+		{
+			name: "lower priority claim is preempted",
+			bound: []capsulev1beta2.ResourcePoolClaim{
+				prioritized(claim(t, "dev", "dev", "dev", base, cpu("4")), 0),
+			},
+			claim:       prioritized(claim(t, "prod", "prod", "prod", base.Add(time.Hour), cpu("2")), 100),
+			wantVictims: []string{"dev"},
+		},
+		{
+			name: "equal priority claims are not preempted",
+			bound: []capsulev1beta2.ResourcePoolClaim{
+				prioritized(claim(t, "dev", "dev", "dev", base, cpu("4")), 100),
+			},
+			claim: prioritized(claim(t, "prod", "prod", "prod", base.Add(time.Hour), cpu("2")), 100),
+		},
+		{
+			name: "lowest priority and newest claims are preempted first",
+			bound: []capsulev1beta2.ResourcePoolClaim{
+				prioritized(claim(t, "staging", "staging", "staging", base, cpu("1")), 50),
+				prioritized(claim(t, "dev-old", "dev", "old", base, cpu("1")), 0),
+				prioritized(claim(t, "dev-new", "dev", "new", base.Add(time.Minute), cpu("1")), 0),
+				prioritized(claim(t, "prod", "prod", "existing", base, cpu("1")), 100),
+			},
+			claim:       prioritized(claim(t, "prod-new", "prod", "new", base.Add(time.Hour), cpu("2")), 100),
+			wantVictims: []string{"dev-new", "dev-old"},
+		},
+		{
+			name: "no preemption when lower priority claims do not free enough",
+			bound: []capsulev1beta2.ResourcePoolClaim{
+				prioritized(claim(t, "dev", "dev", "dev", base, cpu("1")), 0),
+				prioritized(claim(t, "prod", "prod", "existing", base, cpu("3")), 100),
+			},
+			claim: prioritized(claim(t, "prod-new", "prod", "new", base.Add(time.Hour), cpu("3")), 100),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			pool := poolWithClaims(hard, tt.bound...)
+			pool.Spec.Config.Preemption = ptr.To(true)
+
+			claims := append([]capsulev1beta2.ResourcePoolClaim{tt.claim}, tt.bound...)
+			preemption := newPoolPreemption(pool, claims)
+
+			exhaustions := canClaimWithinPoolExcludingClaim(logr.Discard(), pool, &tt.claim, nil, nil)
+			if len(exhaustions) == 0 {
+				t.Fatal("expected the claim to exhaust the pool")
+			}
+
+			victims := preemption.selectVictims(pool, &tt.claim, exhaustions)
+			if len(victims) != len(tt.wantVictims) {
+				t.Fatalf("victims = %d, want %v", len(victims), tt.wantVictims)
+			}
+
+			for i, victim := range victims {
+				if string(victim.UID) != tt.wantVictims[i] {
+					t.Fatalf("victim[%d] = %s, want %s", i, victim.UID, tt.wantVictims[i])
+				}
+			}
+		})
+	}
+}
+
+func TestNewPoolPreemptionDisabled(t *testing.T) {
+	t.Parallel()
+
+	pool := &capsulev1beta2.ResourcePool{}
+	if newPoolPreemption(pool, nil) != nil {
+		t.Fatal("preemption must be disabled by default")
+	}
+
+	var preemption *poolPreemption
+	if preemption.isPreempted(&capsulev1beta2.ResourcePoolClaim{}) {
+		t.Fatal("nil preemption must not report preempted claims")
+	}
+}
+
+func TestHandleClaimPreemptionRecordsConditions(t *testing.T) {
+	t.Parallel()
+
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	cpu := rl(map[corev1.ResourceName]string{corev1.ResourceRequestsCPU: "2"})
+
+	victim := prioritized(claim(t, "dev", "dev", "dev", base, cpu), 0)
+	victim.Status.Pool = meta.LocalRFC1123ObjectReferenceWithUID{Name: "pool", UID: "pool-uid"}
+	preemptor := prioritized(claim(t, "prod", "prod", "prod", base.Add(time.Hour), cpu), 100)
+
+	pool := poolWithClaims(cpu, victim)
+	pool.Name = "pool"
+	pool.UID = "pool-uid"
+	pool.Spec.Config.Preemption = ptr.To(true)
+	pool.Spec.Config.DeleteBoundResources = ptr.To(false)
+
+	scheme := runtime.NewScheme()
+	if err := capsulev1beta2.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	cl := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithStatusSubresource(&capsulev1beta2.ResourcePoolClaim{}).
+		WithObjects(victim.DeepCopy(), preemptor.DeepCopy()).
+		Build()
+
+	r := &resourcePoolController{
+		Client:   cl,
+		reader:   cl,
+		log:      logr.Discard(),
+		recorder: events.NewFakeRecorder(10),
+	}
+
+	claims := []capsulev1beta2.ResourcePoolClaim{preemptor, victim}
+	preemption := newPoolPreemption(pool, claims)
+
+	exhaustions := map[string]api.PoolExhaustionResource{
+		corev1.ResourceRequestsCPU.String(): {Available: q("0"), Requesting: q("2")},
+	}
+
+	preempted, err := r.handleClaimPreemption(context.Background(), logr.Discard(), pool, &claims[0], exhaustions, preemption)
+	if err != nil {
+		t.Fatalf("handleClaimPreemption() error = %v", err)
+	}
+	if !preempted {
+		t.Fatal("handleClaimPreemption() preempted = false, want true")
+	}
+	if !preemption.isPreempted(&victim) {
+		t.Fatal("victim is not tracked as preempted")
+	}
+	if pool.GetClaimFromStatus(&victim) != nil {
+		t.Fatal("victim is still bound to the pool")
+	}
+	if len(canClaimWithinPoolExcludingClaim(logr.Discard(), pool, &preemptor, nil, nil)) != 0 {
+		t.Fatal("preemption did not free enough resources")
+	}
+
+	updatedVictim := &capsulev1beta2.ResourcePoolClaim{}
+	if err := cl.Get(context.Background(), client.ObjectKeyFromObject(&victim), updatedVictim); err != nil {
+		t.Fatal(err)
+	}
+	if updatedVictim.Status.Pool.UID != "" {
+		t.Fatal("victim is still assigned to the pool")
+	}
+	if cond := updatedVictim.Status.Conditions.GetConditionByType(meta.PreemptionCondition); cond == nil || cond.Reason != meta.PreemptedReason {
+		t.Fatalf("victim preemption condition = %#v", cond)
+	}
+
+	updatedPreemptor := &capsulev1beta2.ResourcePoolClaim{}
+	if err := cl.Get(context.Background(), client.ObjectKeyFromObject(&preemptor), updatedPreemptor); err != nil {
+		t.Fatal(err)
+	}
+	if cond := updatedPreemptor.Status.Conditions.GetConditionByType(meta.PreemptionCondition); cond == nil || cond.Reason != meta.PreemptingReason {
+		t.Fatalf("preemptor preemption condition = %#v", cond)
+	}
+}
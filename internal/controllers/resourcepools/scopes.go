@@ -0,0 +1,86 @@
+// Copyright 2020-2026 Project Capsule Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package resourcepools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	"github.com/projectcapsule/capsule/pkg/api/meta"
+)
+
+// Releases claims whose scope selector no longer matches a scope of the pool or which are bound to
+// a different scope than the one they match. Released claims are assigned again by the claim controller.
+// Returns the claims which remain.
+func (r *resourcePoolController) handleClaimScopes(
+	ctx context.Context,
+	log logr.Logger,
+	pool *capsulev1beta2.ResourcePool,
+	claims []capsulev1beta2.ResourcePoolClaim,
+) ([]capsulev1beta2.ResourcePoolClaim, error) {
+	remaining := make([]capsulev1beta2.ResourcePoolClaim, 0, len(claims))
+
+	for i := range claims {
+		claim := &claims[i]
+
+		scope, err := pool.GetScopeForClaim(claim)
+
+		item := pool.GetClaimFromStatus(claim)
+		if err == nil && (item == nil || item.Scope == claimScopeName(scope)) {
+			remaining = append(remaining, *claim)
+
+			continue
+		}
+
+		if item == nil {
+			item = &capsulev1beta2.ResourcePoolClaimsItem{
+				NamespacedRFC1123ObjectReferenceWithNamespaceWithUID: meta.NamespacedRFC1123ObjectReferenceWithNamespaceWithUID{
+					UID:       claim.UID,
+					Name:      meta.RFC1123Name(claim.Name),
+					Namespace: meta.RFC1123SubdomainName(claim.Namespace),
+				},
+			}
+		}
+
+		log.V(4).Info("releasing claim with changed scope", "claim", claim.Name, "namespace", claim.Namespace, "scope", item.Scope)
+
+		if err := r.handleClaimDisassociation(ctx, log, pool, item, nil); err != nil {
+			return nil, fmt.Errorf("release claim %s/%s with changed scope: %w", claim.Namespace, claim.Name, err)
+		}
+	}
+
+	pool.CalculateClaimedResources()
+
+	return remaining, nil
+}
+
+// Gets the name of the scope a claim is bound to, the empty scope refers to the quota of the pool.
+func claimScopeName(scope *capsulev1beta2.ResourcePoolScope) string {
+	if scope == nil {
+		return ""
+	}
+
+	return scope.Name
+}
+
+// Gets the name of the scope of the pool the claim claims from.
+func scopeOfClaim(pool *capsulev1beta2.ResourcePool, claim *capsulev1beta2.ResourcePoolClaim) string {
+	scope, _ := pool.GetScopeForClaim(claim)
+
+	return claimScopeName(scope)
+}
+
+// Gets the key of a resource in the exhaustions of the pool. Resources of a scope are prefixed with
+// the scope, as they are exhausted independently of the quota of the pool.
+func exhaustionKey(scope string, resourceName corev1.ResourceName) string {
+	if scope == "" {
+		return resourceName.String()
+	}
+
+	return scope + ":" + resourceName.String()
+}
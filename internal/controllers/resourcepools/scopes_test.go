@@ -0,0 +1,212 @@
+// Copyright 2020-2026 Project Capsule Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package resourcepools
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/events"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	"github.com/projectcapsule/capsule/pkg/api/meta"
+	"github.com/projectcapsule/capsule/pkg/utils"
+)
+
+func priorityClassSelector(values ...string) *corev1.ScopeSelector {
+	return &corev1.ScopeSelector{
+		MatchExpressions: []corev1.ScopedResourceSelectorRequirement{
+			{ScopeName: corev1.ResourceQuotaScopePriorityClass, Operator: corev1.ScopeSelectorOpIn, Values: values},
+		},
+	}
+}
+
+func scopedPool(hard corev1.ResourceList, scopeHard corev1.ResourceList) *capsulev1beta2.ResourcePool {
+	pool := poolWithClaims(hard)
+	pool.Name = "pool"
+	pool.UID = "uid-pool"
+	pool.Spec.Quota.Hard = hard
+	pool.Spec.Config.DeleteBoundResources = ptr.To(false)
+	pool.Spec.Scopes = []capsulev1beta2.ResourcePoolScope{
+		{Name: "high", ScopeSelector: *priorityClassSelector("high"), Hard: scopeHard},
+	}
+
+	return pool
+}
+
+func scopesClient(t *testing.T, objs ...client.Object) client.Client {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := capsulev1beta2.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	return fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithStatusSubresource(&capsulev1beta2.ResourcePoolClaim{}, &capsulev1beta2.ResourcePool{}).
+		WithObjects(objs...).
+		Build()
+}
+
+func TestCanClaimWithinScope(t *testing.T) {
+	t.Parallel()
+
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	pool := scopedPool(gpus("8"), gpus("2"))
+
+	scoped := claim(t, "scoped", "team-a", "scoped", base, gpus("3"))
+	scoped.Spec.ScopeSelector = priorityClassSelector("high")
+
+	// The quota of the pool is not available for scoped claims
+	exhaustions := canClaimWithinPoolExcludingClaim(logr.Discard(), pool, &scoped, nil, nil)
+	if _, ok := exhaustions[exhaustionKey("high", gpu)]; !ok || len(exhaustions) != 1 {
+		t.Fatalf("exhaustions = %v, want exhaustion of scope high", exhaustions)
+	}
+
+	scoped.Spec.ResourceClaims = gpus("2")
+	if exhaustions := canClaimWithinPoolExcludingClaim(logr.Discard(), pool, &scoped, nil, nil); len(exhaustions) != 0 {
+		t.Fatalf("exhaustions = %v, want none", exhaustions)
+	}
+
+	pool.AddClaimToStatus(&scoped)
+
+	available := pool.GetAvailableClaimableResources()[gpu]
+	if available.Cmp(q("8")) != 0 {
+		t.Fatalf("available resources of the pool = %s, want 8", available.String())
+	}
+}
+
+func TestHandleClaimScopes(t *testing.T) {
+	t.Parallel()
+
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	unscoped := claim(t, "unscoped", "team-a", "unscoped", base, gpus("1"))
+	scoped := claim(t, "scoped", "team-a", "scoped", base, gpus("1"))
+	scoped.Spec.ScopeSelector = priorityClassSelector("high")
+
+	pool := scopedPool(gpus("8"), gpus("2"))
+	pool.AddClaimToStatus(&unscoped)
+	pool.AddClaimToStatus(&scoped)
+
+	changed := scoped.DeepCopy()
+	changed.UID = "changed"
+	changed.Name = "changed"
+	pool.AddClaimToStatus(changed)
+
+	// The scope selector of the claim no longer matches a scope of the pool
+	changed.Spec.ScopeSelector = priorityClassSelector("low")
+
+	for _, cl := range []*capsulev1beta2.ResourcePoolClaim{&unscoped, &scoped, changed} {
+		cl.Status.Pool = meta.LocalRFC1123ObjectReferenceWithUID{Name: "pool", UID: pool.UID}
+	}
+
+	c := scopesClient(t, pool.DeepCopy(), unscoped.DeepCopy(), scoped.DeepCopy(), changed.DeepCopy())
+	r := &resourcePoolController{Client: c, reader: c, log: logr.Discard(), recorder: events.NewFakeRecorder(10)}
+
+	remaining, err := r.handleClaimScopes(context.Background(), logr.Discard(), pool, []capsulev1beta2.ResourcePoolClaim{unscoped, scoped, *changed})
+	if err != nil {
+		t.Fatalf("handleClaimScopes() error = %v", err)
+	}
+
+	if len(remaining) != 2 || remaining[0].UID != unscoped.UID || remaining[1].UID != scoped.UID {
+		t.Fatalf("remaining claims = %v", remaining)
+	}
+
+	if pool.GetClaimFromStatus(changed) != nil {
+		t.Fatal("claim with changed scope is still bound to the pool")
+	}
+
+	claimed := pool.Status.Scopes["high"].Claimed[gpu]
+	if claimed.Cmp(q("1")) != 0 {
+		t.Fatalf("claimed resources of scope = %s, want 1", claimed.String())
+	}
+
+	current := &capsulev1beta2.ResourcePoolClaim{}
+	if err := c.Get(context.Background(), client.ObjectKeyFromObject(changed), current); err != nil {
+		t.Fatal(err)
+	}
+
+	if current.Status.Pool.UID != "" {
+		t.Fatal("claim with changed scope is still assigned to the pool")
+	}
+}
+
+func TestSyncScopedResourceQuotas(t *testing.T) {
+	t.Parallel()
+
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	scoped := claim(t, "scoped", "team-a", "scoped", base, gpus("1"))
+	scoped.Spec.ScopeSelector = priorityClassSelector("high")
+
+	pool := scopedPool(gpus("8"), rl(map[corev1.ResourceName]string{gpu: "2", corev1.ResourceRequestsCPU: "4"}))
+	pool.AddClaimToStatus(&scoped)
+
+	quotaLabel, err := utils.GetTypeLabel(&capsulev1beta2.ResourcePool{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	namespace := corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a"}}
+	stale := &corev1.ResourceQuota{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      pool.GetScopedQuotaName("removed"),
+			Namespace: namespace.Name,
+			Labels:    map[string]string{quotaLabel: pool.Name},
+		},
+	}
+
+	c := scopesClient(t, pool.DeepCopy(), namespace.DeepCopy(), stale)
+	r := &resourcePoolController{Client: c, reader: c, log: logr.Discard()}
+
+	if err := r.syncResourceQuota(context.Background(), c, c, pool, namespace); err != nil {
+		t.Fatalf("syncResourceQuota() error = %v", err)
+	}
+
+	rq := &corev1.ResourceQuota{}
+	if err := c.Get(context.Background(), client.ObjectKey{Name: pool.GetScopedQuotaName("high"), Namespace: namespace.Name}, rq); err != nil {
+		t.Fatalf("scoped resourcequota not provisioned: %v", err)
+	}
+
+	if rq.Spec.ScopeSelector == nil || rq.Spec.ScopeSelector.MatchExpressions[0].Values[0] != "high" {
+		t.Fatalf("scoped resourcequota scope selector = %v", rq.Spec.ScopeSelector)
+	}
+
+	if hard := rq.Spec.Hard[gpu]; hard.Cmp(q("1")) != 0 {
+		t.Fatalf("scoped resourcequota %s = %s, want 1", gpu, hard.String())
+	}
+
+	if hard, ok := rq.Spec.Hard[corev1.ResourceRequestsCPU]; !ok || !hard.IsZero() {
+		t.Fatalf("unclaimed resource of scope = %s, want 0", hard.String())
+	}
+
+	quota := &corev1.ResourceQuota{}
+	if err := c.Get(context.Background(), client.ObjectKey{Name: pool.GetQuotaName(), Namespace: namespace.Name}, quota); err != nil {
+		t.Fatalf("resourcequota of the pool not provisioned: %v", err)
+	}
+
+	if _, ok := quota.Spec.Hard[gpu]; ok {
+		t.Fatalf("scoped claim is provisioned in the resourcequota of the pool: %v", quota.Spec.Hard)
+	}
+
+	if err := c.Get(context.Background(), client.ObjectKeyFromObject(stale), &corev1.ResourceQuota{}); err == nil {
+		t.Fatal("resourcequota of removed scope was not deleted")
+	}
+}
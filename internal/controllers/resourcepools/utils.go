@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 	"sort"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -65,6 +66,19 @@ func updateStatusAndEmitEvent(
 	return err
 }
 
+// Returns the earliest of both durations, ignoring zero durations.
+func earliestRequeue(a, b time.Duration) time.Duration {
+	if a <= 0 {
+		return b
+	}
+
+	if b <= 0 || a < b {
+		return a
+	}
+
+	return b
+}
+
 func filterResourceListByKeys(in corev1.ResourceList, keys corev1.ResourceList) corev1.ResourceList {
 	out := corev1.ResourceList{}
 
@@ -113,7 +127,7 @@ func selectClaimsCoveringUsageGreedy(
 				continue
 			}
 
-			score := claimCoverageScore(remaining, claims[i].Spec.ResourceClaims)
+			score := claimCoverageScore(remaining, claims[i].GetResourceClaims())
 			if score > bestScore {
 				bestScore = score
 				bestIdx = i
@@ -129,7 +143,7 @@ func selectClaimsCoveringUsageGreedy(
 		selected[string(chosen.UID)] = struct{}{}
 
 		// remaining -= chosen.request (clamped at zero)
-		for rName, req := range chosen.Spec.ResourceClaims {
+		for rName, req := range chosen.GetResourceClaims() {
 			if rem, ok := remaining[rName]; ok {
 				rem.Sub(req)
 
@@ -165,7 +165,7 @@ func TestCanClaimWithinPoolExcludingClaim(t *testing.T) {
 		resized := existing
 		resized.Spec.ResourceClaims = rl(map[corev1.ResourceName]string{corev1.ResourceCPU: "10"})
 
-		got := canClaimWithinPoolExcludingClaim(logr.Discard(), pool, &resized, pool.GetClaimFromStatus(&existing))
+		got := canClaimWithinPoolExcludingClaim(logr.Discard(), pool, &resized, pool.GetClaimFromStatus(&existing), nil)
 		if len(got) != 0 {
 			t.Fatalf("expected resize to fit, got exhaustions=%v", got)
 		}
@@ -183,7 +183,7 @@ func TestCanClaimWithinPoolExcludingClaim(t *testing.T) {
 		resized := existing
 		resized.Spec.ResourceClaims = rl(map[corev1.ResourceName]string{corev1.ResourceCPU: "55"})
 
-		got := canClaimWithinPoolExcludingClaim(logr.Discard(), pool, &resized, pool.GetClaimFromStatus(&existing))
+		got := canClaimWithinPoolExcludingClaim(logr.Discard(), pool, &resized, pool.GetClaimFromStatus(&existing), nil)
 		if len(got) != 1 {
 			t.Fatalf("expected one exhaustion, got=%v", got)
 		}
@@ -211,7 +211,7 @@ func TestCanClaimWithinPoolExcludingClaim(t *testing.T) {
 		resized := existing
 		resized.Spec.ResourceClaims = rl(map[corev1.ResourceName]string{corev1.ResourceCPU: "7"})
 
-		got := canClaimWithinPoolExcludingClaim(logr.Discard(), pool, &resized, pool.GetClaimFromStatus(&existing))
+		got := canClaimWithinPoolExcludingClaim(logr.Discard(), pool, &resized, pool.GetClaimFromStatus(&existing), nil)
 		if len(got) != 1 {
 			t.Fatalf("expected resize to exhaust remaining capacity, got=%v", got)
 		}
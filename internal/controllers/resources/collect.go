@@ -5,27 +5,35 @@ package resources
 
 import (
 	"context"
+	"encoding/base64"
 	"errors"
 	"fmt"
 	"maps"
 	"strconv"
 
+	"github.com/fluxcd/pkg/apis/kustomize"
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	k8smeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/selection"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/yaml"
 
 	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
 	"github.com/projectcapsule/capsule/pkg/api/meta"
 	"github.com/projectcapsule/capsule/pkg/api/processor"
+	clt "github.com/projectcapsule/capsule/pkg/runtime/client"
+	"github.com/projectcapsule/capsule/pkg/runtime/configuration"
 	"github.com/projectcapsule/capsule/pkg/runtime/gvk"
+	"github.com/projectcapsule/capsule/pkg/runtime/helm"
 	"github.com/projectcapsule/capsule/pkg/runtime/sanitize"
 	tpl "github.com/projectcapsule/capsule/pkg/template"
 	"github.com/projectcapsule/capsule/pkg/tenant"
@@ -38,6 +46,7 @@ type Collector struct {
 	contextSanitizeOptions sanitize.SanitizeOptions
 	objectSanitizeOptions  sanitize.SanitizeOptions
 	reservedLabelSet       map[string]struct{}
+	charts                 *helm.Loader
 }
 
 type CollectorOptions struct {
@@ -46,6 +55,24 @@ type CollectorOptions struct {
 	Accumulator                  processor.Accumulator
 	Iterator                     CollectorIteratorOptions
 	ValidatorNamespaces          tpl.NamespaceValidator
+	// Charts may be pulled from any location, regardless of ChartAllowList.
+	AllowAnyChart bool
+	// Locations charts may be pulled from, none when nil unless AllowAnyChart is set.
+	ChartAllowList *helm.AllowList
+}
+
+// Returns the locations charts may be pulled from: a nil helm.AllowList allows any location,
+// thus it's only handed over when explicitly requested.
+func (o CollectorOptions) chartLocations() *helm.AllowList {
+	if o.AllowAnyChart {
+		return nil
+	}
+
+	if o.ChartAllowList == nil {
+		return &helm.AllowList{}
+	}
+
+	return o.ChartAllowList
 }
 
 type CollectorIteratorOptions struct {
@@ -88,7 +115,20 @@ func NewCollector(c client.Reader, mapper k8smeta.RESTMapper) Collector {
 			meta.ManagedByCapsuleLabel:    {},
 			meta.NewManagedByCapsuleLabel: {},
 		},
+		charts: helm.NewLoader(helm.DefaultResolveInterval),
+	}
+}
+
+// Returns the locations namespaced TenantResources may replicate charts from, none unless
+// allowed by the Capsule configuration.
+func chartAllowList(cfg configuration.Configuration) (*helm.AllowList, error) {
+	var repositories []string
+
+	if charts := cfg.Charts(); charts != nil {
+		repositories = charts.AllowedRepositories
 	}
+
+	return helm.NewAllowList(repositories...)
 }
 
 // With this function we are attempting to collect all the unstructured items
@@ -199,6 +239,46 @@ func (co *Collector) Collect(
 		}
 	}
 
+	// Run Secret Syncs
+	for syncIndex, item := range spec.SecretSync {
+		log.V(5).Info("processing secret sync item", "index", syncIndex)
+
+		p, syncError := co.handleSecretSyncItem(ctx, c, opts, item, ns)
+		if syncError != nil {
+			syncErr = errors.Join(syncErr, syncError)
+
+			continue
+		}
+
+		syncError = co.AddToAccumulation(tnt, ns, opts, spec, p, resourceIndex+"/secret-"+strconv.Itoa(syncIndex), true)
+		if syncError != nil {
+			syncErr = errors.Join(syncErr, syncError)
+
+			continue
+		}
+	}
+
+	// Run Chart
+	if spec.Chart != nil {
+		log.V(5).Info("processing chart", "repository", spec.Chart.Repository, "chart", spec.Chart.Name)
+
+		p, chartError := co.handleChartItem(ctx, opts, *spec.Chart, ns, tplContext)
+		if chartError != nil {
+			return errors.Join(syncErr, chartError)
+		}
+
+		log.V(5).Info("rendered chart", "amount", len(p))
+
+		for i, o := range p {
+			chartError = co.AddToAccumulation(tnt, ns, opts, spec, o, resourceIndex+"/chart-"+strconv.Itoa(i), true)
+			if chartError != nil {
+				syncErr = errors.Join(syncErr, chartError)
+
+				continue
+			}
+		}
+	}
+
 	return syncErr
 }
 
@@ -263,6 +343,10 @@ func (co *Collector) AddToAccumulation(
 
 	sanitize.SanitizeUnstructured(obj, co.objectSanitizeOptions)
 
+	if err := applyPatches(obj, spec.Patches, opts.Iterator.FastContext); err != nil {
+		return err
+	}
+
 	processor.AccumulatorAdd(opts.Accumulator, resource, processor.AccumulatorObject{
 		Object: obj,
 		Origin: gvk.TenantResourceIDWithOrigin{
@@ -271,11 +355,56 @@ func (co *Collector) AddToAccumulation(
 			},
 			Origin: origin,
 		},
+		DriftPolicy: spec.DriftPolicy,
 	})
 
 	return nil
 }
 
+// Applies the patches targeting the object, templating their documents with the values of the
+// iterated Tenant and Namespace. Patches cannot change the identity of the object, since it was
+// already accounted for.
+func applyPatches(obj *unstructured.Unstructured, patches []kustomize.Patch, fastContext map[string]string) error {
+	if len(patches) == 0 {
+		return nil
+	}
+
+	identity := gvk.NewResourceID(obj, "", "")
+
+	for i, patch := range patches {
+		patch.Patch = tpl.FastTemplate(patch.Patch, fastContext)
+
+		if patch.Target != nil {
+			target := *patch.Target
+			target.Namespace = tpl.FastTemplate(target.Namespace, fastContext)
+			target.Name = tpl.FastTemplate(target.Name, fastContext)
+			patch.Target = &target
+		}
+
+		overlay, err := clt.NewOverlay(patch)
+		if err != nil {
+			return fmt.Errorf("invalid patch %d: %w", i, err)
+		}
+
+		if !overlay.Matches(obj) {
+			continue
+		}
+
+		if err := overlay.Apply(obj); err != nil {
+			return fmt.Errorf("patch %d failed for %s %s/%s: %w", i, obj.GetKind(), obj.GetNamespace(), obj.GetName(), err)
+		}
+	}
+
+	if gvk.NewResourceID(obj, "", "") != identity {
+		return fmt.Errorf(
+			"patches must not change the apiVersion, kind, namespace or name of %s %s/%s",
+			identity.Kind, identity.Namespace, identity.Name,
+		)
+	}
+
+	return nil
+}
+
 // CollectForNamespace collects the items of a single ResourceSpec targeting the given Namespace,
 // replicating into it the already loaded source objects.
 //
@@ -486,6 +615,161 @@ func (co *Collector) handleGeneratorItem(
 	return
 }
 
+// Handles a single secret sync item, assembling a Secret from the keys of the source Secrets.
+// Source Namespaces are validated, so that a Tenant cannot read Secrets outside of its scope.
+// No Secret is replicated when none of the sources exists.
+func (co *Collector) handleSecretSyncItem(
+	ctx context.Context,
+	c client.Client,
+	opts CollectorOptions,
+	item capsulev1beta2.SecretSyncItemSpec,
+	ns *corev1.Namespace,
+) (processed *unstructured.Unstructured, err error) {
+	namespace := ""
+	if ns != nil {
+		namespace = ns.Name
+	}
+
+	name := tpl.FastTemplate(item.Name, opts.Iterator.FastContext)
+	data := map[string][]byte{}
+	found := false
+
+	for _, source := range item.Sources {
+		sourceName := tpl.FastTemplate(source.Name, opts.Iterator.FastContext)
+
+		sourceNamespace := namespace
+		if source.Namespace != "" {
+			sourceNamespace = tpl.FastTemplate(source.Namespace, opts.Iterator.FastContext)
+		}
+
+		if sourceNamespace == "" {
+			return nil, fmt.Errorf("secret sync %s: namespace of source %s is required when no Namespace is targeted", name, sourceName)
+		}
+
+		if opts.ValidatorNamespaces != nil {
+			if err := opts.ValidatorNamespaces(sourceNamespace); err != nil {
+				return nil, err
+			}
+		}
+
+		u := &unstructured.Unstructured{}
+		u.SetAPIVersion("v1")
+		u.SetKind("Secret")
+
+		if err := c.Get(ctx, client.ObjectKey{Namespace: sourceNamespace, Name: sourceName}, u); err != nil {
+			if apierrors.IsNotFound(err) && source.Optional {
+				continue
+			}
+
+			return nil, fmt.Errorf("secret sync %s: failed to get source %s/%s: %w", name, sourceNamespace, sourceName, err)
+		}
+
+		secret := &corev1.Secret{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, secret); err != nil {
+			return nil, err
+		}
+
+		picked, err := pickSecretKeys(secret.Data, source.Keys)
+		if err != nil {
+			return nil, fmt.Errorf("secret sync %s: source %s/%s: %w", name, sourceNamespace, sourceName, err)
+		}
+
+		if err := mergeSecretData(data, picked); err != nil {
+			return nil, fmt.Errorf("secret sync %s: source %s/%s: %w", name, sourceNamespace, sourceName, err)
+		}
+
+		found = true
+	}
+
+	if !found {
+		return nil, nil
+	}
+
+	secretType := item.Type
+	if secretType == "" {
+		secretType = corev1.SecretTypeOpaque
+	}
+
+	data, err = convertSecretData(secretType, data)
+	if err != nil {
+		return nil, fmt.Errorf("secret sync %s: %w", name, err)
+	}
+
+	encoded := make(map[string]any, len(data))
+	for key, value := range data {
+		encoded[key] = base64.StdEncoding.EncodeToString(value)
+	}
+
+	obj := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "v1",
+		"kind":       "Secret",
+		"type":       string(secretType),
+		"data":       encoded,
+	}}
+	obj.SetName(name)
+	obj.SetNamespace(namespace)
+
+	return obj, nil
+}
+
+// Handles the chart item, rendering the chart for the given Namespace.
+func (co *Collector) handleChartItem(
+	ctx context.Context,
+	opts CollectorOptions,
+	item capsulev1beta2.ChartItemSpec,
+	ns *corev1.Namespace,
+	tmplContext tpl.ReferenceContext,
+) (processed []*unstructured.Unstructured, err error) {
+	values := map[string]any{}
+
+	if item.Values != "" {
+		rendered, err := tpl.RenderTemplateBytes(tmplContext, item.MissingKey, []byte(item.Values))
+		if err != nil {
+			return nil, fmt.Errorf("error templating chart values: %w", err)
+		}
+
+		if err := yaml.Unmarshal(rendered, &values); err != nil {
+			return nil, fmt.Errorf("error decoding chart values: %w", err)
+		}
+	}
+
+	releaseName := item.Name
+	if item.ReleaseName != "" {
+		releaseName = tpl.FastTemplate(item.ReleaseName, opts.Iterator.FastContext)
+	}
+
+	namespace := ""
+	if ns != nil {
+		namespace = ns.Name
+	}
+
+	chrt, err := co.charts.Load(ctx, helm.Reference{
+		Repository: item.Repository,
+		Name:       item.Name,
+		Version:    item.Version,
+		PlainHTTP:  item.PlainHTTP,
+		AllowList:  opts.chartLocations(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	objs, err := helm.Render(chrt, releaseName, namespace, values)
+	if err != nil {
+		return nil, fmt.Errorf("error rendering chart %s: %w", item.Name, err)
+	}
+
+	for _, obj := range objs {
+		if ns != nil {
+			obj.SetNamespace(ns.Name)
+		}
+
+		processed = append(processed, obj)
+	}
+
+	return processed, nil
+}
+
 func (co *Collector) handleRawItem(
 	ctx context.Context,
 	c client.Client,
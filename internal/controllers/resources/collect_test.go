@@ -4,17 +4,31 @@
 package resources
 
 import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
 	"strings"
 	"testing"
 
+	"github.com/fluxcd/pkg/apis/kustomize"
+	"helm.sh/helm/v4/pkg/chart/common"
+	chart "helm.sh/helm/v4/pkg/chart/v2"
+	chartutil "helm.sh/helm/v4/pkg/chart/v2/util"
 	corev1 "k8s.io/api/core/v1"
 	k8smeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
 	"github.com/projectcapsule/capsule/pkg/api/processor"
+	"github.com/projectcapsule/capsule/pkg/runtime/configuration"
+	tpl "github.com/projectcapsule/capsule/pkg/template"
+	"github.com/projectcapsule/capsule/pkg/tenant"
 )
 
 func TestCollectorAddToAccumulationClusterScopedObjects(t *testing.T) {
@@ -104,6 +118,210 @@ func TestCollectorAddToAccumulationClusterScopedObjects(t *testing.T) {
 	})
 }
 
+func TestCollectorAddToAccumulationPatches(t *testing.T) {
+	t.Parallel()
+
+	mapper := k8smeta.NewDefaultRESTMapper([]schema.GroupVersion{{Version: "v1"}})
+	mapper.Add(schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}, k8smeta.RESTScopeNamespace)
+
+	collector := NewCollector(nil, mapper)
+
+	tnt := &capsulev1beta2.Tenant{ObjectMeta: metav1.ObjectMeta{Name: "solar"}}
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "solar-prod"}}
+
+	tests := []struct {
+		name     string
+		patches  []kustomize.Patch
+		wantData map[string]string
+		wantErr  string
+	}{
+		{
+			name: "templated strategic merge patch",
+			patches: []kustomize.Patch{{
+				Patch: "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: example\ndata:\n  owner: \"{{tenant.name}}\"\n",
+			}},
+			wantData: map[string]string{"key": "value", "owner": "solar"},
+		},
+		{
+			name: "templated JSON6902 target",
+			patches: []kustomize.Patch{{
+				Patch:  "- op: add\n  path: /data/namespace\n  value: \"{{namespace}}\"\n",
+				Target: &kustomize.Selector{Kind: "ConfigMap", Namespace: "{{namespace}}"},
+			}},
+			wantData: map[string]string{"key": "value", "namespace": "solar-prod"},
+		},
+		{
+			name: "patch not matching the object",
+			patches: []kustomize.Patch{{
+				Patch:  "- op: remove\n  path: /data/key\n",
+				Target: &kustomize.Selector{Kind: "ConfigMap", Name: "other"},
+			}},
+			wantData: map[string]string{"key": "value"},
+		},
+		{
+			name: "patch changing the name",
+			patches: []kustomize.Patch{{
+				Patch:  "- op: replace\n  path: /metadata/name\n  value: renamed\n",
+				Target: &kustomize.Selector{Kind: "ConfigMap"},
+			}},
+			wantErr: "must not change",
+		},
+		{
+			name:    "invalid patch",
+			patches: []kustomize.Patch{{Patch: "- op: remove\n  path: /data/key\n"}},
+			wantErr: "invalid patch 0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			acc := processor.Accumulator{}
+			obj := newUnstructured("v1", "ConfigMap", "source", "example")
+			_ = unstructured.SetNestedStringMap(obj.Object, map[string]string{"key": "value"}, "data")
+
+			opts := CollectorOptions{
+				Accumulator: acc,
+				Iterator:    CollectorIteratorOptions{FastContext: tenant.FastContextForTenantAndNamespace(tnt, ns)},
+			}
+
+			spec := capsuleResourceSpec()
+			spec.Patches = tt.patches
+
+			err := collector.AddToAccumulation(tnt, ns, opts, spec, obj, "test", true)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+
+				if len(acc) != 0 {
+					t.Fatalf("expected object not to be accumulated, got %d items", len(acc))
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+
+			data, _, _ := unstructured.NestedStringMap(obj.Object, "data")
+			if !reflect.DeepEqual(data, tt.wantData) {
+				t.Fatalf("data = %v, want %v", data, tt.wantData)
+			}
+		})
+	}
+}
+
+func TestCollectorHandleChartItem(t *testing.T) {
+	t.Parallel()
+
+	chrt := &chart.Chart{
+		Metadata: &chart.Metadata{APIVersion: chart.APIVersionV2, Name: "addon", Version: "1.0.0"},
+		Values:   map[string]any{"owner": "nobody"},
+		Templates: []*common.File{{
+			Name: "templates/configmap.yaml",
+			Data: []byte("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: {{ .Release.Name }}\ndata:\n  owner: {{ .Values.owner }}\n"),
+		}},
+	}
+
+	path, err := chartutil.Save(chrt, t.TempDir())
+	if err != nil {
+		t.Fatalf("saving chart: %v", err)
+	}
+
+	archive, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading chart: %v", err)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/index.yaml", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("apiVersion: v1\nentries:\n  addon:\n  - apiVersion: v2\n    name: addon\n    version: 1.0.0\n    urls:\n    - addon-1.0.0.tgz\n"))
+	})
+	mux.HandleFunc("/addon-1.0.0.tgz", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write(archive)
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	collector := NewCollector(nil, nil)
+
+	tnt := &capsulev1beta2.Tenant{ObjectMeta: metav1.ObjectMeta{Name: "solar"}}
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "solar-prod"}}
+
+	opts := CollectorOptions{
+		Iterator:      CollectorIteratorOptions{FastContext: tenant.FastContextForTenantAndNamespace(tnt, ns)},
+		AllowAnyChart: true,
+	}
+
+	item := capsulev1beta2.ChartItemSpec{
+		Repository:  server.URL,
+		Name:        "addon",
+		ReleaseName: "{{tenant.name}}-addon",
+		Values:      "owner: {{ .tenant.name }}",
+		MissingKey:  tpl.MissingKeyZero,
+	}
+
+	objs, err := collector.handleChartItem(context.Background(), opts, item, ns, tpl.ReferenceContext{
+		"tenant": map[string]any{"name": "solar"},
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(objs) != 1 {
+		t.Fatalf("expected 1 object, got %d", len(objs))
+	}
+
+	if objs[0].GetName() != "solar-addon" || objs[0].GetNamespace() != "solar-prod" {
+		t.Fatalf("unexpected identity %s/%s", objs[0].GetNamespace(), objs[0].GetName())
+	}
+
+	if owner, _, _ := unstructured.NestedString(objs[0].Object, "data", "owner"); owner != "solar" {
+		t.Fatalf("expected templated values, got owner %q", owner)
+	}
+
+	item.Values = "owner: {{ .tenant.name"
+
+	if _, err := collector.handleChartItem(context.Background(), opts, item, ns, tpl.ReferenceContext{}); err == nil {
+		t.Fatal("expected templating error, got nil")
+	}
+
+	item.Values = ""
+
+	// Options lacking an allow-list never pull charts, unless any location is explicitly allowed.
+	opts.AllowAnyChart = false
+
+	if _, err := collector.handleChartItem(context.Background(), opts, item, ns, tpl.ReferenceContext{}); err == nil || !strings.Contains(err.Error(), "is not allowed") {
+		t.Fatalf("expected repository not allowed error without allow-list, got %v", err)
+	}
+
+	// Namespaced TenantResources are restricted to the allowed repositories.
+	sch := runtime.NewScheme()
+	if err := capsulev1beta2.AddToScheme(sch); err != nil {
+		t.Fatal(err)
+	}
+
+	cl := fake.NewClientBuilder().WithScheme(sch).WithObjects(&capsulev1beta2.CapsuleConfiguration{
+		ObjectMeta: metav1.ObjectMeta{Name: "capsule"},
+		Spec: capsulev1beta2.CapsuleConfigurationSpec{
+			Charts: &capsulev1beta2.ChartsConfiguration{AllowedRepositories: []string{"oci://registry.example.com/charts"}},
+		},
+	}).Build()
+
+	opts.ChartAllowList, err = chartAllowList(configuration.NewCapsuleConfiguration(context.Background(), cl, cl, nil, "capsule"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := collector.handleChartItem(context.Background(), opts, item, ns, tpl.ReferenceContext{}); err == nil || !strings.Contains(err.Error(), "is not allowed") {
+		t.Fatalf("expected repository not allowed error, got %v", err)
+	}
+}
+
 func newUnstructured(apiVersion, kind, namespace, name string) *unstructured.Unstructured {
 	obj := &unstructured.Unstructured{}
 	obj.SetAPIVersion(apiVersion)
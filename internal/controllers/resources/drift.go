@@ -0,0 +1,229 @@
+// Copyright 2020-2026 Project Capsule Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package resources
+
+import (
+	"context"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/events"
+	ctrl "sigs.k8s.io/controller-runtime"
+	crcache "sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	"github.com/projectcapsule/capsule/pkg/api/meta"
+	evt "github.com/projectcapsule/capsule/pkg/runtime/events"
+	"github.com/projectcapsule/capsule/pkg/runtime/predicates"
+)
+
+// Builds the cache backing the drift watches. It is restricted to the objects replicated by
+// Capsule, and only their metadata is cached: the drift itself is detected by the reconciliation.
+func newDriftCache(mgr ctrl.Manager) (crcache.Cache, error) {
+	return crcache.New(mgr.GetConfig(), crcache.Options{
+		HTTPClient: mgr.GetHTTPClient(),
+		Scheme:     mgr.GetScheme(),
+		Mapper:     mgr.GetRESTMapper(),
+		DefaultLabelSelector: labels.SelectorFromSet(labels.Set{
+			meta.NewManagedByCapsuleLabel: meta.ValueControllerReplications,
+		}),
+	})
+}
+
+// Identity of an object observed for drift.
+type driftObjectKey struct {
+	gvk       schema.GroupVersionKind
+	namespace string
+	name      string
+}
+
+// Watches the objects replicated with a drift policy observing their changes, enqueuing the
+// replication resources managing them. Watches are registered lazily for the kinds listed by
+// the processed items, since the replicated kinds are only known at runtime.
+type driftWatcher struct {
+	cache      crcache.Cache
+	controller controller.Controller
+
+	mu      sync.Mutex
+	watched sets.Set[schema.GroupVersionKind]
+	// Replication resources managing each observed object.
+	owners map[driftObjectKey]sets.Set[types.NamespacedName]
+	// Objects observed for each replication resource.
+	objects map[types.NamespacedName][]driftObjectKey
+}
+
+func newDriftWatcher(cache crcache.Cache) *driftWatcher {
+	return &driftWatcher{
+		cache:   cache,
+		watched: sets.New[schema.GroupVersionKind](),
+		owners:  map[driftObjectKey]sets.Set[types.NamespacedName]{},
+		objects: map[types.NamespacedName][]driftObjectKey{},
+	}
+}
+
+// Track observes the applied items of the given replication resource whose drift policy asks
+// for it, replacing the ones observed so far.
+func (w *driftWatcher) Track(owner types.NamespacedName, items meta.ProcessedItems) error {
+	if w == nil {
+		return nil
+	}
+
+	objects := make([]driftObjectKey, 0)
+
+	for _, item := range items {
+		if !item.DriftPolicy.Observed() || item.LastApply.IsZero() {
+			continue
+		}
+
+		key := driftObjectKey{gvk: item.GetGVK(), namespace: item.GetNamespace(), name: item.GetName()}
+		if item.ClusterScoped {
+			key.namespace = ""
+		}
+
+		objects = append(objects, key)
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for _, object := range objects {
+		if err := w.watch(object.gvk); err != nil {
+			return err
+		}
+	}
+
+	w.forget(owner)
+
+	for _, object := range objects {
+		if _, ok := w.owners[object]; !ok {
+			w.owners[object] = sets.New[types.NamespacedName]()
+		}
+
+		w.owners[object].Insert(owner)
+	}
+
+	if len(objects) > 0 {
+		w.objects[owner] = objects
+	}
+
+	return nil
+}
+
+// Forget stops observing the items of the given replication resource.
+func (w *driftWatcher) Forget(owner types.NamespacedName) {
+	if w == nil {
+		return
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.forget(owner)
+}
+
+func (w *driftWatcher) forget(owner types.NamespacedName) {
+	for _, object := range w.objects[owner] {
+		owners := w.owners[object]
+		owners.Delete(owner)
+
+		if owners.Len() == 0 {
+			delete(w.owners, object)
+		}
+	}
+
+	delete(w.objects, owner)
+}
+
+// Registers a watch for the given kind, unless already registered. Watches are never removed,
+// events for objects which are no longer observed are simply not enqueued.
+func (w *driftWatcher) watch(gvk schema.GroupVersionKind) error {
+	if w.watched.Has(gvk) {
+		return nil
+	}
+
+	obj := &metav1.PartialObjectMetadata{}
+	obj.SetGroupVersionKind(gvk)
+
+	if err := w.controller.Watch(source.Kind(
+		w.cache,
+		client.Object(obj),
+		handler.EnqueueRequestsFromMapFunc(w.enqueueOwners(gvk)),
+		predicates.ManagedFieldsChangedPredicate{},
+	)); err != nil {
+		return err
+	}
+
+	w.watched.Insert(gvk)
+
+	return nil
+}
+
+func (w *driftWatcher) enqueueOwners(gvk schema.GroupVersionKind) handler.MapFunc {
+	return func(_ context.Context, obj client.Object) []reconcile.Request {
+		key := driftObjectKey{gvk: gvk, namespace: obj.GetNamespace(), name: obj.GetName()}
+
+		w.mu.Lock()
+		defer w.mu.Unlock()
+
+		owners := w.owners[key]
+
+		requests := make([]reconcile.Request, 0, owners.Len())
+		for owner := range owners {
+			requests = append(requests, reconcile.Request{NamespacedName: owner})
+		}
+
+		return requests
+	}
+}
+
+// Emits an event for each item which drifted since the previous reconciliation, and for each
+// item whose drift has been corrected.
+func recordDriftEvents(
+	recorder events.EventRecorder,
+	obj client.Object,
+	previous meta.ProcessedItems,
+	current meta.ProcessedItems,
+) {
+	if recorder == nil {
+		return
+	}
+
+	for _, item := range current {
+		switch item.Drift {
+		case meta.DriftStateDrifted:
+			if before := previous.GetItem(item.ResourceID); before != nil && before.Drift == meta.DriftStateDrifted {
+				continue
+			}
+
+			recorder.Eventf(
+				obj,
+				nil,
+				corev1.EventTypeWarning,
+				evt.ReasonDriftDetected,
+				evt.ActionReconciled,
+				"%s %s/%s drifted: %s",
+				item.Kind, item.Namespace, item.Name, item.DriftMessage,
+			)
+		case meta.DriftStateCorrected:
+			recorder.Eventf(
+				obj,
+				nil,
+				corev1.EventTypeNormal,
+				evt.ReasonDriftCorrected,
+				evt.ActionReconciled,
+				"%s %s/%s drift corrected: %s",
+				item.Kind, item.Namespace, item.Name, item.DriftMessage,
+			)
+		}
+	}
+}
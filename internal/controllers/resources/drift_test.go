@@ -0,0 +1,144 @@
+// Copyright 2020-2026 Project Capsule Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package resources
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	"github.com/projectcapsule/capsule/pkg/api/meta"
+	"github.com/projectcapsule/capsule/pkg/runtime/gvk"
+)
+
+func TestDriftWatcherTracksObservedItems(t *testing.T) {
+	t.Parallel()
+
+	configMaps := schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}
+
+	// The kind is registered upfront, no controller is required to watch it.
+	watcher := newDriftWatcher(nil)
+	watcher.watched.Insert(configMaps)
+
+	first := types.NamespacedName{Namespace: "tenant-a", Name: "first"}
+	second := types.NamespacedName{Namespace: "tenant-a", Name: "second"}
+
+	if err := watcher.Track(first, meta.ProcessedItems{
+		driftItem("observed", meta.DriftPolicyCorrect, true),
+		driftItem("ignored", meta.DriftPolicyIgnore, true),
+		driftItem("pending", meta.DriftPolicyReport, false),
+	}); err != nil {
+		t.Fatalf("Track() error = %v", err)
+	}
+
+	if err := watcher.Track(second, meta.ProcessedItems{
+		driftItem("observed", meta.DriftPolicyReport, true),
+	}); err != nil {
+		t.Fatalf("Track() error = %v", err)
+	}
+
+	enqueue := watcher.enqueueOwners(configMaps)
+
+	if got := enqueue(context.Background(), driftObject("observed")); len(got) != 2 {
+		t.Fatalf("requests for observed object = %v, want both owners", got)
+	}
+
+	for _, name := range []string{"ignored", "pending"} {
+		if got := enqueue(context.Background(), driftObject(name)); len(got) != 0 {
+			t.Fatalf("requests for %s object = %v, want none", name, got)
+		}
+	}
+
+	watcher.Forget(first)
+
+	got := enqueue(context.Background(), driftObject("observed"))
+	if len(got) != 1 || got[0].NamespacedName != second {
+		t.Fatalf("requests after forget = %v, want only %s", got, second)
+	}
+
+	if err := watcher.Track(second, nil); err != nil {
+		t.Fatalf("Track() error = %v", err)
+	}
+
+	if len(watcher.owners) != 0 || len(watcher.objects) != 0 {
+		t.Fatalf("watcher still observes objects: %v", watcher.owners)
+	}
+}
+
+func TestRecordDriftEvents(t *testing.T) {
+	t.Parallel()
+
+	recorder := events.NewFakeRecorder(10)
+
+	drifted := driftItem("drifted", meta.DriftPolicyReport, true)
+	drifted.Drift = meta.DriftStateDrifted
+	drifted.DriftMessage = "modified .data.a by kubectl-edit"
+
+	stillDrifted := driftItem("still-drifted", meta.DriftPolicyReport, true)
+	stillDrifted.Drift = meta.DriftStateDrifted
+
+	corrected := driftItem("corrected", meta.DriftPolicyCorrect, true)
+	corrected.Drift = meta.DriftStateCorrected
+	corrected.DriftMessage = "object was deleted"
+
+	recordDriftEvents(
+		recorder,
+		&capsulev1beta2.TenantResource{},
+		meta.ProcessedItems{stillDrifted},
+		meta.ProcessedItems{drifted, stillDrifted, corrected},
+	)
+
+	close(recorder.Events)
+
+	got := make([]string, 0)
+	for event := range recorder.Events {
+		got = append(got, event)
+	}
+
+	want := []string{
+		"Warning DriftDetected ConfigMap tenant-a/drifted drifted: modified .data.a by kubectl-edit",
+		"Normal DriftCorrected ConfigMap tenant-a/corrected drift corrected: object was deleted",
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("events = %v, want %v", got, want)
+	}
+
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("event %d = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
+
+func driftItem(name string, policy meta.DriftPolicy, applied bool) meta.ObjectReferenceStatus {
+	item := meta.ObjectReferenceStatus{
+		ResourceID: gvk.ResourceID{
+			Version:   "v1",
+			Kind:      "ConfigMap",
+			Namespace: "tenant-a",
+			Name:      name,
+		},
+	}
+
+	item.DriftPolicy = policy
+
+	if applied {
+		item.LastApply = metav1.NewTime(time.Now())
+	}
+
+	return item
+}
+
+func driftObject(name string) *metav1.PartialObjectMetadata {
+	return &metav1.PartialObjectMetadata{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "tenant-a", Name: name},
+	}
+}
@@ -5,9 +5,12 @@ package resources
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"reflect"
+	"slices"
 	"strconv"
+	"strings"
 
 	"github.com/go-logr/logr"
 	gherrors "github.com/pkg/errors"
@@ -16,6 +19,7 @@ import (
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/events"
 	"k8s.io/client-go/util/retry"
 	"sigs.k8s.io/cluster-api/util/patch"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -49,6 +53,8 @@ type globalResourceController struct {
 	collector     Collector
 	configuration configuration.Configuration
 	metrics       *metrics.GlobalTenantResourceRecorder
+	recorder      events.EventRecorder
+	drift         *driftWatcher
 	clients       impersonatedClientLoader[*capsulev1beta2.GlobalTenantResource]
 
 	impersonation *cache.ImpersonationCache
@@ -76,7 +82,7 @@ func (r *globalResourceController) SetupWithManager(mgr ctrl.Manager, ctrlConfig
 		resolve:       globalServiceAccount,
 	}
 
-	return ctrl.NewControllerManagedBy(mgr).
+	controller, err := ctrl.NewControllerManagedBy(mgr).
 		For(
 			&capsulev1beta2.GlobalTenantResource{},
 			builder.WithPredicates(
@@ -105,7 +111,14 @@ func (r *globalResourceController) SetupWithManager(mgr ctrl.Manager, ctrlConfig
 			builder.WithPredicates(predicates.TenantSelectionChangedPredicate{}),
 		).
 		WithOptions(ctrlConfig.Runtime.ToControllerOptions()).
-		Complete(r)
+		Build(r)
+	if err != nil {
+		return err
+	}
+
+	r.drift.controller = controller
+
+	return nil
 }
 
 func (r *globalResourceController) Reconcile(ctx context.Context, request reconcile.Request) (res reconcile.Result, err error) {
@@ -119,6 +132,7 @@ func (r *globalResourceController) Reconcile(ctx context.Context, request reconc
 			log.V(5).Info("Request object not found, could have been deleted after reconcile request")
 
 			r.metrics.DeleteMetrics(request.Name)
+			r.drift.Forget(request.NamespacedName)
 
 			return reconcile.Result{}, nil
 		}
@@ -199,11 +213,13 @@ func (r *globalResourceController) Reconcile(ctx context.Context, request reconc
 				return requeue, nil
 			}
 
-			stat := d.Status.Conditions.GetConditionByType(meta.ReadyCondition)
-			if stat == nil || stat.Status != metav1.ConditionTrue {
-				statusErr = fmt.Errorf("dependency %s not ready", dep.Name)
+			for _, conditionType := range tntResource.Spec.DependsOnConditions() {
+				stat := d.Status.Conditions.GetConditionByType(conditionType)
+				if stat == nil || stat.Status != metav1.ConditionTrue {
+					statusErr = fmt.Errorf("dependency %s not %s", dep.Name, strings.ToLower(conditionType))
 
-				return requeue, nil
+					return requeue, nil
+				}
 			}
 		}
 	}
@@ -234,6 +250,8 @@ func (r *globalResourceController) Reconcile(ctx context.Context, request reconc
 
 	statusErr = r.reconcile(ctx, c, tntResource)
 
+	requeue.RequeueAfter = healthResync(requeue.RequeueAfter, tntResource.Status.ProcessedItems)
+
 	if len(tntResource.Status.ProcessedItems) > 0 {
 		controllerutil.AddFinalizer(tntResource, meta.ControllerFinalizer)
 	} else {
@@ -383,7 +401,9 @@ func (r *globalResourceController) reconcile(
 		}
 	}
 
-	return r.processor.Reconcile(
+	previous := slices.Clone(tntResource.Status.ProcessedItems)
+
+	reconcileErr := r.processor.Reconcile(
 		ctx,
 		log,
 		c,
@@ -396,6 +416,14 @@ func (r *globalResourceController) reconcile(
 			Force:            *tntResource.Spec.Settings.Force,
 			Owner:            &owner,
 		})
+
+	recordDriftEvents(r.recorder, tntResource, previous, tntResource.Status.ProcessedItems)
+
+	if err := r.drift.Track(client.ObjectKeyFromObject(tntResource), tntResource.Status.ProcessedItems); err != nil {
+		return errors.Join(reconcileErr, fmt.Errorf("failed to watch replicated items for drift: %w", err))
+	}
+
+	return reconcileErr
 }
 
 func (r *globalResourceController) gatherResources(
@@ -410,6 +438,7 @@ func (r *globalResourceController) gatherResources(
 		Accumulator:                  acc,
 		AllowCrossNamespaceSelection: true,
 		AllowClusterScopedObjects:    true,
+		AllowAnyChart:                true,
 	}
 
 	// Collect Available Generated Items
@@ -578,6 +607,7 @@ func (r *globalResourceController) updateStatus(ctx context.Context, instance *c
 		}
 
 		latest.Status.Conditions.UpdateConditionByType(readyCondition)
+		latest.Status.UpdateItemConditions(instance)
 
 		// Set Cordoned Condition
 		cordonedCondition := meta.NewCordonedCondition(instance)
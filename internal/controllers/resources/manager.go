@@ -7,6 +7,7 @@ import (
 	"fmt"
 
 	"github.com/go-logr/logr"
+	"k8s.io/client-go/tools/events"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 
 	"github.com/projectcapsule/capsule/internal/cache"
@@ -21,11 +22,26 @@ func Add(
 	configuration configuration.Configuration,
 	opts utils.ControllerOptions,
 	cache *cache.ImpersonationCache,
+	recorder events.EventRecorder,
 ) (err error) {
+	driftCache, err := newDriftCache(mgr)
+	if err != nil {
+		return fmt.Errorf("unable to create drift cache: %w", err)
+	}
+
+	if err = mgr.Add(driftCache); err != nil {
+		return fmt.Errorf("unable to add drift cache: %w", err)
+	}
+
+	globalDrift := newDriftWatcher(driftCache)
+	namespacedDrift := newDriftWatcher(driftCache)
+
 	if err = (&NamespaceTrigger{
-		log:           log.WithName("Global"),
-		configuration: configuration,
-		impersonation: cache,
+		log:             log.WithName("Global"),
+		configuration:   configuration,
+		impersonation:   cache,
+		globalDrift:     globalDrift,
+		namespacedDrift: namespacedDrift,
 	}).SetupWithManager(mgr, opts); err != nil {
 		return fmt.Errorf("unable to create watcher controller: %w", err)
 	}
@@ -34,6 +50,8 @@ func Add(
 		log:           log.WithName("Global"),
 		configuration: configuration,
 		metrics:       metrics.MustMakeGlobalTenantResourceRecorder(),
+		recorder:      recorder,
+		drift:         globalDrift,
 
 		impersonation: cache,
 	}).SetupWithManager(mgr, opts); err != nil {
@@ -44,6 +62,8 @@ func Add(
 		log:           log.WithName("Namespaced"),
 		configuration: configuration,
 		metrics:       metrics.MustMakeTenantResourceRecorder(),
+		recorder:      recorder,
+		drift:         namespacedDrift,
 
 		impersonation: cache,
 	}).SetupWithManager(mgr, opts); err != nil {
@@ -51,6 +51,8 @@ type NamespaceTrigger struct {
 	namespacedClients impersonatedClientLoader[*capsulev1beta2.TenantResource]
 	globalStatus      scopedStatusPatcher[*capsulev1beta2.GlobalTenantResource]
 	namespacedStatus  scopedStatusPatcher[*capsulev1beta2.TenantResource]
+	globalDrift       *driftWatcher
+	namespacedDrift   *driftWatcher
 }
 
 func (r *NamespaceTrigger) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
@@ -293,8 +295,9 @@ func (r *NamespaceTrigger) replicateGlobal(
 	// The items are reported even along an error, since they carry the outcome of the
 	// single objects which have been processed.
 	statusErr := r.globalStatus.Patch(ctx, tntResource, scope, items)
+	driftErr := r.globalDrift.Track(client.ObjectKeyFromObject(tntResource), tntResource.Status.ProcessedItems)
 
-	return errors.Join(reconcileErr, statusErr)
+	return errors.Join(reconcileErr, statusErr, driftErr)
 }
 
 // Replicates a single TenantResource into the given Namespace of the given Tenant,
@@ -335,8 +338,9 @@ func (r *NamespaceTrigger) replicateNamespaced(
 	)
 
 	statusErr := r.namespacedStatus.Patch(ctx, tntResource, scope, items)
+	driftErr := r.namespacedDrift.Track(client.ObjectKeyFromObject(tntResource), tntResource.Status.ProcessedItems)
 
-	return errors.Join(reconcileErr, statusErr)
+	return errors.Join(reconcileErr, statusErr, driftErr)
 }
 
 // Accumulates the items of a GlobalTenantResource the given Namespace must be holding.
@@ -352,6 +356,7 @@ func (r *NamespaceTrigger) gatherGlobalResources(
 	opts := CollectorOptions{
 		Accumulator:               acc,
 		AllowClusterScopedObjects: true,
+		AllowAnyChart:             true,
 	}
 
 	for resourceIndex, resource := range tntResource.Spec.Resources {
@@ -429,13 +434,20 @@ func (r *NamespaceTrigger) gatherNamespacedResources(
 	allowed := sets.New[string](tnt.Status.Namespaces...)
 	allowed.Insert(namespace.GetName())
 
+	charts, err := chartAllowList(r.configuration)
+	if err != nil {
+		return err
+	}
+
 	// The very same boundaries of the full reconciliation: a Tenant owner must not be able to
-	// select cluster scoped objects, nor objects living outside of its own Namespaces.
+	// select cluster scoped objects, objects living outside of its own Namespaces, nor pull
+	// charts from locations which are not allowed.
 	opts := CollectorOptions{
 		Accumulator:                  acc,
 		AllowCrossNamespaceSelection: false,
 		AllowClusterScopedObjects:    false,
 		ValidatorNamespaces:          tpl.NewNamespaceValidator(false, allowed),
+		ChartAllowList:               charts,
 	}
 
 	// The sources of a TenantResource always live in the Namespace it is deployed in.
@@ -5,9 +5,12 @@ package resources
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"reflect"
+	"slices"
 	"strconv"
+	"strings"
 
 	"github.com/go-logr/logr"
 	gherrors "github.com/pkg/errors"
@@ -16,6 +19,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/events"
 	"k8s.io/client-go/util/retry"
 	"sigs.k8s.io/cluster-api/util/patch"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -51,6 +55,8 @@ type namespacedResourceController struct {
 	collector     Collector
 	configuration configuration.Configuration
 	metrics       *metrics.TenantResourceRecorder
+	recorder      events.EventRecorder
+	drift         *driftWatcher
 	clients       impersonatedClientLoader[*capsulev1beta2.TenantResource]
 
 	impersonation *cache.ImpersonationCache
@@ -77,7 +83,7 @@ func (r *namespacedResourceController) SetupWithManager(mgr ctrl.Manager, ctrlCo
 		resolve:       namespacedServiceAccount,
 	}
 
-	return ctrl.NewControllerManagedBy(mgr).
+	controller, err := ctrl.NewControllerManagedBy(mgr).
 		For(
 			&capsulev1beta2.TenantResource{},
 			builder.WithPredicates(
@@ -113,7 +119,14 @@ func (r *namespacedResourceController) SetupWithManager(mgr ctrl.Manager, ctrlCo
 			builder.WithPredicates(predicates.TenantNamespacesChangedPredicate{}),
 		).
 		WithOptions(ctrlConfig.Runtime.ToControllerOptions()).
-		Complete(r)
+		Build(r)
+	if err != nil {
+		return err
+	}
+
+	r.drift.controller = controller
+
+	return nil
 }
 
 func (r *namespacedResourceController) Reconcile(ctx context.Context, request reconcile.Request) (res reconcile.Result, err error) {
@@ -127,6 +140,7 @@ func (r *namespacedResourceController) Reconcile(ctx context.Context, request re
 			log.V(5).Info("Request object not found, could have been deleted after reconcile request")
 
 			r.metrics.DeleteMetrics(request.Name, request.Namespace)
+			r.drift.Forget(request.NamespacedName)
 
 			return reconcile.Result{}, nil
 		}
@@ -210,11 +224,13 @@ func (r *namespacedResourceController) Reconcile(ctx context.Context, request re
 				return requeue, nil
 			}
 
-			stat := d.Status.Conditions.GetConditionByType(meta.ReadyCondition)
-			if stat == nil || stat.Status != metav1.ConditionTrue {
-				statusErr = fmt.Errorf("dependency %s not ready", dep.Name)
+			for _, conditionType := range tntResource.Spec.DependsOnConditions() {
+				stat := d.Status.Conditions.GetConditionByType(conditionType)
+				if stat == nil || stat.Status != metav1.ConditionTrue {
+					statusErr = fmt.Errorf("dependency %s not %s", dep.Name, strings.ToLower(conditionType))
 
-				return requeue, nil
+					return requeue, nil
+				}
 			}
 		}
 	}
@@ -245,6 +261,8 @@ func (r *namespacedResourceController) Reconcile(ctx context.Context, request re
 
 	statusErr = r.reconcile(ctx, c, tntResource)
 
+	requeue.RequeueAfter = healthResync(requeue.RequeueAfter, tntResource.Status.ProcessedItems)
+
 	if len(tntResource.Status.ProcessedItems) > 0 {
 		controllerutil.AddFinalizer(tntResource, meta.ControllerFinalizer)
 	} else {
@@ -448,7 +466,9 @@ func (r *namespacedResourceController) reconcile(
 		}
 	}
 
-	return r.processor.Reconcile(
+	previous := slices.Clone(tntResource.Status.ProcessedItems)
+
+	reconcileErr := r.processor.Reconcile(
 		ctx,
 		log,
 		c,
@@ -461,6 +481,14 @@ func (r *namespacedResourceController) reconcile(
 			Force:            *tntResource.Spec.Settings.Force,
 			Owner:            nil,
 		})
+
+	recordDriftEvents(r.recorder, tntResource, previous, tntResource.Status.ProcessedItems)
+
+	if err := r.drift.Track(client.ObjectKeyFromObject(tntResource), tntResource.Status.ProcessedItems); err != nil {
+		return errors.Join(reconcileErr, fmt.Errorf("failed to watch replicated items for drift: %w", err))
+	}
+
+	return reconcileErr
 }
 
 func (r *namespacedResourceController) gatherResources(
@@ -471,11 +499,17 @@ func (r *namespacedResourceController) gatherResources(
 	tnt capsulev1beta2.Tenant,
 	acc processor.Accumulator,
 ) (err error) {
+	charts, err := chartAllowList(r.configuration)
+	if err != nil {
+		return err
+	}
+
 	opts := CollectorOptions{
 		Accumulator:                  acc,
 		AllowCrossNamespaceSelection: false,
 		AllowClusterScopedObjects:    false,
 		ValidatorNamespaces:          tpl.NewNamespaceValidator(false, sets.New[string](tnt.Status.Namespaces...)),
+		ChartAllowList:               charts,
 	}
 
 	for resourceIndex, resource := range tntResource.Spec.Resources {
@@ -609,6 +643,7 @@ func (r *namespacedResourceController) updateStatus(ctx context.Context, instanc
 		}
 
 		latest.Status.Conditions.UpdateConditionByType(readyCondition)
+		latest.Status.UpdateItemConditions(instance)
 
 		// Set Cordoned Condition
 		cordonedCondition := meta.NewCordonedCondition(instance)
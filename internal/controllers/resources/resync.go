@@ -7,8 +7,14 @@ import (
 	"time"
 
 	"k8s.io/apimachinery/pkg/util/wait"
+
+	"github.com/projectcapsule/capsule/pkg/api/meta"
 )
 
+// healthResyncPeriod is the interval the health of replicated items is assessed again at,
+// as long as any of them is progressing or in an unknown state.
+const healthResyncPeriod = 15 * time.Second
+
 func jitteredResync(period time.Duration) time.Duration {
 	if period <= 0 {
 		return 0
@@ -16,3 +22,17 @@ func jitteredResync(period time.Duration) time.Duration {
 
 	return wait.Jitter(period, 0.1)
 }
+
+// healthResync shortens the requeue while the processed items did not settle, since their
+// health is only assessed when they are applied.
+func healthResync(requeue time.Duration, items meta.ProcessedItems) time.Duration {
+	if !items.Settling() {
+		return requeue
+	}
+
+	if period := jitteredResync(healthResyncPeriod); requeue <= 0 || period < requeue {
+		return period
+	}
+
+	return requeue
+}
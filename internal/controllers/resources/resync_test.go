@@ -0,0 +1,61 @@
+// Copyright 2020-2026 Project Capsule Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package resources
+
+import (
+	"testing"
+	"time"
+
+	"github.com/projectcapsule/capsule/pkg/api/meta"
+)
+
+func TestHealthResync(t *testing.T) {
+	t.Parallel()
+
+	items := func(health ...meta.HealthStatus) meta.ProcessedItems {
+		out := make(meta.ProcessedItems, 0, len(health))
+		for _, h := range health {
+			item := meta.ObjectReferenceStatus{}
+			item.Health = h
+
+			out = append(out, item)
+		}
+
+		return out
+	}
+
+	tests := []struct {
+		name      string
+		requeue   time.Duration
+		items     meta.ProcessedItems
+		wantShort bool
+	}{
+		{name: "healthy items keep the resync period", requeue: time.Hour, items: items(meta.HealthStatusHealthy, "")},
+		{name: "degraded items keep the resync period", requeue: time.Hour, items: items(meta.HealthStatusDegraded)},
+		{name: "progressing item", requeue: time.Hour, items: items(meta.HealthStatusHealthy, meta.HealthStatusProgressing), wantShort: true},
+		{name: "unknown item", requeue: time.Hour, items: items(meta.HealthStatusUnknown), wantShort: true},
+		{name: "progressing item without resync period", items: items(meta.HealthStatusProgressing), wantShort: true},
+		{name: "shorter resync period is kept", requeue: time.Second, items: items(meta.HealthStatusProgressing)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := healthResync(tt.requeue, tt.items)
+
+			if !tt.wantShort {
+				if got != tt.requeue {
+					t.Fatalf("healthResync() = %s, want %s", got, tt.requeue)
+				}
+
+				return
+			}
+
+			if got < healthResyncPeriod || got > healthResyncPeriod+healthResyncPeriod/10 {
+				t.Fatalf("healthResync() = %s, want about %s", got, healthResyncPeriod)
+			}
+		})
+	}
+}
@@ -52,6 +52,7 @@ func (p scopedStatusPatcher[T]) Patch(
 		original := status.DeepCopy()
 
 		status.ProcessedItems.ReplaceScope(scope.Tenant, scope.Namespace, items)
+		status.UpdateItemConditions(latest)
 		status.UpdateStats()
 
 		// Only the shared status is compared, since it is the only one being mutated: the
@@ -0,0 +1,241 @@
+// Copyright 2020-2026 Project Capsule Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package resources
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"maps"
+
+	corev1 "k8s.io/api/core/v1"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+)
+
+// Keys a kubernetes.io/dockerconfigjson Secret is built from, named after the flags of
+// kubectl create secret docker-registry.
+const (
+	secretSyncServerKey   = "server"
+	secretSyncUsernameKey = "username"
+	secretSyncPasswordKey = "password"
+	secretSyncEmailKey    = "email"
+)
+
+// Picks the selected keys of a source Secret, renaming them. All the keys are picked when none
+// is selected.
+func pickSecretKeys(data map[string][]byte, keys []capsulev1beta2.SecretSyncKey) (map[string][]byte, error) {
+	if len(keys) == 0 {
+		return maps.Clone(data), nil
+	}
+
+	picked := make(map[string][]byte, len(keys))
+
+	for _, key := range keys {
+		value, ok := data[key.Key]
+		if !ok {
+			if key.Optional {
+				continue
+			}
+
+			return nil, fmt.Errorf("key %q not found", key.Key)
+		}
+
+		target := key.Key
+		if key.As != "" {
+			target = key.As
+		}
+
+		picked[target] = value
+	}
+
+	return picked, nil
+}
+
+// Merges the keys of a source into the replicated data. Keys override the ones of the previous
+// sources, except for .dockerconfigjson keys whose registries are merged together.
+func mergeSecretData(dst map[string][]byte, src map[string][]byte) error {
+	for key, value := range src {
+		existing, ok := dst[key]
+		if key != corev1.DockerConfigJsonKey || !ok {
+			dst[key] = value
+
+			continue
+		}
+
+		merged, err := mergeDockerConfigJSON(existing, value)
+		if err != nil {
+			return err
+		}
+
+		dst[key] = merged
+	}
+
+	return nil
+}
+
+func mergeDockerConfigJSON(a, b []byte) ([]byte, error) {
+	configA, err := decodeDockerConfigJSON(a)
+	if err != nil {
+		return nil, err
+	}
+
+	configB, err := decodeDockerConfigJSON(b)
+	if err != nil {
+		return nil, err
+	}
+
+	auths := map[string]any{}
+	if existing, ok := configA["auths"].(map[string]any); ok {
+		maps.Copy(auths, existing)
+	}
+
+	if added, ok := configB["auths"].(map[string]any); ok {
+		maps.Copy(auths, added)
+	}
+
+	maps.Copy(configA, configB)
+	configA["auths"] = auths
+
+	return json.Marshal(configA)
+}
+
+func decodeDockerConfigJSON(data []byte) (map[string]any, error) {
+	config := map[string]any{}
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", corev1.DockerConfigJsonKey, err)
+	}
+
+	return config, nil
+}
+
+// Converts the merged keys to the given Secret type, ensuring the keys the type requires are
+// present.
+func convertSecretData(secretType corev1.SecretType, data map[string][]byte) (map[string][]byte, error) {
+	switch secretType {
+	case corev1.SecretTypeDockerConfigJson:
+		if _, ok := data[corev1.DockerConfigJsonKey]; !ok {
+			if err := toDockerConfigJSON(data); err != nil {
+				return nil, err
+			}
+		}
+
+		if _, err := decodeDockerConfigJSON(data[corev1.DockerConfigJsonKey]); err != nil {
+			return nil, err
+		}
+	case corev1.SecretTypeDockercfg:
+		if _, ok := data[corev1.DockerConfigKey]; !ok {
+			if err := toDockercfg(data); err != nil {
+				return nil, err
+			}
+		}
+	case corev1.SecretTypeBasicAuth:
+		_, username := data[corev1.BasicAuthUsernameKey]
+		_, password := data[corev1.BasicAuthPasswordKey]
+
+		if !username && !password {
+			return nil, fmt.Errorf("%s Secrets require the %s or %s key", secretType, corev1.BasicAuthUsernameKey, corev1.BasicAuthPasswordKey)
+		}
+	case corev1.SecretTypeTLS:
+		if err := requireSecretKeys(secretType, data, corev1.TLSCertKey, corev1.TLSPrivateKeyKey); err != nil {
+			return nil, err
+		}
+	case corev1.SecretTypeSSHAuth:
+		if err := requireSecretKeys(secretType, data, corev1.SSHAuthPrivateKey); err != nil {
+			return nil, err
+		}
+	}
+
+	return data, nil
+}
+
+// Builds the .dockerconfigjson key from either a .dockercfg key, or the credentials of a single
+// registry.
+func toDockerConfigJSON(data map[string][]byte) error {
+	if dockercfg, ok := data[corev1.DockerConfigKey]; ok {
+		auths := map[string]any{}
+		if err := json.Unmarshal(dockercfg, &auths); err != nil {
+			return fmt.Errorf("invalid %s: %w", corev1.DockerConfigKey, err)
+		}
+
+		config, err := json.Marshal(map[string]any{"auths": auths})
+		if err != nil {
+			return err
+		}
+
+		delete(data, corev1.DockerConfigKey)
+		data[corev1.DockerConfigJsonKey] = config
+
+		return nil
+	}
+
+	if err := requireSecretKeys(corev1.SecretTypeDockerConfigJson, data, secretSyncServerKey, secretSyncUsernameKey, secretSyncPasswordKey); err != nil {
+		return fmt.Errorf("%w, or the %s key", err, corev1.DockerConfigJsonKey)
+	}
+
+	username, password := string(data[secretSyncUsernameKey]), string(data[secretSyncPasswordKey])
+
+	auth := map[string]any{
+		"username": username,
+		"password": password,
+		"auth":     base64.StdEncoding.EncodeToString([]byte(username + ":" + password)),
+	}
+
+	if email, ok := data[secretSyncEmailKey]; ok {
+		auth["email"] = string(email)
+	}
+
+	config, err := json.Marshal(map[string]any{
+		"auths": map[string]any{string(data[secretSyncServerKey]): auth},
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, key := range []string{secretSyncServerKey, secretSyncUsernameKey, secretSyncPasswordKey, secretSyncEmailKey} {
+		delete(data, key)
+	}
+
+	data[corev1.DockerConfigJsonKey] = config
+
+	return nil
+}
+
+// Builds the .dockercfg key from the registries of a .dockerconfigjson key.
+func toDockercfg(data map[string][]byte) error {
+	dockerconfigjson, ok := data[corev1.DockerConfigJsonKey]
+	if !ok {
+		return fmt.Errorf("%s Secrets require the %s or %s key", corev1.SecretTypeDockercfg, corev1.DockerConfigKey, corev1.DockerConfigJsonKey)
+	}
+
+	config, err := decodeDockerConfigJSON(dockerconfigjson)
+	if err != nil {
+		return err
+	}
+
+	auths, ok := config["auths"].(map[string]any)
+	if !ok {
+		auths = map[string]any{}
+	}
+
+	dockercfg, err := json.Marshal(auths)
+	if err != nil {
+		return err
+	}
+
+	delete(data, corev1.DockerConfigJsonKey)
+	data[corev1.DockerConfigKey] = dockercfg
+
+	return nil
+}
+
+func requireSecretKeys(secretType corev1.SecretType, data map[string][]byte, keys ...string) error {
+	for _, key := range keys {
+		if _, ok := data[key]; !ok {
+			return fmt.Errorf("%s Secrets require the %s key", secretType, key)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,337 @@
+// Copyright 2020-2026 Project Capsule Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package resources
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	tpl "github.com/projectcapsule/capsule/pkg/template"
+	"github.com/projectcapsule/capsule/pkg/tenant"
+)
+
+func TestPickSecretKeys(t *testing.T) {
+	t.Parallel()
+
+	data := map[string][]byte{"user": []byte("admin"), "pass": []byte("secret")}
+
+	tests := []struct {
+		name    string
+		keys    []capsulev1beta2.SecretSyncKey
+		want    map[string][]byte
+		wantErr bool
+	}{
+		{
+			name: "all keys",
+			want: data,
+		},
+		{
+			name: "selected and renamed keys",
+			keys: []capsulev1beta2.SecretSyncKey{{Key: "user", As: "username"}, {Key: "pass"}},
+			want: map[string][]byte{"username": []byte("admin"), "pass": []byte("secret")},
+		},
+		{
+			name: "optional missing key",
+			keys: []capsulev1beta2.SecretSyncKey{{Key: "user"}, {Key: "token", Optional: true}},
+			want: map[string][]byte{"user": []byte("admin")},
+		},
+		{
+			name:    "missing key",
+			keys:    []capsulev1beta2.SecretSyncKey{{Key: "token"}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := pickSecretKeys(data, tt.keys)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, wantErr %t", err, tt.wantErr)
+			}
+
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMergeSecretData(t *testing.T) {
+	t.Parallel()
+
+	dst := map[string][]byte{
+		"a":                        []byte("1"),
+		corev1.DockerConfigJsonKey: []byte(`{"auths":{"one.io":{"auth":"b25l"}}}`),
+	}
+
+	src := map[string][]byte{
+		"a":                        []byte("2"),
+		"b":                        []byte("3"),
+		corev1.DockerConfigJsonKey: []byte(`{"auths":{"two.io":{"auth":"dHdv"}}}`),
+	}
+
+	if err := mergeSecretData(dst, src); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if string(dst["a"]) != "2" || string(dst["b"]) != "3" {
+		t.Fatalf("expected later sources to override keys, got %v", dst)
+	}
+
+	config := map[string]map[string]any{}
+	if err := json.Unmarshal(dst[corev1.DockerConfigJsonKey], &config); err != nil {
+		t.Fatalf("invalid merged config: %v", err)
+	}
+
+	if len(config["auths"]) != 2 {
+		t.Fatalf("expected the registries to be merged, got %v", config["auths"])
+	}
+
+	if err := mergeSecretData(dst, map[string][]byte{corev1.DockerConfigJsonKey: []byte("{")}); err == nil {
+		t.Fatal("expected error for invalid config, got nil")
+	}
+}
+
+func TestConvertSecretData(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		secretType corev1.SecretType
+		data       map[string][]byte
+		wantKeys   []string
+		wantErr    string
+	}{
+		{
+			name:       "opaque keeps keys",
+			secretType: corev1.SecretTypeOpaque,
+			data:       map[string][]byte{"server": []byte("registry.io")},
+			wantKeys:   []string{"server"},
+		},
+		{
+			name:       "dockerconfigjson from credentials",
+			secretType: corev1.SecretTypeDockerConfigJson,
+			data: map[string][]byte{
+				"server":   []byte("registry.io"),
+				"username": []byte("robot"),
+				"password": []byte("token"),
+				"email":    []byte("robot@registry.io"),
+			},
+			wantKeys: []string{corev1.DockerConfigJsonKey},
+		},
+		{
+			name:       "dockerconfigjson from dockercfg",
+			secretType: corev1.SecretTypeDockerConfigJson,
+			data:       map[string][]byte{corev1.DockerConfigKey: []byte(`{"registry.io":{"auth":"cm9ib3Q6dG9rZW4="}}`)},
+			wantKeys:   []string{corev1.DockerConfigJsonKey},
+		},
+		{
+			name:       "dockerconfigjson missing credentials",
+			secretType: corev1.SecretTypeDockerConfigJson,
+			data:       map[string][]byte{"server": []byte("registry.io")},
+			wantErr:    "require the username key",
+		},
+		{
+			name:       "dockercfg from dockerconfigjson",
+			secretType: corev1.SecretTypeDockercfg,
+			data:       map[string][]byte{corev1.DockerConfigJsonKey: []byte(`{"auths":{"registry.io":{"auth":"cm9ib3Q6dG9rZW4="}}}`)},
+			wantKeys:   []string{corev1.DockerConfigKey},
+		},
+		{
+			name:       "tls missing key",
+			secretType: corev1.SecretTypeTLS,
+			data:       map[string][]byte{corev1.TLSCertKey: []byte("cert")},
+			wantErr:    "require the tls.key key",
+		},
+		{
+			name:       "basic auth",
+			secretType: corev1.SecretTypeBasicAuth,
+			data:       map[string][]byte{corev1.BasicAuthUsernameKey: []byte("admin")},
+			wantKeys:   []string{corev1.BasicAuthUsernameKey},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := convertSecretData(tt.secretType, tt.data)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if keys := sets.KeySet(got); !keys.Equal(sets.New(tt.wantKeys...)) {
+				t.Fatalf("keys = %v, want %v", sets.List(keys), tt.wantKeys)
+			}
+		})
+	}
+}
+
+func TestConvertSecretData_DockerConfigJSONAuth(t *testing.T) {
+	t.Parallel()
+
+	data, err := convertSecretData(corev1.SecretTypeDockerConfigJson, map[string][]byte{
+		"server":   []byte("registry.io"),
+		"username": []byte("robot"),
+		"password": []byte("token"),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	config := struct {
+		Auths map[string]struct {
+			Username string `json:"username"`
+			Password string `json:"password"`
+			Auth     string `json:"auth"`
+		} `json:"auths"`
+	}{}
+
+	if err := json.Unmarshal(data[corev1.DockerConfigJsonKey], &config); err != nil {
+		t.Fatalf("invalid config: %v", err)
+	}
+
+	auth := config.Auths["registry.io"]
+	if auth.Username != "robot" || auth.Password != "token" || auth.Auth != base64.StdEncoding.EncodeToString([]byte("robot:token")) {
+		t.Fatalf("unexpected auth %+v", auth)
+	}
+}
+
+func TestCollectorHandleSecretSyncItem(t *testing.T) {
+	t.Parallel()
+
+	c := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "registry", Namespace: "solar-infra"},
+			Data: map[string][]byte{
+				"host":  []byte("registry.io"),
+				"user":  []byte("robot"),
+				"token": []byte("secret"),
+			},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "registry", Namespace: "wind-infra"},
+			Data:       map[string][]byte{"token": []byte("other")},
+		},
+	).Build()
+
+	collector := NewCollector(nil, nil)
+
+	tnt := &capsulev1beta2.Tenant{ObjectMeta: metav1.ObjectMeta{Name: "solar"}}
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "solar-prod"}}
+
+	opts := CollectorOptions{
+		Iterator:            CollectorIteratorOptions{FastContext: tenant.FastContextForTenantAndNamespace(tnt, ns)},
+		ValidatorNamespaces: tpl.NewNamespaceValidator(false, sets.New("solar-infra", "solar-prod")),
+	}
+
+	pullSecret := capsulev1beta2.SecretSyncItemSpec{
+		Name: "{{tenant.name}}-pull",
+		Type: corev1.SecretTypeDockerConfigJson,
+		Sources: []capsulev1beta2.SecretSyncSource{{
+			Name:      "registry",
+			Namespace: "{{tenant.name}}-infra",
+			Keys: []capsulev1beta2.SecretSyncKey{
+				{Key: "host", As: "server"},
+				{Key: "user", As: "username"},
+				{Key: "token", As: "password"},
+			},
+		}},
+	}
+
+	t.Run("converts the picked keys", func(t *testing.T) {
+		t.Parallel()
+
+		obj, err := collector.handleSecretSyncItem(context.Background(), c, opts, pullSecret, ns)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if obj.GetName() != "solar-pull" || obj.GetNamespace() != "solar-prod" {
+			t.Fatalf("unexpected identity %s/%s", obj.GetNamespace(), obj.GetName())
+		}
+
+		if secretType, _, _ := unstructured.NestedString(obj.Object, "type"); secretType != string(corev1.SecretTypeDockerConfigJson) {
+			t.Fatalf("unexpected type %q", secretType)
+		}
+
+		data, _, _ := unstructured.NestedStringMap(obj.Object, "data")
+		if _, ok := data[corev1.DockerConfigJsonKey]; !ok || len(data) != 1 {
+			t.Fatalf("expected a single %s key, got %v", corev1.DockerConfigJsonKey, data)
+		}
+	})
+
+	t.Run("rejects sources outside of the allowed namespaces", func(t *testing.T) {
+		t.Parallel()
+
+		item := *pullSecret.DeepCopy()
+		item.Sources[0].Namespace = "wind-infra"
+
+		if _, err := collector.handleSecretSyncItem(context.Background(), c, opts, item, ns); err == nil || !strings.Contains(err.Error(), "cross-namespace selection is not allowed") {
+			t.Fatalf("expected namespace validation error, got %v", err)
+		}
+	})
+
+	t.Run("skips missing optional sources", func(t *testing.T) {
+		t.Parallel()
+
+		item := capsulev1beta2.SecretSyncItemSpec{
+			Name:    "copy",
+			Sources: []capsulev1beta2.SecretSyncSource{{Name: "missing", Optional: true}},
+		}
+
+		obj, err := collector.handleSecretSyncItem(context.Background(), c, opts, item, ns)
+		if err != nil || obj != nil {
+			t.Fatalf("expected no Secret and no error, got %v, %v", obj, err)
+		}
+	})
+
+	t.Run("fails on missing sources", func(t *testing.T) {
+		t.Parallel()
+
+		item := capsulev1beta2.SecretSyncItemSpec{
+			Name:    "copy",
+			Sources: []capsulev1beta2.SecretSyncSource{{Name: "missing"}},
+		}
+
+		if _, err := collector.handleSecretSyncItem(context.Background(), c, opts, item, ns); err == nil {
+			t.Fatal("expected error, got nil")
+		}
+	})
+
+	t.Run("requires a source namespace without target", func(t *testing.T) {
+		t.Parallel()
+
+		item := capsulev1beta2.SecretSyncItemSpec{
+			Name:    "copy",
+			Sources: []capsulev1beta2.SecretSyncSource{{Name: "registry"}},
+		}
+
+		if _, err := collector.handleSecretSyncItem(context.Background(), c, opts, item, nil); err == nil || !strings.Contains(err.Error(), "is required") {
+			t.Fatalf("expected namespace required error, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,94 @@
+// Copyright 2020-2026 Project Capsule Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package rulestatus
+
+import (
+	"context"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	"github.com/projectcapsule/capsule/pkg/api/meta"
+	"github.com/projectcapsule/capsule/pkg/api/rules"
+	"github.com/projectcapsule/capsule/pkg/ruleengine"
+	"github.com/projectcapsule/capsule/pkg/runtime/events"
+)
+
+// reconcileAudit evaluates the existing objects of the namespace against the
+// enforced rules. Objects matching audit rules, or which admission would deny,
+// are reported. This covers objects created before a rule existed.
+func (r Manager) reconcileAudit(ctx context.Context, instance *capsulev1beta2.RuleStatus, bodies []*rules.NamespaceRuleBodyNamespace) error {
+	if r.AuditInterval <= 0 || len(bodies) == 0 {
+		instance.Status.Audit = nil
+		instance.Status.Conditions.RemoveConditionByType(meta.AuditCompliantCondition)
+
+		return nil
+	}
+
+	if evaluationCurrent(instance.Status.Audit, instance, r.AuditInterval, time.Now()) {
+		return nil
+	}
+
+	targets, err := evaluationTargets(r.RESTMapper, bodies)
+	if err != nil {
+		return err
+	}
+
+	scan, err := r.newObjectScan(ctx, instance.GetNamespace())
+	if err != nil {
+		return err
+	}
+
+	enforceBodies := ruleengine.EnforceBodiesFromNamespaceRules(bodies)
+
+	status, err := scan.run(ctx, targets, auditViolations(scan.evaluator, enforceBodies))
+	if err != nil {
+		return err
+	}
+
+	status.ObservedGeneration = instance.GetGeneration()
+	status.NotEvaluable = scan.evaluator.NotEvaluable(enforceBodies)
+
+	instance.Status.Audit = status
+	instance.Status.Conditions.UpdateConditionByType(evaluationCondition(
+		meta.AuditCompliantCondition,
+		instance,
+		status,
+		"%d violations found in %d objects",
+	))
+
+	return nil
+}
+
+func auditViolations(
+	evaluator ruleengine.ObjectEvaluator,
+	enforceBodies []*rules.NamespaceRuleEnforceBody,
+) objectViolations {
+	return func(
+		ctx context.Context,
+		obj *unstructured.Unstructured,
+		tnt *capsulev1beta2.Tenant,
+	) ([]capsulev1beta2.RuleViolation, error) {
+		evaluation, err := evaluator.Evaluate(ctx, obj, tnt, enforceBodies)
+		if err != nil || evaluation == nil {
+			return nil, err
+		}
+
+		violations := make([]capsulev1beta2.RuleViolation, 0, len(evaluation.Audits)+1)
+
+		for _, audit := range evaluation.Audits {
+			violation := ruleViolation(obj, rules.ActionTypeAudit, audit)
+			violation.Reason = events.ReasonNamespaceRuleAudit
+
+			violations = append(violations, violation)
+		}
+
+		if evaluation.Blocking != nil {
+			violations = append(violations, ruleViolation(obj, rules.ActionTypeDeny, evaluation.Blocking))
+		}
+
+		return violations, nil
+	}
+}
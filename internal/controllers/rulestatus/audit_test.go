@@ -0,0 +1,175 @@
+// Copyright 2020-2026 Project Capsule Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package rulestatus
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	"github.com/projectcapsule/capsule/pkg/api/meta"
+	"github.com/projectcapsule/capsule/pkg/api/rules"
+	"github.com/projectcapsule/capsule/pkg/ruleengine"
+	"github.com/projectcapsule/capsule/pkg/runtime/events"
+)
+
+type auditTestEvaluator struct {
+	evaluation *ruleengine.Evaluation
+}
+
+func (auditTestEvaluator) Supports(schema.GroupVersionKind) bool { return true }
+
+func (auditTestEvaluator) NotEvaluable([]*rules.NamespaceRuleEnforceBody) []string { return nil }
+
+func (e auditTestEvaluator) Evaluate(
+	context.Context,
+	*unstructured.Unstructured,
+	*capsulev1beta2.Tenant,
+	[]*rules.NamespaceRuleEnforceBody,
+) (*ruleengine.Evaluation, error) {
+	return e.evaluation, nil
+}
+
+func TestAuditViolations(t *testing.T) {
+	t.Parallel()
+
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion("v1")
+	obj.SetKind("ConfigMap")
+	obj.SetName("settings")
+
+	tests := []struct {
+		name       string
+		evaluation *ruleengine.Evaluation
+		want       []capsulev1beta2.RuleViolation
+	}{
+		{
+			name:       "compliant object",
+			evaluation: &ruleengine.Evaluation{},
+			want:       []capsulev1beta2.RuleViolation{},
+		},
+		{
+			name: "audits and denial are reported",
+			evaluation: &ruleengine.Evaluation{
+				Audits: []*ruleengine.Decision{{EventReason: "ForbiddenCELRule", Message: "audited"}},
+				Blocking: &ruleengine.Decision{
+					EventReason: "Forbidden",
+					Message:     "denied",
+				},
+			},
+			want: []capsulev1beta2.RuleViolation{
+				{
+					APIVersion: "v1",
+					Kind:       "ConfigMap",
+					Name:       "settings",
+					Action:     rules.ActionTypeAudit,
+					Reason:     events.ReasonNamespaceRuleAudit,
+					Message:    "audited",
+				},
+				{
+					APIVersion: "v1",
+					Kind:       "ConfigMap",
+					Name:       "settings",
+					Action:     rules.ActionTypeDeny,
+					Reason:     "Forbidden",
+					Message:    "denied",
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := auditViolations(auditTestEvaluator{evaluation: tt.evaluation}, nil)(context.Background(), obj, nil)
+			if err != nil {
+				t.Fatalf("auditViolations() error = %v", err)
+			}
+
+			if len(got) != len(tt.want) {
+				t.Fatalf("auditViolations() = %#v, want %#v", got, tt.want)
+			}
+
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("violation %d = %#v, want %#v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestReconcileAuditDisabledClearsStatus(t *testing.T) {
+	t.Parallel()
+
+	instance := &capsulev1beta2.RuleStatus{
+		Status: capsulev1beta2.RuleStatusStatus{
+			Audit: &capsulev1beta2.RuleEvaluationStatus{ViolationCount: 1},
+			Conditions: meta.ConditionList{
+				{Type: meta.AuditCompliantCondition, Status: metav1.ConditionFalse},
+			},
+		},
+	}
+
+	err := (Manager{}).reconcileAudit(context.Background(), instance, []*rules.NamespaceRuleBodyNamespace{
+		{Enforce: &rules.NamespaceRuleEnforceBody{Action: rules.ActionTypeDeny}},
+	})
+	if err != nil {
+		t.Fatalf("reconcileAudit() error = %v", err)
+	}
+
+	if instance.Status.Audit != nil {
+		t.Fatalf("audit status = %#v, want nil", instance.Status.Audit)
+	}
+
+	if instance.Status.Conditions.GetConditionByType(meta.AuditCompliantCondition) != nil {
+		t.Fatalf("audit condition was not removed")
+	}
+}
+
+func TestEvaluationRequeueInterval(t *testing.T) {
+	t.Parallel()
+
+	enforced := []*rules.NamespaceRuleBodyNamespace{
+		{Enforce: &rules.NamespaceRuleEnforceBody{Action: rules.ActionTypeDeny}},
+	}
+	dryRun := []*rules.NamespaceRuleBodyNamespace{
+		{DryRun: true, Enforce: &rules.NamespaceRuleEnforceBody{Action: rules.ActionTypeDeny}},
+	}
+
+	tests := []struct {
+		name     string
+		interval time.Duration
+		spec     []*rules.NamespaceRuleBodyNamespace
+		status   []*rules.NamespaceRuleBodyNamespace
+		want     time.Duration
+	}{
+		{name: "no rules", interval: time.Minute},
+		{name: "audit disabled", status: enforced},
+		{name: "audit", interval: time.Minute, spec: enforced, status: enforced, want: time.Minute},
+		{name: "dry-run", spec: dryRun, want: dryRunRequeueInterval},
+		{name: "shorter interval wins", interval: time.Hour, spec: dryRun, status: enforced, want: dryRunRequeueInterval},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			instance := &capsulev1beta2.RuleStatus{
+				Spec:   tt.spec,
+				Status: capsulev1beta2.RuleStatusStatus{Rules: tt.status},
+			}
+
+			if got := (Manager{AuditInterval: tt.interval}).evaluationRequeueInterval(instance); got != tt.want {
+				t.Fatalf("evaluationRequeueInterval() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
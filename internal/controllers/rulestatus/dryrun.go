@@ -0,0 +1,114 @@
+// Copyright 2020-2026 Project Capsule Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package rulestatus
+
+import (
+	"context"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	"github.com/projectcapsule/capsule/pkg/api/meta"
+	"github.com/projectcapsule/capsule/pkg/api/rules"
+	"github.com/projectcapsule/capsule/pkg/ruleengine"
+)
+
+// Existing objects change without touching the RuleStatus, dry-run rules
+// are therefore evaluated again periodically. Within the interval the last
+// evaluation is kept until the rules change.
+const dryRunRequeueInterval = 10 * time.Minute
+
+// reconcileDryRun evaluates the existing objects of the namespace against the
+// rules marked as dry-run. An object is reported when enforcing the dry-run
+// rules would deny it while the currently enforced rules admit it.
+func (r Manager) reconcileDryRun(ctx context.Context, instance *capsulev1beta2.RuleStatus, bodies []*rules.NamespaceRuleBodyNamespace) error {
+	if !ruleengine.HasDryRunNamespaceRules(bodies) {
+		instance.Status.DryRun = nil
+		instance.Status.Conditions.RemoveConditionByType(meta.DryRunCompliantCondition)
+
+		return nil
+	}
+
+	if evaluationCurrent(instance.Status.DryRun, instance, dryRunRequeueInterval, time.Now()) {
+		return nil
+	}
+
+	dryRunBodies := make([]*rules.NamespaceRuleBodyNamespace, 0, len(bodies))
+
+	for _, body := range bodies {
+		if body != nil && body.DryRun {
+			dryRunBodies = append(dryRunBodies, body)
+		}
+	}
+
+	targets, err := evaluationTargets(r.RESTMapper, dryRunBodies)
+	if err != nil {
+		return err
+	}
+
+	scan, err := r.newObjectScan(ctx, instance.GetNamespace())
+	if err != nil {
+		return err
+	}
+
+	combined := ruleengine.EnforceBodiesFromNamespaceRules(bodies)
+
+	status, err := scan.run(ctx, targets, dryRunViolations(
+		scan.evaluator,
+		ruleengine.EnforceBodiesFromNamespaceRules(ruleengine.EnforcedNamespaceRules(bodies)),
+		combined,
+	))
+	if err != nil {
+		return err
+	}
+
+	status.ObservedGeneration = instance.GetGeneration()
+	status.NotEvaluable = scan.evaluator.NotEvaluable(combined)
+
+	instance.Status.DryRun = status
+	instance.Status.Conditions.UpdateConditionByType(evaluationCondition(
+		meta.DryRunCompliantCondition,
+		instance,
+		status,
+		"%d of %d objects would be denied by dry-run rules",
+	))
+
+	return nil
+}
+
+func dryRunViolations(
+	evaluator ruleengine.ObjectEvaluator,
+	enforced, combined []*rules.NamespaceRuleEnforceBody,
+) objectViolations {
+	return func(
+		ctx context.Context,
+		obj *unstructured.Unstructured,
+		tnt *capsulev1beta2.Tenant,
+	) ([]capsulev1beta2.RuleViolation, error) {
+		withDryRun, err := evaluator.Evaluate(ctx, obj, tnt, combined)
+		if err != nil {
+			return nil, err
+		}
+
+		if withDryRun == nil || withDryRun.Blocking == nil {
+			return nil, nil
+		}
+
+		current, err := evaluator.Evaluate(ctx, obj, tnt, enforced)
+		if err != nil {
+			return nil, err
+		}
+
+		if current != nil && current.Blocking != nil {
+			// Already denied by enforced rules, the dry-run rules do not change
+			// the outcome for this object.
+			return nil, nil
+		}
+
+		return []capsulev1beta2.RuleViolation{
+			ruleViolation(obj, rules.ActionTypeDeny, withDryRun.Blocking),
+		}, nil
+	}
+}
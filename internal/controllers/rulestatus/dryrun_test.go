@@ -0,0 +1,393 @@
+// Copyright 2020-2026 Project Capsule Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package rulestatus
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	k8smeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	"github.com/projectcapsule/capsule/pkg/api/meta"
+	"github.com/projectcapsule/capsule/pkg/api/rules"
+	apiruntime "github.com/projectcapsule/capsule/pkg/api/runtime"
+	"github.com/projectcapsule/capsule/pkg/ruleengine"
+)
+
+// dryRunTestEvaluator blocks every object not named "compliant" when the
+// dry-run rule is part of the evaluated bodies, and objects named "denied"
+// with the enforced rules only.
+type dryRunTestEvaluator struct {
+	dryRun *rules.NamespaceRuleEnforceBody
+}
+
+func (dryRunTestEvaluator) Supports(schema.GroupVersionKind) bool { return true }
+
+func (dryRunTestEvaluator) NotEvaluable([]*rules.NamespaceRuleEnforceBody) []string { return nil }
+
+func (e dryRunTestEvaluator) Evaluate(
+	_ context.Context,
+	obj *unstructured.Unstructured,
+	_ *capsulev1beta2.Tenant,
+	enforceBodies []*rules.NamespaceRuleEnforceBody,
+) (*ruleengine.Evaluation, error) {
+	if obj.GetName() == "broken" {
+		return nil, fmt.Errorf("no such key: spec")
+	}
+
+	blocked := obj.GetName() == "denied"
+
+	for _, body := range enforceBodies {
+		if body == e.dryRun && obj.GetName() != "compliant" {
+			blocked = true
+		}
+	}
+
+	if !blocked {
+		return &ruleengine.Evaluation{}, nil
+	}
+
+	return &ruleengine.Evaluation{Blocking: &ruleengine.Decision{
+		EventReason: "Forbidden",
+		Message:     "object " + obj.GetName() + " is denied",
+	}}, nil
+}
+
+func TestReconcileExcludesDryRunRulesFromRuleStatus(t *testing.T) {
+	t.Parallel()
+
+	instance := &capsulev1beta2.RuleStatus{
+		Spec: []*rules.NamespaceRuleBodyNamespace{
+			{Enforce: &rules.NamespaceRuleEnforceBody{Action: rules.ActionTypeDeny}},
+			{DryRun: true, Enforce: &rules.NamespaceRuleEnforceBody{Action: rules.ActionTypeAllow}},
+		},
+	}
+
+	err := (Manager{}).reconcile(context.Background(), instance)
+	if err == nil || !strings.Contains(err.Error(), "REST mapper is required") {
+		t.Fatalf("reconcile() error = %v, want missing REST mapper error", err)
+	}
+
+	if len(instance.Status.Rules) != 1 || instance.Status.Rules[0].DryRun {
+		t.Fatalf("status rules = %#v, want only the enforced rule", instance.Status.Rules)
+	}
+}
+
+func TestReconcileDryRunClearsStatusWithoutDryRunRules(t *testing.T) {
+	t.Parallel()
+
+	instance := &capsulev1beta2.RuleStatus{
+		Status: capsulev1beta2.RuleStatusStatus{
+			DryRun: &capsulev1beta2.RuleEvaluationStatus{ViolationCount: 1},
+			Conditions: meta.ConditionList{
+				{Type: meta.DryRunCompliantCondition, Status: metav1.ConditionFalse},
+			},
+		},
+	}
+
+	err := (Manager{}).reconcileDryRun(context.Background(), instance, []*rules.NamespaceRuleBodyNamespace{
+		{Enforce: &rules.NamespaceRuleEnforceBody{Action: rules.ActionTypeDeny}},
+	})
+	if err != nil {
+		t.Fatalf("reconcileDryRun() error = %v", err)
+	}
+
+	if instance.Status.DryRun != nil {
+		t.Fatalf("dry-run status = %#v, want nil", instance.Status.DryRun)
+	}
+
+	if instance.Status.Conditions.GetConditionByType(meta.DryRunCompliantCondition) != nil {
+		t.Fatalf("dry-run condition was not removed")
+	}
+}
+
+func TestReconcileDryRunKeepsCurrentEvaluation(t *testing.T) {
+	t.Parallel()
+
+	previous := &capsulev1beta2.RuleEvaluationStatus{
+		LastEvaluationTime: metav1.NewTime(time.Now().Add(-time.Minute)),
+		ObservedGeneration: 2,
+		ViolationCount:     1,
+	}
+
+	instance := &capsulev1beta2.RuleStatus{
+		ObjectMeta: metav1.ObjectMeta{Generation: 2},
+		Status:     capsulev1beta2.RuleStatusStatus{DryRun: previous},
+	}
+
+	// Without a REST mapper any evaluation fails, the current one is kept.
+	err := (Manager{}).reconcileDryRun(context.Background(), instance, []*rules.NamespaceRuleBodyNamespace{
+		{DryRun: true, Enforce: &rules.NamespaceRuleEnforceBody{Action: rules.ActionTypeDeny}},
+	})
+	if err != nil {
+		t.Fatalf("reconcileDryRun() error = %v", err)
+	}
+
+	if instance.Status.DryRun != previous {
+		t.Fatalf("dry-run status = %#v, want the previous evaluation", instance.Status.DryRun)
+	}
+}
+
+func TestEvaluationCurrent(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	activation := metav1.NewTime(now.Add(-time.Minute))
+
+	evaluated := func(ago time.Duration, generation int64) *capsulev1beta2.RuleEvaluationStatus {
+		return &capsulev1beta2.RuleEvaluationStatus{
+			LastEvaluationTime: metav1.NewTime(now.Add(-ago)),
+			ObservedGeneration: generation,
+		}
+	}
+
+	tests := []struct {
+		name     string
+		previous *capsulev1beta2.RuleEvaluationStatus
+		spec     []*rules.NamespaceRuleBodyNamespace
+		want     bool
+	}{
+		{
+			name: "never evaluated",
+		},
+		{
+			name:     "recent evaluation of the current generation",
+			previous: evaluated(5*time.Minute, 2),
+			want:     true,
+		},
+		{
+			name:     "evaluation of a previous generation",
+			previous: evaluated(5*time.Minute, 1),
+		},
+		{
+			name:     "interval elapsed",
+			previous: evaluated(dryRunRequeueInterval, 2),
+		},
+		{
+			name:     "rule activated since the evaluation",
+			previous: evaluated(5*time.Minute, 2),
+			spec: []*rules.NamespaceRuleBodyNamespace{{
+				NotBefore: &activation,
+				Enforce:   &rules.NamespaceRuleEnforceBody{Action: rules.ActionTypeDeny},
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			instance := &capsulev1beta2.RuleStatus{
+				ObjectMeta: metav1.ObjectMeta{Generation: 2},
+				Spec:       tt.spec,
+			}
+
+			if got := evaluationCurrent(tt.previous, instance, dryRunRequeueInterval, now); got != tt.want {
+				t.Fatalf("evaluationCurrent() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if got := nextEvaluation(evaluated(4*time.Minute, 2), dryRunRequeueInterval, now); got != 6*time.Minute {
+		t.Fatalf("nextEvaluation() = %s, want 6m", got)
+	}
+
+	if got := nextEvaluation(nil, dryRunRequeueInterval, now); got != dryRunRequeueInterval {
+		t.Fatalf("nextEvaluation(nil) = %s, want %s", got, dryRunRequeueInterval)
+	}
+}
+
+func TestEvaluationTargets(t *testing.T) {
+	t.Parallel()
+
+	mapper := k8smeta.NewDefaultRESTMapper([]schema.GroupVersion{
+		{Version: "v1"},
+		{Group: "apps", Version: "v1"},
+		{Group: "networking.k8s.io", Version: "v1"},
+	})
+	mapper.Add(schema.GroupVersionKind{Version: "v1", Kind: "Namespace"}, k8smeta.RESTScopeRoot)
+	mapper.Add(schema.GroupVersionKind{Version: "v1", Kind: "Pod"}, k8smeta.RESTScopeNamespace)
+	mapper.Add(schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}, k8smeta.RESTScopeNamespace)
+	mapper.Add(schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}, k8smeta.RESTScopeNamespace)
+	mapper.Add(schema.GroupVersionKind{Group: "networking.k8s.io", Version: "v1", Kind: "Ingress"}, k8smeta.RESTScopeNamespace)
+
+	bodies := []*rules.NamespaceRuleBodyNamespace{
+		{
+			Enforce: &rules.NamespaceRuleEnforceBody{
+				Metadata: []rules.MetadataRule{{
+					VersionKinds: apiruntime.VersionKinds{Kinds: []string{"ConfigMap"}},
+				}},
+			},
+		},
+		{
+			DryRun: true,
+			Enforce: &rules.NamespaceRuleEnforceBody{
+				Workloads: rules.NamespaceRuleEnforceWorkloadsBody{
+					QoSClasses: []corev1.PodQOSClass{corev1.PodQOSGuaranteed},
+				},
+				Ingress: rules.NamespaceRuleEnforceIngressBody{
+					Types: []rules.IngressType{rules.IngressTypeIngress},
+				},
+				Metadata: []rules.MetadataRule{{
+					VersionKinds: apiruntime.VersionKinds{Kinds: []string{"Namespace", "Unknown"}},
+				}},
+				CEL: []rules.CELRule{
+					{VersionKinds: apiruntime.VersionKinds{APIGroups: []string{"apps"}, Kinds: []string{"Deployment"}}},
+					{VersionKinds: apiruntime.VersionKinds{APIGroups: []string{"*"}, Kinds: []string{"*"}}},
+				},
+			},
+		},
+	}
+
+	targets, err := evaluationTargets(mapper, bodies)
+	if err != nil {
+		t.Fatalf("evaluationTargets() error = %v", err)
+	}
+
+	want := []string{
+		"/v1, Resource=configmaps",
+		"/v1, Resource=pods",
+		"apps/v1, Resource=deployments",
+		"networking.k8s.io/v1, Resource=ingresses",
+	}
+
+	got := make([]string, 0, len(targets))
+	for _, target := range targets {
+		got = append(got, target.gvr.String())
+	}
+
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Fatalf("evaluationTargets() = %v, want %v", got, want)
+	}
+}
+
+func TestObjectScanDryRunViolations(t *testing.T) {
+	t.Parallel()
+
+	enforced := &rules.NamespaceRuleEnforceBody{Action: rules.ActionTypeDeny}
+	dryRun := &rules.NamespaceRuleEnforceBody{Action: rules.ActionTypeAllow}
+
+	gvr := schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(
+		runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{gvr: "ConfigMapList"},
+	)
+
+	names := []string{"compliant", "denied", "broken"}
+	for i := range maxReportedViolations + 5 {
+		names = append(names, fmt.Sprintf("violating-%d", i))
+	}
+
+	dynamicClient.PrependReactor("list", "configmaps", func(k8stesting.Action) (bool, runtime.Object, error) {
+		items := &unstructured.UnstructuredList{}
+
+		for _, name := range names {
+			item := unstructured.Unstructured{}
+			item.SetName(name)
+			items.Items = append(items.Items, item)
+		}
+
+		return true, items, nil
+	})
+
+	evaluator := dryRunTestEvaluator{dryRun: dryRun}
+	scan := &objectScan{client: dynamicClient, evaluator: evaluator, namespace: "solar-test"}
+
+	status, err := scan.run(
+		context.Background(),
+		[]managedMetadataTarget{{gvr: gvr, gvk: schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}}},
+		dryRunViolations(
+			evaluator,
+			[]*rules.NamespaceRuleEnforceBody{enforced},
+			[]*rules.NamespaceRuleEnforceBody{enforced, dryRun},
+		),
+	)
+	if err != nil {
+		t.Fatalf("run() error = %v", err)
+	}
+
+	if status.Evaluated != int32(len(names)-1) {
+		t.Fatalf("evaluated = %d, want %d", status.Evaluated, len(names)-1)
+	}
+
+	if status.Failed != 1 || len(status.Failures) != 1 || status.Failures[0] != "ConfigMap broken: no such key: spec" {
+		t.Fatalf("failed = %d %v, want the broken object only", status.Failed, status.Failures)
+	}
+
+	if status.ViolationCount != maxReportedViolations+5 {
+		t.Fatalf("violation count = %d, want %d", status.ViolationCount, maxReportedViolations+5)
+	}
+
+	if len(status.Violations) != maxReportedViolations {
+		t.Fatalf("violations = %d, want %d", len(status.Violations), maxReportedViolations)
+	}
+
+	first := status.Violations[0]
+	if first.Name != "violating-0" || first.Kind != "ConfigMap" || first.APIVersion != "v1" || first.Reason != "Forbidden" ||
+		first.Action != rules.ActionTypeDeny {
+		t.Fatalf("first violation = %#v", first)
+	}
+}
+
+func TestEvaluationCondition(t *testing.T) {
+	t.Parallel()
+
+	instance := &capsulev1beta2.RuleStatus{}
+	message := "%d of %d objects would be denied by dry-run rules"
+
+	compliant := evaluationCondition(
+		meta.DryRunCompliantCondition,
+		instance,
+		&capsulev1beta2.RuleEvaluationStatus{Evaluated: 3},
+		message,
+	)
+	if compliant.Type != meta.DryRunCompliantCondition || compliant.Status != metav1.ConditionTrue ||
+		compliant.Reason != meta.CompliantReason {
+		t.Fatalf("compliant condition = %#v", compliant)
+	}
+
+	violations := evaluationCondition(
+		meta.DryRunCompliantCondition,
+		instance,
+		&capsulev1beta2.RuleEvaluationStatus{Evaluated: 3, ViolationCount: 2},
+		message,
+	)
+	if violations.Status != metav1.ConditionFalse || violations.Reason != meta.ViolationsReason ||
+		violations.Message != "2 of 3 objects would be denied by dry-run rules" {
+		t.Fatalf("violations condition = %#v", violations)
+	}
+
+	failed := evaluationCondition(
+		meta.DryRunCompliantCondition,
+		instance,
+		&capsulev1beta2.RuleEvaluationStatus{Evaluated: 3, Failed: 1},
+		message,
+	)
+	if failed.Status != metav1.ConditionFalse || failed.Reason != meta.FailedReason ||
+		failed.Message != "1 objects could not be evaluated" {
+		t.Fatalf("failed condition = %#v", failed)
+	}
+
+	both := evaluationCondition(
+		meta.DryRunCompliantCondition,
+		instance,
+		&capsulev1beta2.RuleEvaluationStatus{Evaluated: 3, ViolationCount: 2, Failed: 1},
+		message,
+	)
+	if both.Reason != meta.ViolationsReason ||
+		both.Message != "2 of 3 objects would be denied by dry-run rules, 1 objects could not be evaluated" {
+		t.Fatalf("violations and failures condition = %#v", both)
+	}
+}
@@ -0,0 +1,350 @@
+// Copyright 2020-2026 Project Capsule Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package rulestatus
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	k8smeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	"github.com/projectcapsule/capsule/pkg/api/meta"
+	"github.com/projectcapsule/capsule/pkg/api/rules"
+	apiruntime "github.com/projectcapsule/capsule/pkg/api/runtime"
+	"github.com/projectcapsule/capsule/pkg/ruleengine"
+	"github.com/projectcapsule/capsule/pkg/tenant"
+)
+
+// maxReportedViolations bounds the violations listed in the status, the
+// total is always reported.
+const maxReportedViolations = 50
+
+// objectViolations returns the violations of one existing object.
+type objectViolations func(
+	ctx context.Context,
+	obj *unstructured.Unstructured,
+	tnt *capsulev1beta2.Tenant,
+) ([]capsulev1beta2.RuleViolation, error)
+
+// objectScan evaluates the existing objects of a namespace.
+type objectScan struct {
+	client    dynamic.Interface
+	evaluator ruleengine.ObjectEvaluator
+	tenant    *capsulev1beta2.Tenant
+	namespace string
+}
+
+func (r Manager) newObjectScan(ctx context.Context, namespace string) (*objectScan, error) {
+	if r.Evaluator == nil {
+		return nil, fmt.Errorf("object evaluator is required")
+	}
+
+	if r.RESTConfig == nil {
+		return nil, fmt.Errorf("REST config is required")
+	}
+
+	dynamicClient, err := dynamic.NewForConfig(r.RESTConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	tnt, err := tenant.GetTenantByNamespace(ctx, r.Client, namespace)
+	if err != nil {
+		return nil, fmt.Errorf("resolve tenant: %w", err)
+	}
+
+	return &objectScan{
+		client:    dynamicClient,
+		evaluator: r.Evaluator,
+		tenant:    tnt,
+		namespace: namespace,
+	}, nil
+}
+
+func (s *objectScan) run(
+	ctx context.Context,
+	targets []managedMetadataTarget,
+	violations objectViolations,
+) (*capsulev1beta2.RuleEvaluationStatus, error) {
+	status := &capsulev1beta2.RuleEvaluationStatus{
+		LastEvaluationTime: metav1.Now(),
+	}
+
+	for _, target := range targets {
+		if !s.evaluator.Supports(target.gvk) {
+			continue
+		}
+
+		if err := s.runTarget(ctx, target, violations, status); err != nil {
+			return nil, err
+		}
+	}
+
+	return status, nil
+}
+
+func (s *objectScan) runTarget(
+	ctx context.Context,
+	target managedMetadataTarget,
+	violations objectViolations,
+	status *capsulev1beta2.RuleEvaluationStatus,
+) error {
+	continueToken := ""
+
+	for {
+		items, err := s.client.Resource(target.gvr).Namespace(s.namespace).List(ctx, metav1.ListOptions{
+			Limit:    managedMetadataListPageSize,
+			Continue: continueToken,
+		})
+		if err != nil {
+			if isManagedMetadataObjectGone(err) {
+				return nil
+			}
+
+			return fmt.Errorf("list %s in namespace %q: %w", target.gvr.String(), s.namespace, err)
+		}
+
+		for i := range items.Items {
+			obj := &items.Items[i]
+			obj.SetGroupVersionKind(target.gvk)
+
+			found, err := violations(ctx, obj, s.tenant)
+			if err != nil {
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+
+				// One object failing evaluation must not hide the findings
+				// for the others.
+				status.Failed++
+
+				if len(status.Failures) < maxReportedViolations {
+					status.Failures = append(status.Failures, fmt.Sprintf("%s %s: %v", target.gvk.Kind, obj.GetName(), err))
+				}
+
+				continue
+			}
+
+			status.Evaluated++
+			status.ViolationCount += int32(len(found))
+
+			for _, violation := range found {
+				if len(status.Violations) >= maxReportedViolations {
+					break
+				}
+
+				status.Violations = append(status.Violations, violation)
+			}
+		}
+
+		continueToken = items.GetContinue()
+		if continueToken == "" {
+			return nil
+		}
+	}
+}
+
+// evaluationCurrent reports whether the previous evaluation is kept: it was
+// made against the current generation less than interval ago, and no rule
+// entered or left its activation window since. Reconciles triggered by
+// metadata updates therefore don't list the objects of the namespace again.
+func evaluationCurrent(
+	previous *capsulev1beta2.RuleEvaluationStatus,
+	instance *capsulev1beta2.RuleStatus,
+	interval time.Duration,
+	now time.Time,
+) bool {
+	if previous == nil || previous.ObservedGeneration != instance.GetGeneration() {
+		return false
+	}
+
+	last := previous.LastEvaluationTime.Time
+	if now.Sub(last) >= interval {
+		return false
+	}
+
+	change := ruleengine.NextNamespaceRuleActivationChange(instance.Spec, last)
+
+	return change == 0 || last.Add(change).After(now)
+}
+
+// nextEvaluation returns the time left until the previous evaluation is
+// refreshed.
+func nextEvaluation(previous *capsulev1beta2.RuleEvaluationStatus, interval time.Duration, now time.Time) time.Duration {
+	if previous == nil {
+		return interval
+	}
+
+	if left := previous.LastEvaluationTime.Add(interval).Sub(now); left > 0 && left < interval {
+		return left
+	}
+
+	return interval
+}
+
+func ruleViolation(
+	obj *unstructured.Unstructured,
+	action rules.ActionType,
+	decision *ruleengine.Decision,
+) capsulev1beta2.RuleViolation {
+	return capsulev1beta2.RuleViolation{
+		APIVersion: obj.GetAPIVersion(),
+		Kind:       obj.GetKind(),
+		Name:       obj.GetName(),
+		Action:     action,
+		Reason:     decision.EventReason,
+		Message:    decision.Message,
+	}
+}
+
+func evaluationCondition(
+	conditionType string,
+	instance *capsulev1beta2.RuleStatus,
+	status *capsulev1beta2.RuleEvaluationStatus,
+	violationsMessage string,
+) meta.Condition {
+	condition := meta.Condition{
+		Type:               conditionType,
+		Status:             metav1.ConditionTrue,
+		Reason:             meta.CompliantReason,
+		Message:            fmt.Sprintf("%d objects evaluated without violations", status.Evaluated),
+		ObservedGeneration: instance.GetGeneration(),
+		LastTransitionTime: metav1.Now(),
+	}
+
+	if status.ViolationCount > 0 {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = meta.ViolationsReason
+		condition.Message = fmt.Sprintf(violationsMessage, status.ViolationCount, status.Evaluated)
+	}
+
+	if status.Failed > 0 {
+		failed := fmt.Sprintf("%d objects could not be evaluated", status.Failed)
+
+		if status.ViolationCount > 0 {
+			condition.Message += ", " + failed
+		} else {
+			condition.Status = metav1.ConditionFalse
+			condition.Reason = meta.FailedReason
+			condition.Message = failed
+		}
+	}
+
+	return condition
+}
+
+// evaluationTargets resolves the namespaced kinds the given rules apply to.
+// Wildcard selectors and kinds unknown to the cluster are skipped, since
+// their objects cannot be listed.
+func evaluationTargets(
+	mapper k8smeta.RESTMapper,
+	bodies []*rules.NamespaceRuleBodyNamespace,
+) ([]managedMetadataTarget, error) {
+	if mapper == nil {
+		return nil, fmt.Errorf("REST mapper is required")
+	}
+
+	targets := make(map[schema.GroupVersionResource]managedMetadataTarget)
+
+	add := func(apiGroup, kind string) error {
+		apiGroup = strings.TrimSpace(apiGroup)
+		kind = strings.TrimSpace(kind)
+
+		if strings.Contains(apiGroup, apiruntime.WildcardVersionKindMatcher) ||
+			strings.Contains(kind, apiruntime.WildcardVersionKindMatcher) {
+			return nil
+		}
+
+		mapping, err := managedMetadataRESTMapping(mapper, apiGroup, kind)
+		if err != nil {
+			if k8smeta.IsNoMatchError(err) {
+				return nil
+			}
+
+			return fmt.Errorf("resolve target %q/%q: %w", apiGroup, kind, err)
+		}
+
+		if mapping.Scope.Name() != k8smeta.RESTScopeNameNamespace {
+			return nil
+		}
+
+		targets[mapping.Resource] = managedMetadataTarget{
+			gvr: mapping.Resource,
+			gvk: mapping.GroupVersionKind,
+		}
+
+		return nil
+	}
+
+	addVersionKinds := func(selector apiruntime.VersionKinds) error {
+		for _, kind := range selector.Kinds {
+			for _, apiGroup := range selector.StatusAPIGroups() {
+				if err := add(apiGroup, kind); err != nil {
+					return err
+				}
+			}
+		}
+
+		return nil
+	}
+
+	for _, body := range bodies {
+		if body == nil || body.Enforce == nil {
+			continue
+		}
+
+		enforce := body.Enforce
+
+		if !reflect.ValueOf(enforce.Workloads).IsZero() {
+			if err := add(apiruntime.CoreAPIVersion, "Pod"); err != nil {
+				return nil, err
+			}
+		}
+
+		if !reflect.ValueOf(enforce.Services).IsZero() {
+			if err := add(apiruntime.CoreAPIVersion, "Service"); err != nil {
+				return nil, err
+			}
+		}
+
+		for _, ingressType := range enforce.Ingress.Types {
+			gvk := ingressType.GroupVersionKind()
+			if err := add(gvk.GroupVersion().String(), gvk.Kind); err != nil {
+				return nil, err
+			}
+		}
+
+		for _, rule := range enforce.Metadata {
+			if err := addVersionKinds(rule.VersionKinds); err != nil {
+				return nil, err
+			}
+		}
+
+		for _, rule := range enforce.CEL {
+			if err := addVersionKinds(rule.VersionKinds); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	out := make([]managedMetadataTarget, 0, len(targets))
+	for _, target := range targets {
+		out = append(out, target)
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].gvr.String() < out[j].gvr.String()
+	})
+
+	return out, nil
+}
@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"time"
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
@@ -30,6 +31,7 @@ import (
 	caperrors "github.com/projectcapsule/capsule/pkg/api/errors"
 	meta "github.com/projectcapsule/capsule/pkg/api/meta"
 	"github.com/projectcapsule/capsule/pkg/api/rules"
+	"github.com/projectcapsule/capsule/pkg/ruleengine"
 	"github.com/projectcapsule/capsule/pkg/runtime/configuration"
 	"github.com/projectcapsule/capsule/pkg/runtime/predicates"
 )
@@ -45,6 +47,11 @@ type Manager struct {
 	Configuration configuration.Configuration
 	RESTConfig    *rest.Config
 	RESTMapper    k8smeta.RESTMapper
+	// Evaluator evaluates rules against existing objects.
+	Evaluator ruleengine.ObjectEvaluator
+	// AuditInterval is the interval at which existing objects are audited
+	// against the enforced rules. Zero disables the audit.
+	AuditInterval time.Duration
 }
 
 func (r *Manager) SetupWithManager(mgr ctrl.Manager, ctrlConfig utils.ControllerOptions) error {
@@ -104,6 +111,7 @@ func (r Manager) Reconcile(ctx context.Context, request ctrl.Request) (result ct
 		}
 
 		r.Metrics.RecordConditions(instance)
+		r.Metrics.RecordAudit(instance)
 
 		if e := patchHelper.Patch(ctx, instance); e != nil {
 			if apierrors.IsNotFound(e) || apierrors.HasStatusCause(e, corev1.NamespaceTerminatingCause) {
@@ -144,13 +152,15 @@ func (r Manager) Reconcile(ctx context.Context, request ctrl.Request) (result ct
 
 	log.V(4).Info("reconciling completed")
 
-	return ctrl.Result{}, reconcileError
+	result.RequeueAfter = r.evaluationRequeueInterval(instance)
+
+	return result, reconcileError
 }
 
 func (r Manager) reconcile(ctx context.Context, instance *capsulev1beta2.RuleStatus) error {
 	previousRules := instance.Status.Rules
 	hadManagedMetadata := hasManagedMetadata(previousRules)
-	ruleStatus := make([]*rules.NamespaceRuleBodyNamespace, 0, len(instance.Spec))
+	statusRules := make([]*rules.NamespaceRuleBodyNamespace, 0, len(instance.Spec))
 
 	for _, rule := range instance.Spec {
 		if rule == nil || rule.Enforce == nil {
@@ -169,9 +179,16 @@ func (r Manager) reconcile(ctx context.Context, instance *capsulev1beta2.RuleSta
 		}
 
 		statusRule.Enforce = enforce
-		ruleStatus = append(ruleStatus, statusRule)
+		statusRules = append(statusRules, statusRule)
 	}
 
+	// Rules outside of their activation window are neither enforced nor
+	// evaluated, escalated rules are published with their effective action.
+	statusRules = ruleengine.ActiveNamespaceRules(statusRules, time.Now())
+
+	// Dry-run rules are only reported, admission must not enforce them.
+	ruleStatus := ruleengine.EnforcedNamespaceRules(statusRules)
+
 	instance.Status.Rules = ruleStatus
 	//nolint:staticcheck
 	instance.Status.Rule = rules.NamespaceRuleBodyNamespace{}
@@ -186,9 +203,41 @@ func (r Manager) reconcile(ctx context.Context, instance *capsulev1beta2.RuleSta
 		}
 	}
 
+	if err := r.reconcileDryRun(ctx, instance, statusRules); err != nil {
+		return fmt.Errorf("evaluate dry-run rules: %w", err)
+	}
+
+	if err := r.reconcileAudit(ctx, instance, ruleStatus); err != nil {
+		return fmt.Errorf("audit existing objects: %w", err)
+	}
+
 	return nil
 }
 
+// evaluationRequeueInterval returns the interval after which existing objects
+// must be evaluated again or the activation of a rule changes, zero if neither
+// is expected.
+func (r Manager) evaluationRequeueInterval(instance *capsulev1beta2.RuleStatus) (interval time.Duration) {
+	now := time.Now()
+
+	if ruleengine.HasDryRunNamespaceRules(instance.Spec) {
+		interval = nextEvaluation(instance.Status.DryRun, dryRunRequeueInterval, now)
+	}
+
+	if r.AuditInterval > 0 && len(instance.Status.Rules) > 0 {
+		if audit := nextEvaluation(instance.Status.Audit, r.AuditInterval, now); interval == 0 || audit < interval {
+			interval = audit
+		}
+	}
+
+	change := ruleengine.NextNamespaceRuleActivationChange(instance.Spec, now)
+	if change > 0 && (interval == 0 || change < interval) {
+		interval = change
+	}
+
+	return interval
+}
+
 func (r *Manager) publishRulesStatus(ctx context.Context, instance *capsulev1beta2.RuleStatus) error {
 	return retry.RetryOnConflict(retry.DefaultBackoff, func() error {
 		latest := &capsulev1beta2.RuleStatus{}
@@ -6,9 +6,11 @@ package rulestatus
 import (
 	"context"
 	"testing"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
 	"github.com/projectcapsule/capsule/pkg/api/rules"
@@ -68,3 +70,42 @@ func TestRemoveQuotaDefinitionsCleansLegacyStatus(t *testing.T) {
 		t.Fatal("clean status was reported as changed")
 	}
 }
+
+func TestReconcilePublishesEffectiveActionAndRequeuesAtBoundary(t *testing.T) {
+	t.Parallel()
+
+	past := metav1.NewTime(time.Now().Add(-time.Hour))
+	future := metav1.NewTime(time.Now().Add(time.Hour))
+
+	instance := &capsulev1beta2.RuleStatus{
+		Spec: []*rules.NamespaceRuleBodyNamespace{
+			{
+				NotAfter:   &past,
+				EscalateTo: rules.ActionTypeDeny,
+				Enforce:    &rules.NamespaceRuleEnforceBody{Action: rules.ActionTypeAudit},
+			},
+			{
+				NotBefore: &future,
+				Enforce:   &rules.NamespaceRuleEnforceBody{Action: rules.ActionTypeDeny},
+			},
+		},
+	}
+
+	if err := (Manager{}).reconcile(context.Background(), instance); err != nil {
+		t.Fatalf("reconcile() error = %v", err)
+	}
+	if len(instance.Status.Rules) != 1 {
+		t.Fatalf("status rules = %d, want only the active rule", len(instance.Status.Rules))
+	}
+	if got := instance.Status.Rules[0].Enforce.Action; got != rules.ActionTypeDeny {
+		t.Fatalf("status action = %q, want escalated deny", got)
+	}
+	if instance.Spec[0].Enforce.Action != rules.ActionTypeAudit {
+		t.Fatal("reconcile() modified the rule spec")
+	}
+
+	interval := (Manager{}).evaluationRequeueInterval(instance)
+	if interval <= 0 || interval > time.Hour {
+		t.Fatalf("requeue interval = %s, want until the activation boundary", interval)
+	}
+}
@@ -7,55 +7,186 @@ package tenant
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strconv"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
 	"github.com/projectcapsule/capsule/pkg/api/meta"
+	evt "github.com/projectcapsule/capsule/pkg/runtime/events"
+	"github.com/projectcapsule/capsule/pkg/tenant"
+	"github.com/projectcapsule/capsule/pkg/utils"
 )
 
+type networkPolicyItem struct {
+	key  string
+	name string
+	spec networkingv1.NetworkPolicySpec
+	// hash is only set for policies rendered from rules, which are tracked for drift.
+	hash string
+}
+
+type networkPolicyDrift struct {
+	mu       sync.Mutex
+	policies []string
+}
+
+func (d *networkPolicyDrift) add(policy *networkingv1.NetworkPolicy) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.policies = append(d.policies, policy.Namespace+"/"+policy.Name)
+}
+
 // Ensuring all the NetworkPolicies are applied to each Namespace handled by the Tenant.
 //
 
-func (r *Manager) syncNetworkPolicies(ctx context.Context, log logr.Logger, tenant *capsulev1beta2.Tenant) error {
-	if err := r.runGarbageCollection(ctx, tenant, &networkingv1.NetworkPolicy{}); err != nil {
+func (r *Manager) syncNetworkPolicies(ctx context.Context, log logr.Logger, tnt *capsulev1beta2.Tenant) error {
+	if err := r.runGarbageCollection(ctx, tnt, &networkingv1.NetworkPolicy{}); err != nil {
 		return err
 	}
 
 	//nolint:staticcheck
-	keys := make([]string, 0, len(tenant.Spec.NetworkPolicies.Items))
+	common := make([]networkPolicyItem, 0, len(tnt.Spec.NetworkPolicies.Items))
 
 	//nolint:staticcheck
-	for i := range tenant.Spec.NetworkPolicies.Items {
-		keys = append(keys, strconv.Itoa(i))
+	for i, spec := range tnt.Spec.NetworkPolicies.Items {
+		common = append(common, networkPolicyItem{
+			key:  strconv.Itoa(i),
+			name: fmt.Sprintf("capsule-%s-%d", tnt.Name, i),
+			spec: spec,
+		})
 	}
 
-	return runForTenantNamespaces(ctx, tenant, func(ctx context.Context, namespace string) error {
-		return r.syncNetworkPolicy(ctx, log, tenant, namespace, keys)
+	items, hasRules, err := r.collectRuleNetworkPolicies(ctx, tnt, common)
+	if err != nil {
+		return err
+	}
+
+	drift := &networkPolicyDrift{}
+
+	err = runForTenantNamespaces(ctx, tnt, func(ctx context.Context, namespace string) error {
+		return r.syncNetworkPolicy(ctx, log, tnt, namespace, items[namespace], drift)
 	})
+
+	setNetworkPolicyDriftCondition(tnt, hasRules, drift.policies)
+
+	return err
 }
 
-func (r *Manager) syncNetworkPolicy(ctx context.Context, log logr.Logger, tenant *capsulev1beta2.Tenant, namespace string, keys []string) (err error) {
+// collectRuleNetworkPolicies returns the NetworkPolicies for each Tenant namespace,
+// the common ones followed by those rendered from the network rules selecting it.
+func (r *Manager) collectRuleNetworkPolicies(
+	ctx context.Context,
+	tnt *capsulev1beta2.Tenant,
+	common []networkPolicyItem,
+) (map[string][]networkPolicyItem, bool, error) {
+	namespaces := readyTenantNamespaces(tnt)
+
+	items := make(map[string][]networkPolicyItem, len(namespaces))
+	for _, namespace := range namespaces {
+		items[namespace] = slices.Clone(common)
+	}
+
+	hasRules := false
+	nsCache := make(map[string]*corev1.Namespace, len(namespaces))
+
+	for i, rule := range tnt.Spec.Rules {
+		if rule == nil {
+			continue
+		}
+
+		spec, ok := tenant.RuleNetworkPolicySpec(tnt, rule.Network)
+		if !ok {
+			continue
+		}
+
+		hasRules = true
+
+		item := networkPolicyItem{
+			key:  tenant.RuleNetworkPolicyKey(i),
+			name: tenant.RuleNetworkPolicyName(tnt, i),
+			spec: spec,
+			hash: tenant.NetworkPolicySpecHash(spec),
+		}
+
+		for _, namespace := range namespaces {
+			if rule.NamespaceSelector != nil {
+				ns, ok := nsCache[namespace]
+				if !ok {
+					ns = &corev1.Namespace{}
+					if err := r.Get(ctx, client.ObjectKey{Name: namespace}, ns); err != nil {
+						if !apierrors.IsNotFound(err) {
+							return nil, false, fmt.Errorf("get namespace %q for rules[%d]: %w", namespace, i, err)
+						}
+
+						ns = nil
+					}
+
+					nsCache[namespace] = ns
+				}
+
+				if ns == nil {
+					continue
+				}
+
+				matches, err := utils.IsNamespaceSelectedBySelector(ns, rule.NamespaceSelector)
+				if err != nil {
+					return nil, false, fmt.Errorf("invalid namespaceSelector in rules[%d]: %w", i, err)
+				}
+
+				if !matches {
+					continue
+				}
+			}
+
+			items[namespace] = append(items[namespace], item)
+		}
+	}
+
+	return items, hasRules, nil
+}
+
+func (r *Manager) syncNetworkPolicy(
+	ctx context.Context,
+	log logr.Logger,
+	tnt *capsulev1beta2.Tenant,
+	namespace string,
+	items []networkPolicyItem,
+	drift *networkPolicyDrift,
+) (err error) {
+	keys := make([]string, 0, len(items))
+	for _, item := range items {
+		keys = append(keys, item.key)
+	}
+
 	if err = r.pruningResources(ctx, namespace, keys, &networkingv1.NetworkPolicy{}); err != nil {
 		return err
 	}
 
-	//nolint:staticcheck
-	for i, spec := range tenant.Spec.NetworkPolicies.Items {
+	for _, item := range items {
 		target := &networkingv1.NetworkPolicy{
 			ObjectMeta: metav1.ObjectMeta{
-				Name:      fmt.Sprintf("capsule-%s-%d", tenant.Name, i),
+				Name:      item.name,
 				Namespace: namespace,
 			},
 		}
 
-		var result controllerutil.OperationResult
+		var (
+			result  controllerutil.OperationResult
+			drifted bool
+		)
 
 		result, err = controllerutil.CreateOrUpdate(ctx, r.Client, target, func() (err error) {
 			labels := target.GetLabels()
@@ -64,16 +195,21 @@ func (r *Manager) syncNetworkPolicy(ctx context.Context, log logr.Logger, tenant
 			}
 
 			labels[meta.NewManagedByCapsuleLabel] = meta.ValueController
-			labels[meta.NewTenantLabel] = tenant.Name
-			labels[meta.NetworkPolicyLabel] = strconv.Itoa(i)
+			labels[meta.NewTenantLabel] = tnt.Name
+			labels[meta.NetworkPolicyLabel] = item.key
 
 			// Remove Legacy labels
 			delete(labels, meta.TenantLabel)
 
 			target.SetLabels(labels)
-			target.Spec = spec
 
-			return controllerutil.SetControllerReference(tenant, target, r.Scheme())
+			if item.hash != "" {
+				drifted = trackNetworkPolicyDrift(target, item, drift)
+			}
+
+			target.Spec = item.spec
+
+			return controllerutil.SetControllerReference(tnt, target, r.Scheme())
 		})
 		if err != nil {
 			if apierrors.HasStatusCause(err, corev1.NamespaceTerminatingCause) {
@@ -81,7 +217,7 @@ func (r *Manager) syncNetworkPolicy(ctx context.Context, log logr.Logger, tenant
 					"skipping NetworkPolicy sync because namespace is terminating",
 					"name", target.Name,
 					"namespace", target.Namespace,
-					"tenant", tenant.Name,
+					"tenant", tnt.Name,
 				)
 
 				return nil
@@ -90,8 +226,78 @@ func (r *Manager) syncNetworkPolicy(ctx context.Context, log logr.Logger, tenant
 			return err
 		}
 
+		if drifted && r.Recorder != nil {
+			r.Recorder.Eventf(
+				target,
+				tnt,
+				corev1.EventTypeWarning,
+				evt.ReasonDriftDetected,
+				evt.ActionReconciled,
+				"NetworkPolicy %s/%s was modified and has been restored",
+				target.Namespace, target.Name,
+			)
+		}
+
 		log.V(4).Info("NetworkPolicy sync result", "result", result, "name", target.Name, "namespace", target.Namespace)
 	}
 
 	return nil
 }
+
+// trackNetworkPolicyDrift annotates an existing NetworkPolicy whose spec no
+// longer matches the spec Capsule rendered for the same rule, and reports
+// whether the drift was detected now. Restoring the spec does not clear the
+// drift: it stays reported until the annotation is removed or the rule itself
+// changed, as reported by a changed hash.
+func trackNetworkPolicyDrift(target *networkingv1.NetworkPolicy, item networkPolicyItem, drift *networkPolicyDrift) (detected bool) {
+	annotations := target.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+
+	switch {
+	case target.ResourceVersion == "":
+	case annotations[meta.NetworkPolicyHashAnnotation] != item.hash:
+		delete(annotations, meta.DriftDetectedAnnotation)
+	case !equality.Semantic.DeepEqual(target.Spec, item.spec):
+		annotations[meta.DriftDetectedAnnotation] = time.Now().UTC().Format(time.RFC3339)
+		detected = true
+	}
+
+	if _, ok := annotations[meta.DriftDetectedAnnotation]; ok {
+		drift.add(target)
+	}
+
+	annotations[meta.NetworkPolicyHashAnnotation] = item.hash
+
+	target.SetAnnotations(annotations)
+
+	return detected
+}
+
+func setNetworkPolicyDriftCondition(tnt *capsulev1beta2.Tenant, hasRules bool, drifted []string) {
+	if !hasRules {
+		tnt.Status.Conditions.RemoveConditionByType(meta.DriftedCondition)
+
+		return
+	}
+
+	condition := meta.Condition{
+		Type:               meta.DriftedCondition,
+		Status:             metav1.ConditionFalse,
+		Reason:             meta.InSyncReason,
+		Message:            "generated NetworkPolicies are in sync",
+		ObservedGeneration: tnt.GetGeneration(),
+		LastTransitionTime: metav1.Now(),
+	}
+
+	if len(drifted) > 0 {
+		slices.Sort(drifted)
+
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = meta.DriftDetectedReason
+		condition.Message = "generated NetworkPolicies were modified and have been restored: " + strings.Join(drifted, ", ")
+	}
+
+	tnt.Status.Conditions.UpdateConditionByType(condition)
+}
@@ -0,0 +1,204 @@
+// Copyright 2020-2026 Project Capsule Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tenant
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	"github.com/projectcapsule/capsule/pkg/api/meta"
+	"github.com/projectcapsule/capsule/pkg/api/rules"
+	tenantutils "github.com/projectcapsule/capsule/pkg/tenant"
+)
+
+func TestSyncNetworkPoliciesRendersRulesAndReportsDrift(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	if err := corev1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := networkingv1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := capsulev1beta2.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	tnt := &capsulev1beta2.Tenant{
+		ObjectMeta: metav1.ObjectMeta{Name: "tenant-a", UID: types.UID("tenant-uid")},
+		Spec: capsulev1beta2.TenantSpec{Rules: []*rules.NamespaceRuleBodyTenant{{
+			NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "restricted"}},
+			Network: &rules.NamespaceRuleNetworkBody{
+				IngressFromTenant: true,
+				Egress:            []rules.NetworkEgressRule{{CIDR: "10.0.0.0/8"}},
+			},
+		}}},
+		Status: capsulev1beta2.TenantStatus{Spaces: []*capsulev1beta2.TenantStatusNamespaceItem{
+			{Name: "restricted"},
+			{Name: "open"},
+		}},
+	}
+
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		tnt,
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "restricted", Labels: map[string]string{"tier": "restricted"}}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "open"}},
+	).Build()
+	recorder := events.NewFakeRecorder(10)
+	manager := &Manager{Client: cl, reader: cl, Log: logr.Discard(), Recorder: recorder}
+
+	if err := manager.syncNetworkPolicies(context.Background(), logr.Discard(), tnt); err != nil {
+		t.Fatalf("syncNetworkPolicies() error = %v", err)
+	}
+
+	name := tenantutils.RuleNetworkPolicyName(tnt, 0)
+	key := client.ObjectKey{Namespace: "restricted", Name: name}
+
+	generated := &networkingv1.NetworkPolicy{}
+	if err := cl.Get(context.Background(), key, generated); err != nil {
+		t.Fatalf("get generated NetworkPolicy: %v", err)
+	}
+	if generated.Labels[meta.NetworkPolicyLabel] != tenantutils.RuleNetworkPolicyKey(0) {
+		t.Fatalf("network policy label = %q, want %q", generated.Labels[meta.NetworkPolicyLabel], tenantutils.RuleNetworkPolicyKey(0))
+	}
+	if len(generated.Spec.Egress) != 1 || generated.Spec.Egress[0].To[0].IPBlock.CIDR != "10.0.0.0/8" {
+		t.Fatalf("generated egress = %#v", generated.Spec.Egress)
+	}
+
+	err := cl.Get(context.Background(), client.ObjectKey{Namespace: "open", Name: name}, &networkingv1.NetworkPolicy{})
+	if !apierrors.IsNotFound(err) {
+		t.Fatalf("get NetworkPolicy in unselected namespace error = %v, want NotFound", err)
+	}
+
+	condition := tnt.Status.Conditions.GetConditionByType(meta.DriftedCondition)
+	if condition == nil || condition.Status != metav1.ConditionFalse {
+		t.Fatalf("drifted condition = %#v, want False", condition)
+	}
+
+	generated.Spec.Egress = append(generated.Spec.Egress, networkingv1.NetworkPolicyEgressRule{})
+	if err := cl.Update(context.Background(), generated); err != nil {
+		t.Fatalf("modify generated NetworkPolicy: %v", err)
+	}
+
+	if err := manager.syncNetworkPolicies(context.Background(), logr.Discard(), tnt); err != nil {
+		t.Fatalf("syncNetworkPolicies() after drift error = %v", err)
+	}
+
+	restored := &networkingv1.NetworkPolicy{}
+	if err := cl.Get(context.Background(), key, restored); err != nil {
+		t.Fatalf("get restored NetworkPolicy: %v", err)
+	}
+	if len(restored.Spec.Egress) != 1 {
+		t.Fatalf("restored egress = %#v, want the rendered rule only", restored.Spec.Egress)
+	}
+	if _, ok := restored.Annotations[meta.DriftDetectedAnnotation]; !ok {
+		t.Fatal("restored NetworkPolicy is missing the drift annotation")
+	}
+
+	assertDrifted := func(step string, want metav1.ConditionStatus) {
+		t.Helper()
+
+		condition := tnt.Status.Conditions.GetConditionByType(meta.DriftedCondition)
+		if condition == nil || condition.Status != want {
+			t.Fatalf("drifted condition %s = %#v, want %s", step, condition, want)
+		}
+	}
+
+	assertDriftEvents := func(step string, want int) {
+		t.Helper()
+
+		if got := len(recorder.Events); got != want {
+			t.Fatalf("drift events %s = %d, want %d", step, got, want)
+		}
+
+		for range want {
+			if event := <-recorder.Events; !strings.HasPrefix(event, "Warning DriftDetected NetworkPolicy restricted/"+name) {
+				t.Fatalf("drift event %s = %q", step, event)
+			}
+		}
+	}
+
+	assertDrifted("after drift", metav1.ConditionTrue)
+	assertDriftEvents("after drift", 1)
+
+	// Restoring the spec keeps the drift reported.
+	if err := manager.syncNetworkPolicies(context.Background(), logr.Discard(), tnt); err != nil {
+		t.Fatalf("syncNetworkPolicies() after restore error = %v", err)
+	}
+
+	restored = &networkingv1.NetworkPolicy{}
+	if err := cl.Get(context.Background(), key, restored); err != nil {
+		t.Fatalf("get restored NetworkPolicy: %v", err)
+	}
+	if _, ok := restored.Annotations[meta.DriftDetectedAnnotation]; !ok {
+		t.Fatal("drift annotation removed from the restored NetworkPolicy")
+	}
+
+	assertDrifted("after restore", metav1.ConditionTrue)
+	assertDriftEvents("after restore", 0)
+
+	// Removing the annotation acknowledges the drift.
+	delete(restored.Annotations, meta.DriftDetectedAnnotation)
+	if err := cl.Update(context.Background(), restored); err != nil {
+		t.Fatalf("remove drift annotation: %v", err)
+	}
+
+	if err := manager.syncNetworkPolicies(context.Background(), logr.Discard(), tnt); err != nil {
+		t.Fatalf("syncNetworkPolicies() after acknowledgement error = %v", err)
+	}
+
+	assertDrifted("after acknowledgement", metav1.ConditionFalse)
+
+	generated = &networkingv1.NetworkPolicy{}
+	if err := cl.Get(context.Background(), key, generated); err != nil {
+		t.Fatalf("get generated NetworkPolicy: %v", err)
+	}
+
+	generated.Spec.Egress = append(generated.Spec.Egress, networkingv1.NetworkPolicyEgressRule{})
+	if err := cl.Update(context.Background(), generated); err != nil {
+		t.Fatalf("modify generated NetworkPolicy: %v", err)
+	}
+
+	if err := manager.syncNetworkPolicies(context.Background(), logr.Discard(), tnt); err != nil {
+		t.Fatalf("syncNetworkPolicies() after second drift error = %v", err)
+	}
+
+	assertDrifted("after second drift", metav1.ConditionTrue)
+	assertDriftEvents("after second drift", 1)
+
+	tnt.Spec.Rules[0].Network.Egress[0].CIDR = "192.168.0.0/16"
+	if err := manager.syncNetworkPolicies(context.Background(), logr.Discard(), tnt); err != nil {
+		t.Fatalf("syncNetworkPolicies() after rule change error = %v", err)
+	}
+
+	assertDrifted("after rule change", metav1.ConditionFalse)
+	assertDriftEvents("after rule change", 0)
+
+	tnt.Spec.Rules = nil
+	if err := manager.syncNetworkPolicies(context.Background(), logr.Discard(), tnt); err != nil {
+		t.Fatalf("syncNetworkPolicies() after rule removal error = %v", err)
+	}
+
+	err = cl.Get(context.Background(), key, &networkingv1.NetworkPolicy{})
+	if !apierrors.IsNotFound(err) {
+		t.Fatalf("get pruned NetworkPolicy error = %v, want NotFound", err)
+	}
+	if tnt.Status.Conditions.GetConditionByType(meta.DriftedCondition) != nil {
+		t.Fatal("drifted condition still set without network rules")
+	}
+}
@@ -80,7 +80,9 @@ func (r *Manager) updateTenantStatus(ctx context.Context, instance *capsulev1bet
 
 		originalStatus := latest.Status.DeepCopy()
 
+		// The chargeback summary is written by the chargeback controller.
 		latest.Status = instance.Status
+		latest.Status.Chargeback = originalStatus.Chargeback
 		latest.Status.ObservedGeneration = instance.GetGeneration()
 		ensureTenantStatusInitialized(latest)
 
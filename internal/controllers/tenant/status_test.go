@@ -276,3 +276,49 @@ func TestUpdateTenantClassStatusPreservesOwnersAndConditions(t *testing.T) {
 		t.Fatalf("storage classes = %#v, want [fast]", updated.Status.Classes.StorageClasses)
 	}
 }
+
+func TestUpdateTenantStatusPreservesChargeback(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	if err := capsulev1beta2.AddToScheme(scheme); err != nil {
+		t.Fatalf("add Capsule scheme: %v", err)
+	}
+
+	chargeback := &capsulev1beta2.TenantChargebackStatus{
+		Currency: "USD",
+	}
+	stored := &capsulev1beta2.Tenant{
+		ObjectMeta: metav1.ObjectMeta{Name: "tenant", Generation: 1},
+		Status: capsulev1beta2.TenantStatus{
+			State:      capsulev1beta2.TenantStateActive,
+			Chargeback: chargeback,
+		},
+	}
+	cl := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithStatusSubresource(&capsulev1beta2.Tenant{}).
+		WithObjects(stored).
+		Build()
+	manager := &Manager{Client: cl, reader: cl}
+
+	// The instance was read before the chargeback status was written.
+	instance := stored.DeepCopy()
+	instance.Status.Chargeback = nil
+	instance.Status.Size = 3
+
+	if err := manager.updateTenantStatus(context.Background(), instance, nil); err != nil {
+		t.Fatalf("update tenant status: %v", err)
+	}
+
+	updated := &capsulev1beta2.Tenant{}
+	if err := cl.Get(context.Background(), client.ObjectKey{Name: stored.Name}, updated); err != nil {
+		t.Fatalf("get updated tenant: %v", err)
+	}
+	if updated.Status.Size != 3 {
+		t.Fatalf("size = %d, want 3", updated.Status.Size)
+	}
+	if !reflect.DeepEqual(updated.Status.Chargeback, chargeback) {
+		t.Fatalf("chargeback = %#v, want preserved %#v", updated.Status.Chargeback, chargeback)
+	}
+}
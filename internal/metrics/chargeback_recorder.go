@@ -0,0 +1,74 @@
+// Copyright 2020-2026 Project Capsule Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	crtlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	ChargebackTypeAllocated = "allocated"
+	ChargebackTypeUsed      = "used"
+)
+
+type ChargebackRecorder struct {
+	CostCounter   *prometheus.CounterVec
+	ResourceGauge *prometheus.GaugeVec
+}
+
+func MustMakeChargebackRecorder() *ChargebackRecorder {
+	recorder := NewChargebackRecorder()
+	crtlmetrics.Registry.MustRegister(recorder.Collectors()...)
+
+	return recorder
+}
+
+func NewChargebackRecorder() *ChargebackRecorder {
+	return &ChargebackRecorder{
+		CostCounter: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsPrefix,
+			Name:      "tenant_chargeback_cost_total",
+			Help:      "Accumulated costs of the allocated or used resources of a tenant.",
+		}, []string{"tenant", "resource", "type"}),
+		ResourceGauge: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: metricsPrefix,
+			Name:      "tenant_chargeback_resource",
+			Help:      "Allocated or used resources of a tenant at the last chargeback sample.",
+		}, []string{"tenant", "resource", "type"}),
+	}
+}
+
+func (r *ChargebackRecorder) Collectors() []prometheus.Collector {
+	return []prometheus.Collector{
+		r.CostCounter,
+		r.ResourceGauge,
+	}
+}
+
+// Records a sample of the tenant and the costs which were accumulated since the previous sample.
+func (r *ChargebackRecorder) Record(tenant string, kind string, sample corev1.ResourceList, costs corev1.ResourceList) {
+	r.ResourceGauge.DeletePartialMatch(map[string]string{
+		"tenant": tenant,
+		"type":   kind,
+	})
+
+	for name, quantity := range sample {
+		r.ResourceGauge.WithLabelValues(tenant, name.String(), kind).Set(quantity.AsApproximateFloat64())
+	}
+
+	for name, cost := range costs {
+		r.CostCounter.WithLabelValues(tenant, name.String(), kind).Add(cost.AsApproximateFloat64())
+	}
+}
+
+func (r *ChargebackRecorder) DeleteAllMetricsForTenant(tenant string) {
+	r.CostCounter.DeletePartialMatch(map[string]string{
+		"tenant": tenant,
+	})
+	r.ResourceGauge.DeletePartialMatch(map[string]string{
+		"tenant": tenant,
+	})
+}
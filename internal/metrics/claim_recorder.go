@@ -16,6 +16,7 @@ type ClaimRecorder struct {
 	claimConditions *prometheus.GaugeVec
 	claimResources  *prometheus.GaugeVec
 	claimPool       *prometheus.GaugeVec
+	claimExpiry     *prometheus.GaugeVec
 }
 
 func MustMakeClaimRecorder() *ClaimRecorder {
@@ -52,6 +53,14 @@ func NewClaimRecorder() *ClaimRecorder {
 			},
 			[]string{"name", "target_namespace", "resource"},
 		),
+		claimExpiry: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: metricsPrefix,
+				Name:      "claim_expiry_timestamp_seconds",
+				Help:      "The Unix time in seconds at which the claim expires.",
+			},
+			[]string{"name", "target_namespace"},
+		),
 	}
 }
 
@@ -60,13 +69,14 @@ func (r *ClaimRecorder) Collectors() []prometheus.Collector {
 		r.claimConditions,
 		r.claimResources,
 		r.claimPool,
+		r.claimExpiry,
 	}
 }
 
 // RecordCondition records the condition as given for the ref.
 func (r *ClaimRecorder) RecordClaimCondition(claim *capsulev1beta2.ResourcePoolClaim) {
 	// Record Condition Metrics
-	for _, status := range []string{meta.ReadyCondition, meta.ExhaustedCondition, meta.BoundCondition} {
+	for _, status := range []string{meta.ReadyCondition, meta.ExhaustedCondition, meta.BoundCondition, meta.ExpiredCondition} {
 		var value float64
 
 		cond := claim.Status.Conditions.GetConditionByType(status)
@@ -92,13 +102,23 @@ func (r *ClaimRecorder) RecordClaimCondition(claim *capsulev1beta2.ResourcePoolC
 		"target_namespace": claim.Namespace,
 	})
 
-	for resourceName, qt := range claim.Spec.ResourceClaims {
+	for resourceName, qt := range claim.GetResourceClaims() {
 		r.claimResources.WithLabelValues(
 			claim.Name,
 			claim.Namespace,
 			resourceName.String(),
 		).Set(float64(qt.MilliValue()) / 1000)
 	}
+
+	// Record expiry time
+	expiry := claim.GetExpiry()
+	if expiry == nil {
+		r.claimExpiry.DeleteLabelValues(claim.Name, claim.Namespace)
+
+		return
+	}
+
+	r.claimExpiry.WithLabelValues(claim.Name, claim.Namespace).Set(float64(expiry.Unix()))
 }
 
 func (r *ClaimRecorder) DeleteConditionMetricByType(claim string, namespace string, condition string) {
@@ -131,4 +151,8 @@ func (r *ClaimRecorder) DeleteClaimMetric(claim string, namespace string) {
 		"name":             claim,
 		"target_namespace": namespace,
 	})
+	r.claimExpiry.DeletePartialMatch(map[string]string{
+		"name":             claim,
+		"target_namespace": namespace,
+	})
 }
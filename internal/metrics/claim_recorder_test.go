@@ -0,0 +1,36 @@
+// Copyright 2020-2026 Project Capsule Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+)
+
+func TestClaimRecorderTracksExpiryTimestamp(t *testing.T) {
+	t.Parallel()
+
+	recorder := NewClaimRecorder()
+	expiresAt := metav1.NewTime(time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC))
+
+	claim := &capsulev1beta2.ResourcePoolClaim{}
+	claim.Name = "compute"
+	claim.Namespace = "solar-test"
+	claim.Spec.ExpiresAt = &expiresAt
+
+	recorder.RecordClaimCondition(claim)
+
+	assertGauge(t, recorder.claimExpiry, float64(expiresAt.Unix()), "compute", "solar-test")
+
+	claim.Spec.ExpiresAt = nil
+	recorder.RecordClaimCondition(claim)
+
+	if got := metricCount(recorder.claimExpiry); got != 0 {
+		t.Fatalf("claim expiry metric count = %d, want 0", got)
+	}
+}
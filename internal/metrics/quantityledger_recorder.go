@@ -0,0 +1,109 @@
+// Copyright 2020-2026 Project Capsule Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	crtlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	QuantityLedgerDriftUsed     = "used"
+	QuantityLedgerDriftReserved = "reserved"
+
+	QuantityLedgerRepairExpiredReservation = "expired_reservation"
+	QuantityLedgerRepairStalePendingDelete = "stale_pending_delete"
+	QuantityLedgerRepairUsed               = "used"
+	QuantityLedgerRepairReserved           = "reserved"
+)
+
+type QuantityLedgerRecorder struct {
+	DriftGauge      *prometheus.GaugeVec
+	ConsistentGauge *prometheus.GaugeVec
+	RepairCounter   *prometheus.CounterVec
+}
+
+func MustMakeQuantityLedgerRecorder() *QuantityLedgerRecorder {
+	recorder := NewQuantityLedgerRecorder()
+	crtlmetrics.Registry.MustRegister(recorder.Collectors()...)
+
+	return recorder
+}
+
+func NewQuantityLedgerRecorder() *QuantityLedgerRecorder {
+	return &QuantityLedgerRecorder{
+		DriftGauge: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: metricsPrefix,
+			Name:      "quantity_ledger_drift",
+			Help:      "Difference between the used or reserved quantities accounted by a QuantityLedger and the live usage at its last audit.",
+		}, []string{"ledger", "ledger_namespace", "target_kind", "resource", "type"}),
+		ConsistentGauge: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: metricsPrefix,
+			Name:      "quantity_ledger_consistent",
+			Help:      "Whether a QuantityLedger matched the live usage at its last audit (1) or had to be repaired (0).",
+		}, []string{"ledger", "ledger_namespace", "target_kind"}),
+		RepairCounter: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsPrefix,
+			Name:      "quantity_ledger_repairs_total",
+			Help:      "Repairs applied to a QuantityLedger by its audit.",
+		}, []string{"ledger", "ledger_namespace", "target_kind", "reason"}),
+	}
+}
+
+func (r *QuantityLedgerRecorder) Collectors() []prometheus.Collector {
+	return []prometheus.Collector{
+		r.DriftGauge,
+		r.ConsistentGauge,
+		r.RepairCounter,
+	}
+}
+
+// Records the outcome of the audit of a ledger: the drift of the used and reserved quantities
+// against the live usage and whether the ledger was consistent.
+func (r *QuantityLedgerRecorder) RecordAudit(
+	ledger, namespace, kind string,
+	used, reserved corev1.ResourceList,
+	consistent bool,
+) {
+	r.DriftGauge.DeletePartialMatch(map[string]string{
+		"ledger":           ledger,
+		"ledger_namespace": namespace,
+	})
+
+	for name, quantity := range used {
+		r.DriftGauge.WithLabelValues(ledger, namespace, kind, name.String(), QuantityLedgerDriftUsed).Set(quantity.AsApproximateFloat64())
+	}
+
+	for name, quantity := range reserved {
+		r.DriftGauge.WithLabelValues(ledger, namespace, kind, name.String(), QuantityLedgerDriftReserved).Set(quantity.AsApproximateFloat64())
+	}
+
+	value := 0.0
+	if consistent {
+		value = 1
+	}
+
+	r.ConsistentGauge.WithLabelValues(ledger, namespace, kind).Set(value)
+}
+
+// Records repairs applied to a ledger by its audit.
+func (r *QuantityLedgerRecorder) RecordRepair(ledger, namespace, kind, reason string, count int) {
+	if count <= 0 {
+		return
+	}
+
+	r.RepairCounter.WithLabelValues(ledger, namespace, kind, reason).Add(float64(count))
+}
+
+func (r *QuantityLedgerRecorder) DeleteAllMetricsForLedger(ledger, namespace string) {
+	labels := map[string]string{
+		"ledger":           ledger,
+		"ledger_namespace": namespace,
+	}
+
+	r.DriftGauge.DeletePartialMatch(labels)
+	r.ConsistentGauge.DeletePartialMatch(labels)
+	r.RepairCounter.DeletePartialMatch(labels)
+}
@@ -0,0 +1,67 @@
+// Copyright 2020-2026 Project Capsule Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package metrics
+
+import (
+	"testing"
+
+	dto "github.com/prometheus/client_model/go"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestQuantityLedgerRecorderTracksAudit(t *testing.T) {
+	t.Parallel()
+
+	recorder := NewQuantityLedgerRecorder()
+
+	recorder.RecordAudit(
+		"shared",
+		"capsule-system",
+		"GlobalResourceQuota",
+		corev1.ResourceList{corev1.ResourcePods: resource.MustParse("2")},
+		corev1.ResourceList{corev1.ResourcePods: resource.MustParse("-1")},
+		false,
+	)
+	recorder.RecordRepair("shared", "capsule-system", "GlobalResourceQuota", QuantityLedgerRepairExpiredReservation, 2)
+	recorder.RecordRepair("shared", "capsule-system", "GlobalResourceQuota", QuantityLedgerRepairStalePendingDelete, 0)
+
+	assertGauge(t, recorder.DriftGauge, 2, "shared", "capsule-system", "GlobalResourceQuota", "pods", QuantityLedgerDriftUsed)
+	assertGauge(t, recorder.DriftGauge, -1, "shared", "capsule-system", "GlobalResourceQuota", "pods", QuantityLedgerDriftReserved)
+	assertGauge(t, recorder.ConsistentGauge, 0, "shared", "capsule-system", "GlobalResourceQuota")
+
+	counter, err := recorder.RepairCounter.GetMetricWithLabelValues(
+		"shared", "capsule-system", "GlobalResourceQuota", QuantityLedgerRepairExpiredReservation,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	value := &dto.Metric{}
+	if err := counter.Write(value); err != nil {
+		t.Fatal(err)
+	}
+	if got := value.GetCounter().GetValue(); got != 2 {
+		t.Fatalf("expired reservation repairs = %v, want 2", got)
+	}
+	if got := metricCount(recorder.RepairCounter); got != 1 {
+		t.Fatalf("repair metric count = %d, want 1", got)
+	}
+
+	recorder.RecordAudit("shared", "capsule-system", "GlobalResourceQuota", nil, nil, true)
+
+	if got := metricCount(recorder.DriftGauge); got != 0 {
+		t.Fatalf("drift metric count = %d, want 0", got)
+	}
+	assertGauge(t, recorder.ConsistentGauge, 1, "shared", "capsule-system", "GlobalResourceQuota")
+
+	recorder.DeleteAllMetricsForLedger("shared", "capsule-system")
+
+	if got := metricCount(recorder.ConsistentGauge); got != 0 {
+		t.Fatalf("consistent metric count after delete = %d, want 0", got)
+	}
+	if got := metricCount(recorder.RepairCounter); got != 0 {
+		t.Fatalf("repair metric count after delete = %d, want 0", got)
+	}
+}
@@ -15,6 +15,8 @@ import (
 
 type RuleStatusRecorder struct {
 	resourceConditionGauge *prometheus.GaugeVec
+	auditViolationsGauge   *prometheus.GaugeVec
+	auditEvaluatedGauge    *prometheus.GaugeVec
 }
 
 func MustMakeRuleStatusRecorder() *RuleStatusRecorder {
@@ -34,18 +36,41 @@ func NewRuleStatusRecorder() *RuleStatusRecorder {
 			},
 			[]string{"name", "target_namespace", "condition"},
 		),
+		auditViolationsGauge: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: metricsPrefix,
+				Name:      "rulestatus_audit_violations",
+				Help:      "The number of rule violations found by the last audit of existing objects.",
+			},
+			[]string{"name", "target_namespace"},
+		),
+		auditEvaluatedGauge: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: metricsPrefix,
+				Name:      "rulestatus_audit_evaluated_objects",
+				Help:      "The number of existing objects evaluated by the last audit.",
+			},
+			[]string{"name", "target_namespace"},
+		),
 	}
 }
 
 func (r *RuleStatusRecorder) Collectors() []prometheus.Collector {
 	return []prometheus.Collector{
 		r.resourceConditionGauge,
+		r.auditViolationsGauge,
+		r.auditEvaluatedGauge,
 	}
 }
 
 // RecordCondition records the condition as given for the ref.
 func (r *RuleStatusRecorder) RecordConditions(resource *capsulev1beta2.RuleStatus) {
-	for _, status := range []string{meta.ReadyCondition, meta.CordonedCondition} {
+	for _, status := range []string{
+		meta.ReadyCondition,
+		meta.CordonedCondition,
+		meta.DryRunCompliantCondition,
+		meta.AuditCompliantCondition,
+	} {
 		var value float64
 
 		cond := resource.Status.Conditions.GetConditionByType(status)
@@ -63,6 +88,30 @@ func (r *RuleStatusRecorder) RecordConditions(resource *capsulev1beta2.RuleStatu
 	}
 }
 
+// RecordAudit records the result of the last audit for the ref.
+func (r *RuleStatusRecorder) RecordAudit(resource *capsulev1beta2.RuleStatus) {
+	if resource.Status.Audit == nil {
+		r.DeleteAuditMetrics(resource.GetName(), resource.GetNamespace())
+
+		return
+	}
+
+	r.auditViolationsGauge.WithLabelValues(resource.GetName(), resource.GetNamespace()).
+		Set(float64(resource.Status.Audit.ViolationCount))
+	r.auditEvaluatedGauge.WithLabelValues(resource.GetName(), resource.GetNamespace()).
+		Set(float64(resource.Status.Audit.Evaluated))
+}
+
+func (r *RuleStatusRecorder) DeleteAuditMetrics(name string, namespace string) {
+	labels := map[string]string{
+		"name":             name,
+		"target_namespace": namespace,
+	}
+
+	r.auditViolationsGauge.DeletePartialMatch(labels)
+	r.auditEvaluatedGauge.DeletePartialMatch(labels)
+}
+
 func (r *RuleStatusRecorder) DeleteConditionMetrics(name string, namespace string) {
 	r.resourceConditionGauge.DeletePartialMatch(map[string]string{
 		"name":             name,
@@ -81,4 +130,5 @@ func (r *RuleStatusRecorder) DeleteConditionMetricByType(name string, namespace
 // DeleteCondition deletes the condition metrics for the ref.
 func (r *RuleStatusRecorder) DeleteMetrics(resourceName string, resourceNamespace string) {
 	r.DeleteConditionMetrics(resourceName, resourceNamespace)
+	r.DeleteAuditMetrics(resourceName, resourceNamespace)
 }
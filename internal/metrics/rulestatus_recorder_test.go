@@ -0,0 +1,39 @@
+// Copyright 2020-2026 Project Capsule Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package metrics
+
+import (
+	"testing"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+)
+
+func TestRuleStatusRecorderTracksAudit(t *testing.T) {
+	t.Parallel()
+
+	recorder := NewRuleStatusRecorder()
+	instance := &capsulev1beta2.RuleStatus{}
+	instance.Name = "default"
+	instance.Namespace = "solar-test"
+	instance.Status.Audit = &capsulev1beta2.RuleEvaluationStatus{
+		Evaluated:      12,
+		ViolationCount: 3,
+	}
+
+	recorder.RecordAudit(instance)
+
+	assertGauge(t, recorder.auditViolationsGauge, 3, "default", "solar-test")
+	assertGauge(t, recorder.auditEvaluatedGauge, 12, "default", "solar-test")
+
+	instance.Status.Audit = nil
+	recorder.RecordAudit(instance)
+
+	if got := metricCount(recorder.auditViolationsGauge); got != 0 {
+		t.Fatalf("audit violation metric count = %d, want 0", got)
+	}
+
+	if got := metricCount(recorder.auditEvaluatedGauge); got != 0 {
+		t.Fatalf("audit evaluated metric count = %d, want 0", got)
+	}
+}
@@ -17,6 +17,7 @@ import (
 	ad "github.com/projectcapsule/capsule/pkg/runtime/admission"
 	"github.com/projectcapsule/capsule/pkg/runtime/events"
 	"github.com/projectcapsule/capsule/pkg/runtime/handlers"
+	"github.com/projectcapsule/capsule/pkg/runtime/helm"
 )
 
 type validationHandler struct {
@@ -95,6 +96,22 @@ func (h *validationHandler) handle(
 		return ad.Deny(err.Error())
 	}
 
+	if err := validateCharts(config.Spec.Charts); err != nil {
+		return ad.Deny(err.Error())
+	}
+
+	return nil
+}
+
+func validateCharts(config *capsulev1beta2.ChartsConfiguration) error {
+	if config == nil {
+		return nil
+	}
+
+	if _, err := helm.NewAllowList(config.AllowedRepositories...); err != nil {
+		return fmt.Errorf("spec.charts.allowedRepositories is invalid: %w", err)
+	}
+
 	return nil
 }
 
@@ -5,6 +5,7 @@ package customquota
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"reflect"
 	"slices"
@@ -97,6 +98,8 @@ func (h *objectCalculationHandler) OnCreate(
 		var finalResp *admission.Response
 
 		err = retry.OnError(customAdmissionBackoff, apierrors.IsConflict, func() error {
+			var passed []passedThreshold
+
 			matched, err := h.matchAllQuotas(ctx, reader, req, u)
 			if err != nil {
 				finalResp = ad.ErroredResponse(err)
@@ -121,6 +124,25 @@ func (h *objectCalculationHandler) OnCreate(
 				return nil
 			}
 
+			capping, evaluated := splitCappingQuotas(evaluated)
+			if item := exceededCappingQuota(nil, capping); item != nil {
+				finalResp = ad.Denyf(
+					"creating resource exceeds per-object limit for %s %q (requested=%s, limit=%s)",
+					quotaTypeName(item.IsGlobal),
+					item.Name,
+					item.Usage.String(),
+					item.Limit.String(),
+				)
+
+				return nil
+			}
+
+			for _, item := range capping {
+				if p := passedQuotaThreshold(item, item.Usage); p != nil {
+					passed = append(passed, *p)
+				}
+			}
+
 			type appliedReservation struct {
 				LedgerKey     types.NamespacedName
 				ReservationID string
@@ -132,6 +154,11 @@ func (h *objectCalculationHandler) OnCreate(
 				ledgerKey := quantityLedgerKeyForMatchedQuota(item)
 
 				reservation := buildReservation(req, u, item.Usage, item.Usage, item.Key)
+				if item.Window > 0 {
+					// Admissions of windowed quotas are counted until they leave the window.
+					expiresAt := metav1.NewTime(reservation.CreatedAt.Add(item.Window))
+					reservation.ExpiresAt = &expiresAt
+				}
 
 				allowed, effectiveUsed, reserved, err := reserveCreateOnLedger(
 					ctx,
@@ -146,6 +173,18 @@ func (h *objectCalculationHandler) OnCreate(
 						_ = deleteLedgerReservation(ctx, c, reader, a.LedgerKey, a.ReservationID)
 					}
 
+					if errors.Is(err, errWindowAdmissionsExhausted) {
+						finalResp = ad.Denyf(
+							"creating resource exceeds the maximum of %d admissions within the window of %s for %s %q, retry once earlier admissions leave the window",
+							maxQuantityLedgerReservations,
+							item.Window.String(),
+							quotaTypeName(item.IsGlobal),
+							item.Name,
+						)
+
+						return nil
+					}
+
 					return err
 				}
 
@@ -192,9 +231,13 @@ func (h *objectCalculationHandler) OnCreate(
 						ReservationID: reservation.ID,
 					})
 				}
+
+				if p := passedQuotaThreshold(item, effectiveUsed); p != nil {
+					passed = append(passed, *p)
+				}
 			}
 
-			finalResp = nil
+			finalResp = auditPassedThresholds(ctx, recorder, req, &u, passed, dryRun)
 
 			return nil
 		})
@@ -266,6 +309,8 @@ func (h *objectCalculationHandler) OnUpdate(
 		var finalResp *admission.Response
 
 		err = retry.OnError(customAdmissionBackoff, apierrors.IsConflict, func() error {
+			var passed []passedThreshold
+
 			policies, err := loadQuotaPolicySnapshot(ctx, reader, req.Namespace)
 			if err != nil {
 				if statusUpdate {
@@ -311,6 +356,10 @@ func (h *objectCalculationHandler) OnUpdate(
 				return nil
 			}
 
+			// Windowed quotas only count admissions of new objects.
+			oldMatched = withoutWindowedQuotas(oldMatched)
+			newMatched = withoutWindowedQuotas(newMatched)
+
 			oldEvaluated, err := h.evaluateMatchedQuotas(ctx, oldObj, oldMatched)
 			if err != nil {
 				if statusUpdate {
@@ -353,6 +402,35 @@ func (h *objectCalculationHandler) OnUpdate(
 				return nil
 			}
 
+			oldCapping, oldEvaluated := splitCappingQuotas(oldEvaluated)
+			newCapping, newEvaluated := splitCappingQuotas(newEvaluated)
+
+			if item := exceededCappingQuota(oldCapping, newCapping); item != nil && !statusUpdate {
+				finalResp = ad.Denyf(
+					"updating resource exceeds per-object limit for %s %q (requested=%s, limit=%s)",
+					quotaTypeName(item.IsGlobal),
+					item.Name,
+					item.Usage.String(),
+					item.Limit.String(),
+				)
+
+				return nil
+			}
+
+			if !statusUpdate {
+				oldCappingByKey := evaluatedByKey(oldCapping)
+
+				for _, item := range newCapping {
+					if old, ok := oldCappingByKey[item.Key]; ok && item.Usage.Cmp(old.Usage) <= 0 {
+						continue
+					}
+
+					if p := passedQuotaThreshold(item, item.Usage); p != nil {
+						passed = append(passed, *p)
+					}
+				}
+			}
+
 			oldByKey := evaluatedByKey(oldEvaluated)
 			newByKey := evaluatedByKey(newEvaluated)
 
@@ -369,7 +447,7 @@ func (h *objectCalculationHandler) OnUpdate(
 			}
 
 			if !relevantChange {
-				finalResp = nil
+				finalResp = auditPassedThresholds(ctx, recorder, req, &newObj, passed, dryRun)
 
 				return nil
 			}
@@ -530,9 +608,16 @@ func (h *objectCalculationHandler) OnUpdate(
 						PendingDelete: pendingDelete,
 					})
 				}
+
+				// Only updates raising the usage are audited, status updates never are.
+				if hadNew && !statusUpdate && newUsage.Cmp(oldUsage) > 0 {
+					if p := passedQuotaThreshold(base, effectiveUsed); p != nil {
+						passed = append(passed, *p)
+					}
+				}
 			}
 
-			finalResp = nil
+			finalResp = auditPassedThresholds(ctx, recorder, req, &newObj, passed, dryRun)
 
 			return nil
 		})
@@ -875,8 +960,12 @@ func (h *objectCalculationHandler) matchCustomQuotasFromItems(
 				Operation:    target.Operation,
 				Limit:        cq.Spec.Limit.DeepCopy(),
 				Used:         cq.Status.Usage.Used.DeepCopy(),
+				Values:       cq.Status.Usage.Values,
 				IsGlobal:     false,
 				SourceRank:   i,
+				Window:       cq.Spec.GetWindow(),
+				WarnAt:       cq.Spec.GetWarnAt(),
+				SoftLimit:    cq.Spec.GetSoftLimit(),
 			})
 		}
 	}
@@ -976,8 +1065,12 @@ func (h *objectCalculationHandler) matchGlobalCustomQuotasFromItems(
 				Operation:    target.Operation,
 				Limit:        gcq.Spec.Limit.DeepCopy(),
 				Used:         gcq.Status.Usage.Used.DeepCopy(),
+				Values:       gcq.Status.Usage.Values,
 				IsGlobal:     true,
 				SourceRank:   i,
+				Window:       gcq.Spec.GetWindow(),
+				WarnAt:       gcq.Spec.GetWarnAt(),
+				SoftLimit:    gcq.Spec.GetSoftLimit(),
 			})
 		}
 	}
@@ -1027,6 +1120,57 @@ func namespaceTerminating(
 	return ns.DeletionTimestamp != nil, nil
 }
 
+func withoutWindowedQuotas(matched []quota.MatchedQuota) []quota.MatchedQuota {
+	out := make([]quota.MatchedQuota, 0, len(matched))
+
+	for _, mq := range matched {
+		if mq.Window > 0 {
+			continue
+		}
+
+		out = append(out, mq)
+	}
+
+	return out
+}
+
+// Capping quotas limit each object on its own, they are enforced without the QuantityLedger.
+func splitCappingQuotas(evaluated []evaluatedQuota) (capping []evaluatedQuota, accounted []evaluatedQuota) {
+	accounted = make([]evaluatedQuota, 0, len(evaluated))
+
+	for _, item := range evaluated {
+		if item.Operation.Capping() {
+			capping = append(capping, item)
+
+			continue
+		}
+
+		accounted = append(accounted, item)
+	}
+
+	return capping, accounted
+}
+
+// Gets the first capping quota exceeded by the object. Values which did not grow are not rejected,
+// so objects exceeding the limit before the quota was created can still be updated.
+func exceededCappingQuota(oldCapping, newCapping []evaluatedQuota) *evaluatedQuota {
+	oldByKey := evaluatedByKey(oldCapping)
+
+	for i, item := range newCapping {
+		if item.Usage.Cmp(item.Limit) <= 0 {
+			continue
+		}
+
+		if old, ok := oldByKey[item.Key]; ok && item.Usage.Cmp(old.Usage) <= 0 {
+			continue
+		}
+
+		return &newCapping[i]
+	}
+
+	return nil
+}
+
 func quotaTypeName(global bool) string {
 	if global {
 		return "GlobalCustomQuota"
@@ -1039,6 +1183,9 @@ type evaluatedQuota struct {
 	quota.MatchedQuota
 
 	Usage resource.Quantity
+
+	// Values of the object evaluated by distinct sources.
+	values []string
 }
 
 func (h *objectCalculationHandler) evaluateMatchedQuotas(
@@ -1051,8 +1198,8 @@ func (h *objectCalculationHandler) evaluateMatchedQuotas(
 	usageByExpression := make(map[string]resource.Quantity, len(matched))
 
 	for _, mq := range matched {
-		// count does not use a calculation expression
-		if mq.Operation == quota.OpCount {
+		// count does not use a calculation expression, distinct values are evaluated per quota
+		if mq.Operation == quota.OpCount || mq.Operation == quota.OpDistinct {
 			continue
 		}
 
@@ -1061,20 +1208,7 @@ func (h *objectCalculationHandler) evaluateMatchedQuotas(
 			continue
 		}
 
-		var (
-			usage resource.Quantity
-			err   error
-		)
-
-		switch {
-		case mq.CompiledCEL != nil:
-			usage, err = mq.CompiledCEL.EvaluateQuantity(ctx, u)
-		case mq.CompiledPath != nil:
-			usage, err = quota.ParseQuantityFromUnstructured(u, mq.CompiledPath)
-		default:
-			err = fmt.Errorf("compiled usage expression is missing")
-		}
-
+		usage, err := quota.EvaluateQuantity(ctx, u, mq.Operation, mq.CompiledPath, mq.CompiledCEL)
 		if err != nil {
 			return nil, fmt.Errorf(
 				"%s %q source path %q cel %q op %q did not resolve to a valid quantity: %w",
@@ -1130,6 +1264,37 @@ func (h *objectCalculationHandler) evaluateMatchedQuotas(
 		case quota.OpAdd:
 			usage = usageByExpression[matchedQuotaExpressionKey(mq)].DeepCopy()
 
+		case quota.OpMax, quota.OpPerObject:
+			// Capping quotas limit the largest value of the object.
+			usage = usageByExpression[matchedQuotaExpressionKey(mq)].DeepCopy()
+			if usage.Cmp(ev.Usage) > 0 {
+				ev.Usage = usage
+			}
+
+			byKey[mq.Key] = ev
+
+			continue
+
+		case quota.OpDistinct:
+			values, err := quota.EvaluateDistinctValues(u, mq.CompiledPath)
+			if err != nil {
+				return nil, fmt.Errorf(
+					"%s %q source path %q op %q did not resolve to distinct values: %w",
+					quotaTypeName(mq.IsGlobal),
+					mq.Name,
+					mq.Path,
+					mq.Operation,
+					err,
+				)
+			}
+
+			// The usage of distinct quotas are the values not yet counted by the quota.
+			ev.values = append(ev.values, values...)
+			ev.Usage = *resource.NewQuantity(int64(len(quota.UnknownValues(mq.Values, ev.values))), resource.DecimalSI)
+			byKey[mq.Key] = ev
+
+			continue
+
 		default:
 			return nil, fmt.Errorf("unsupported quota operation %q for key %q", mq.Operation, mq.Key)
 		}
@@ -1147,11 +1312,17 @@ func (h *objectCalculationHandler) evaluateMatchedQuotas(
 }
 
 func matchedQuotaExpressionKey(matched quota.MatchedQuota) string {
+	// max evaluates the largest single value instead of the sum of values.
+	prefix := ""
+	if matched.Operation == quota.OpMax {
+		prefix = "max:"
+	}
+
 	if matched.CEL != "" {
-		return "cel:" + matched.CEL
+		return prefix + "cel:" + matched.CEL
 	}
 
-	return "path:" + matched.Path
+	return prefix + "path:" + matched.Path
 }
 
 func addLedgerPendingDelete(
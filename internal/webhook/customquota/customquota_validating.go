@@ -59,6 +59,18 @@ func (h *customQuotaValidationHandler) OnCreate(
 			return ad.Denyf("invalid CEL expression: %v", err)
 		}
 
+		if err := validateWindow(&q.Spec); err != nil {
+			return ad.Denyf("invalid spec.window: %v", err)
+		}
+
+		if err := validateOperations(&q.Spec); err != nil {
+			return ad.Denyf("invalid spec.sources: %v", err)
+		}
+
+		if err := validateThresholds(&q.Spec); err != nil {
+			return ad.Denyf("invalid spec: %v", err)
+		}
+
 		return nil
 	}
 }
@@ -116,6 +128,18 @@ func (h *customQuotaValidationHandler) OnUpdate(
 			return ad.Denyf("invalid CEL expression: %v", err)
 		}
 
+		if err := validateWindow(&newQuota.Spec); err != nil {
+			return ad.Denyf("invalid spec.window: %v", err)
+		}
+
+		if err := validateOperations(&newQuota.Spec); err != nil {
+			return ad.Denyf("invalid spec.sources: %v", err)
+		}
+
+		if err := validateThresholds(&newQuota.Spec); err != nil {
+			return ad.Denyf("invalid spec: %v", err)
+		}
+
 		used := oldQuota.Status.Usage.Used
 
 		// No recorded usage: allow normal mutation rules below.
@@ -128,6 +152,12 @@ func (h *customQuotaValidationHandler) OnUpdate(
 				)
 			}
 
+			if windowChanged(oldQuota.Spec.Window, newQuota.Spec.Window) {
+				return ad.Denyf(
+					"spec.window cannot be changed while usage is recorded (usage: %s); create a new %s instead", used.String(), req.Kind.Kind,
+				)
+			}
+
 			if newQuota.Spec.Limit.Cmp(used) < 0 {
 				return ad.Denyf(
 					"spec.limit cannot be lowered below current usage (%s); requested limit: %s",
@@ -59,6 +59,18 @@ func (h *globalCustomQuotaValidationHandler) OnCreate(
 			return ad.Denyf("invalid CEL expression: %v", err)
 		}
 
+		if err := validateWindow(&q.Spec.CustomQuotaSpec); err != nil {
+			return ad.Denyf("invalid spec.window: %v", err)
+		}
+
+		if err := validateOperations(&q.Spec.CustomQuotaSpec); err != nil {
+			return ad.Denyf("invalid spec.sources: %v", err)
+		}
+
+		if err := validateThresholds(&q.Spec.CustomQuotaSpec); err != nil {
+			return ad.Denyf("invalid spec: %v", err)
+		}
+
 		return nil
 	}
 }
@@ -115,6 +127,18 @@ func (h *globalCustomQuotaValidationHandler) OnUpdate(
 			return ad.Denyf("invalid CEL expression: %v", err)
 		}
 
+		if err := validateWindow(&newQuota.Spec.CustomQuotaSpec); err != nil {
+			return ad.Denyf("invalid spec.window: %v", err)
+		}
+
+		if err := validateOperations(&newQuota.Spec.CustomQuotaSpec); err != nil {
+			return ad.Denyf("invalid spec.sources: %v", err)
+		}
+
+		if err := validateThresholds(&newQuota.Spec.CustomQuotaSpec); err != nil {
+			return ad.Denyf("invalid spec: %v", err)
+		}
+
 		used := oldQuota.Status.Usage.Used
 
 		// No recorded usage: allow normal mutation rules below.
@@ -127,6 +151,12 @@ func (h *globalCustomQuotaValidationHandler) OnUpdate(
 				)
 			}
 
+			if windowChanged(oldQuota.Spec.Window, newQuota.Spec.Window) {
+				return ad.Denyf(
+					"spec.window cannot be changed while usage is recorded (usage: %s); create a new %s instead", used.String(), req.Kind.Kind,
+				)
+			}
+
 			if newQuota.Spec.Limit.Cmp(used) < 0 {
 				return ad.Denyf(
 					"spec.limit cannot be lowered below current usage (%s); requested limit: %s",
@@ -0,0 +1,129 @@
+// Copyright 2020-2026 Project Capsule Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package customquota
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	ad "github.com/projectcapsule/capsule/pkg/runtime/admission"
+	"github.com/projectcapsule/capsule/pkg/runtime/events"
+	"github.com/projectcapsule/capsule/pkg/runtime/quota"
+)
+
+// Threshold of a quota passed by an admission.
+type passedThreshold struct {
+	evaluatedQuota
+
+	used      resource.Quantity
+	threshold quota.Threshold
+}
+
+// Returns the threshold of the quota passed by the usage after the admission, nil if the usage
+// passed no threshold.
+func passedQuotaThreshold(item evaluatedQuota, used resource.Quantity) *passedThreshold {
+	threshold := quota.PassedThreshold(used, item.WarnAt, item.SoftLimit)
+	if threshold == quota.ThresholdNone {
+		return nil
+	}
+
+	return &passedThreshold{
+		evaluatedQuota: item,
+		used:           used.DeepCopy(),
+		threshold:      threshold,
+	}
+}
+
+func (p passedThreshold) message() string {
+	if p.threshold == quota.ThresholdSoftLimit {
+		return fmt.Sprintf(
+			"usage of %s %q exceeds the soft limit (used=%s, softLimit=%s, limit=%s)",
+			quotaTypeName(p.IsGlobal),
+			p.Name,
+			p.used.String(),
+			p.SoftLimit.String(),
+			p.Limit.String(),
+		)
+	}
+
+	return fmt.Sprintf(
+		"usage of %s %q reached the warning threshold (used=%s, warnAt=%s, limit=%s)",
+		quotaTypeName(p.IsGlobal),
+		p.Name,
+		p.used.String(),
+		p.WarnAt.String(),
+		p.Limit.String(),
+	)
+}
+
+func (p passedThreshold) reason() string {
+	if p.threshold == quota.ThresholdSoftLimit {
+		return events.ReasonQuotaSoftLimitExceeded
+	}
+
+	return events.ReasonQuotaNearLimit
+}
+
+// Returns a reference to the quota, used as regarding object of the events.
+func (p passedThreshold) quotaObject() runtime.Object {
+	if p.IsGlobal {
+		return &capsulev1beta2.GlobalCustomQuota{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: capsulev1beta2.GroupVersion.String(),
+				Kind:       "GlobalCustomQuota",
+			},
+			ObjectMeta: metav1.ObjectMeta{Name: p.Name},
+		}
+	}
+
+	return &capsulev1beta2.CustomQuota{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: capsulev1beta2.GroupVersion.String(),
+			Kind:       "CustomQuota",
+		},
+		ObjectMeta: metav1.ObjectMeta{Name: p.Name, Namespace: p.Namespace},
+	}
+}
+
+// Audits the passed thresholds. An event is recorded for each passed threshold, unless the request
+// is a dry-run, and the request is admitted with a warning for each of them.
+func auditPassedThresholds(
+	ctx context.Context,
+	recorder events.EventRecorder,
+	req admission.Request,
+	obj *unstructured.Unstructured,
+	passed []passedThreshold,
+	dryRun bool,
+) *admission.Response {
+	warnings := make([]string, 0, len(passed))
+
+	for _, p := range passed {
+		warnings = append(warnings, p.message())
+
+		if dryRun || recorder == nil {
+			continue
+		}
+
+		recorder.LabeledEvent(
+			p.quotaObject(),
+			corev1.EventTypeWarning,
+			p.reason(),
+			events.ActionQuotaAudit,
+			p.message(),
+		).
+			WithRelated(obj).
+			WithRequestAnnotations(req).
+			Emit(ctx)
+	}
+
+	return ad.AllowWithWarnings(warnings...)
+}
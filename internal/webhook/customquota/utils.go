@@ -5,6 +5,7 @@ package customquota
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -27,6 +28,10 @@ const (
 	maxQuantityLedgerPendingDeletes = 1024
 )
 
+// errWindowAdmissionsExhausted is returned when a windowed quota already tracks the maximum of
+// admissions within its window.
+var errWindowAdmissionsExhausted = errors.New("windowed quota has reached the maximum of admissions within its window")
+
 func quantityLedgerKeyForMatchedQuota(item evaluatedQuota) types.NamespacedName {
 	if item.IsGlobal {
 		return types.NamespacedName{
@@ -91,6 +96,12 @@ func reserveCreateOnLedger(
 
 		if !foundReservation {
 			if len(activeReservations) >= maxQuantityLedgerReservations {
+				// Reservations of windowed quotas are only released once they leave the window,
+				// the ceiling is an admission rate instead of a transient overload.
+				if item.Window > 0 {
+					return errWindowAdmissionsExhausted
+				}
+
 				return fmt.Errorf(
 					"quantity ledger %s has reached the maximum of %d inflight reservations",
 					ledgerKey.String(),
@@ -469,3 +480,86 @@ func sourcesChanged(a, b []capsulev1beta2.CustomQuotaSpecSource) bool {
 
 	return false
 }
+
+func validateWindow(spec *capsulev1beta2.CustomQuotaSpec) error {
+	if spec.Window != nil && spec.Window.Duration <= 0 {
+		return fmt.Errorf("window must be a positive duration, got %s", spec.Window.Duration)
+	}
+
+	if !spec.IsWindowed() || len(spec.Sources) == 0 {
+		return nil
+	}
+
+	// Each admission within the window holds a reservation on the ledger, so every windowed quota
+	// admits at most the maximum of reservations within the window. A quota only counting objects
+	// could therefore never reach a limit above it.
+	for _, source := range spec.Sources {
+		if source.Operation != quota.OpCount {
+			return nil
+		}
+	}
+
+	if spec.Limit.CmpInt64(maxQuantityLedgerReservations) > 0 {
+		return fmt.Errorf(
+			"limit %s of a windowed count quota exceeds the maximum of %d admissions tracked within the window",
+			spec.Limit.String(),
+			maxQuantityLedgerReservations,
+		)
+	}
+
+	return nil
+}
+
+func validateOperations(spec *capsulev1beta2.CustomQuotaSpec) error {
+	operations := make([]quota.Operation, 0, len(spec.Sources))
+
+	for _, source := range spec.Sources {
+		if source.Operation == quota.OpDistinct && source.CEL != "" {
+			return fmt.Errorf("operation %q requires path, cel is not supported", source.Operation)
+		}
+
+		if spec.IsWindowed() && source.Operation.Exclusive() {
+			return fmt.Errorf("operation %q cannot be used with a window", source.Operation)
+		}
+
+		operations = append(operations, source.Operation)
+	}
+
+	return quota.ValidateOperations(operations)
+}
+
+// The soft limit and the warning threshold must not exceed the limit.
+func validateThresholds(spec *capsulev1beta2.CustomQuotaSpec) error {
+	if spec.SoftLimit != nil {
+		if spec.SoftLimit.Sign() < 0 {
+			return fmt.Errorf("softLimit %s must not be negative", spec.SoftLimit.String())
+		}
+
+		if spec.SoftLimit.Cmp(spec.Limit) > 0 {
+			return fmt.Errorf("softLimit %s exceeds limit %s", spec.SoftLimit.String(), spec.Limit.String())
+		}
+	}
+
+	if spec.WarnAt == "" {
+		return nil
+	}
+
+	if err := spec.WarnAt.Validate(); err != nil {
+		return err
+	}
+
+	if warnAt := spec.GetWarnAt(); warnAt != nil && warnAt.Cmp(spec.Limit) > 0 {
+		return fmt.Errorf("warnAt %s exceeds limit %s", spec.WarnAt, spec.Limit.String())
+	}
+
+	return nil
+}
+
+// Changing the window changes how the recorded usage is accounted.
+func windowChanged(a, b *metav1.Duration) bool {
+	if a == nil || b == nil {
+		return a != b
+	}
+
+	return a.Duration != b.Duration
+}
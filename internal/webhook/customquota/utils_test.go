@@ -5,6 +5,8 @@ package customquota
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"strings"
 	"testing"
 	"time"
@@ -24,6 +26,8 @@ import (
 	"github.com/projectcapsule/capsule/internal/cache"
 	"github.com/projectcapsule/capsule/pkg/api/meta"
 	caprunt "github.com/projectcapsule/capsule/pkg/api/runtime"
+	"github.com/projectcapsule/capsule/pkg/runtime/events"
+	"github.com/projectcapsule/capsule/pkg/runtime/jsonpath"
 	"github.com/projectcapsule/capsule/pkg/runtime/quota"
 	"github.com/projectcapsule/capsule/pkg/runtime/selectors"
 )
@@ -134,6 +138,70 @@ func TestReserveCreateOnLedgerReleasesExpiredReservation(t *testing.T) {
 	}
 }
 
+func TestReserveCreateOnLedgerWindowedAdmissionCeiling(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		window     time.Duration
+		wantWindow bool
+	}{
+		{name: "windowed quota", window: time.Hour, wantWindow: true},
+		{name: "unwindowed quota"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			key := types.NamespacedName{Namespace: "tenant-a", Name: "storage"}
+			ledger := ledgerForTest(key, "0")
+			expiresAt := metav1.NewTime(time.Now().Add(time.Hour))
+			usage := resource.MustParse("1Mi")
+
+			for i := range maxQuantityLedgerReservations {
+				ledger.Status.Reservations = append(ledger.Status.Reservations, capsulev1beta2.QuantityLedgerReservation{
+					ID:        fmt.Sprintf("admission-%d", i),
+					Usage:     usage.DeepCopy(),
+					Delta:     quantityPtr(usage),
+					ExpiresAt: &expiresAt,
+				})
+			}
+
+			cl := ledgerClientForTest(t, ledger)
+
+			reservation := capsulev1beta2.QuantityLedgerReservation{
+				ID:    "next",
+				Usage: usage.DeepCopy(),
+				Delta: quantityPtr(usage),
+			}
+
+			_, _, _, err := reserveCreateOnLedger(
+				ctx,
+				cl,
+				cl,
+				evaluatedQuota{MatchedQuota: quota.MatchedQuota{
+					Name:      key.Name,
+					Namespace: key.Namespace,
+					Limit:     resource.MustParse("100Gi"),
+					Operation: quota.OpAdd,
+					Window:    tt.window,
+				}},
+				&reservation,
+				false,
+			)
+			if err == nil {
+				t.Fatal("reserveCreateOnLedger() error = nil, want the reservation ceiling")
+			}
+
+			if got := errors.Is(err, errWindowAdmissionsExhausted); got != tt.wantWindow {
+				t.Fatalf("reserveCreateOnLedger() error = %v, window ceiling %v, want %v", err, got, tt.wantWindow)
+			}
+		})
+	}
+}
+
 func TestReplaceUsageOnLedgerDoesNotReleaseDecreaseBeforePersistence(t *testing.T) {
 	t.Parallel()
 
@@ -925,3 +993,337 @@ func (r *readinessFlappingReader) List(
 
 	return nil
 }
+
+func TestValidateWindow(t *testing.T) {
+	t.Parallel()
+
+	source := func(op quota.Operation) capsulev1beta2.CustomQuotaSpecSource {
+		return capsulev1beta2.CustomQuotaSpecSource{
+			CustomQuotaSpecSourceConfig: capsulev1beta2.CustomQuotaSpecSourceConfig{Operation: op},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		window  *metav1.Duration
+		limit   string
+		sources []capsulev1beta2.CustomQuotaSpecSource
+		wantErr bool
+	}{
+		{name: "unset", window: nil},
+		{name: "positive", window: &metav1.Duration{Duration: time.Hour}},
+		{name: "zero", window: &metav1.Duration{}, wantErr: true},
+		{name: "negative", window: &metav1.Duration{Duration: -time.Minute}, wantErr: true},
+		{
+			name:    "count limit within reservations",
+			window:  &metav1.Duration{Duration: time.Hour},
+			limit:   "1024",
+			sources: []capsulev1beta2.CustomQuotaSpecSource{source(quota.OpCount)},
+		},
+		{
+			name:    "count limit above reservations",
+			window:  &metav1.Duration{Duration: time.Hour},
+			limit:   "1025",
+			sources: []capsulev1beta2.CustomQuotaSpecSource{source(quota.OpCount), source(quota.OpCount)},
+			wantErr: true,
+		},
+		{
+			name:    "unwindowed count limit above reservations",
+			limit:   "5000",
+			sources: []capsulev1beta2.CustomQuotaSpecSource{source(quota.OpCount)},
+		},
+		{
+			name:    "summed limit above reservations",
+			window:  &metav1.Duration{Duration: time.Hour},
+			limit:   "100Gi",
+			sources: []capsulev1beta2.CustomQuotaSpecSource{source(quota.OpCount), source(quota.OpAdd)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			spec := &capsulev1beta2.CustomQuotaSpec{Window: tt.window, Sources: tt.sources}
+			if tt.limit != "" {
+				spec.Limit = resource.MustParse(tt.limit)
+			}
+
+			err := validateWindow(spec)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("validateWindow() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestWindowChanged(t *testing.T) {
+	t.Parallel()
+
+	hour := &metav1.Duration{Duration: time.Hour}
+
+	tests := []struct {
+		name string
+		a, b *metav1.Duration
+		want bool
+	}{
+		{name: "both unset", want: false},
+		{name: "set", b: hour, want: true},
+		{name: "unset", a: hour, want: true},
+		{name: "equal", a: hour, b: &metav1.Duration{Duration: time.Hour}, want: false},
+		{name: "different", a: hour, b: &metav1.Duration{Duration: 2 * time.Hour}, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := windowChanged(tt.a, tt.b); got != tt.want {
+				t.Fatalf("windowChanged() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithoutWindowedQuotas(t *testing.T) {
+	t.Parallel()
+
+	got := withoutWindowedQuotas([]quota.MatchedQuota{
+		{Name: "counted"},
+		{Name: "windowed", Window: time.Hour},
+	})
+	if len(got) != 1 || got[0].Name != "counted" {
+		t.Fatalf("withoutWindowedQuotas() = %+v, want only the counted quota", got)
+	}
+}
+
+func TestEvaluateMatchedQuotasNonAdditiveOperations(t *testing.T) {
+	t.Parallel()
+
+	images, err := jsonpath.CompileJSONPath(".spec.containers[*].image")
+	if err != nil {
+		t.Fatalf("compile images path: %v", err)
+	}
+
+	cpu, err := jsonpath.CompileJSONPath(".spec.containers[*].resources.requests.cpu")
+	if err != nil {
+		t.Fatalf("compile cpu path: %v", err)
+	}
+
+	object := unstructured.Unstructured{Object: map[string]any{
+		"spec": map[string]any{
+			"containers": []any{
+				map[string]any{
+					"image":     "nginx:1.27",
+					"resources": map[string]any{"requests": map[string]any{"cpu": "250m"}},
+				},
+				map[string]any{
+					"image":     "redis:7",
+					"resources": map[string]any{"requests": map[string]any{"cpu": "1"}},
+				},
+			},
+		},
+	}}
+
+	h := &objectCalculationHandler{}
+
+	evaluated, err := h.evaluateMatchedQuotas(context.Background(), object, []quota.MatchedQuota{
+		{Key: "images", Path: ".spec.containers[*].image", CompiledPath: images, Operation: quota.OpDistinct, Values: []string{"nginx:1.27"}},
+		{Key: "max", Path: ".spec.containers[*].resources.requests.cpu", CompiledPath: cpu, Operation: quota.OpMax, Limit: resource.MustParse("2")},
+		{Key: "object", Path: ".spec.containers[*].resources.requests.cpu", CompiledPath: cpu, Operation: quota.OpPerObject, Limit: resource.MustParse("1")},
+	})
+	if err != nil {
+		t.Fatalf("evaluateMatchedQuotas() error = %v", err)
+	}
+
+	got := evaluatedByKey(evaluated)
+	for key, want := range map[string]string{"images": "1", "max": "1", "object": "1250m"} {
+		usage := got[key].Usage
+		if usage.Cmp(resource.MustParse(want)) != 0 {
+			t.Fatalf("%s usage = %s, want %s", key, usage.String(), want)
+		}
+	}
+
+	capping, accounted := splitCappingQuotas(evaluated)
+	if len(capping) != 2 || len(accounted) != 1 || accounted[0].Key != "images" {
+		t.Fatalf("splitCappingQuotas() = %d capping, %d accounted, want 2 and 1", len(capping), len(accounted))
+	}
+
+	exceeded := exceededCappingQuota(nil, capping)
+	if exceeded == nil || exceeded.Key != "object" {
+		t.Fatalf("exceededCappingQuota() = %v, want object", exceeded)
+	}
+
+	// Values which did not grow are not rejected.
+	if exceeded := exceededCappingQuota(capping, capping); exceeded != nil {
+		t.Fatalf("exceededCappingQuota() for unchanged object = %v, want nil", exceeded.Key)
+	}
+}
+
+func TestValidateOperations(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		spec    capsulev1beta2.CustomQuotaSpec
+		wantErr bool
+	}{
+		{
+			name: "distinct with path",
+			spec: capsulev1beta2.CustomQuotaSpec{Sources: []capsulev1beta2.CustomQuotaSpecSource{
+				{CustomQuotaSpecSourceConfig: capsulev1beta2.CustomQuotaSpecSourceConfig{Operation: quota.OpDistinct, Path: ".spec.image"}},
+			}},
+		},
+		{
+			name: "distinct with cel",
+			spec: capsulev1beta2.CustomQuotaSpec{Sources: []capsulev1beta2.CustomQuotaSpecSource{
+				{CustomQuotaSpecSourceConfig: capsulev1beta2.CustomQuotaSpecSourceConfig{Operation: quota.OpDistinct, CEL: "object.spec.image"}},
+			}},
+			wantErr: true,
+		},
+		{
+			name: "max with window",
+			spec: capsulev1beta2.CustomQuotaSpec{
+				Window: &metav1.Duration{Duration: time.Hour},
+				Sources: []capsulev1beta2.CustomQuotaSpecSource{
+					{CustomQuotaSpecSourceConfig: capsulev1beta2.CustomQuotaSpecSourceConfig{Operation: quota.OpMax, Path: ".spec.replicas"}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "perObject combined with count",
+			spec: capsulev1beta2.CustomQuotaSpec{Sources: []capsulev1beta2.CustomQuotaSpecSource{
+				{CustomQuotaSpecSourceConfig: capsulev1beta2.CustomQuotaSpecSourceConfig{Operation: quota.OpPerObject, Path: ".spec.replicas"}},
+				{CustomQuotaSpecSourceConfig: capsulev1beta2.CustomQuotaSpecSourceConfig{Operation: quota.OpCount}},
+			}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := validateOperations(&tt.spec)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("validateOperations() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateThresholds(t *testing.T) {
+	t.Parallel()
+
+	softLimit := func(value string) *resource.Quantity {
+		quantity := resource.MustParse(value)
+
+		return &quantity
+	}
+
+	tests := []struct {
+		name    string
+		spec    capsulev1beta2.CustomQuotaSpec
+		wantErr bool
+	}{
+		{
+			name: "no thresholds",
+			spec: capsulev1beta2.CustomQuotaSpec{Limit: resource.MustParse("10")},
+		},
+		{
+			name: "percentage and soft limit",
+			spec: capsulev1beta2.CustomQuotaSpec{Limit: resource.MustParse("10"), WarnAt: "80%", SoftLimit: softLimit("9")},
+		},
+		{
+			name: "quantity threshold",
+			spec: capsulev1beta2.CustomQuotaSpec{Limit: resource.MustParse("10Gi"), WarnAt: "8Gi"},
+		},
+		{
+			name:    "quantity threshold above limit",
+			spec:    capsulev1beta2.CustomQuotaSpec{Limit: resource.MustParse("10Gi"), WarnAt: "12Gi"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid percentage",
+			spec:    capsulev1beta2.CustomQuotaSpec{Limit: resource.MustParse("10"), WarnAt: "120%"},
+			wantErr: true,
+		},
+		{
+			name:    "soft limit above limit",
+			spec:    capsulev1beta2.CustomQuotaSpec{Limit: resource.MustParse("10"), SoftLimit: softLimit("11")},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := validateThresholds(&tt.spec)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("validateThresholds() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAuditPassedThresholds(t *testing.T) {
+	t.Parallel()
+
+	warnAt := resource.MustParse("8")
+	softLimit := resource.MustParse("9")
+
+	item := evaluatedQuota{
+		MatchedQuota: quota.MatchedQuota{
+			Key:       "tenant-a/replicas",
+			Name:      "replicas",
+			Namespace: "tenant-a",
+			Limit:     resource.MustParse("10"),
+			WarnAt:    &warnAt,
+			SoftLimit: &softLimit,
+		},
+		Usage: resource.MustParse("1"),
+	}
+
+	if passed := passedQuotaThreshold(item, resource.MustParse("7")); passed != nil {
+		t.Fatalf("passedQuotaThreshold() = %#v, want nil below threshold", passed)
+	}
+
+	nearLimit := passedQuotaThreshold(item, resource.MustParse("8"))
+	if nearLimit == nil || nearLimit.reason() != events.ReasonQuotaNearLimit {
+		t.Fatalf("passedQuotaThreshold() = %#v, want near limit", nearLimit)
+	}
+
+	softLimitExceeded := passedQuotaThreshold(item, resource.MustParse("10"))
+	if softLimitExceeded == nil || softLimitExceeded.reason() != events.ReasonQuotaSoftLimitExceeded {
+		t.Fatalf("passedQuotaThreshold() = %#v, want soft limit exceeded", softLimitExceeded)
+	}
+
+	req := admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{UID: "uid"}}
+	obj := &unstructured.Unstructured{}
+
+	if resp := auditPassedThresholds(context.Background(), nil, req, obj, nil, true); resp != nil {
+		t.Fatalf("auditPassedThresholds() = %#v, want nil without passed thresholds", resp)
+	}
+
+	resp := auditPassedThresholds(
+		context.Background(),
+		nil,
+		req,
+		obj,
+		[]passedThreshold{*nearLimit, *softLimitExceeded},
+		true,
+	)
+	if resp == nil || !resp.Allowed || len(resp.Warnings) != 2 {
+		t.Fatalf("auditPassedThresholds() = %#v, want allowed response with two warnings", resp)
+	}
+
+	if !strings.Contains(resp.Warnings[0], "warning threshold (used=8, warnAt=8, limit=10)") {
+		t.Fatalf("unexpected near limit warning %q", resp.Warnings[0])
+	}
+
+	if !strings.Contains(resp.Warnings[1], "exceeds the soft limit (used=10, softLimit=9, limit=10)") {
+		t.Fatalf("unexpected soft limit warning %q", resp.Warnings[1])
+	}
+}
@@ -58,18 +58,18 @@ func (h *handler) OnCreate(
 	c client.Client,
 	reader client.Reader,
 	_ admission.Decoder,
-	_ events.EventRecorder,
+	recorder events.EventRecorder,
 ) handlers.Func {
-	return h.handle(c, reader)
+	return h.handle(c, reader, recorder)
 }
 
 func (h *handler) OnUpdate(
 	c client.Client,
 	reader client.Reader,
 	_ admission.Decoder,
-	_ events.EventRecorder,
+	recorder events.EventRecorder,
 ) handlers.Func {
-	return h.handle(c, reader)
+	return h.handle(c, reader, recorder)
 }
 
 func (h *handler) OnDelete(
@@ -83,13 +83,13 @@ func (h *handler) OnDelete(
 	}
 }
 
-func (h *handler) handle(c client.Client, reader client.Reader) handlers.Func {
+func (h *handler) handle(c client.Client, reader client.Reader, recorder events.EventRecorder) handlers.Func {
 	return func(ctx context.Context, req admission.Request) *admission.Response {
 		if isGlobalResourceQuotaRequest(req) {
 			return validateGlobalResourceQuotaRequest(ctx, reader, req)
 		}
 
-		return enforceGlobalResourceQuotaRequest(ctx, c, reader, req)
+		return enforceGlobalResourceQuotaRequest(ctx, c, reader, recorder, req)
 	}
 }
 
@@ -162,6 +162,7 @@ func enforceGlobalResourceQuotaRequest(
 	ctx context.Context,
 	c client.Client,
 	reader client.Reader,
+	recorder events.EventRecorder,
 	req admission.Request,
 ) *admission.Response {
 	if req.Namespace == "" {
@@ -203,9 +204,10 @@ func enforceGlobalResourceQuotaRequest(
 	}
 
 	applied := make([]appliedReservation, 0, len(quotaList))
+	passed := make([]passedThreshold, 0)
 
 	for _, quota := range quotaList {
-		reservation, response := reserveForGlobalResourceQuota(ctx, c, reader, req, quota, evaluation)
+		reservation, thresholds, response := reserveForGlobalResourceQuota(ctx, c, reader, req, quota, evaluation)
 		if response != nil {
 			rollbackReservations(ctx, c, reader, applied)
 
@@ -215,9 +217,11 @@ func enforceGlobalResourceQuotaRequest(
 		if reservation != nil {
 			applied = append(applied, *reservation)
 		}
+
+		passed = append(passed, thresholds...)
 	}
 
-	return nil
+	return auditPassedThresholds(ctx, recorder, req, passed)
 }
 
 func reserveForGlobalResourceQuota(
@@ -227,14 +231,14 @@ func reserveForGlobalResourceQuota(
 	req admission.Request,
 	quota *capsulev1beta2.GlobalResourceQuota,
 	evaluation quotaevaluator.Result,
-) (*appliedReservation, *admission.Response) {
+) (*appliedReservation, []passedThreshold, *admission.Response) {
 	if quota.DeletionTimestamp != nil {
-		return nil, nil
+		return nil, nil, nil
 	}
 
 	oldUsage, newUsage, err := usageForQuota(quota.Spec.Quota, evaluation)
 	if err != nil {
-		return nil, ad.Denyf(
+		return nil, nil, ad.Denyf(
 			"resource cannot be evaluated against GlobalResourceQuota %q: %v",
 			quota.Name,
 			err,
@@ -242,12 +246,12 @@ func reserveForGlobalResourceQuota(
 	}
 
 	if !resourceListPositive(newUsage) && !resourceListPositive(oldUsage) {
-		return nil, nil
+		return nil, nil, nil
 	}
 
 	delta := positiveDifference(newUsage, oldUsage)
 	if !resourceListPositive(delta) {
-		return nil, nil
+		return nil, nil, nil
 	}
 
 	ledgerKey := types.NamespacedName{
@@ -267,29 +271,31 @@ func reserveForGlobalResourceQuota(
 	)
 	if err != nil {
 		if apierrors.IsNotFound(err) {
-			return nil, ad.Denyf(
+			return nil, nil, ad.Denyf(
 				"GlobalResourceQuota %q is not ready: QuantityLedger %s does not exist",
 				quota.Name,
 				ledgerKey.String(),
 			)
 		}
 
-		return nil, ad.ErroredResponse(err)
+		return nil, nil, ad.ErroredResponse(err)
 	}
 
 	if !allowed {
-		return nil, ad.Denyf(
+		return nil, nil, ad.Denyf(
 			"resource exceeds GlobalResourceQuota %q: %s",
 			quota.Name,
 			formatExceededResources(delta, projected, quota.Spec.Quota.Hard),
 		)
 	}
 
+	thresholds := passedThresholds(quota, delta, projected)
+
 	if !applied || (req.DryRun != nil && *req.DryRun) {
-		return nil, nil
+		return nil, thresholds, nil
 	}
 
-	return &appliedReservation{Key: ledgerKey, ID: reservation.ID}, nil
+	return &appliedReservation{Key: ledgerKey, ID: reservation.ID}, thresholds, nil
 }
 
 func isGlobalResourceQuotaRequest(req admission.Request) bool {
@@ -309,6 +315,31 @@ func validateGlobalResourceQuota(quota *capsulev1beta2.GlobalResourceQuota) erro
 		}
 	}
 
+	for name, quantity := range quota.Spec.Soft {
+		hard, ok := quota.Spec.Quota.Hard[name]
+		if !ok {
+			return fmt.Errorf("spec.soft[%q] has no hard limit in spec.quota.hard", name)
+		}
+
+		if quantity.Sign() < 0 {
+			return fmt.Errorf("spec.soft[%q] must not be negative", name)
+		}
+
+		if quantity.Cmp(hard) > 0 {
+			return fmt.Errorf("spec.soft[%q] %s exceeds the hard limit %s", name, quantity.String(), hard.String())
+		}
+	}
+
+	if quota.Spec.WarnAt != "" {
+		if !quota.Spec.WarnAt.IsPercentage() {
+			return fmt.Errorf("spec.warnAt %q must be a percentage of the hard limits", quota.Spec.WarnAt)
+		}
+
+		if err := quota.Spec.WarnAt.Validate(); err != nil {
+			return fmt.Errorf("spec.warnAt is invalid: %w", err)
+		}
+	}
+
 	for index, namespaceSelector := range quota.Spec.NamespaceSelectors {
 		if namespaceSelector.LabelSelector == nil {
 			continue
@@ -24,6 +24,7 @@ import (
 
 	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
 	"github.com/projectcapsule/capsule/pkg/api/meta"
+	runtimequota "github.com/projectcapsule/capsule/pkg/runtime/quota"
 	"github.com/projectcapsule/capsule/pkg/runtime/selectors"
 )
 
@@ -224,6 +225,54 @@ func TestValidateGlobalResourceQuota(t *testing.T) {
 			}),
 			wantErr: true,
 		},
+		{
+			name: "rejects soft limits above the hard limit",
+			quota: func() *capsulev1beta2.GlobalResourceQuota {
+				quota := globalQuotaForTest("soft", corev1.ResourceList{
+					corev1.ResourceRequestsCPU: resource.MustParse("8"),
+				})
+				quota.Spec.Soft = corev1.ResourceList{corev1.ResourceRequestsCPU: resource.MustParse("9")}
+
+				return quota
+			}(),
+			wantErr: true,
+		},
+		{
+			name: "rejects soft limits without hard limit",
+			quota: func() *capsulev1beta2.GlobalResourceQuota {
+				quota := globalQuotaForTest("soft", corev1.ResourceList{
+					corev1.ResourceRequestsCPU: resource.MustParse("8"),
+				})
+				quota.Spec.Soft = corev1.ResourceList{corev1.ResourceRequestsMemory: resource.MustParse("1Gi")}
+
+				return quota
+			}(),
+			wantErr: true,
+		},
+		{
+			name: "rejects quantity thresholds",
+			quota: func() *capsulev1beta2.GlobalResourceQuota {
+				quota := globalQuotaForTest("warn", corev1.ResourceList{
+					corev1.ResourceRequestsCPU: resource.MustParse("8"),
+				})
+				quota.Spec.WarnAt = "6"
+
+				return quota
+			}(),
+			wantErr: true,
+		},
+		{
+			name: "accepts soft limits and percentage thresholds",
+			quota: func() *capsulev1beta2.GlobalResourceQuota {
+				quota := globalQuotaForTest("thresholds", corev1.ResourceList{
+					corev1.ResourceRequestsCPU: resource.MustParse("8"),
+				})
+				quota.Spec.Soft = corev1.ResourceList{corev1.ResourceRequestsCPU: resource.MustParse("7")}
+				quota.Spec.WarnAt = "75%"
+
+				return quota
+			}(),
+		},
 		{
 			name: "accepts an empty selector as all namespaces",
 			quota: func() *capsulev1beta2.GlobalResourceQuota {
@@ -420,6 +469,52 @@ func initializedLedger(
 	}
 }
 
+func TestPassedThresholdsOnlyConsidersRequestedResources(t *testing.T) {
+	t.Parallel()
+
+	quota := globalQuotaForTest("shared", corev1.ResourceList{
+		corev1.ResourceRequestsCPU:    resource.MustParse("10"),
+		corev1.ResourceRequestsMemory: resource.MustParse("10Gi"),
+		corev1.ResourcePods:           resource.MustParse("10"),
+	})
+	quota.Spec.WarnAt = "80%"
+	quota.Spec.Soft = corev1.ResourceList{corev1.ResourceRequestsMemory: resource.MustParse("9Gi")}
+
+	projected := corev1.ResourceList{
+		corev1.ResourceRequestsCPU:    resource.MustParse("8"),
+		corev1.ResourceRequestsMemory: resource.MustParse("9500Mi"),
+		corev1.ResourcePods:           resource.MustParse("9"),
+	}
+	requested := corev1.ResourceList{
+		corev1.ResourceRequestsCPU:    resource.MustParse("1"),
+		corev1.ResourceRequestsMemory: resource.MustParse("1Gi"),
+	}
+
+	passed := passedThresholds(quota, requested, projected)
+	if len(passed) != 2 {
+		t.Fatalf("passedThresholds() = %#v, want soft limit and warning threshold", passed)
+	}
+
+	if passed[0].threshold != runtimequota.ThresholdSoftLimit ||
+		passed[0].message != `resource exceeds the soft limit of GlobalResourceQuota "shared": requests.memory (projected=9500Mi, soft=9Gi, hard=10Gi)` {
+		t.Fatalf("unexpected soft limit threshold %#v", passed[0])
+	}
+
+	if passed[1].threshold != runtimequota.ThresholdWarnAt ||
+		passed[1].message != `resource usage reached the warning threshold of GlobalResourceQuota "shared": requests.cpu (projected=8, warnAt=8, hard=10)` {
+		t.Fatalf("unexpected warning threshold %#v", passed[1])
+	}
+
+	resp := auditPassedThresholds(context.Background(), nil, admission.Request{}, passed)
+	if resp == nil || !resp.Allowed || len(resp.Warnings) != 2 {
+		t.Fatalf("auditPassedThresholds() = %#v, want allowed response with two warnings", resp)
+	}
+
+	if resp := auditPassedThresholds(context.Background(), nil, admission.Request{}, nil); resp != nil {
+		t.Fatalf("auditPassedThresholds() = %#v, want nil without passed thresholds", resp)
+	}
+}
+
 func globalQuotaForTest(name string, hard corev1.ResourceList) *capsulev1beta2.GlobalResourceQuota {
 	return &capsulev1beta2.GlobalResourceQuota{
 		ObjectMeta: metav1.ObjectMeta{Name: name, UID: types.UID(name + "-uid"), Generation: 1},
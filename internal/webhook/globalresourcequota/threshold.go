@@ -0,0 +1,161 @@
+// Copyright 2020-2026 Project Capsule Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package globalresourcequota
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	ad "github.com/projectcapsule/capsule/pkg/runtime/admission"
+	"github.com/projectcapsule/capsule/pkg/runtime/events"
+	runtimequota "github.com/projectcapsule/capsule/pkg/runtime/quota"
+)
+
+// Threshold of a GlobalResourceQuota passed by an admission.
+type passedThreshold struct {
+	quota     *capsulev1beta2.GlobalResourceQuota
+	threshold runtimequota.Threshold
+	message   string
+}
+
+// Returns the thresholds of the quota passed by the requested resources. Only resources requested by
+// the admission are considered, so admissions not raising the usage are not warned about.
+func passedThresholds(
+	quota *capsulev1beta2.GlobalResourceQuota,
+	requested corev1.ResourceList,
+	projected corev1.ResourceList,
+) []passedThreshold {
+	warnAt := quota.GetWarnAt()
+	if len(warnAt) == 0 && len(quota.Spec.Soft) == 0 {
+		return nil
+	}
+
+	names := make([]corev1.ResourceName, 0, len(quota.Spec.Quota.Hard))
+	for name := range quota.Spec.Quota.Hard {
+		names = append(names, name)
+	}
+
+	slices.Sort(names)
+
+	var nearLimit, softLimitExceeded []string
+
+	for _, name := range names {
+		requestedQuantity := quantityForResource(requested, name)
+		if requestedQuantity.Sign() <= 0 {
+			continue
+		}
+
+		projectedQuantity := quantityForResource(projected, name)
+		hardQuantity := quantityForResource(quota.Spec.Quota.Hard, name)
+		warnAtQuantity := resourceOrNil(warnAt, name)
+		softQuantity := resourceOrNil(quota.Spec.Soft, name)
+
+		switch runtimequota.PassedThreshold(projectedQuantity, warnAtQuantity, softQuantity) {
+		case runtimequota.ThresholdSoftLimit:
+			softLimitExceeded = append(softLimitExceeded, fmt.Sprintf(
+				"%s (projected=%s, soft=%s, hard=%s)",
+				name,
+				projectedQuantity.String(),
+				softQuantity.String(),
+				hardQuantity.String(),
+			))
+		case runtimequota.ThresholdWarnAt:
+			nearLimit = append(nearLimit, fmt.Sprintf(
+				"%s (projected=%s, warnAt=%s, hard=%s)",
+				name,
+				projectedQuantity.String(),
+				warnAtQuantity.String(),
+				hardQuantity.String(),
+			))
+		case runtimequota.ThresholdNone:
+		}
+	}
+
+	out := make([]passedThreshold, 0, 2)
+
+	if len(softLimitExceeded) > 0 {
+		out = append(out, passedThreshold{
+			quota:     quota,
+			threshold: runtimequota.ThresholdSoftLimit,
+			message: fmt.Sprintf(
+				"resource exceeds the soft limit of GlobalResourceQuota %q: %s",
+				quota.Name,
+				strings.Join(softLimitExceeded, "; "),
+			),
+		})
+	}
+
+	if len(nearLimit) > 0 {
+		out = append(out, passedThreshold{
+			quota:     quota,
+			threshold: runtimequota.ThresholdWarnAt,
+			message: fmt.Sprintf(
+				"resource usage reached the warning threshold of GlobalResourceQuota %q: %s",
+				quota.Name,
+				strings.Join(nearLimit, "; "),
+			),
+		})
+	}
+
+	return out
+}
+
+// Audits the passed thresholds. An event is recorded for each passed threshold, unless the request
+// is a dry-run, and the request is admitted with a warning for each of them.
+func auditPassedThresholds(
+	ctx context.Context,
+	recorder events.EventRecorder,
+	req admission.Request,
+	passed []passedThreshold,
+) *admission.Response {
+	warnings := make([]string, 0, len(passed))
+
+	for _, p := range passed {
+		warnings = append(warnings, p.message)
+
+		if (req.DryRun != nil && *req.DryRun) || recorder == nil {
+			continue
+		}
+
+		reason := events.ReasonQuotaNearLimit
+		if p.threshold == runtimequota.ThresholdSoftLimit {
+			reason = events.ReasonQuotaSoftLimitExceeded
+		}
+
+		recorder.LabeledEvent(
+			&capsulev1beta2.GlobalResourceQuota{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: capsulev1beta2.GroupVersion.String(),
+					Kind:       "GlobalResourceQuota",
+				},
+				ObjectMeta: metav1.ObjectMeta{Name: p.quota.Name, UID: p.quota.UID},
+			},
+			corev1.EventTypeWarning,
+			reason,
+			events.ActionQuotaAudit,
+			p.message,
+		).
+			WithRequestAnnotations(req).
+			Emit(ctx)
+	}
+
+	return ad.AllowWithWarnings(warnings...)
+}
+
+func resourceOrNil(resources corev1.ResourceList, name corev1.ResourceName) *resource.Quantity {
+	quantity, ok := resources[name]
+	if !ok {
+		return nil
+	}
+
+	return &quantity
+}
@@ -5,6 +5,7 @@ package mutation
 
 import (
 	"context"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -58,7 +59,7 @@ func mutateNamespaceRules(c client.Client, reader client.Reader, cfg configurati
 			return handlers.ErroredResponse(err)
 		}
 
-		bodies, err = ruleengine.FilterNamespaceRulesByAudience(cfg, tnt, req, bodies)
+		bodies, err = ruleengine.FilterNamespaceRulesByAudience(cfg, tnt, req, ruleengine.AdmissionNamespaceRules(bodies, time.Now()))
 		if err != nil {
 			return handlers.ErroredResponse(err)
 		}
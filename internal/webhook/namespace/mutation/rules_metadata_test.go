@@ -6,6 +6,7 @@ package mutation
 import (
 	"context"
 	"testing"
+	"time"
 
 	admissionv1 "k8s.io/api/admission/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -80,3 +81,52 @@ func TestMutateNamespaceRules(t *testing.T) {
 		t.Fatalf("managed namespace label = %q, want true", got)
 	}
 }
+
+func TestMutateNamespaceRulesSkipsPendingRules(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	if err := corev1.AddToScheme(scheme); err != nil {
+		t.Fatalf("add core API to scheme: %v", err)
+	}
+	if err := capsulev1beta2.AddToScheme(scheme); err != nil {
+		t.Fatalf("add Capsule API to scheme: %v", err)
+	}
+
+	notBefore := metav1.NewTime(time.Now().Add(time.Hour))
+	tnt := &capsulev1beta2.Tenant{
+		ObjectMeta: metav1.ObjectMeta{Name: "solar"},
+		Spec: capsulev1beta2.TenantSpec{
+			Rules: []*rules.NamespaceRuleBodyTenant{{
+				NamespaceRuleBodyNamespace: &rules.NamespaceRuleBodyNamespace{
+					NotBefore: &notBefore,
+					Enforce: &rules.NamespaceRuleEnforceBody{
+						Metadata: []rules.MetadataRule{{
+							VersionKinds: apiruntime.VersionKinds{
+								APIGroups: []string{"v1"},
+								Kinds:     []string{"Namespace"},
+							},
+							Labels: map[string]rules.MetadataValueRule{
+								"rules.example.com/managed": {Managed: ptr.To("true")},
+							},
+						}},
+					},
+				},
+			}},
+		},
+	}
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+		Name:   "solar-production",
+		Labels: map[string]string{meta.TenantLabel: tnt.Name},
+	}}
+
+	client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(tnt).Build()
+	response := mutateNamespaceRules(client, client, nil, ns)(context.Background(), admission.Request{})
+	if response != nil {
+		t.Fatalf("mutateNamespaceRules() response = %#v", response)
+	}
+
+	if got, ok := ns.Labels["rules.example.com/managed"]; ok {
+		t.Fatalf("managed namespace label = %q, want unset before the rule is active", got)
+	}
+}
@@ -5,6 +5,7 @@ package validation
 
 import (
 	"context"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -46,7 +47,7 @@ func (h *rulesMetadataHandler) OnCreate(
 			return handlers.ErroredResponse(err)
 		}
 
-		bodies, err = ruleengine.FilterNamespaceRulesByAudience(h.configuration, tnt, req, bodies)
+		bodies, err = ruleengine.FilterNamespaceRulesByAudience(h.configuration, tnt, req, ruleengine.AdmissionNamespaceRules(bodies, time.Now()))
 		if err != nil {
 			return handlers.ErroredResponse(err)
 		}
@@ -75,7 +76,7 @@ func (h *rulesMetadataHandler) OnUpdate(
 			return handlers.ErroredResponse(err)
 		}
 
-		bodies, err = ruleengine.FilterNamespaceRulesByAudience(h.configuration, tnt, req, bodies)
+		bodies, err = ruleengine.FilterNamespaceRulesByAudience(h.configuration, tnt, req, ruleengine.AdmissionNamespaceRules(bodies, time.Now()))
 		if err != nil {
 			return handlers.ErroredResponse(err)
 		}
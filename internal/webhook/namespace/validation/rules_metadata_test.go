@@ -6,6 +6,7 @@ package validation
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/go-logr/logr"
 	admissionv1 "k8s.io/api/admission/v1"
@@ -106,3 +107,108 @@ func TestRulesMetadataHandlerValidatesStatusMetadata(t *testing.T) {
 		t.Fatalf("OnUpdate() response = %#v, want metadata injection denied", response)
 	}
 }
+
+func TestRulesMetadataHandlerHonorsActivationWindows(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	if err := corev1.AddToScheme(scheme); err != nil {
+		t.Fatalf("add core API to scheme: %v", err)
+	}
+	if err := capsulev1beta2.AddToScheme(scheme); err != nil {
+		t.Fatalf("add Capsule API to scheme: %v", err)
+	}
+
+	past := metav1.NewTime(time.Now().Add(-time.Hour))
+	future := metav1.NewTime(time.Now().Add(time.Hour))
+
+	tests := []struct {
+		name    string
+		rule    func(*rules.NamespaceRuleBodyNamespace)
+		allowed bool
+	}{
+		{
+			name: "pending rule",
+			rule: func(body *rules.NamespaceRuleBodyNamespace) {
+				body.NotBefore = &future
+			},
+			allowed: true,
+		},
+		{
+			name: "expired rule",
+			rule: func(body *rules.NamespaceRuleBodyNamespace) {
+				body.NotAfter = &past
+			},
+			allowed: true,
+		},
+		{
+			name: "escalated audit rule",
+			rule: func(body *rules.NamespaceRuleBodyNamespace) {
+				body.Enforce.Action = rules.ActionTypeAudit
+				body.Enforce.Metadata[0].Labels = map[string]rules.MetadataValueRule{
+					"pod-security.kubernetes.io/enforce": {
+						Values: []apiruntime.ExpressionMatch{{Exact: []string{"privileged"}}},
+					},
+				}
+				body.NotAfter = &past
+				body.EscalateTo = rules.ActionTypeDeny
+			},
+			allowed: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			body := &rules.NamespaceRuleBodyNamespace{
+				Enforce: &rules.NamespaceRuleEnforceBody{
+					Action: rules.ActionTypeAllow,
+					Metadata: []rules.MetadataRule{{
+						VersionKinds: apiruntime.VersionKinds{APIGroups: []string{"v1"}, Kinds: []string{"Namespace"}},
+						Labels: map[string]rules.MetadataValueRule{
+							"pod-security.kubernetes.io/enforce": {
+								Required: true,
+								Values:   []apiruntime.ExpressionMatch{{Exact: []string{"restricted", "baseline"}}},
+							},
+						},
+					}},
+				},
+			}
+			tt.rule(body)
+
+			tnt := &capsulev1beta2.Tenant{ObjectMeta: metav1.ObjectMeta{Name: "solar"}}
+			tnt.Spec.Rules = []*rules.NamespaceRuleBodyTenant{{NamespaceRuleBodyNamespace: body}}
+
+			oldNs := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+				Name:   "solar-system",
+				Labels: map[string]string{"pod-security.kubernetes.io/enforce": "baseline"},
+			}}
+			newNs := oldNs.DeepCopy()
+			newNs.Labels["pod-security.kubernetes.io/enforce"] = "privileged"
+
+			client := fake.NewClientBuilder().WithScheme(scheme).Build()
+			recorder := events.NewEventRecorder(nil, logr.Discard(), nil, nil)
+			handler := RulesMetadataHandler(cache.NewRegexCache(), nil)
+			request := admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+				Kind:      metav1.GroupVersionKind{Version: "v1", Kind: "Namespace"},
+				Operation: admissionv1.Update,
+			}}
+
+			response := handler.OnUpdate(
+				client,
+				client,
+				users.AdmissionUser{},
+				newNs,
+				oldNs,
+				nil,
+				recorder,
+				tnt,
+			)(context.Background(), request)
+
+			if allowed := response == nil || response.Allowed; allowed != tt.allowed {
+				t.Fatalf("OnUpdate() response = %#v, want allowed %t", response, tt.allowed)
+			}
+		})
+	}
+}
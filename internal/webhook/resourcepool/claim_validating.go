@@ -80,6 +80,10 @@ func (h *claimValidationHandler) OnUpdate(
 			if oldClaim.Spec.Pool != newClaim.Spec.Pool || !reflect.DeepEqual(oldClaim.Spec.ResourceClaims, newClaim.Spec.ResourceClaims) {
 				return ad.Denyf("cannot change the requested resources while claim is allocated to a resourcepool %s", oldClaim.Status.Pool.Name)
 			}
+
+			if !reflect.DeepEqual(oldClaim.Spec.ScopeSelector, newClaim.Spec.ScopeSelector) {
+				return ad.Denyf("cannot change the scope selector while claim is allocated to a resourcepool %s", oldClaim.Status.Pool.Name)
+			}
 		}
 
 		return nil
@@ -84,7 +84,13 @@ func (h *poolValidationHandler) OnUpdate(
 					)
 				}
 
-				if allocation.Cmp(qt) < 0 {
+				// Claimed resources may exceed the hard resources by the borrowed resources
+				capacity := allocation.DeepCopy()
+				if limit, ok := pool.Spec.BorrowingLimit[resourceName]; ok && pool.Spec.Cohort != "" {
+					capacity.Add(limit)
+				}
+
+				if capacity.Cmp(qt) < 0 {
 					return ad.Denyf(
 						"can not reduce %s usage to %s because quantity %s is claimed . Remove corresponding claims or keep the resources in the pool",
 						resourceName,
@@ -95,6 +101,36 @@ func (h *poolValidationHandler) OnUpdate(
 			}
 		}
 
+		// Verify the budgets of scopes are not reduced below their claimed resources
+		for name, status := range oldPool.Status.Scopes {
+			scope := pool.GetScope(name)
+
+			for resourceName, qt := range status.Claimed {
+				if qt.IsZero() {
+					continue
+				}
+
+				if scope == nil {
+					return ad.Denyf(
+						"can not remove scope %s as resource %s is still being allocated. Remove corresponding claims or keep the scope in the pool",
+						name,
+						resourceName,
+					)
+				}
+
+				allocation, exists := scope.Hard[resourceName]
+				if !exists || allocation.Cmp(qt) < 0 {
+					return ad.Denyf(
+						"can not reduce %s of scope %s to %s because quantity %s is claimed. Remove corresponding claims or keep the resources in the scope",
+						resourceName,
+						name,
+						allocation.String(),
+						qt.String(),
+					)
+				}
+			}
+		}
+
 		return nil
 	}
 }
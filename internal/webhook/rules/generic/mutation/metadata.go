@@ -29,18 +29,18 @@ func MetadataRules() handlers.TypedHandlerWithTenantWithRuleset[*unstructured.Un
 }
 
 func (h *metadataRules) OnCreate(_ client.Client, _ client.Reader, obj *unstructured.Unstructured, _ admission.Decoder, _ events.EventRecorder, _ *capsulev1beta2.Tenant, bodies []*apirules.NamespaceRuleBodyNamespace) handlers.Func {
-	return h.mutate(obj, bodies)
+	return h.mutate(obj, nil, bodies)
 }
 
-func (h *metadataRules) OnUpdate(_ client.Client, _ client.Reader, _ *unstructured.Unstructured, obj *unstructured.Unstructured, _ admission.Decoder, _ events.EventRecorder, _ *capsulev1beta2.Tenant, bodies []*apirules.NamespaceRuleBodyNamespace) handlers.Func {
-	return h.mutate(obj, bodies)
+func (h *metadataRules) OnUpdate(_ client.Client, _ client.Reader, oldObj *unstructured.Unstructured, obj *unstructured.Unstructured, _ admission.Decoder, _ events.EventRecorder, _ *capsulev1beta2.Tenant, bodies []*apirules.NamespaceRuleBodyNamespace) handlers.Func {
+	return h.mutate(obj, oldObj, bodies)
 }
 
 func (*metadataRules) OnDelete(client.Client, client.Reader, *unstructured.Unstructured, admission.Decoder, events.EventRecorder, *capsulev1beta2.Tenant, []*apirules.NamespaceRuleBodyNamespace) handlers.Func {
 	return func(context.Context, admission.Request) *admission.Response { return nil }
 }
 
-func (*metadataRules) mutate(obj *unstructured.Unstructured, bodies []*apirules.NamespaceRuleBodyNamespace) handlers.Func {
+func (*metadataRules) mutate(obj *unstructured.Unstructured, oldObj *unstructured.Unstructured, bodies []*apirules.NamespaceRuleBodyNamespace) handlers.Func {
 	return func(_ context.Context, req admission.Request) *admission.Response {
 		gvk := schema.GroupVersionKind{Group: req.Kind.Group, Version: req.Kind.Version, Kind: req.Kind.Kind}
 		if gvk.Version == "" || gvk.Kind == "" {
@@ -49,22 +49,38 @@ func (*metadataRules) mutate(obj *unstructured.Unstructured, bodies []*apirules.
 			return &response
 		}
 
-		metadataMutated := MutateMetadata(obj, gvk, bodies)
+		// Subresource requests (pods/ephemeralcontainers) only carry image changes.
+		metadataMutated := false
+		if req.SubResource == "" {
+			metadataMutated = MutateMetadata(obj, gvk, bodies)
+		}
 
-		resourcesMutated := false
+		specMutated := false
 
 		if req.Operation == admissionv1.Create {
-			var err error
+			for _, mutate := range []func(*unstructured.Unstructured, schema.GroupVersionKind, []*apirules.NamespaceRuleBodyNamespace) (bool, error){
+				MutateWorkloadResources,
+				MutateWorkloadScheduling,
+			} {
+				changed, err := mutate(obj, gvk, bodies)
+				if err != nil {
+					response := admission.Errored(http.StatusInternalServerError, err)
+
+					return &response
+				}
+
+				specMutated = specMutated || changed
+			}
+		}
 
-			resourcesMutated, err = MutateWorkloadResources(obj, gvk, bodies)
-			if err != nil {
-				response := admission.Errored(http.StatusInternalServerError, err)
+		imagesMutated, err := MutateWorkloadImages(obj, oldObj, gvk, bodies)
+		if err != nil {
+			response := admission.Errored(http.StatusInternalServerError, err)
 
-				return &response
-			}
+			return &response
 		}
 
-		if !metadataMutated && !resourcesMutated {
+		if !metadataMutated && !specMutated && !imagesMutated {
 			return nil
 		}
 
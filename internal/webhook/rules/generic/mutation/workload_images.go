@@ -0,0 +1,227 @@
+// Copyright 2020-2026 Project Capsule Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package mutation
+
+import (
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	apirules "github.com/projectcapsule/capsule/pkg/api/rules"
+)
+
+const dockerHubRegistry = "docker.io"
+
+// MutateWorkloadImages rewrites the image references of a Pod according to
+// the registry rewrite rules. References which are unchanged compared to the
+// old Pod are kept, so running containers are never restarted by a rewrite.
+func MutateWorkloadImages(
+	obj *unstructured.Unstructured,
+	oldObj *unstructured.Unstructured,
+	gvk schema.GroupVersionKind,
+	bodies []*apirules.NamespaceRuleBodyNamespace,
+) (bool, error) {
+	if obj == nil || gvk != corev1.SchemeGroupVersion.WithKind("Pod") || !hasRegistryRewrites(bodies) {
+		return false, nil
+	}
+
+	pod := &corev1.Pod{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, pod); err != nil {
+		return false, fmt.Errorf("decode Pod images: %w", err)
+	}
+
+	var oldPod *corev1.Pod
+
+	if oldObj != nil && len(oldObj.Object) > 0 {
+		oldPod = &corev1.Pod{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(oldObj.Object, oldPod); err != nil {
+			return false, fmt.Errorf("decode old Pod images: %w", err)
+		}
+	}
+
+	if !MutatePodImages(pod, oldPod, bodies) {
+		return false, nil
+	}
+
+	mutated, err := runtime.DefaultUnstructuredConverter.ToUnstructured(pod)
+	if err != nil {
+		return false, fmt.Errorf("encode Pod images: %w", err)
+	}
+
+	obj.Object = mutated
+
+	return true, nil
+}
+
+func MutatePodImages(
+	pod *corev1.Pod,
+	oldPod *corev1.Pod,
+	bodies []*apirules.NamespaceRuleBodyNamespace,
+) bool {
+	if pod == nil {
+		return false
+	}
+
+	var oldImages map[string]string
+	if oldPod != nil {
+		oldImages = podImages(oldPod)
+	}
+
+	mutated := false
+
+	rewrite := func(rewrites []apirules.OCIRegistryRewrite, key string, reference *string) {
+		if len(rewrites) == 0 {
+			return
+		}
+
+		if old, found := oldImages[key]; found && old == *reference {
+			return
+		}
+
+		rewritten, changed := rewriteImageReference(*reference, rewrites)
+		if !changed {
+			return
+		}
+
+		*reference = rewritten
+		mutated = true
+	}
+
+	initContainerRewrites := collectRegistryRewrites(bodies, apirules.ValidateInitContainers)
+	for i := range pod.Spec.InitContainers {
+		c := &pod.Spec.InitContainers[i]
+		rewrite(initContainerRewrites, "initContainers/"+c.Name, &c.Image)
+	}
+
+	containerRewrites := collectRegistryRewrites(bodies, apirules.ValidateContainers)
+	for i := range pod.Spec.Containers {
+		c := &pod.Spec.Containers[i]
+		rewrite(containerRewrites, "containers/"+c.Name, &c.Image)
+	}
+
+	ephemeralContainerRewrites := collectRegistryRewrites(bodies, apirules.ValidateEphemeralContainers)
+	for i := range pod.Spec.EphemeralContainers {
+		c := &pod.Spec.EphemeralContainers[i]
+		rewrite(ephemeralContainerRewrites, "ephemeralContainers/"+c.Name, &c.Image)
+	}
+
+	volumeRewrites := collectRegistryRewrites(bodies, apirules.ValidateVolumes)
+	for i := range pod.Spec.Volumes {
+		v := &pod.Spec.Volumes[i]
+		if v.Image == nil {
+			continue
+		}
+
+		rewrite(volumeRewrites, "volumes/"+v.Name, &v.Image.Reference)
+	}
+
+	return mutated
+}
+
+func podImages(pod *corev1.Pod) map[string]string {
+	out := make(map[string]string)
+
+	for _, c := range pod.Spec.InitContainers {
+		out["initContainers/"+c.Name] = c.Image
+	}
+
+	for _, c := range pod.Spec.Containers {
+		out["containers/"+c.Name] = c.Image
+	}
+
+	for _, c := range pod.Spec.EphemeralContainers {
+		out["ephemeralContainers/"+c.Name] = c.Image
+	}
+
+	for _, v := range pod.Spec.Volumes {
+		if v.Image != nil {
+			out["volumes/"+v.Name] = v.Image.Reference
+		}
+	}
+
+	return out
+}
+
+func hasRegistryRewrites(bodies []*apirules.NamespaceRuleBodyNamespace) bool {
+	for _, body := range bodies {
+		if body == nil || body.Enforce == nil {
+			continue
+		}
+
+		for _, registry := range body.Enforce.Workloads.Registries {
+			if registry.Rewrite != nil {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// collectRegistryRewrites returns the rewrites applying to the target in rule order.
+func collectRegistryRewrites(
+	bodies []*apirules.NamespaceRuleBodyNamespace,
+	target apirules.WorkloadValidationTarget,
+) []apirules.OCIRegistryRewrite {
+	var out []apirules.OCIRegistryRewrite
+
+	for _, body := range bodies {
+		if body == nil || body.Enforce == nil || !body.Enforce.GetWorkloadTargets(target) {
+			continue
+		}
+
+		for _, registry := range body.Enforce.Workloads.Registries {
+			if registry.Rewrite != nil {
+				out = append(out, *registry.Rewrite)
+			}
+		}
+	}
+
+	return out
+}
+
+// rewriteImageReference applies the last matching rewrite. Rewrites are not
+// chained, the reference is rewritten at most once.
+func rewriteImageReference(reference string, rewrites []apirules.OCIRegistryRewrite) (string, bool) {
+	reference = strings.TrimSpace(reference)
+	if reference == "" {
+		return reference, false
+	}
+
+	for i := len(rewrites) - 1; i >= 0; i-- {
+		from := strings.TrimSuffix(strings.TrimSpace(rewrites[i].From), "/")
+		to := strings.TrimSuffix(strings.TrimSpace(rewrites[i].To), "/")
+
+		if from == "" || to == "" {
+			continue
+		}
+
+		for _, candidate := range []string{reference, dockerHubReference(reference)} {
+			if rest, ok := strings.CutPrefix(candidate, from+"/"); ok {
+				return to + "/" + rest, true
+			}
+		}
+	}
+
+	return reference, false
+}
+
+// dockerHubReference returns the fully qualified form of references without
+// a registry, which are pulled from Docker Hub.
+func dockerHubReference(reference string) string {
+	first, rest, found := strings.Cut(reference, "/")
+	if !found {
+		return dockerHubRegistry + "/library/" + reference
+	}
+
+	if strings.ContainsAny(first, ".:") || first == "localhost" {
+		return reference
+	}
+
+	return dockerHubRegistry + "/" + first + "/" + rest
+}
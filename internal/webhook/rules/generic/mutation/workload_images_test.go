@@ -0,0 +1,165 @@
+// Copyright 2020-2026 Project Capsule Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package mutation
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/projectcapsule/capsule/pkg/api/rules"
+)
+
+func TestRewriteImageReference(t *testing.T) {
+	t.Parallel()
+
+	dockerHub := []rules.OCIRegistryRewrite{{From: "docker.io", To: "mirror.internal/dockerhub"}}
+
+	tests := []struct {
+		name      string
+		reference string
+		rewrites  []rules.OCIRegistryRewrite
+		want      string
+		changed   bool
+	}{
+		{
+			name:      "explicit registry",
+			reference: "docker.io/library/nginx:1.27",
+			rewrites:  dockerHub,
+			want:      "mirror.internal/dockerhub/library/nginx:1.27",
+			changed:   true,
+		},
+		{
+			name:      "implicit docker hub library image",
+			reference: "nginx:1.27",
+			rewrites:  dockerHub,
+			want:      "mirror.internal/dockerhub/library/nginx:1.27",
+			changed:   true,
+		},
+		{
+			name:      "implicit docker hub user image",
+			reference: "bitnami/redis@sha256:abc",
+			rewrites:  dockerHub,
+			want:      "mirror.internal/dockerhub/bitnami/redis@sha256:abc",
+			changed:   true,
+		},
+		{
+			name:      "other registry is kept",
+			reference: "ghcr.io/projectcapsule/capsule:v0.10.0",
+			rewrites:  dockerHub,
+			want:      "ghcr.io/projectcapsule/capsule:v0.10.0",
+		},
+		{
+			name:      "prefix must end on a path boundary",
+			reference: "docker.io.evil.com/app:1.0",
+			rewrites:  dockerHub,
+			want:      "docker.io.evil.com/app:1.0",
+		},
+		{
+			name:      "repository prefix",
+			reference: "ghcr.io/projectcapsule/capsule:v0.10.0",
+			rewrites:  []rules.OCIRegistryRewrite{{From: "ghcr.io/projectcapsule/", To: "mirror.internal/capsule"}},
+			want:      "mirror.internal/capsule/capsule:v0.10.0",
+			changed:   true,
+		},
+		{
+			name:      "last matching rewrite wins and is applied once",
+			reference: "quay.io/app:1.0",
+			rewrites: []rules.OCIRegistryRewrite{
+				{From: "quay.io", To: "first.internal"},
+				{From: "quay.io", To: "second.internal"},
+				{From: "second.internal", To: "third.internal"},
+			},
+			want:    "second.internal/app:1.0",
+			changed: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, changed := rewriteImageReference(tt.reference, tt.rewrites)
+			if got != tt.want || changed != tt.changed {
+				t.Fatalf("rewriteImageReference() = (%q, %v), want (%q, %v)", got, changed, tt.want, tt.changed)
+			}
+		})
+	}
+}
+
+func TestMutatePodImagesHonorsTargets(t *testing.T) {
+	t.Parallel()
+
+	pod := &corev1.Pod{Spec: corev1.PodSpec{
+		InitContainers:      []corev1.Container{{Name: "init", Image: "busybox"}},
+		Containers:          []corev1.Container{{Name: "app", Image: "nginx"}},
+		EphemeralContainers: []corev1.EphemeralContainer{{EphemeralContainerCommon: corev1.EphemeralContainerCommon{Name: "debug", Image: "busybox"}}},
+		Volumes: []corev1.Volume{{
+			Name:         "data",
+			VolumeSource: corev1.VolumeSource{Image: &corev1.ImageVolumeSource{Reference: "docker.io/org/data:1"}},
+		}},
+	}}
+
+	bodies := []*rules.NamespaceRuleBodyNamespace{{Enforce: &rules.NamespaceRuleEnforceBody{
+		Workloads: rules.NamespaceRuleEnforceWorkloadsBody{
+			Targets: []rules.WorkloadValidationTarget{rules.ValidateContainers, rules.ValidateVolumes},
+			Registries: []rules.OCIRegistry{{
+				Rewrite: &rules.OCIRegistryRewrite{From: "docker.io", To: "mirror.internal/dockerhub"},
+			}},
+		},
+	}}}
+
+	if !MutatePodImages(pod, nil, bodies) {
+		t.Fatal("MutatePodImages() changed = false, want true")
+	}
+
+	if got := pod.Spec.Containers[0].Image; got != "mirror.internal/dockerhub/library/nginx" {
+		t.Fatalf("container image = %q", got)
+	}
+
+	if got := pod.Spec.Volumes[0].Image.Reference; got != "mirror.internal/dockerhub/org/data:1" {
+		t.Fatalf("volume image = %q", got)
+	}
+
+	if got := pod.Spec.InitContainers[0].Image; got != "busybox" {
+		t.Fatalf("init container image = %q, want unchanged", got)
+	}
+
+	if got := pod.Spec.EphemeralContainers[0].Image; got != "busybox" {
+		t.Fatalf("ephemeral container image = %q, want unchanged", got)
+	}
+}
+
+func TestMutatePodImagesKeepsUnchangedImagesOnUpdate(t *testing.T) {
+	t.Parallel()
+
+	oldPod := &corev1.Pod{Spec: corev1.PodSpec{
+		Containers: []corev1.Container{{Name: "app", Image: "nginx"}},
+	}}
+
+	pod := oldPod.DeepCopy()
+	pod.Spec.EphemeralContainers = []corev1.EphemeralContainer{{
+		EphemeralContainerCommon: corev1.EphemeralContainerCommon{Name: "debug", Image: "busybox"},
+	}}
+
+	bodies := []*rules.NamespaceRuleBodyNamespace{{Enforce: &rules.NamespaceRuleEnforceBody{
+		Workloads: rules.NamespaceRuleEnforceWorkloadsBody{
+			Registries: []rules.OCIRegistry{{
+				Rewrite: &rules.OCIRegistryRewrite{From: "docker.io", To: "mirror.internal/dockerhub"},
+			}},
+		},
+	}}}
+
+	if !MutatePodImages(pod, oldPod, bodies) {
+		t.Fatal("MutatePodImages() changed = false, want true")
+	}
+
+	if got := pod.Spec.Containers[0].Image; got != "nginx" {
+		t.Fatalf("container image = %q, want unchanged", got)
+	}
+
+	if got := pod.Spec.EphemeralContainers[0].Image; got != "mirror.internal/dockerhub/library/busybox" {
+		t.Fatalf("ephemeral container image = %q", got)
+	}
+}
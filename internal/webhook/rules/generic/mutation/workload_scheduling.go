@@ -0,0 +1,123 @@
+// Copyright 2020-2026 Project Capsule Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package mutation
+
+import (
+	"fmt"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	apirules "github.com/projectcapsule/capsule/pkg/api/rules"
+)
+
+// MutateWorkloadScheduling injects the default tolerations and the required
+// node affinity of the scheduling rules into a Pod.
+func MutateWorkloadScheduling(
+	obj *unstructured.Unstructured,
+	gvk schema.GroupVersionKind,
+	bodies []*apirules.NamespaceRuleBodyNamespace,
+) (bool, error) {
+	if obj == nil || gvk != corev1.SchemeGroupVersion.WithKind("Pod") || !hasSchedulingRules(bodies) {
+		return false, nil
+	}
+
+	pod := &corev1.Pod{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, pod); err != nil {
+		return false, fmt.Errorf("decode Pod scheduling: %w", err)
+	}
+
+	if !MutatePodScheduling(pod, bodies) {
+		return false, nil
+	}
+
+	mutated, err := runtime.DefaultUnstructuredConverter.ToUnstructured(pod)
+	if err != nil {
+		return false, fmt.Errorf("encode Pod scheduling: %w", err)
+	}
+
+	obj.Object = mutated
+
+	return true, nil
+}
+
+func MutatePodScheduling(
+	pod *corev1.Pod,
+	bodies []*apirules.NamespaceRuleBodyNamespace,
+) bool {
+	if pod == nil {
+		return false
+	}
+
+	mutated := false
+
+	var nodeAffinity *corev1.NodeSelector
+
+	for _, body := range bodies {
+		scheduling := bodySchedulingRules(body)
+		if scheduling == nil {
+			continue
+		}
+
+		for _, toleration := range scheduling.DefaultTolerations {
+			if slices.ContainsFunc(pod.Spec.Tolerations, func(existing corev1.Toleration) bool {
+				return existing.MatchToleration(&toleration)
+			}) {
+				continue
+			}
+
+			pod.Spec.Tolerations = append(pod.Spec.Tolerations, toleration)
+			mutated = true
+		}
+
+		if scheduling.NodeAffinity != nil && body.Enforce.Action.OrDefault() == apirules.ActionTypeAllow {
+			nodeAffinity = scheduling.NodeAffinity
+		}
+	}
+
+	if nodeAffinity == nil || len(nodeAffinity.NodeSelectorTerms) == 0 || hasRequiredNodeAffinity(pod) {
+		return mutated
+	}
+
+	if pod.Spec.Affinity == nil {
+		pod.Spec.Affinity = &corev1.Affinity{}
+	}
+
+	if pod.Spec.Affinity.NodeAffinity == nil {
+		pod.Spec.Affinity.NodeAffinity = &corev1.NodeAffinity{}
+	}
+
+	pod.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution = nodeAffinity.DeepCopy()
+
+	return true
+}
+
+func hasSchedulingRules(bodies []*apirules.NamespaceRuleBodyNamespace) bool {
+	return slices.ContainsFunc(bodies, func(body *apirules.NamespaceRuleBodyNamespace) bool {
+		return bodySchedulingRules(body) != nil
+	})
+}
+
+func bodySchedulingRules(body *apirules.NamespaceRuleBodyNamespace) *apirules.WorkloadSchedulingRules {
+	if body == nil || body.Enforce == nil || body.Enforce.Workloads.Scheduling == nil {
+		return nil
+	}
+
+	if !body.Enforce.GetWorkloadTargets(apirules.ValidatePod) {
+		return nil
+	}
+
+	return body.Enforce.Workloads.Scheduling
+}
+
+func hasRequiredNodeAffinity(pod *corev1.Pod) bool {
+	affinity := pod.Spec.Affinity
+
+	return affinity != nil && affinity.NodeAffinity != nil &&
+		affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution != nil &&
+		len(affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms) > 0
+}
@@ -0,0 +1,111 @@
+// Copyright 2020-2026 Project Capsule Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package mutation
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/projectcapsule/capsule/pkg/api/rules"
+)
+
+func TestMutatePodSchedulingInjectsDefaults(t *testing.T) {
+	t.Parallel()
+
+	gpuToleration := corev1.Toleration{
+		Key:      "dedicated",
+		Operator: corev1.TolerationOpEqual,
+		Value:    "gpu",
+		Effect:   corev1.TaintEffectNoSchedule,
+	}
+
+	pod := &corev1.Pod{Spec: corev1.PodSpec{
+		Containers:  []corev1.Container{{Name: "app"}},
+		Tolerations: []corev1.Toleration{gpuToleration},
+	}}
+
+	bodies := []*rules.NamespaceRuleBodyNamespace{{Enforce: &rules.NamespaceRuleEnforceBody{
+		Action: rules.ActionTypeAllow,
+		Workloads: rules.NamespaceRuleEnforceWorkloadsBody{
+			Scheduling: &rules.WorkloadSchedulingRules{
+				NodeAffinity: &corev1.NodeSelector{NodeSelectorTerms: []corev1.NodeSelectorTerm{{
+					MatchExpressions: []corev1.NodeSelectorRequirement{
+						{Key: "pool", Operator: corev1.NodeSelectorOpIn, Values: []string{"gpu"}},
+					},
+				}}},
+				DefaultTolerations: []corev1.Toleration{
+					gpuToleration,
+					{Key: "pool", Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoSchedule},
+				},
+			},
+		},
+	}}}
+
+	if !MutatePodScheduling(pod, bodies) {
+		t.Fatal("MutatePodScheduling() changed = false, want true")
+	}
+
+	if len(pod.Spec.Tolerations) != 2 {
+		t.Fatalf("expected 2 tolerations, got %#v", pod.Spec.Tolerations)
+	}
+
+	if pod.Spec.Tolerations[1].Key != "pool" {
+		t.Fatalf("expected injected pool toleration, got %#v", pod.Spec.Tolerations[1])
+	}
+
+	if !hasRequiredNodeAffinity(pod) {
+		t.Fatal("expected required node affinity to be injected")
+	}
+
+	if MutatePodScheduling(pod, bodies) {
+		t.Fatal("MutatePodScheduling() changed = true on second run, want false")
+	}
+}
+
+func TestMutatePodSchedulingKeepsExistingNodeAffinity(t *testing.T) {
+	t.Parallel()
+
+	existing := &corev1.NodeSelector{NodeSelectorTerms: []corev1.NodeSelectorTerm{{
+		MatchExpressions: []corev1.NodeSelectorRequirement{
+			{Key: "zone", Operator: corev1.NodeSelectorOpIn, Values: []string{"z1"}},
+		},
+	}}}
+
+	pod := &corev1.Pod{Spec: corev1.PodSpec{
+		Affinity: &corev1.Affinity{NodeAffinity: &corev1.NodeAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: existing.DeepCopy(),
+		}},
+	}}
+
+	bodies := []*rules.NamespaceRuleBodyNamespace{
+		{Enforce: &rules.NamespaceRuleEnforceBody{
+			Action: rules.ActionTypeDeny,
+			Workloads: rules.NamespaceRuleEnforceWorkloadsBody{
+				Scheduling: &rules.WorkloadSchedulingRules{NodeAffinity: existing},
+			},
+		}},
+		{Enforce: &rules.NamespaceRuleEnforceBody{
+			Action: rules.ActionTypeAllow,
+			Workloads: rules.NamespaceRuleEnforceWorkloadsBody{
+				Scheduling: &rules.WorkloadSchedulingRules{NodeAffinity: &corev1.NodeSelector{
+					NodeSelectorTerms: []corev1.NodeSelectorTerm{{
+						MatchExpressions: []corev1.NodeSelectorRequirement{
+							{Key: "pool", Operator: corev1.NodeSelectorOpExists},
+						},
+					}},
+				}},
+			},
+		}},
+	}
+
+	if MutatePodScheduling(pod, bodies) {
+		t.Fatal("MutatePodScheduling() changed = true, want false")
+	}
+
+	got := pod.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms[0].MatchExpressions[0].Key
+	if got != "zone" {
+		t.Fatalf("expected existing node affinity to be kept, got key %q", got)
+	}
+}
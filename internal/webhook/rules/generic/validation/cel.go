@@ -0,0 +1,277 @@
+// Copyright 2020-2026 Project Capsule Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package validation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apiserver/pkg/cel/environment"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	"github.com/projectcapsule/capsule/internal/cache"
+	apirules "github.com/projectcapsule/capsule/pkg/api/rules"
+	"github.com/projectcapsule/capsule/pkg/ruleengine"
+	ad "github.com/projectcapsule/capsule/pkg/runtime/admission"
+	celruntime "github.com/projectcapsule/capsule/pkg/runtime/cel"
+	"github.com/projectcapsule/capsule/pkg/runtime/events"
+	"github.com/projectcapsule/capsule/pkg/runtime/handlers"
+)
+
+type celRules struct {
+	celCache *cache.CELCache
+}
+
+func CELRules(celCache *cache.CELCache) handlers.TypedHandlerWithTenantWithRuleset[*unstructured.Unstructured] {
+	return &celRules{celCache: celCache}
+}
+
+func (h *celRules) OnCreate(
+	_ client.Client,
+	_ client.Reader,
+	obj *unstructured.Unstructured,
+	_ admission.Decoder,
+	recorder events.EventRecorder,
+	tnt *capsulev1beta2.Tenant,
+	bodies []*apirules.NamespaceRuleBodyNamespace,
+) handlers.Func {
+	return h.validate(nil, obj, recorder, tnt, bodies)
+}
+
+func (h *celRules) OnUpdate(
+	_ client.Client,
+	_ client.Reader,
+	old *unstructured.Unstructured,
+	obj *unstructured.Unstructured,
+	_ admission.Decoder,
+	recorder events.EventRecorder,
+	tnt *capsulev1beta2.Tenant,
+	bodies []*apirules.NamespaceRuleBodyNamespace,
+) handlers.Func {
+	return h.validate(old, obj, recorder, tnt, bodies)
+}
+
+func (*celRules) OnDelete(
+	client.Client,
+	client.Reader,
+	*unstructured.Unstructured,
+	admission.Decoder,
+	events.EventRecorder,
+	*capsulev1beta2.Tenant,
+	[]*apirules.NamespaceRuleBodyNamespace,
+) handlers.Func {
+	return func(context.Context, admission.Request) *admission.Response { return nil }
+}
+
+func (h *celRules) validate(
+	old *unstructured.Unstructured,
+	obj *unstructured.Unstructured,
+	recorder events.EventRecorder,
+	tnt *capsulev1beta2.Tenant,
+	bodies []*apirules.NamespaceRuleBodyNamespace,
+) handlers.Func {
+	return func(ctx context.Context, req admission.Request) *admission.Response {
+		enforceBodies := ruleengine.EnforceBodiesFromNamespaceRules(bodies)
+
+		evaluation, err := h.evaluate(ctx, requestGVK(req), req.UserInfo, old, obj, tnt, enforceBodies, false)
+		if err != nil {
+			return ad.Deny(err.Error())
+		}
+
+		if evaluation == nil {
+			return nil
+		}
+
+		for _, audit := range evaluation.Audits {
+			recorder.LabeledEvent(
+				obj,
+				corev1.EventTypeNormal,
+				events.ReasonNamespaceRuleAudit,
+				events.ActionRuleAudit,
+				audit.Message,
+			).
+				WithRelated(tnt).
+				WithTenantLabel(tnt).
+				WithRequestAnnotations(req).
+				Emit(ctx)
+		}
+
+		if err := evaluation.BlockingError(); err != nil {
+			var decisionErr *ruleengine.DecisionError
+
+			if errors.As(err, &decisionErr) && decisionErr.Decision != nil {
+				recorder.LabeledEvent(
+					obj,
+					corev1.EventTypeWarning,
+					decisionErr.Decision.EventReason,
+					events.ActionValidationDenied,
+					decisionErr.Decision.Message,
+				).
+					WithRelated(tnt).
+					WithTenantLabel(tnt).
+					WithRequestAnnotations(req).
+					Emit(ctx)
+			}
+
+			return ad.Deny(err.Error())
+		}
+
+		return nil
+	}
+}
+
+func (h *celRules) evaluate(
+	ctx context.Context,
+	gvk schema.GroupVersionKind,
+	userInfo authenticationv1.UserInfo,
+	old *unstructured.Unstructured,
+	obj *unstructured.Unstructured,
+	tnt *capsulev1beta2.Tenant,
+	enforceBodies []*apirules.NamespaceRuleEnforceBody,
+	existing bool,
+) (*ruleengine.Evaluation, error) {
+	if obj == nil {
+		return nil, nil
+	}
+
+	if !hasCELRules(gvk, enforceBodies) {
+		return nil, nil
+	}
+
+	activation, err := celActivation(userInfo, old, obj, tnt)
+	if err != nil {
+		return nil, err
+	}
+
+	return ruleengine.EvaluateEnforce(
+		obj,
+		enforceBodies,
+		ruleengine.Set[apirules.CELRule, *unstructured.Unstructured]{
+			Name:        "object",
+			EventReason: events.ReasonForbiddenCELRule,
+			Values: func(obj *unstructured.Unstructured) []ruleengine.Value {
+				return []ruleengine.Value{
+					{
+						Value: celObjectName(gvk, obj),
+						Path:  "object",
+					},
+				}
+			},
+			Rules: func(enforce *apirules.NamespaceRuleEnforceBody) []apirules.CELRule {
+				if enforce == nil {
+					return nil
+				}
+
+				out := make([]apirules.CELRule, 0, len(enforce.CEL))
+
+				for _, rule := range enforce.CEL {
+					if !rule.MatchesGroupVersionKind(gvk) || (existing && h.requestDependent(rule)) {
+						continue
+					}
+
+					out = append(out, rule)
+				}
+
+				return out
+			},
+			Matches: func(rule apirules.CELRule, _ ruleengine.Value) (ruleengine.Match, error) {
+				compiled, err := h.celCache.GetOrCompileRule(rule.Expression, environment.StoredExpressions)
+				if err != nil {
+					return ruleengine.Match{}, fmt.Errorf("CEL rule %q cannot be compiled: %w", rule.Description(), err)
+				}
+
+				matched, err := compiled.EvaluateRule(ctx, activation)
+				if err != nil {
+					if rule.IgnoresFailures() {
+						return ruleengine.Match{}, nil
+					}
+
+					return ruleengine.Match{}, fmt.Errorf("CEL rule %q failed on %s: %w", rule.Description(), celObjectName(gvk, obj), err)
+				}
+
+				return ruleengine.Match{Matched: matched}, nil
+			},
+			RuleDescription:    apirules.CELRule.Description,
+			AllowedDescription: "Allowed expressions",
+		},
+	)
+}
+
+// requestDependent reports whether the rule reads the previous object or the
+// requesting user. Such rules can only be evaluated during admission.
+func (h *celRules) requestDependent(rule apirules.CELRule) bool {
+	compiled, err := h.celCache.GetOrCompileRule(rule.Expression, environment.StoredExpressions)
+	if err != nil {
+		// Evaluation reports the compile error.
+		return false
+	}
+
+	return compiled.References(celruntime.OldObjectVariable) || compiled.References(celruntime.UserInfoVariable)
+}
+
+func hasCELRules(gvk schema.GroupVersionKind, bodies []*apirules.NamespaceRuleEnforceBody) bool {
+	for _, body := range bodies {
+		if body == nil {
+			continue
+		}
+
+		for _, rule := range body.CEL {
+			if rule.MatchesGroupVersionKind(gvk) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func celObjectName(gvk schema.GroupVersionKind, obj *unstructured.Unstructured) string {
+	name := obj.GetName()
+	if name == "" {
+		name = obj.GetGenerateName()
+	}
+
+	return fmt.Sprintf("%s/%s", gvk.Kind, name)
+}
+
+func celActivation(
+	userInfo authenticationv1.UserInfo,
+	old *unstructured.Unstructured,
+	obj *unstructured.Unstructured,
+	tnt *capsulev1beta2.Tenant,
+) (celruntime.RuleActivation, error) {
+	activation := celruntime.RuleActivation{
+		Object: obj.Object,
+	}
+
+	if old != nil {
+		activation.OldObject = old.Object
+	}
+
+	if tnt != nil {
+		tenant, err := k8sruntime.DefaultUnstructuredConverter.ToUnstructured(tnt)
+		if err != nil {
+			return activation, fmt.Errorf("convert tenant for CEL evaluation: %w", err)
+		}
+
+		activation.Tenant = tenant
+	}
+
+	user, err := k8sruntime.DefaultUnstructuredConverter.ToUnstructured(&userInfo)
+	if err != nil {
+		return activation, fmt.Errorf("convert user info for CEL evaluation: %w", err)
+	}
+
+	activation.UserInfo = user
+
+	return activation, nil
+}
@@ -0,0 +1,261 @@
+// Copyright 2020-2026 Project Capsule Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package validation
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	"github.com/projectcapsule/capsule/internal/cache"
+	"github.com/projectcapsule/capsule/pkg/api/rules"
+	"github.com/projectcapsule/capsule/pkg/api/runtime"
+)
+
+func TestCELRulesEvaluate(t *testing.T) {
+	t.Parallel()
+
+	celCache, err := cache.NewCELCache()
+	if err != nil {
+		t.Fatalf("NewCELCache() error = %v", err)
+	}
+
+	handler := &celRules{celCache: celCache}
+	tnt := &capsulev1beta2.Tenant{ObjectMeta: metav1.ObjectMeta{Name: "solar"}}
+
+	tests := []struct {
+		name         string
+		action       rules.ActionType
+		expression   string
+		kinds        []string
+		old          *unstructured.Unstructured
+		wantBlocking bool
+		wantAudits   int
+	}{
+		{
+			name:         "deny matching expression",
+			action:       rules.ActionTypeDeny,
+			expression:   `object.spec.replicas > 3`,
+			kinds:        []string{"Deployment"},
+			wantBlocking: true,
+		},
+		{
+			name:       "deny non matching expression",
+			action:     rules.ActionTypeDeny,
+			expression: `object.spec.replicas > 10`,
+			kinds:      []string{"Deployment"},
+		},
+		{
+			name:         "allow miss blocks",
+			action:       rules.ActionTypeAllow,
+			expression:   `tenant.metadata.name == "wind"`,
+			kinds:        []string{"Deployment"},
+			wantBlocking: true,
+		},
+		{
+			name:       "allow match with tenant and user",
+			action:     rules.ActionTypeAllow,
+			expression: `tenant.metadata.name == "solar" && userInfo.username == "alice"`,
+			kinds:      []string{"Deployment"},
+		},
+		{
+			name:       "audit does not block",
+			action:     rules.ActionTypeAudit,
+			expression: `object.spec.replicas > 3`,
+			kinds:      []string{"Deployment"},
+			wantAudits: 1,
+		},
+		{
+			name:       "old object is null on create",
+			action:     rules.ActionTypeDeny,
+			expression: `oldObject != null`,
+			kinds:      []string{"Deployment"},
+		},
+		{
+			name:         "old object on update",
+			action:       rules.ActionTypeDeny,
+			expression:   `oldObject != null && object.spec.replicas > oldObject.spec.replicas`,
+			kinds:        []string{"Deployment"},
+			old:          deploymentWithReplicas(2),
+			wantBlocking: true,
+		},
+		{
+			name:       "kind mismatch is ignored",
+			action:     rules.ActionTypeDeny,
+			expression: `true`,
+			kinds:      []string{"StatefulSet"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			bodies := []*rules.NamespaceRuleEnforceBody{{
+				Action: tt.action,
+				CEL: []rules.CELRule{{
+					VersionKinds: runtime.VersionKinds{APIGroups: []string{"apps"}, Kinds: tt.kinds},
+					Expression:   tt.expression,
+				}},
+			}}
+
+			evaluation, err := handler.evaluate(
+				context.Background(),
+				celGVK,
+				authenticationv1.UserInfo{Username: "alice"},
+				tt.old,
+				deploymentWithReplicas(5),
+				tnt,
+				bodies,
+				false,
+			)
+			if err != nil {
+				t.Fatalf("evaluate() error = %v", err)
+			}
+
+			blocking := evaluation != nil && evaluation.Blocking != nil
+			if blocking != tt.wantBlocking {
+				t.Fatalf("evaluate() blocking = %v, want %v (%#v)", blocking, tt.wantBlocking, evaluation)
+			}
+
+			audits := 0
+			if evaluation != nil {
+				audits = len(evaluation.Audits)
+			}
+
+			if audits != tt.wantAudits {
+				t.Fatalf("evaluate() audits = %d, want %d", audits, tt.wantAudits)
+			}
+		})
+	}
+}
+
+func TestCELRulesEvaluateUsesMessage(t *testing.T) {
+	t.Parallel()
+
+	celCache, err := cache.NewCELCache()
+	if err != nil {
+		t.Fatalf("NewCELCache() error = %v", err)
+	}
+
+	evaluation, err := (&celRules{celCache: celCache}).evaluate(
+		context.Background(),
+		celGVK,
+		authenticationv1.UserInfo{Username: "alice"},
+		nil,
+		deploymentWithReplicas(5),
+		nil,
+		[]*rules.NamespaceRuleEnforceBody{{
+			Action: rules.ActionTypeDeny,
+			CEL: []rules.CELRule{{
+				VersionKinds: runtime.VersionKinds{APIGroups: []string{"apps"}, Kinds: []string{"*"}},
+				Expression:   `object.spec.replicas > 3`,
+				Message:      "at most 3 replicas are allowed",
+			}},
+		}},
+		false,
+	)
+	if err != nil {
+		t.Fatalf("evaluate() error = %v", err)
+	}
+
+	if err := evaluation.BlockingError(); err == nil ||
+		!strings.Contains(err.Error(), "at most 3 replicas are allowed") ||
+		!strings.Contains(err.Error(), "Deployment/web") {
+		t.Fatalf("BlockingError() = %v, want configured message", err)
+	}
+}
+
+func TestMatchesGenericCELRequest(t *testing.T) {
+	t.Parallel()
+
+	if !matchesGenericCELRequest(requestWithKind("apps", "Deployment")) {
+		t.Fatalf("matchesGenericCELRequest(Deployment) = false, want true")
+	}
+
+	if matchesGenericCELRequest(requestWithKind("", "Namespace")) {
+		t.Fatalf("matchesGenericCELRequest(Namespace) = true, want false")
+	}
+
+	scale := requestWithKind("apps", "Deployment")
+	scale.SubResource = "scale"
+
+	if matchesGenericCELRequest(scale) {
+		t.Fatalf("matchesGenericCELRequest(scale) = true, want false")
+	}
+}
+
+var celGVK = schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}
+
+func deploymentWithReplicas(replicas int64) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata":   map[string]any{"name": "web", "namespace": "solar-prod"},
+		"spec":       map[string]any{"replicas": replicas},
+	}}
+}
+
+func TestCELRulesEvaluateFailurePolicy(t *testing.T) {
+	t.Parallel()
+
+	celCache, err := cache.NewCELCache()
+	if err != nil {
+		t.Fatalf("NewCELCache() error = %v", err)
+	}
+
+	tests := []struct {
+		name          string
+		failurePolicy admissionregistrationv1.FailurePolicyType
+		wantErr       bool
+	}{
+		{name: "fail by default", wantErr: true},
+		{name: "fail", failurePolicy: admissionregistrationv1.Fail, wantErr: true},
+		{name: "ignore", failurePolicy: admissionregistrationv1.Ignore},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			evaluation, err := (&celRules{celCache: celCache}).evaluate(
+				context.Background(),
+				celGVK,
+				authenticationv1.UserInfo{Username: "alice"},
+				nil,
+				deploymentWithReplicas(5),
+				nil,
+				[]*rules.NamespaceRuleEnforceBody{{
+					Action: rules.ActionTypeDeny,
+					CEL: []rules.CELRule{{
+						VersionKinds:  runtime.VersionKinds{APIGroups: []string{"apps"}, Kinds: []string{"*"}},
+						Name:          "no-host-network",
+						Expression:    `object.spec.template.spec.hostNetwork == true`,
+						FailurePolicy: tt.failurePolicy,
+					}},
+				}},
+				false,
+			)
+
+			if !tt.wantErr {
+				if err != nil || (evaluation != nil && evaluation.Blocking != nil) {
+					t.Fatalf("evaluate() = %#v, %v, want the rule ignored", evaluation, err)
+				}
+
+				return
+			}
+
+			if err == nil || !strings.Contains(err.Error(), `CEL rule "no-host-network" failed on Deployment/web`) {
+				t.Fatalf("evaluate() error = %v, want failure naming the rule", err)
+			}
+		})
+	}
+}
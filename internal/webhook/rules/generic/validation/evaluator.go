@@ -0,0 +1,120 @@
+// Copyright 2020-2026 Project Capsule Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package validation
+
+import (
+	"context"
+	"fmt"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	"github.com/projectcapsule/capsule/internal/cache"
+	"github.com/projectcapsule/capsule/pkg/api/meta"
+	apirules "github.com/projectcapsule/capsule/pkg/api/rules"
+	"github.com/projectcapsule/capsule/pkg/ruleengine"
+)
+
+type objectEvaluator struct {
+	generic *genericRules
+	ingress *ingressRules
+	cel     *celRules
+}
+
+// Evaluator evaluates the rules served by the generic rules endpoint
+// (metadata, ingress hostnames and CEL expressions) against existing objects.
+// CEL expressions reading oldObject or userInfo are not evaluated, since an
+// existing object has no admission request.
+func Evaluator(
+	regexCache *cache.RegexCache,
+	celCache *cache.CELCache,
+) ruleengine.ObjectEvaluator {
+	generic := newGenericRules(regexCache)
+
+	return &objectEvaluator{
+		generic: generic,
+		ingress: &ingressRules{regexCache: generic.regexCache},
+		cel:     &celRules{celCache: celCache},
+	}
+}
+
+func (*objectEvaluator) Supports(gvk schema.GroupVersionKind) bool {
+	return gvk.Group != "" || gvk.Kind != "Namespace"
+}
+
+func (e *objectEvaluator) NotEvaluable(enforceBodies []*apirules.NamespaceRuleEnforceBody) []string {
+	var out []string
+
+	for _, body := range enforceBodies {
+		if body == nil {
+			continue
+		}
+
+		for _, rule := range body.CEL {
+			if e.cel.requestDependent(rule) {
+				out = append(out, rule.Description())
+			}
+		}
+	}
+
+	return out
+}
+
+func (e *objectEvaluator) Evaluate(
+	ctx context.Context,
+	obj *unstructured.Unstructured,
+	tnt *capsulev1beta2.Tenant,
+	enforceBodies []*apirules.NamespaceRuleEnforceBody,
+) (*ruleengine.Evaluation, error) {
+	out := &ruleengine.Evaluation{}
+	gvk := obj.GroupVersionKind()
+
+	partial := &metav1.PartialObjectMetadata{}
+	if err := k8sruntime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, partial); err != nil {
+		return out, fmt.Errorf("convert %s %s: %w", gvk.Kind, obj.GetName(), err)
+	}
+
+	partial.SetGroupVersionKind(gvk)
+
+	if !meta.ShouldSkipObjectByRules(partial, e.generic.objectSkipRules) {
+		for _, evaluate := range e.generic.rules {
+			evaluation, err := evaluate(partial, gvk, enforceBodies)
+			if err != nil {
+				return out, err
+			}
+
+			out.Append(evaluation)
+
+			if out.Blocking != nil {
+				return out, nil
+			}
+		}
+	}
+
+	if resourceType, supported := ingressTypeForGVK(gvk); supported {
+		evaluation, err := e.ingress.evaluate(obj, resourceType, enforceBodies)
+		if err != nil {
+			return out, err
+		}
+
+		out.Append(evaluation)
+
+		if out.Blocking != nil {
+			return out, nil
+		}
+	}
+
+	evaluation, err := e.cel.evaluate(ctx, gvk, authenticationv1.UserInfo{}, nil, obj, tnt, enforceBodies, true)
+	if err != nil {
+		return out, err
+	}
+
+	out.Append(evaluation)
+
+	return out, nil
+}
@@ -0,0 +1,140 @@
+// Copyright 2020-2026 Project Capsule Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package validation
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/projectcapsule/capsule/internal/cache"
+	apirules "github.com/projectcapsule/capsule/pkg/api/rules"
+	"github.com/projectcapsule/capsule/pkg/api/runtime"
+)
+
+func TestObjectEvaluator(t *testing.T) {
+	t.Parallel()
+
+	celCache, err := cache.NewCELCache()
+	if err != nil {
+		t.Fatalf("NewCELCache() error = %v", err)
+	}
+
+	evaluator := Evaluator(nil, celCache)
+
+	if evaluator.Supports(coreGVK("Namespace")) {
+		t.Fatalf("Supports(Namespace) = true, want false")
+	}
+
+	if !evaluator.Supports(celGVK) {
+		t.Fatalf("Supports(Deployment) = false, want true")
+	}
+
+	tests := []struct {
+		name         string
+		obj          *unstructured.Unstructured
+		bodies       []*apirules.NamespaceRuleEnforceBody
+		wantBlocking bool
+	}{
+		{
+			name: "missing required label is blocked",
+			obj:  deploymentWithReplicas(1),
+			bodies: []*apirules.NamespaceRuleEnforceBody{
+				enforceMetadata(
+					apirules.ActionTypeAllow,
+					[]string{"apps"},
+					[]string{"Deployment"},
+					map[string]apirules.MetadataValueRule{
+						"env": metadataPolicy(true, exact("prod")),
+					},
+					nil,
+				),
+			},
+			wantBlocking: true,
+		},
+		{
+			name: "matching CEL deny expression is blocked",
+			obj:  deploymentWithReplicas(5),
+			bodies: []*apirules.NamespaceRuleEnforceBody{{
+				Action: apirules.ActionTypeDeny,
+				CEL: []apirules.CELRule{{
+					VersionKinds: runtime.VersionKinds{APIGroups: []string{"apps"}, Kinds: []string{"Deployment"}},
+					Expression:   `object.spec.replicas > 3`,
+				}},
+			}},
+			wantBlocking: true,
+		},
+		{
+			name: "CEL expression reading the admission request is not evaluated",
+			obj:  deploymentWithReplicas(5),
+			bodies: []*apirules.NamespaceRuleEnforceBody{{
+				Action: apirules.ActionTypeDeny,
+				CEL: []apirules.CELRule{
+					{
+						VersionKinds: runtime.VersionKinds{APIGroups: []string{"apps"}, Kinds: []string{"Deployment"}},
+						Expression:   `object.spec.replicas > 3 && oldObject == null`,
+					},
+					{
+						VersionKinds: runtime.VersionKinds{APIGroups: []string{"apps"}, Kinds: []string{"Deployment"}},
+						Expression:   `object.spec.replicas > 3 && userInfo.username != "admin"`,
+					},
+				},
+			}},
+		},
+		{
+			name: "compliant object is not blocked",
+			obj:  deploymentWithReplicas(1),
+			bodies: []*apirules.NamespaceRuleEnforceBody{{
+				Action: apirules.ActionTypeDeny,
+				CEL: []apirules.CELRule{{
+					VersionKinds: runtime.VersionKinds{APIGroups: []string{"apps"}, Kinds: []string{"Deployment"}},
+					Expression:   `object.spec.replicas > 3`,
+				}},
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			evaluation, err := evaluator.Evaluate(context.Background(), tt.obj, nil, tt.bodies)
+			if err != nil {
+				t.Fatalf("Evaluate() error = %v", err)
+			}
+
+			if blocking := evaluation.Blocking != nil; blocking != tt.wantBlocking {
+				t.Fatalf("Evaluate() blocking = %v, want %v (%#v)", blocking, tt.wantBlocking, evaluation)
+			}
+		})
+	}
+}
+
+func TestObjectEvaluatorNotEvaluable(t *testing.T) {
+	t.Parallel()
+
+	celCache, err := cache.NewCELCache()
+	if err != nil {
+		t.Fatalf("NewCELCache() error = %v", err)
+	}
+
+	got := Evaluator(nil, celCache).NotEvaluable([]*apirules.NamespaceRuleEnforceBody{
+		nil,
+		{
+			Action: apirules.ActionTypeDeny,
+			CEL: []apirules.CELRule{
+				{Expression: `object.spec.replicas > 3`},
+				{Expression: `oldObject == null`},
+				{Name: "admins-only", Expression: `"admins" in userInfo.groups`},
+			},
+		},
+	})
+
+	want := []string{"expression oldObject == null", "admins-only"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("NotEvaluable() = %v, want %v", got, want)
+	}
+}
@@ -60,6 +60,10 @@ type genericRules struct {
 func GenericRules(
 	regexCache *cache.RegexCache,
 ) handlers.TypedHandlerWithTenantWithRuleset[genericObject] {
+	return newGenericRules(regexCache)
+}
+
+func newGenericRules(regexCache *cache.RegexCache) *genericRules {
 	if regexCache == nil {
 		regexCache = cache.NewRegexCache()
 	}
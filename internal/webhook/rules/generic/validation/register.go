@@ -23,24 +23,27 @@ const Path = "/rules/generic/validating"
 
 type genericValidating struct {
 	regexCache    *cache.RegexCache
+	celCache      *cache.CELCache
 	configuration configuration.Configuration
 	resourceRules []handlers.Handler
 }
 
 func Register(
 	regexCache *cache.RegexCache,
+	celCache *cache.CELCache,
 	cfg configuration.Configuration,
 	resourceRules ...handlers.Handler,
 ) handlers.Webhook {
 	return &genericValidating{
 		regexCache:    regexCache,
+		celCache:      celCache,
 		configuration: cfg,
 		resourceRules: resourceRules,
 	}
 }
 
 func (w *genericValidating) GetHandlers() []handlers.Handler {
-	out := make([]handlers.Handler, 0, len(w.resourceRules)+2)
+	out := make([]handlers.Handler, 0, len(w.resourceRules)+3)
 	out = append(out, matchingRequest(
 		matchesGenericMetadataRequest,
 		genericHandler(w.configuration,
@@ -54,14 +57,26 @@ func (w *genericValidating) GetHandlers() []handlers.Handler {
 
 			return supported && req.SubResource == ""
 		},
-		ingressHandler(w.configuration,
+		objectHandler(w.configuration,
 			IngressRules(w.regexCache),
 		),
 	))
+	out = append(out, matchingRequest(
+		matchesGenericCELRequest,
+		objectHandler(w.configuration,
+			CELRules(w.celCache),
+		),
+	))
 
 	return out
 }
 
+func matchesGenericCELRequest(req admission.Request) bool {
+	gvk := requestGVK(req)
+
+	return req.SubResource == "" && (gvk.Group != "" || gvk.Kind != "Namespace")
+}
+
 func matchesGenericMetadataRequest(req admission.Request) bool {
 	if req.SubResource == "" {
 		return true
@@ -76,7 +91,7 @@ func (genericValidating) GetPath() string {
 	return Path
 }
 
-func ingressHandler(cfg configuration.Configuration,
+func objectHandler(cfg configuration.Configuration,
 	handler ...handlers.TypedHandlerWithTenantWithRuleset[*unstructured.Unstructured],
 ) handlers.Handler {
 	return &handlers.TypedTenantWithRulesetHandler[*unstructured.Unstructured]{
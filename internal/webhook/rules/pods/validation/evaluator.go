@@ -0,0 +1,68 @@
+// Copyright 2020-2026 Project Capsule Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package validation
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	"github.com/projectcapsule/capsule/internal/cache"
+	apirules "github.com/projectcapsule/capsule/pkg/api/rules"
+	"github.com/projectcapsule/capsule/pkg/ruleengine"
+)
+
+type podEvaluator struct {
+	rules *podRules
+}
+
+// PodEvaluator evaluates the pod rules against existing Pods.
+func PodEvaluator(
+	regexCache *cache.RegexCache,
+	registryCache *cache.RegistryRuleSetCache,
+) ruleengine.ObjectEvaluator {
+	return &podEvaluator{rules: newPodRules(regexCache, registryCache)}
+}
+
+func (*podEvaluator) Supports(gvk schema.GroupVersionKind) bool {
+	return gvk.GroupKind() == corev1.SchemeGroupVersion.WithKind("Pod").GroupKind()
+}
+
+func (*podEvaluator) NotEvaluable([]*apirules.NamespaceRuleEnforceBody) []string {
+	return nil
+}
+
+func (e *podEvaluator) Evaluate(
+	_ context.Context,
+	obj *unstructured.Unstructured,
+	_ *capsulev1beta2.Tenant,
+	enforceBodies []*apirules.NamespaceRuleEnforceBody,
+) (*ruleengine.Evaluation, error) {
+	pod := &corev1.Pod{}
+	if err := k8sruntime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, pod); err != nil {
+		return nil, fmt.Errorf("convert pod %s: %w", obj.GetName(), err)
+	}
+
+	out := &ruleengine.Evaluation{}
+
+	for _, rule := range e.rules.rules {
+		evaluation, err := rule.evaluate(pod, enforceBodies)
+		if err != nil {
+			return out, err
+		}
+
+		out.Append(evaluation)
+
+		if out.Blocking != nil {
+			break
+		}
+	}
+
+	return out, nil
+}
@@ -0,0 +1,75 @@
+// Copyright 2020-2026 Project Capsule Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package validation
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+
+	apirules "github.com/projectcapsule/capsule/pkg/api/rules"
+)
+
+func TestPodEvaluator(t *testing.T) {
+	t.Parallel()
+
+	evaluator := PodEvaluator(nil, nil)
+
+	if !evaluator.Supports(corev1.SchemeGroupVersion.WithKind("Pod")) {
+		t.Fatalf("Supports(Pod) = false, want true")
+	}
+
+	if evaluator.Supports(corev1.SchemeGroupVersion.WithKind("Service")) {
+		t.Fatalf("Supports(Service) = true, want false")
+	}
+
+	tests := []struct {
+		name         string
+		pod          *corev1.Pod
+		wantBlocking bool
+	}{
+		{
+			name:         "pod violating allowed QoS classes is blocked",
+			pod:          bestEffortPodForQoSTest(),
+			wantBlocking: true,
+		},
+		{
+			name: "pod matching allowed QoS classes is not blocked",
+			pod:  burstablePodForQoSTest(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			content, err := k8sruntime.DefaultUnstructuredConverter.ToUnstructured(tt.pod)
+			if err != nil {
+				t.Fatalf("ToUnstructured() error = %v", err)
+			}
+
+			obj := &unstructured.Unstructured{Object: content}
+			obj.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("Pod"))
+
+			evaluation, err := evaluator.Evaluate(
+				context.Background(),
+				obj,
+				nil,
+				[]*apirules.NamespaceRuleEnforceBody{
+					qosEnforceForTest(apirules.ActionTypeAllow, corev1.PodQOSBurstable),
+				},
+			)
+			if err != nil {
+				t.Fatalf("Evaluate() error = %v", err)
+			}
+
+			if blocking := evaluation.Blocking != nil; blocking != tt.wantBlocking {
+				t.Fatalf("Evaluate() blocking = %v, want %v", blocking, tt.wantBlocking)
+			}
+		})
+	}
+}
@@ -56,6 +56,13 @@ func PodRules(
 	regexCache *cache.RegexCache,
 	registryCache *cache.RegistryRuleSetCache,
 ) handlers.TypedHandlerWithTenantWithRuleset[*corev1.Pod] {
+	return newPodRules(regexCache, registryCache)
+}
+
+func newPodRules(
+	regexCache *cache.RegexCache,
+	registryCache *cache.RegistryRuleSetCache,
+) *podRules {
 	if regexCache == nil {
 		regexCache = cache.NewRegexCache()
 	}
@@ -72,8 +79,11 @@ func PodRules(
 	h.rules = []podRuleValidator{
 		{evaluate: h.validateResources},
 		{evaluate: h.validateSchedulers, includeSubresources: true},
+		{evaluate: h.validateScheduling},
 		{evaluate: h.validateQoSClasses, includeSubresources: true},
 		{evaluate: h.validateRegistries, includeSubresources: true},
+		{evaluate: h.validateSecurityContext, includeSubresources: true},
+		{evaluate: h.validateVolumes, includeSubresources: true},
 	}
 
 	return h
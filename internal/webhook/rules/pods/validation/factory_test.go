@@ -5,8 +5,10 @@ package validation
 
 import (
 	"context"
+	"strings"
 	"testing"
 
+	"github.com/go-logr/logr"
 	admissionv1 "k8s.io/api/admission/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -15,6 +17,7 @@ import (
 	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
 	"github.com/projectcapsule/capsule/internal/cache"
 	apirules "github.com/projectcapsule/capsule/pkg/api/rules"
+	"github.com/projectcapsule/capsule/pkg/runtime/events"
 )
 
 func podRulesForTest() *podRules {
@@ -57,3 +60,43 @@ func TestPodResourceRulesSkipSubresources(t *testing.T) {
 		t.Fatalf("validatePodRules() error = %v, resource rules must skip subresources", err)
 	}
 }
+
+func TestPodVolumeRulesIncludeEphemeralContainers(t *testing.T) {
+	t.Parallel()
+
+	h := newPodRules(nil, nil)
+
+	pod := volumePodForTest(true, corev1.Volume{
+		Name:         "host",
+		VolumeSource: corev1.VolumeSource{HostPath: &corev1.HostPathVolumeSource{Path: "/var/log"}},
+	})
+	pod.Spec.EphemeralContainers = []corev1.EphemeralContainer{{
+		EphemeralContainerCommon: corev1.EphemeralContainerCommon{
+			Name:         "debugger",
+			Image:        "registry.example.com/debug:1.0.0",
+			VolumeMounts: []corev1.VolumeMount{{Name: "host", MountPath: "/host"}},
+		},
+	}}
+
+	enforce := []*apirules.NamespaceRuleEnforceBody{
+		volumeEnforceForTest(apirules.ActionTypeAllow, nil, &apirules.WorkloadVolumeRules{
+			HostPaths: []apirules.WorkloadHostPathRule{{PathPrefix: "/var/log", ReadOnly: true}},
+		}),
+	}
+	req := admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+		Operation:   admissionv1.Update,
+		SubResource: "ephemeralcontainers",
+	}}
+
+	err := h.validatePodRules(
+		context.Background(),
+		req,
+		pod,
+		&capsulev1beta2.Tenant{},
+		events.NewEventRecorder(nil, logr.Discard(), nil, nil),
+		enforce,
+	)
+	if err == nil || !strings.Contains(err.Error(), "read-only") {
+		t.Fatalf("validatePodRules() error = %v, want writable hostPath mount of the ephemeral container denied", err)
+	}
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/projectcapsule/capsule/pkg/api/runtime"
 	ruleengine "github.com/projectcapsule/capsule/pkg/ruleengine"
 	"github.com/projectcapsule/capsule/pkg/runtime/events"
+	"github.com/projectcapsule/capsule/pkg/runtime/workloads"
 )
 
 type registryReference struct {
@@ -69,6 +70,21 @@ func (h *podRules) validateRegistries(
 			continue
 		}
 
+		// Reference constraints of matching audit rules are reported, they
+		// never influence the allow/deny decision.
+		for _, audit := range evaluation.Audits {
+			matched, _ := audit.MatchedValue.(*cache.CompiledRule)
+
+			decision, err := h.registryReferenceDecision(ref, matched, apirules.ActionTypeAudit)
+			if err != nil {
+				return out, err
+			}
+
+			if decision != nil {
+				out.Audits = append(out.Audits, decision)
+			}
+		}
+
 		//nolint:nilerr
 		if err := evaluation.BlockingError(); err != nil {
 			return out, nil
@@ -86,6 +102,17 @@ func (h *podRules) validateRegistries(
 
 			return out, nil
 		}
+
+		blocking, err := h.registryReferenceDecision(ref, matched, apirules.ActionTypeDeny)
+		if err != nil {
+			return out, err
+		}
+
+		if blocking != nil {
+			out.Blocking = blocking
+
+			return out, nil
+		}
 	}
 
 	return out, nil
@@ -387,3 +414,102 @@ func formatAllowedPullPolicies(policies map[corev1.PullPolicy]struct{}) string {
 
 	return strings.Join(out, ", ")
 }
+
+// registryReferenceDecision enforces the digest and tag constraints of the
+// matched registry rule. It returns nil when the reference complies.
+func (h *podRules) registryReferenceDecision(
+	ref registryReference,
+	matched *cache.CompiledRule,
+	action apirules.ActionType,
+) (*ruleengine.Decision, error) {
+	if matched == nil || !matched.HasReferenceConstraints() {
+		return nil, nil
+	}
+
+	message, err := h.registryReferenceViolation(ref, matched)
+	if err != nil || message == "" {
+		return nil, err
+	}
+
+	return &ruleengine.Decision{
+		SetName:     "registry",
+		EventReason: events.ReasonForbiddenImageReference,
+		Action:      action,
+		Value: ruleengine.Value{
+			Value: ref.Reference,
+			Path:  ref.Path,
+		},
+		MatchedValue: matched,
+		MatchedRule:  registryRuleDescription(matched),
+		Message:      message,
+	}, nil
+}
+
+func (h *podRules) registryReferenceViolation(
+	ref registryReference,
+	matched *cache.CompiledRule,
+) (string, error) {
+	image := workloads.ParseImageReference(ref.Reference)
+	rule := registryRuleDescription(matched)
+
+	if (matched.RequireDigest || len(matched.Digests) > 0) && !image.HasValidDigest() {
+		return fmt.Sprintf(
+			"%s reference %q must be pinned by a sha256 or sha512 digest (registry rule %q)",
+			ref.Path,
+			ref.Reference,
+			rule,
+		), nil
+	}
+
+	if len(matched.Digests) > 0 && !digestAllowed(image, matched.Digests) {
+		return fmt.Sprintf(
+			"%s reference %q uses a digest which is not allowed by registry rule %q",
+			ref.Path,
+			ref.Reference,
+			rule,
+		), nil
+	}
+
+	// Digest pinned references are immutable, their tag does not matter.
+	if image.HasValidDigest() {
+		return "", nil
+	}
+
+	tag := image.Tag
+	if tag == "" {
+		tag = "latest"
+	}
+
+	for _, forbidden := range matched.ForbiddenTags {
+		forbiddenTag, err := forbidden.MatchesWithExpressionMatcher(h.regexCache, tag)
+		if err != nil {
+			return "", err
+		}
+
+		if forbiddenTag {
+			return fmt.Sprintf(
+				"%s reference %q uses tag %q which is forbidden by registry rule %q",
+				ref.Path,
+				ref.Reference,
+				tag,
+				rule,
+			), nil
+		}
+	}
+
+	return "", nil
+}
+
+func digestAllowed(image workloads.ImageReference, digests []apirules.OCIDigest) bool {
+	for _, digest := range digests {
+		if digest.Digest != image.Digest {
+			continue
+		}
+
+		if digest.Tag == "" || image.Tag == "" || digest.Tag == image.Tag {
+			return true
+		}
+	}
+
+	return false
+}
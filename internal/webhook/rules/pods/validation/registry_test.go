@@ -1062,6 +1062,186 @@ func TestFormatAllowedPullPolicies(t *testing.T) {
 	}
 }
 
+func TestPodRulesValidateRegistryReferenceConstraints(t *testing.T) {
+	t.Parallel()
+
+	digest := "sha256:" + strings.Repeat("a", 64)
+	otherDigest := "sha256:" + strings.Repeat("b", 64)
+
+	withConstraints := func(mutate func(*apirules.OCIRegistry)) apirules.OCIRegistry {
+		registry := registryExpressionForTest("harbor/.*")
+		mutate(&registry)
+
+		return registry
+	}
+
+	requireDigest := withConstraints(func(r *apirules.OCIRegistry) { r.RequireDigest = true })
+	forbidLatest := withConstraints(func(r *apirules.OCIRegistry) {
+		r.ForbiddenTags = []runtime.ExpressionMatch{
+			{Exact: []string{"latest"}},
+			{ExpressionRegex: runtime.ExpressionRegex{Expression: `^.*-dev$`}},
+		}
+	})
+	digests := withConstraints(func(r *apirules.OCIRegistry) {
+		r.Digests = []apirules.OCIDigest{{Digest: digest, Tag: "1.0.0"}}
+	})
+	sha512Digest := "sha512:" + strings.Repeat("c", 128)
+	sha512Digests := withConstraints(func(r *apirules.OCIRegistry) {
+		r.Digests = []apirules.OCIDigest{{Digest: sha512Digest}}
+	})
+
+	tests := []struct {
+		name         string
+		reference    string
+		action       apirules.ActionType
+		registry     apirules.OCIRegistry
+		wantBlocking bool
+		wantAudits   int
+		wantMessage  string
+	}{
+		{
+			name:         "tag reference is denied when digest is required",
+			reference:    "harbor/platform/app:1.0.0",
+			action:       apirules.ActionTypeAllow,
+			registry:     requireDigest,
+			wantBlocking: true,
+			wantMessage:  `containers[0] reference "harbor/platform/app:1.0.0" must be pinned by a sha256 or sha512 digest`,
+		},
+		{
+			name:      "digest reference is admitted when digest is required",
+			reference: "harbor/platform/app@" + digest,
+			action:    apirules.ActionTypeAllow,
+			registry:  requireDigest,
+		},
+		{
+			name:      "sha512 digest reference is admitted when digest is required",
+			reference: "harbor/platform/app@sha512:" + strings.Repeat("c", 128),
+			action:    apirules.ActionTypeAllow,
+			registry:  requireDigest,
+		},
+		{
+			name:      "listed sha512 digest is admitted",
+			reference: "harbor/platform/app@" + sha512Digest,
+			action:    apirules.ActionTypeAllow,
+			registry:  sha512Digests,
+		},
+		{
+			name:         "malformed digest is denied when digest is required",
+			reference:    "harbor/platform/app@sha256:abc",
+			action:       apirules.ActionTypeAllow,
+			registry:     requireDigest,
+			wantBlocking: true,
+			wantMessage:  `reference "harbor/platform/app@sha256:abc" must be pinned by a sha256 or sha512 digest`,
+		},
+		{
+			name:         "unsupported digest algorithm is denied when digest is required",
+			reference:    "harbor/platform/app@md5:" + strings.Repeat("a", 32),
+			action:       apirules.ActionTypeAllow,
+			registry:     requireDigest,
+			wantBlocking: true,
+			wantMessage:  "must be pinned by a sha256 or sha512 digest",
+		},
+		{
+			name:         "malformed digest does not bypass forbidden tags",
+			reference:    "harbor/platform/app:latest@foo",
+			action:       apirules.ActionTypeAllow,
+			registry:     forbidLatest,
+			wantBlocking: true,
+			wantMessage:  `uses tag "latest" which is forbidden`,
+		},
+		{
+			name:         "untagged reference is evaluated as latest",
+			reference:    "harbor/platform/app",
+			action:       apirules.ActionTypeAllow,
+			registry:     forbidLatest,
+			wantBlocking: true,
+			wantMessage:  `uses tag "latest" which is forbidden`,
+		},
+		{
+			name:         "forbidden tag pattern is denied",
+			reference:    "harbor/platform/app:1.0.0-dev",
+			action:       apirules.ActionTypeAllow,
+			registry:     forbidLatest,
+			wantBlocking: true,
+			wantMessage:  `uses tag "1.0.0-dev" which is forbidden`,
+		},
+		{
+			name:      "digest pinned reference ignores forbidden tags",
+			reference: "harbor/platform/app:latest@" + digest,
+			action:    apirules.ActionTypeAllow,
+			registry:  forbidLatest,
+		},
+		{
+			name:      "allowed digest with matching tag is admitted",
+			reference: "harbor/platform/app:1.0.0@" + digest,
+			action:    apirules.ActionTypeAllow,
+			registry:  digests,
+		},
+		{
+			name:         "digest bound to another tag is denied",
+			reference:    "harbor/platform/app:2.0.0@" + digest,
+			action:       apirules.ActionTypeAllow,
+			registry:     digests,
+			wantBlocking: true,
+			wantMessage:  "uses a digest which is not allowed",
+		},
+		{
+			name:         "digest outside the allow-list is denied",
+			reference:    "harbor/platform/app@" + otherDigest,
+			action:       apirules.ActionTypeAllow,
+			registry:     digests,
+			wantBlocking: true,
+			wantMessage:  "uses a digest which is not allowed",
+		},
+		{
+			name:       "audit rule reports constraint violations",
+			reference:  "harbor/platform/app:latest",
+			action:     apirules.ActionTypeAudit,
+			registry:   forbidLatest,
+			wantAudits: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			h := &podRules{
+				regexCache:    cache.NewRegexCache(),
+				registryCache: cache.NewRegistryRuleSetCache(cache.NewRegexCache()),
+			}
+
+			evaluation, err := h.validateRegistries(
+				registryPodForTest(tt.reference, corev1.PullAlways),
+				[]*apirules.NamespaceRuleEnforceBody{registryEnforceForTest(tt.action, nil, tt.registry)},
+			)
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+
+			if blocking := evaluation.Blocking != nil; blocking != tt.wantBlocking {
+				t.Fatalf("expected blocking %v, got %#v", tt.wantBlocking, evaluation.Blocking)
+			}
+
+			if len(evaluation.Audits) != tt.wantAudits {
+				t.Fatalf("expected %d audit decisions, got %d", tt.wantAudits, len(evaluation.Audits))
+			}
+
+			if !tt.wantBlocking {
+				return
+			}
+
+			if evaluation.Blocking.EventReason != events.ReasonForbiddenImageReference {
+				t.Fatalf("blocking event reason = %q, want %q", evaluation.Blocking.EventReason, events.ReasonForbiddenImageReference)
+			}
+
+			if !strings.Contains(evaluation.Blocking.Message, tt.wantMessage) {
+				t.Fatalf("expected message %q to contain %q", evaluation.Blocking.Message, tt.wantMessage)
+			}
+		})
+	}
+}
+
 func registryEnforceForTest(
 	action apirules.ActionType,
 	policies []corev1.PullPolicy,
@@ -0,0 +1,431 @@
+// Copyright 2020-2026 Project Capsule Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package validation
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	apirules "github.com/projectcapsule/capsule/pkg/api/rules"
+	"github.com/projectcapsule/capsule/pkg/api/runtime"
+	ruleengine "github.com/projectcapsule/capsule/pkg/ruleengine"
+	"github.com/projectcapsule/capsule/pkg/runtime/events"
+)
+
+const (
+	// schedulingWildcard is the evaluated key or effect of tolerations
+	// which leave it empty and therefore tolerate every taint.
+	schedulingWildcard = "*"
+
+	// unconstrainedNodeAffinity is the evaluated value of Pods without
+	// node selector and required node affinity.
+	unconstrainedNodeAffinity = "any node"
+)
+
+func (h *podRules) validateScheduling(
+	pod *corev1.Pod,
+	enforceBodies []*apirules.NamespaceRuleEnforceBody,
+) (*ruleengine.Evaluation, error) {
+	if pod == nil || !hasSchedulingRules(enforceBodies) {
+		return nil, nil
+	}
+
+	out := &ruleengine.Evaluation{}
+
+	for _, evaluate := range []func(*corev1.Pod, []*apirules.NamespaceRuleEnforceBody) (*ruleengine.Evaluation, error){
+		h.evaluateNodeAffinity,
+		h.evaluateTolerations,
+	} {
+		evaluation, err := evaluate(pod, enforceBodies)
+		if err != nil {
+			return out, err
+		}
+
+		out.Append(evaluation)
+
+		if evaluation != nil && evaluation.Blocking != nil {
+			return out, nil
+		}
+	}
+
+	return out, nil
+}
+
+func (h *podRules) evaluateNodeAffinity(
+	pod *corev1.Pod,
+	enforceBodies []*apirules.NamespaceRuleEnforceBody,
+) (*ruleengine.Evaluation, error) {
+	terms := podNodeAffinityTerms(pod)
+
+	byPath := make(map[string]corev1.NodeSelectorTerm, len(terms))
+	for _, term := range terms {
+		byPath[term.Path] = term.Term
+	}
+
+	return evaluatePodRules[corev1.NodeSelectorTerm](
+		pod,
+		enforceBodies,
+		podRuleSet[corev1.NodeSelectorTerm]{
+			Name:        "node affinity",
+			EventReason: events.ReasonForbiddenPodScheduling,
+			Values: func(*corev1.Pod) []ruleengine.Value {
+				values := make([]ruleengine.Value, 0, len(terms))
+
+				for _, term := range terms {
+					value := describeNodeSelectorTerm(term.Term)
+					if value == "" {
+						value = unconstrainedNodeAffinity
+					}
+
+					values = append(values, ruleengine.Value{
+						Value: value,
+						Path:  term.Path,
+					})
+				}
+
+				return values
+			},
+			Rules: func(enforce *apirules.NamespaceRuleEnforceBody) []corev1.NodeSelectorTerm {
+				rules := schedulingRules(enforce)
+				if rules == nil || rules.NodeAffinity == nil {
+					return nil
+				}
+
+				return rules.NodeAffinity.NodeSelectorTerms
+			},
+			Matches: func(rule corev1.NodeSelectorTerm, value ruleengine.Value) (ruleengine.Match, error) {
+				return ruleengine.Match{
+					Matched: nodeSelectorTermSatisfies(byPath[value.Path], rule),
+				}, nil
+			},
+			RuleDescription:    describeNodeSelectorTerm,
+			AllowedDescription: "Allowed node affinity terms",
+		},
+	)
+}
+
+func (h *podRules) evaluateTolerations(
+	pod *corev1.Pod,
+	enforceBodies []*apirules.NamespaceRuleEnforceBody,
+) (*ruleengine.Evaluation, error) {
+	return evaluatePodRules[apirules.WorkloadTolerationRule](
+		pod,
+		enforceBodies,
+		podRuleSet[apirules.WorkloadTolerationRule]{
+			Name:        "toleration",
+			EventReason: events.ReasonForbiddenPodScheduling,
+			Values: func(pod *corev1.Pod) []ruleengine.Value {
+				values := make([]ruleengine.Value, 0, len(pod.Spec.Tolerations))
+
+				for i, toleration := range pod.Spec.Tolerations {
+					if isDefaultNotReadyToleration(toleration) {
+						continue
+					}
+
+					values = append(values, ruleengine.Value{
+						Value: tolerationKey(toleration) + ":" + tolerationEffect(toleration),
+						Path:  fmt.Sprintf("spec.tolerations[%d]", i),
+					})
+				}
+
+				return values
+			},
+			Rules: func(enforce *apirules.NamespaceRuleEnforceBody) []apirules.WorkloadTolerationRule {
+				rules := schedulingRules(enforce)
+				if rules == nil {
+					return nil
+				}
+
+				return rules.Tolerations
+			},
+			Matches: func(rule apirules.WorkloadTolerationRule, value ruleengine.Value) (ruleengine.Match, error) {
+				// Taint keys are qualified names and never contain a colon.
+				key, effect, _ := strings.Cut(value.Value, ":")
+
+				for _, candidate := range []struct {
+					match *runtime.ExpressionMatch
+					value string
+				}{
+					{match: rule.Key, value: key},
+					{match: rule.Effect, value: effect},
+				} {
+					if candidate.match == nil {
+						continue
+					}
+
+					matched, err := candidate.match.MatchesWithExpressionMatcher(h.regexCache, candidate.value)
+					if err != nil || !matched {
+						return ruleengine.Match{}, err
+					}
+				}
+
+				return ruleengine.Match{
+					Matched: rule.Key != nil || rule.Effect != nil,
+				}, nil
+			},
+			RuleDescription:    describeTolerationRule,
+			AllowedDescription: "Allowed tolerations",
+		},
+	)
+}
+
+func hasSchedulingRules(enforceBodies []*apirules.NamespaceRuleEnforceBody) bool {
+	for _, enforce := range enforceBodies {
+		if schedulingRules(enforce) != nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+func schedulingRules(enforce *apirules.NamespaceRuleEnforceBody) *apirules.WorkloadSchedulingRules {
+	if enforce == nil || enforce.Workloads.Scheduling == nil {
+		return nil
+	}
+
+	if !enforce.GetWorkloadTargets(apirules.ValidatePod) {
+		return nil
+	}
+
+	return enforce.Workloads.Scheduling
+}
+
+// isDefaultNotReadyToleration reports whether the toleration is one of those
+// injected into every Pod by the DefaultTolerationSeconds admission plugin.
+// They are not evaluated, as tenants can't create Pods without them.
+func isDefaultNotReadyToleration(toleration corev1.Toleration) bool {
+	switch toleration.Key {
+	case corev1.TaintNodeNotReady, corev1.TaintNodeUnreachable:
+	default:
+		return false
+	}
+
+	return toleration.Operator == corev1.TolerationOpExists &&
+		toleration.Effect == corev1.TaintEffectNoExecute &&
+		toleration.TolerationSeconds != nil
+}
+
+func tolerationKey(toleration corev1.Toleration) string {
+	if toleration.Key == "" {
+		return schedulingWildcard
+	}
+
+	return toleration.Key
+}
+
+func tolerationEffect(toleration corev1.Toleration) string {
+	if toleration.Effect == "" {
+		return schedulingWildcard
+	}
+
+	return string(toleration.Effect)
+}
+
+func describeTolerationRule(rule apirules.WorkloadTolerationRule) string {
+	key, effect := schedulingWildcard, schedulingWildcard
+
+	if rule.Key != nil {
+		key = runtime.DescribeExpressionMatch(*rule.Key)
+	}
+
+	if rule.Effect != nil {
+		effect = runtime.DescribeExpressionMatch(*rule.Effect)
+	}
+
+	return key + ":" + effect
+}
+
+// nodeAffinityTerm is one required node affinity alternative of a Pod.
+type nodeAffinityTerm struct {
+	Path string
+	Term corev1.NodeSelectorTerm
+}
+
+// podNodeAffinityTerms returns the required node affinity terms of the Pod.
+// The requirements of spec.nodeSelector are added to every term, since both
+// must be satisfied by the selected node.
+func podNodeAffinityTerms(pod *corev1.Pod) []nodeAffinityTerm {
+	selector := make([]corev1.NodeSelectorRequirement, 0, len(pod.Spec.NodeSelector))
+
+	for key, value := range pod.Spec.NodeSelector {
+		selector = append(selector, corev1.NodeSelectorRequirement{
+			Key:      key,
+			Operator: corev1.NodeSelectorOpIn,
+			Values:   []string{value},
+		})
+	}
+
+	sort.Slice(selector, func(i, j int) bool {
+		return selector[i].Key < selector[j].Key
+	})
+
+	var required []corev1.NodeSelectorTerm
+
+	if affinity := pod.Spec.Affinity; affinity != nil && affinity.NodeAffinity != nil &&
+		affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution != nil {
+		required = affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms
+	}
+
+	if len(required) == 0 {
+		path := "spec.affinity.nodeAffinity"
+		if len(selector) > 0 {
+			path = "spec.nodeSelector"
+		}
+
+		return []nodeAffinityTerm{
+			{
+				Path: path,
+				Term: corev1.NodeSelectorTerm{MatchExpressions: selector},
+			},
+		}
+	}
+
+	terms := make([]nodeAffinityTerm, 0, len(required))
+
+	for i, term := range required {
+		terms = append(terms, nodeAffinityTerm{
+			Path: fmt.Sprintf("spec.affinity.nodeAffinity.requiredDuringSchedulingIgnoredDuringExecution.nodeSelectorTerms[%d]", i),
+			Term: corev1.NodeSelectorTerm{
+				MatchExpressions: append(slices.Clone(selector), term.MatchExpressions...),
+				MatchFields:      term.MatchFields,
+			},
+		})
+	}
+
+	return terms
+}
+
+// nodeSelectorTermSatisfies reports whether the Pod term contains every
+// requirement of the rule term, or a narrower one. An empty rule term
+// matches no Pod term.
+func nodeSelectorTermSatisfies(term corev1.NodeSelectorTerm, rule corev1.NodeSelectorTerm) bool {
+	if len(rule.MatchExpressions) == 0 && len(rule.MatchFields) == 0 {
+		return false
+	}
+
+	return nodeSelectorRequirementsSatisfy(term.MatchExpressions, rule.MatchExpressions) &&
+		nodeSelectorRequirementsSatisfy(term.MatchFields, rule.MatchFields)
+}
+
+func nodeSelectorRequirementsSatisfy(requirements []corev1.NodeSelectorRequirement, rules []corev1.NodeSelectorRequirement) bool {
+	for _, rule := range rules {
+		if !slices.ContainsFunc(requirements, func(requirement corev1.NodeSelectorRequirement) bool {
+			return nodeSelectorRequirementSatisfies(requirement, rule)
+		}) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// nodeSelectorRequirementSatisfies reports whether every node selected by
+// the requirement is also selected by the rule requirement.
+func nodeSelectorRequirementSatisfies(requirement corev1.NodeSelectorRequirement, rule corev1.NodeSelectorRequirement) bool {
+	if requirement.Key != rule.Key {
+		return false
+	}
+
+	switch rule.Operator {
+	case corev1.NodeSelectorOpIn:
+		return requirement.Operator == corev1.NodeSelectorOpIn &&
+			len(requirement.Values) > 0 &&
+			isSubset(requirement.Values, rule.Values)
+	case corev1.NodeSelectorOpNotIn:
+		switch requirement.Operator {
+		case corev1.NodeSelectorOpNotIn:
+			return isSubset(rule.Values, requirement.Values)
+		case corev1.NodeSelectorOpIn:
+			return len(requirement.Values) > 0 && !slices.ContainsFunc(requirement.Values, func(value string) bool {
+				return slices.Contains(rule.Values, value)
+			})
+		case corev1.NodeSelectorOpDoesNotExist:
+			return true
+		}
+
+		return false
+	case corev1.NodeSelectorOpExists:
+		switch requirement.Operator {
+		case corev1.NodeSelectorOpExists, corev1.NodeSelectorOpGt, corev1.NodeSelectorOpLt:
+			return true
+		case corev1.NodeSelectorOpIn:
+			return len(requirement.Values) > 0
+		}
+
+		return false
+	case corev1.NodeSelectorOpDoesNotExist:
+		return requirement.Operator == corev1.NodeSelectorOpDoesNotExist
+	case corev1.NodeSelectorOpGt, corev1.NodeSelectorOpLt:
+		if requirement.Operator != rule.Operator || len(requirement.Values) != 1 || len(rule.Values) != 1 {
+			return false
+		}
+
+		value, err := strconv.ParseInt(requirement.Values[0], 10, 64)
+		if err != nil {
+			return false
+		}
+
+		bound, err := strconv.ParseInt(rule.Values[0], 10, 64)
+		if err != nil {
+			return false
+		}
+
+		if rule.Operator == corev1.NodeSelectorOpGt {
+			return value >= bound
+		}
+
+		return value <= bound
+	}
+
+	return false
+}
+
+func isSubset(values []string, of []string) bool {
+	for _, value := range values {
+		if !slices.Contains(of, value) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func describeNodeSelectorTerm(term corev1.NodeSelectorTerm) string {
+	parts := make([]string, 0, len(term.MatchExpressions)+len(term.MatchFields))
+
+	for _, requirement := range term.MatchExpressions {
+		parts = append(parts, describeNodeSelectorRequirement(requirement))
+	}
+
+	for _, requirement := range term.MatchFields {
+		parts = append(parts, describeNodeSelectorRequirement(requirement))
+	}
+
+	return strings.Join(parts, ",")
+}
+
+func describeNodeSelectorRequirement(requirement corev1.NodeSelectorRequirement) string {
+	switch requirement.Operator {
+	case corev1.NodeSelectorOpIn:
+		return requirement.Key + " in (" + strings.Join(requirement.Values, ",") + ")"
+	case corev1.NodeSelectorOpNotIn:
+		return requirement.Key + " notin (" + strings.Join(requirement.Values, ",") + ")"
+	case corev1.NodeSelectorOpExists:
+		return requirement.Key
+	case corev1.NodeSelectorOpDoesNotExist:
+		return "!" + requirement.Key
+	case corev1.NodeSelectorOpGt:
+		return requirement.Key + ">" + strings.Join(requirement.Values, ",")
+	case corev1.NodeSelectorOpLt:
+		return requirement.Key + "<" + strings.Join(requirement.Values, ",")
+	}
+
+	return requirement.Key + " " + string(requirement.Operator) + " (" + strings.Join(requirement.Values, ",") + ")"
+}
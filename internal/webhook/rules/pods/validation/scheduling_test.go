@@ -0,0 +1,302 @@
+// Copyright 2020-2026 Project Capsule Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package validation
+
+import (
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+
+	apirules "github.com/projectcapsule/capsule/pkg/api/rules"
+	"github.com/projectcapsule/capsule/pkg/api/runtime"
+	"github.com/projectcapsule/capsule/pkg/runtime/events"
+)
+
+func TestPodRulesValidateScheduling(t *testing.T) {
+	t.Parallel()
+
+	poolA := &corev1.NodeSelector{NodeSelectorTerms: []corev1.NodeSelectorTerm{{
+		MatchExpressions: []corev1.NodeSelectorRequirement{
+			{Key: "pool", Operator: corev1.NodeSelectorOpIn, Values: []string{"a"}},
+		},
+	}}}
+
+	requiredAffinity := func(requirements ...corev1.NodeSelectorRequirement) *corev1.Affinity {
+		return &corev1.Affinity{NodeAffinity: &corev1.NodeAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+				NodeSelectorTerms: []corev1.NodeSelectorTerm{{MatchExpressions: requirements}},
+			},
+		}}
+	}
+
+	tests := []struct {
+		name          string
+		pod           *corev1.Pod
+		enforceBodies []*apirules.NamespaceRuleEnforceBody
+		wantBlocking  bool
+		wantAudits    int
+		wantMessage   string
+	}{
+		{
+			name: "no scheduling rules returns nil",
+			pod:  schedulingPodForTest(nil, nil),
+			enforceBodies: []*apirules.NamespaceRuleEnforceBody{
+				{Action: apirules.ActionTypeDeny},
+			},
+		},
+		{
+			name: "allowed node affinity blocks unconstrained pod",
+			pod:  schedulingPodForTest(nil, nil),
+			enforceBodies: []*apirules.NamespaceRuleEnforceBody{
+				schedulingEnforceForTest(apirules.ActionTypeAllow, &apirules.WorkloadSchedulingRules{NodeAffinity: poolA}),
+			},
+			wantBlocking: true,
+			wantMessage:  `node affinity "any node" at spec.affinity.nodeAffinity is not allowed by namespace rule`,
+		},
+		{
+			name: "node selector satisfies allowed node affinity",
+			pod: func() *corev1.Pod {
+				pod := schedulingPodForTest(nil, nil)
+				pod.Spec.NodeSelector = map[string]string{"pool": "a", "zone": "z1"}
+
+				return pod
+			}(),
+			enforceBodies: []*apirules.NamespaceRuleEnforceBody{
+				schedulingEnforceForTest(apirules.ActionTypeAllow, &apirules.WorkloadSchedulingRules{NodeAffinity: poolA}),
+			},
+		},
+		{
+			name: "narrower required node affinity is allowed",
+			pod: schedulingPodForTest(requiredAffinity(
+				corev1.NodeSelectorRequirement{Key: "pool", Operator: corev1.NodeSelectorOpIn, Values: []string{"a"}},
+			), nil),
+			enforceBodies: []*apirules.NamespaceRuleEnforceBody{
+				schedulingEnforceForTest(apirules.ActionTypeAllow, &apirules.WorkloadSchedulingRules{
+					NodeAffinity: &corev1.NodeSelector{NodeSelectorTerms: []corev1.NodeSelectorTerm{{
+						MatchExpressions: []corev1.NodeSelectorRequirement{
+							{Key: "pool", Operator: corev1.NodeSelectorOpIn, Values: []string{"a", "b"}},
+						},
+					}}},
+				}),
+			},
+		},
+		{
+			name: "wider required node affinity is not allowed",
+			pod: schedulingPodForTest(requiredAffinity(
+				corev1.NodeSelectorRequirement{Key: "pool", Operator: corev1.NodeSelectorOpIn, Values: []string{"a", "c"}},
+			), nil),
+			enforceBodies: []*apirules.NamespaceRuleEnforceBody{
+				schedulingEnforceForTest(apirules.ActionTypeAllow, &apirules.WorkloadSchedulingRules{NodeAffinity: poolA}),
+			},
+			wantBlocking: true,
+			wantMessage:  "Allowed node affinity terms: pool in (a)",
+		},
+		{
+			name: "deny node affinity of dedicated pool",
+			pod: schedulingPodForTest(requiredAffinity(
+				corev1.NodeSelectorRequirement{Key: "pool", Operator: corev1.NodeSelectorOpIn, Values: []string{"a"}},
+			), nil),
+			enforceBodies: []*apirules.NamespaceRuleEnforceBody{
+				schedulingEnforceForTest(apirules.ActionTypeDeny, &apirules.WorkloadSchedulingRules{NodeAffinity: poolA}),
+			},
+			wantBlocking: true,
+			wantMessage:  "is denied by namespace rule",
+		},
+		{
+			name: "forbidden toleration key",
+			pod: schedulingPodForTest(nil, []corev1.Toleration{
+				{Key: "dedicated", Operator: corev1.TolerationOpEqual, Value: "gpu", Effect: corev1.TaintEffectNoSchedule},
+			}),
+			enforceBodies: []*apirules.NamespaceRuleEnforceBody{
+				schedulingEnforceForTest(apirules.ActionTypeDeny, &apirules.WorkloadSchedulingRules{
+					Tolerations: []apirules.WorkloadTolerationRule{{Key: &runtime.ExpressionMatch{Exact: []string{"dedicated"}}}},
+				}),
+			},
+			wantBlocking: true,
+			wantMessage:  `toleration "dedicated:NoSchedule" at spec.tolerations[0] is denied by namespace rule`,
+		},
+		{
+			name: "wildcard toleration is not allowed by key allowlist",
+			pod: schedulingPodForTest(nil, []corev1.Toleration{
+				{Operator: corev1.TolerationOpExists},
+			}),
+			enforceBodies: []*apirules.NamespaceRuleEnforceBody{
+				schedulingEnforceForTest(apirules.ActionTypeAllow, &apirules.WorkloadSchedulingRules{
+					Tolerations: []apirules.WorkloadTolerationRule{{
+						Key: &runtime.ExpressionMatch{ExpressionRegex: runtime.ExpressionRegex{Expression: `^node\.kubernetes\.io/`}},
+					}},
+				}),
+			},
+			wantBlocking: true,
+			wantMessage:  `toleration "*:*" at spec.tolerations[0] is not allowed by namespace rule`,
+		},
+		{
+			name: "toleration allowlist by key and effect",
+			pod: schedulingPodForTest(nil, []corev1.Toleration{
+				{Key: "node.kubernetes.io/not-ready", Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoExecute},
+			}),
+			enforceBodies: []*apirules.NamespaceRuleEnforceBody{
+				schedulingEnforceForTest(apirules.ActionTypeAllow, &apirules.WorkloadSchedulingRules{
+					Tolerations: []apirules.WorkloadTolerationRule{{
+						Key:    &runtime.ExpressionMatch{ExpressionRegex: runtime.ExpressionRegex{Expression: `^node\.kubernetes\.io/`}},
+						Effect: &runtime.ExpressionMatch{Exact: []string{"NoExecute"}},
+					}},
+				}),
+			},
+		},
+		{
+			name: "default not-ready tolerations are exempt from key allowlist",
+			pod: schedulingPodForTest(nil, []corev1.Toleration{
+				{Key: "dedicated", Operator: corev1.TolerationOpEqual, Value: "gpu", Effect: corev1.TaintEffectNoSchedule},
+				{Key: corev1.TaintNodeNotReady, Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoExecute, TolerationSeconds: ptr.To[int64](300)},
+				{Key: corev1.TaintNodeUnreachable, Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoExecute, TolerationSeconds: ptr.To[int64](300)},
+			}),
+			enforceBodies: []*apirules.NamespaceRuleEnforceBody{
+				schedulingEnforceForTest(apirules.ActionTypeAllow, &apirules.WorkloadSchedulingRules{
+					Tolerations: []apirules.WorkloadTolerationRule{{Key: &runtime.ExpressionMatch{Exact: []string{"dedicated"}}}},
+				}),
+			},
+		},
+		{
+			name: "unbounded not-ready toleration is evaluated",
+			pod: schedulingPodForTest(nil, []corev1.Toleration{
+				{Key: corev1.TaintNodeNotReady, Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoExecute},
+			}),
+			enforceBodies: []*apirules.NamespaceRuleEnforceBody{
+				schedulingEnforceForTest(apirules.ActionTypeAllow, &apirules.WorkloadSchedulingRules{
+					Tolerations: []apirules.WorkloadTolerationRule{{Key: &runtime.ExpressionMatch{Exact: []string{"dedicated"}}}},
+				}),
+			},
+			wantBlocking: true,
+			wantMessage:  `toleration "node.kubernetes.io/not-ready:NoExecute" at spec.tolerations[0] is not allowed by namespace rule`,
+		},
+		{
+			name: "audit toleration does not block",
+			pod: schedulingPodForTest(nil, []corev1.Toleration{
+				{Key: "dedicated", Operator: corev1.TolerationOpExists},
+			}),
+			enforceBodies: []*apirules.NamespaceRuleEnforceBody{
+				schedulingEnforceForTest(apirules.ActionTypeAudit, &apirules.WorkloadSchedulingRules{
+					Tolerations: []apirules.WorkloadTolerationRule{{Key: &runtime.ExpressionMatch{Exact: []string{"dedicated"}}}},
+				}),
+			},
+			wantAudits: 1,
+		},
+		{
+			name: "targets without pod skip scheduling rules",
+			pod:  schedulingPodForTest(nil, nil),
+			enforceBodies: []*apirules.NamespaceRuleEnforceBody{
+				func() *apirules.NamespaceRuleEnforceBody {
+					enforce := schedulingEnforceForTest(apirules.ActionTypeAllow, &apirules.WorkloadSchedulingRules{NodeAffinity: poolA})
+					enforce.Workloads.Targets = []apirules.WorkloadValidationTarget{apirules.ValidateContainers}
+
+					return enforce
+				}(),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			evaluation, err := podRulesForTest().validateScheduling(tt.pod, tt.enforceBodies)
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+
+			if evaluation == nil {
+				if tt.wantBlocking || tt.wantAudits > 0 {
+					t.Fatalf("expected evaluation, got nil")
+				}
+
+				return
+			}
+
+			if blocking := evaluation.Blocking != nil; blocking != tt.wantBlocking {
+				t.Fatalf("expected blocking %v, got %#v", tt.wantBlocking, evaluation.Blocking)
+			}
+
+			if len(evaluation.Audits) != tt.wantAudits {
+				t.Fatalf("expected %d audit decisions, got %d", tt.wantAudits, len(evaluation.Audits))
+			}
+
+			if tt.wantBlocking && evaluation.Blocking.EventReason != events.ReasonForbiddenPodScheduling {
+				t.Fatalf("expected event reason %q, got %q", events.ReasonForbiddenPodScheduling, evaluation.Blocking.EventReason)
+			}
+
+			if tt.wantMessage != "" && !strings.Contains(evaluation.Blocking.Message, tt.wantMessage) {
+				t.Fatalf("expected message %q to contain %q", evaluation.Blocking.Message, tt.wantMessage)
+			}
+		})
+	}
+}
+
+func TestNodeSelectorRequirementSatisfies(t *testing.T) {
+	t.Parallel()
+
+	requirement := func(op corev1.NodeSelectorOperator, values ...string) corev1.NodeSelectorRequirement {
+		return corev1.NodeSelectorRequirement{Key: "pool", Operator: op, Values: values}
+	}
+
+	tests := []struct {
+		name        string
+		requirement corev1.NodeSelectorRequirement
+		rule        corev1.NodeSelectorRequirement
+		want        bool
+	}{
+		{name: "in subset", requirement: requirement(corev1.NodeSelectorOpIn, "a"), rule: requirement(corev1.NodeSelectorOpIn, "a", "b"), want: true},
+		{name: "in superset", requirement: requirement(corev1.NodeSelectorOpIn, "a", "c"), rule: requirement(corev1.NodeSelectorOpIn, "a")},
+		{name: "notin superset", requirement: requirement(corev1.NodeSelectorOpNotIn, "a", "b"), rule: requirement(corev1.NodeSelectorOpNotIn, "a"), want: true},
+		{name: "in disjoint from notin", requirement: requirement(corev1.NodeSelectorOpIn, "c"), rule: requirement(corev1.NodeSelectorOpNotIn, "a"), want: true},
+		{name: "exists from in", requirement: requirement(corev1.NodeSelectorOpIn, "a"), rule: requirement(corev1.NodeSelectorOpExists), want: true},
+		{name: "does not exist", requirement: requirement(corev1.NodeSelectorOpExists), rule: requirement(corev1.NodeSelectorOpDoesNotExist)},
+		{name: "greater bound", requirement: requirement(corev1.NodeSelectorOpGt, "8"), rule: requirement(corev1.NodeSelectorOpGt, "4"), want: true},
+		{name: "lower bound", requirement: requirement(corev1.NodeSelectorOpGt, "2"), rule: requirement(corev1.NodeSelectorOpGt, "4")},
+		{
+			name:        "other key",
+			requirement: corev1.NodeSelectorRequirement{Key: "zone", Operator: corev1.NodeSelectorOpIn, Values: []string{"a"}},
+			rule:        requirement(corev1.NodeSelectorOpIn, "a"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := nodeSelectorRequirementSatisfies(tt.requirement, tt.rule); got != tt.want {
+				t.Fatalf("nodeSelectorRequirementSatisfies() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func schedulingPodForTest(affinity *corev1.Affinity, tolerations []corev1.Toleration) *corev1.Pod {
+	return &corev1.Pod{
+		Spec: corev1.PodSpec{
+			Affinity:    affinity,
+			Tolerations: tolerations,
+			Containers: []corev1.Container{
+				{
+					Name:  "app",
+					Image: "registry.example.com/app:1.0.0",
+				},
+			},
+		},
+	}
+}
+
+func schedulingEnforceForTest(
+	action apirules.ActionType,
+	rules *apirules.WorkloadSchedulingRules,
+) *apirules.NamespaceRuleEnforceBody {
+	return &apirules.NamespaceRuleEnforceBody{
+		Action: action,
+		Workloads: apirules.NamespaceRuleEnforceWorkloadsBody{
+			Scheduling: rules,
+		},
+	}
+}
@@ -0,0 +1,499 @@
+// Copyright 2020-2026 Project Capsule Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package validation
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	apirules "github.com/projectcapsule/capsule/pkg/api/rules"
+	"github.com/projectcapsule/capsule/pkg/api/runtime"
+	ruleengine "github.com/projectcapsule/capsule/pkg/ruleengine"
+	"github.com/projectcapsule/capsule/pkg/runtime/events"
+)
+
+const (
+	securityContextProfileRuntimeDefault = "RuntimeDefault"
+	securityContextProfileUnconfined     = "Unconfined"
+	securityContextProfileLocalhost      = "Localhost"
+	securityContextCapabilityAll         = "ALL"
+)
+
+// securityContextField selects one field of the security context rules.
+type securityContextField struct {
+	name    string
+	boolean func(*apirules.WorkloadSecurityContextRules) *bool
+	matches func(*apirules.WorkloadSecurityContextRules) []runtime.ExpressionMatch
+	drop    func(*apirules.WorkloadSecurityContextRules) []corev1.Capability
+}
+
+// securityContextValue is the effective value of one field at one location.
+type securityContextValue struct {
+	Field  securityContextField
+	Target apirules.WorkloadValidationTarget
+	Value  string
+	Path   string
+}
+
+// securityContextRule is either a boolean, an expression matcher or a list
+// of capabilities which must be dropped.
+type securityContextRule struct {
+	Boolean *bool
+	Match   *runtime.ExpressionMatch
+	Drop    []corev1.Capability
+}
+
+var (
+	securityContextCapabilities = securityContextField{
+		name: "capabilities",
+		matches: func(rules *apirules.WorkloadSecurityContextRules) []runtime.ExpressionMatch {
+			return rules.Capabilities
+		},
+	}
+	securityContextRequiredDropCapabilities = securityContextField{
+		name: "capabilities.drop",
+		drop: func(rules *apirules.WorkloadSecurityContextRules) []corev1.Capability {
+			return rules.RequiredDropCapabilities
+		},
+	}
+	securityContextRunAsNonRoot = securityContextField{
+		name: "runAsNonRoot",
+		boolean: func(rules *apirules.WorkloadSecurityContextRules) *bool {
+			return rules.RunAsNonRoot
+		},
+	}
+	securityContextPrivileged = securityContextField{
+		name: "privileged",
+		boolean: func(rules *apirules.WorkloadSecurityContextRules) *bool {
+			return rules.Privileged
+		},
+	}
+	securityContextAllowPrivilegeEscalation = securityContextField{
+		name: "allowPrivilegeEscalation",
+		boolean: func(rules *apirules.WorkloadSecurityContextRules) *bool {
+			return rules.AllowPrivilegeEscalation
+		},
+	}
+	securityContextReadOnlyRootFilesystem = securityContextField{
+		name: "readOnlyRootFilesystem",
+		boolean: func(rules *apirules.WorkloadSecurityContextRules) *bool {
+			return rules.ReadOnlyRootFilesystem
+		},
+	}
+	securityContextHostNetwork = securityContextField{
+		name: "hostNetwork",
+		boolean: func(rules *apirules.WorkloadSecurityContextRules) *bool {
+			return rules.HostNetwork
+		},
+	}
+	securityContextHostPID = securityContextField{
+		name: "hostPID",
+		boolean: func(rules *apirules.WorkloadSecurityContextRules) *bool {
+			return rules.HostPID
+		},
+	}
+	securityContextHostIPC = securityContextField{
+		name: "hostIPC",
+		boolean: func(rules *apirules.WorkloadSecurityContextRules) *bool {
+			return rules.HostIPC
+		},
+	}
+	securityContextSeccompProfile = securityContextField{
+		name: "seccompProfile",
+		matches: func(rules *apirules.WorkloadSecurityContextRules) []runtime.ExpressionMatch {
+			return rules.SeccompProfiles
+		},
+	}
+	securityContextAppArmorProfile = securityContextField{
+		name: "appArmorProfile",
+		matches: func(rules *apirules.WorkloadSecurityContextRules) []runtime.ExpressionMatch {
+			return rules.AppArmorProfiles
+		},
+	}
+)
+
+func (h *podRules) validateSecurityContext(
+	pod *corev1.Pod,
+	enforceBodies []*apirules.NamespaceRuleEnforceBody,
+) (*ruleengine.Evaluation, error) {
+	if pod == nil || !hasSecurityContextRules(enforceBodies) {
+		return nil, nil
+	}
+
+	out := &ruleengine.Evaluation{}
+
+	for _, value := range securityContextValuesFromPod(pod) {
+		evaluation, err := h.evaluateSecurityContextValue(value, enforceBodies)
+		if err != nil {
+			return out, err
+		}
+
+		out.Append(evaluation)
+
+		if evaluation != nil && evaluation.Blocking != nil {
+			return out, nil
+		}
+	}
+
+	return out, nil
+}
+
+func (h *podRules) evaluateSecurityContextValue(
+	value securityContextValue,
+	enforceBodies []*apirules.NamespaceRuleEnforceBody,
+) (*ruleengine.Evaluation, error) {
+	return ruleengine.EvaluateEnforce[securityContextRule](
+		value,
+		enforceBodies,
+		ruleengine.Set[securityContextRule, securityContextValue]{
+			Name:        "securityContext." + value.Field.name,
+			EventReason: events.ReasonForbiddenSecurityContext,
+			Values: func(value securityContextValue) []ruleengine.Value {
+				return []ruleengine.Value{
+					{
+						Value: value.Value,
+						Path:  value.Path,
+					},
+				}
+			},
+			Rules: func(enforce *apirules.NamespaceRuleEnforceBody) []securityContextRule {
+				if enforce == nil || enforce.Workloads.SecurityContext == nil {
+					return nil
+				}
+
+				if !enforce.GetWorkloadTargets(value.Target) {
+					return nil
+				}
+
+				return securityContextRulesForField(enforce.Workloads.SecurityContext, value.Field)
+			},
+			Matches: func(rule securityContextRule, value ruleengine.Value) (ruleengine.Match, error) {
+				if rule.Boolean != nil {
+					return ruleengine.Match{
+						Matched: strconv.FormatBool(*rule.Boolean) == value.Value,
+					}, nil
+				}
+
+				if rule.Drop != nil {
+					return ruleengine.Match{
+						Matched: dropsCapabilities(value.Value, rule.Drop),
+					}, nil
+				}
+
+				if rule.Match == nil {
+					return ruleengine.Match{}, nil
+				}
+
+				matched, err := rule.Match.MatchesWithExpressionMatcher(h.regexCache, value.Value)
+				if err != nil {
+					return ruleengine.Match{}, err
+				}
+
+				return ruleengine.Match{
+					Matched: matched,
+				}, nil
+			},
+			RuleDescription:    describeSecurityContextRule,
+			AllowedDescription: "Allowed values",
+		},
+	)
+}
+
+func hasSecurityContextRules(enforceBodies []*apirules.NamespaceRuleEnforceBody) bool {
+	for _, enforce := range enforceBodies {
+		if enforce != nil && enforce.Workloads.SecurityContext != nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+func securityContextRulesForField(
+	rules *apirules.WorkloadSecurityContextRules,
+	field securityContextField,
+) []securityContextRule {
+	if field.boolean != nil {
+		value := field.boolean(rules)
+		if value == nil {
+			return nil
+		}
+
+		return []securityContextRule{{Boolean: value}}
+	}
+
+	if field.drop != nil {
+		drop := field.drop(rules)
+		if len(drop) == 0 {
+			return nil
+		}
+
+		return []securityContextRule{{Drop: drop}}
+	}
+
+	matches := field.matches(rules)
+	if len(matches) == 0 {
+		return nil
+	}
+
+	out := make([]securityContextRule, 0, len(matches))
+	for i := range matches {
+		out = append(out, securityContextRule{Match: &matches[i]})
+	}
+
+	return out
+}
+
+func describeSecurityContextRule(rule securityContextRule) string {
+	if rule.Boolean != nil {
+		return strconv.FormatBool(*rule.Boolean)
+	}
+
+	if rule.Drop != nil {
+		names := make([]string, 0, len(rule.Drop))
+		for _, capability := range rule.Drop {
+			names = append(names, normalizeCapability(capability))
+		}
+
+		return "drop " + strings.Join(names, ",")
+	}
+
+	if rule.Match == nil {
+		return ""
+	}
+
+	return runtime.DescribeExpressionMatch(*rule.Match)
+}
+
+// securityContextValuesFromPod returns the effective security context values
+// of the Pod and all of its containers.
+func securityContextValuesFromPod(pod *corev1.Pod) []securityContextValue {
+	values := []securityContextValue{
+		podSecurityContextValue(securityContextHostNetwork, pod.Spec.HostNetwork),
+		podSecurityContextValue(securityContextHostPID, pod.Spec.HostPID),
+		podSecurityContextValue(securityContextHostIPC, pod.Spec.HostIPC),
+	}
+
+	for i := range pod.Spec.InitContainers {
+		c := &pod.Spec.InitContainers[i]
+
+		values = append(values, containerSecurityContextValues(
+			pod,
+			apirules.ValidateInitContainers,
+			fmt.Sprintf("initContainers[%d]", i),
+			c.Name,
+			c.SecurityContext,
+		)...)
+	}
+
+	for i := range pod.Spec.Containers {
+		c := &pod.Spec.Containers[i]
+
+		values = append(values, containerSecurityContextValues(
+			pod,
+			apirules.ValidateContainers,
+			fmt.Sprintf("containers[%d]", i),
+			c.Name,
+			c.SecurityContext,
+		)...)
+	}
+
+	for i := range pod.Spec.EphemeralContainers {
+		c := &pod.Spec.EphemeralContainers[i]
+
+		values = append(values, containerSecurityContextValues(
+			pod,
+			apirules.ValidateEphemeralContainers,
+			fmt.Sprintf("ephemeralContainers[%d]", i),
+			c.Name,
+			c.SecurityContext,
+		)...)
+	}
+
+	return values
+}
+
+func podSecurityContextValue(field securityContextField, value bool) securityContextValue {
+	return securityContextValue{
+		Field:  field,
+		Target: apirules.ValidatePod,
+		Value:  strconv.FormatBool(value),
+		Path:   "spec." + field.name,
+	}
+}
+
+func containerSecurityContextValues(
+	pod *corev1.Pod,
+	target apirules.WorkloadValidationTarget,
+	path string,
+	name string,
+	sc *corev1.SecurityContext,
+) []securityContextValue {
+	if sc == nil {
+		sc = &corev1.SecurityContext{}
+	}
+
+	podSC := pod.Spec.SecurityContext
+	if podSC == nil {
+		podSC = &corev1.PodSecurityContext{}
+	}
+
+	value := func(field securityContextField, value string) securityContextValue {
+		return securityContextValue{
+			Field:  field,
+			Target: target,
+			Value:  value,
+			Path:   path + ".securityContext." + field.name,
+		}
+	}
+
+	runAsNonRoot := sc.RunAsNonRoot
+	if runAsNonRoot == nil {
+		runAsNonRoot = podSC.RunAsNonRoot
+	}
+
+	runAsUser := sc.RunAsUser
+	if runAsUser == nil {
+		runAsUser = podSC.RunAsUser
+	}
+
+	// An explicit UID 0 runs as root, whatever runAsNonRoot claims.
+	if runAsUser != nil && *runAsUser == 0 {
+		runAsNonRoot = nil
+	}
+
+	seccompProfile := sc.SeccompProfile
+	if seccompProfile == nil {
+		seccompProfile = podSC.SeccompProfile
+	}
+
+	appArmorProfile := sc.AppArmorProfile
+	if appArmorProfile == nil {
+		appArmorProfile = podSC.AppArmorProfile
+	}
+
+	values := []securityContextValue{
+		value(securityContextRunAsNonRoot, strconv.FormatBool(boolOrDefault(runAsNonRoot, false))),
+		value(securityContextPrivileged, strconv.FormatBool(boolOrDefault(sc.Privileged, false))),
+		value(securityContextAllowPrivilegeEscalation, strconv.FormatBool(boolOrDefault(sc.AllowPrivilegeEscalation, true))),
+		value(securityContextReadOnlyRootFilesystem, strconv.FormatBool(boolOrDefault(sc.ReadOnlyRootFilesystem, false))),
+		value(securityContextSeccompProfile, seccompProfileName(seccompProfile)),
+		value(securityContextAppArmorProfile, appArmorProfileName(appArmorProfile, pod.GetAnnotations()[corev1.DeprecatedAppArmorBetaContainerAnnotationKeyPrefix+name])),
+	}
+
+	var dropped []string
+
+	if sc.Capabilities != nil {
+		for _, capability := range sc.Capabilities.Drop {
+			dropped = append(dropped, normalizeCapability(capability))
+		}
+	}
+
+	values = append(values, securityContextValue{
+		Field:  securityContextRequiredDropCapabilities,
+		Target: target,
+		Value:  "[" + strings.Join(dropped, ",") + "]",
+		Path:   path + ".securityContext.capabilities.drop",
+	})
+
+	if sc.Capabilities != nil {
+		for i, capability := range sc.Capabilities.Add {
+			values = append(values, securityContextValue{
+				Field:  securityContextCapabilities,
+				Target: target,
+				Value:  normalizeCapability(capability),
+				Path:   fmt.Sprintf("%s.securityContext.capabilities.add[%d]", path, i),
+			})
+		}
+	}
+
+	return values
+}
+
+func boolOrDefault(value *bool, def bool) bool {
+	if value == nil {
+		return def
+	}
+
+	return *value
+}
+
+// dropsCapabilities reports whether the dropped capabilities, formatted as
+// [NAME,...], include every required one.
+func dropsCapabilities(dropped string, required []corev1.Capability) bool {
+	names := make(map[string]struct{})
+	for _, name := range strings.Split(strings.Trim(dropped, "[]"), ",") {
+		names[name] = struct{}{}
+	}
+
+	if _, ok := names[securityContextCapabilityAll]; ok {
+		return true
+	}
+
+	for _, capability := range required {
+		if _, ok := names[normalizeCapability(capability)]; !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+func normalizeCapability(capability corev1.Capability) string {
+	return strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(string(capability))), "CAP_")
+}
+
+func seccompProfileName(profile *corev1.SeccompProfile) string {
+	if profile == nil {
+		return securityContextProfileUnconfined
+	}
+
+	switch profile.Type {
+	case corev1.SeccompProfileTypeRuntimeDefault:
+		return securityContextProfileRuntimeDefault
+	case corev1.SeccompProfileTypeLocalhost:
+		return localhostProfileName(profile.LocalhostProfile)
+	case corev1.SeccompProfileTypeUnconfined:
+		return securityContextProfileUnconfined
+	default:
+		return string(profile.Type)
+	}
+}
+
+func appArmorProfileName(profile *corev1.AppArmorProfile, annotation string) string {
+	if profile == nil {
+		switch {
+		case annotation == corev1.DeprecatedAppArmorBetaProfileNameUnconfined:
+			return securityContextProfileUnconfined
+		case strings.HasPrefix(annotation, corev1.DeprecatedAppArmorBetaProfileNamePrefix):
+			name := strings.TrimPrefix(annotation, corev1.DeprecatedAppArmorBetaProfileNamePrefix)
+
+			return localhostProfileName(&name)
+		default:
+			return securityContextProfileRuntimeDefault
+		}
+	}
+
+	switch profile.Type {
+	case corev1.AppArmorProfileTypeRuntimeDefault:
+		return securityContextProfileRuntimeDefault
+	case corev1.AppArmorProfileTypeLocalhost:
+		return localhostProfileName(profile.LocalhostProfile)
+	case corev1.AppArmorProfileTypeUnconfined:
+		return securityContextProfileUnconfined
+	default:
+		return string(profile.Type)
+	}
+}
+
+func localhostProfileName(profile *string) string {
+	if profile == nil || *profile == "" {
+		return securityContextProfileLocalhost
+	}
+
+	return securityContextProfileLocalhost + "/" + *profile
+}
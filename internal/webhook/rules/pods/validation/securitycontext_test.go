@@ -0,0 +1,321 @@
+// Copyright 2020-2026 Project Capsule Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package validation
+
+import (
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+
+	apirules "github.com/projectcapsule/capsule/pkg/api/rules"
+	"github.com/projectcapsule/capsule/pkg/api/runtime"
+	"github.com/projectcapsule/capsule/pkg/runtime/events"
+)
+
+func TestPodRulesValidateSecurityContext(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		pod           *corev1.Pod
+		enforceBodies []*apirules.NamespaceRuleEnforceBody
+		wantBlocking  bool
+		wantAudits    int
+		wantMessage   string
+	}{
+		{
+			name: "no security context rules returns nil",
+			pod:  securityContextPodForTest(nil),
+			enforceBodies: []*apirules.NamespaceRuleEnforceBody{
+				{Action: apirules.ActionTypeDeny},
+			},
+		},
+		{
+			name: "deny privileged container",
+			pod: securityContextPodForTest(&corev1.SecurityContext{
+				Privileged: ptr.To(true),
+			}),
+			enforceBodies: []*apirules.NamespaceRuleEnforceBody{
+				securityContextEnforceForTest(apirules.ActionTypeDeny, nil, &apirules.WorkloadSecurityContextRules{
+					Privileged: ptr.To(true),
+				}),
+			},
+			wantBlocking: true,
+			wantMessage:  `securityContext.privileged "true" at containers[0].securityContext.privileged is denied by namespace rule`,
+		},
+		{
+			name: "unprivileged container is admitted by deny privileged",
+			pod:  securityContextPodForTest(nil),
+			enforceBodies: []*apirules.NamespaceRuleEnforceBody{
+				securityContextEnforceForTest(apirules.ActionTypeDeny, nil, &apirules.WorkloadSecurityContextRules{
+					Privileged: ptr.To(true),
+				}),
+			},
+		},
+		{
+			name: "allow runAsNonRoot blocks unset value",
+			pod:  securityContextPodForTest(nil),
+			enforceBodies: []*apirules.NamespaceRuleEnforceBody{
+				securityContextEnforceForTest(apirules.ActionTypeAllow, nil, &apirules.WorkloadSecurityContextRules{
+					RunAsNonRoot: ptr.To(true),
+				}),
+			},
+			wantBlocking: true,
+			wantMessage:  `securityContext.runAsNonRoot "false" at containers[0].securityContext.runAsNonRoot is not allowed by namespace rule`,
+		},
+		{
+			name: "pod level runAsNonRoot is inherited",
+			pod: func() *corev1.Pod {
+				pod := securityContextPodForTest(nil)
+				pod.Spec.SecurityContext = &corev1.PodSecurityContext{RunAsNonRoot: ptr.To(true)}
+
+				return pod
+			}(),
+			enforceBodies: []*apirules.NamespaceRuleEnforceBody{
+				securityContextEnforceForTest(apirules.ActionTypeAllow, nil, &apirules.WorkloadSecurityContextRules{
+					RunAsNonRoot: ptr.To(true),
+				}),
+			},
+		},
+		{
+			name: "runAsUser 0 is evaluated as root",
+			pod: func() *corev1.Pod {
+				pod := securityContextPodForTest(&corev1.SecurityContext{RunAsUser: ptr.To[int64](0)})
+				pod.Spec.SecurityContext = &corev1.PodSecurityContext{RunAsNonRoot: ptr.To(true)}
+
+				return pod
+			}(),
+			enforceBodies: []*apirules.NamespaceRuleEnforceBody{
+				securityContextEnforceForTest(apirules.ActionTypeAllow, nil, &apirules.WorkloadSecurityContextRules{
+					RunAsNonRoot: ptr.To(true),
+				}),
+			},
+			wantBlocking: true,
+			wantMessage:  `securityContext.runAsNonRoot "false" at containers[0].securityContext.runAsNonRoot is not allowed by namespace rule`,
+		},
+		{
+			name: "required drop ALL blocks containers keeping capabilities",
+			pod: securityContextPodForTest(&corev1.SecurityContext{
+				Capabilities: &corev1.Capabilities{Drop: []corev1.Capability{"NET_RAW"}},
+			}),
+			enforceBodies: []*apirules.NamespaceRuleEnforceBody{
+				securityContextEnforceForTest(apirules.ActionTypeAllow, nil, &apirules.WorkloadSecurityContextRules{
+					RequiredDropCapabilities: []corev1.Capability{"ALL"},
+				}),
+			},
+			wantBlocking: true,
+			wantMessage:  `securityContext.capabilities.drop "[NET_RAW]" at containers[0].securityContext.capabilities.drop is not allowed by namespace rule`,
+		},
+		{
+			name: "required drop is satisfied by dropping ALL",
+			pod: securityContextPodForTest(&corev1.SecurityContext{
+				Capabilities: &corev1.Capabilities{Drop: []corev1.Capability{"all"}},
+			}),
+			enforceBodies: []*apirules.NamespaceRuleEnforceBody{
+				securityContextEnforceForTest(apirules.ActionTypeAllow, nil, &apirules.WorkloadSecurityContextRules{
+					RequiredDropCapabilities: []corev1.Capability{"CAP_NET_RAW", "SYS_ADMIN"},
+				}),
+			},
+		},
+		{
+			name: "required drop blocks containers without capabilities",
+			pod:  securityContextPodForTest(nil),
+			enforceBodies: []*apirules.NamespaceRuleEnforceBody{
+				securityContextEnforceForTest(apirules.ActionTypeAllow, nil, &apirules.WorkloadSecurityContextRules{
+					RequiredDropCapabilities: []corev1.Capability{"NET_RAW"},
+				}),
+			},
+			wantBlocking: true,
+		},
+		{
+			name: "allow privilege escalation defaults to true",
+			pod:  securityContextPodForTest(nil),
+			enforceBodies: []*apirules.NamespaceRuleEnforceBody{
+				securityContextEnforceForTest(apirules.ActionTypeDeny, nil, &apirules.WorkloadSecurityContextRules{
+					AllowPrivilegeEscalation: ptr.To(true),
+				}),
+			},
+			wantBlocking: true,
+		},
+		{
+			name: "capabilities are normalized and matched against allowlist",
+			pod: securityContextPodForTest(&corev1.SecurityContext{
+				Capabilities: &corev1.Capabilities{Add: []corev1.Capability{"cap_net_bind_service", "SYS_ADMIN"}},
+			}),
+			enforceBodies: []*apirules.NamespaceRuleEnforceBody{
+				securityContextEnforceForTest(apirules.ActionTypeAllow, nil, &apirules.WorkloadSecurityContextRules{
+					Capabilities: []runtime.ExpressionMatch{{Exact: []string{"NET_BIND_SERVICE"}}},
+				}),
+			},
+			wantBlocking: true,
+			wantMessage:  `securityContext.capabilities "SYS_ADMIN" at containers[0].securityContext.capabilities.add[1] is not allowed by namespace rule`,
+		},
+		{
+			name: "unset seccomp profile is evaluated as unconfined",
+			pod:  securityContextPodForTest(nil),
+			enforceBodies: []*apirules.NamespaceRuleEnforceBody{
+				securityContextEnforceForTest(apirules.ActionTypeAllow, nil, &apirules.WorkloadSecurityContextRules{
+					SeccompProfiles: []runtime.ExpressionMatch{{Exact: []string{"RuntimeDefault"}}},
+				}),
+			},
+			wantBlocking: true,
+			wantMessage:  `securityContext.seccompProfile "Unconfined"`,
+		},
+		{
+			name: "localhost seccomp profile matches expression",
+			pod: securityContextPodForTest(&corev1.SecurityContext{
+				SeccompProfile: &corev1.SeccompProfile{
+					Type:             corev1.SeccompProfileTypeLocalhost,
+					LocalhostProfile: ptr.To("profiles/audit.json"),
+				},
+			}),
+			enforceBodies: []*apirules.NamespaceRuleEnforceBody{
+				securityContextEnforceForTest(apirules.ActionTypeAllow, nil, &apirules.WorkloadSecurityContextRules{
+					SeccompProfiles: []runtime.ExpressionMatch{
+						{ExpressionRegex: runtime.ExpressionRegex{Expression: "^Localhost/profiles/.*$"}},
+					},
+				}),
+			},
+		},
+		{
+			name: "deprecated AppArmor annotation is honored",
+			pod: func() *corev1.Pod {
+				pod := securityContextPodForTest(nil)
+				pod.Annotations = map[string]string{
+					corev1.DeprecatedAppArmorBetaContainerAnnotationKeyPrefix + "app": corev1.DeprecatedAppArmorBetaProfileNameUnconfined,
+				}
+
+				return pod
+			}(),
+			enforceBodies: []*apirules.NamespaceRuleEnforceBody{
+				securityContextEnforceForTest(apirules.ActionTypeDeny, nil, &apirules.WorkloadSecurityContextRules{
+					AppArmorProfiles: []runtime.ExpressionMatch{{Exact: []string{"Unconfined"}}},
+				}),
+			},
+			wantBlocking: true,
+		},
+		{
+			name: "host network is evaluated on the pod target",
+			pod: func() *corev1.Pod {
+				pod := securityContextPodForTest(nil)
+				pod.Spec.HostNetwork = true
+
+				return pod
+			}(),
+			enforceBodies: []*apirules.NamespaceRuleEnforceBody{
+				securityContextEnforceForTest(
+					apirules.ActionTypeDeny,
+					[]apirules.WorkloadValidationTarget{apirules.ValidatePod},
+					&apirules.WorkloadSecurityContextRules{HostNetwork: ptr.To(true)},
+				),
+			},
+			wantBlocking: true,
+			wantMessage:  `securityContext.hostNetwork "true" at spec.hostNetwork is denied by namespace rule`,
+		},
+		{
+			name: "targets restrict container locations",
+			pod: securityContextPodForTest(&corev1.SecurityContext{
+				Privileged: ptr.To(true),
+			}),
+			enforceBodies: []*apirules.NamespaceRuleEnforceBody{
+				securityContextEnforceForTest(
+					apirules.ActionTypeDeny,
+					[]apirules.WorkloadValidationTarget{apirules.ValidateInitContainers},
+					&apirules.WorkloadSecurityContextRules{Privileged: ptr.To(true)},
+				),
+			},
+		},
+		{
+			name: "audit privileged container does not block",
+			pod: securityContextPodForTest(&corev1.SecurityContext{
+				Privileged: ptr.To(true),
+			}),
+			enforceBodies: []*apirules.NamespaceRuleEnforceBody{
+				securityContextEnforceForTest(apirules.ActionTypeAudit, nil, &apirules.WorkloadSecurityContextRules{
+					Privileged: ptr.To(true),
+				}),
+			},
+			wantAudits: 1,
+		},
+		{
+			name: "later allow overrides earlier deny",
+			pod: securityContextPodForTest(&corev1.SecurityContext{
+				Privileged: ptr.To(true),
+			}),
+			enforceBodies: []*apirules.NamespaceRuleEnforceBody{
+				securityContextEnforceForTest(apirules.ActionTypeDeny, nil, &apirules.WorkloadSecurityContextRules{
+					Privileged: ptr.To(true),
+				}),
+				securityContextEnforceForTest(apirules.ActionTypeAllow, nil, &apirules.WorkloadSecurityContextRules{
+					Privileged: ptr.To(true),
+				}),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			evaluation, err := podRulesForTest().validateSecurityContext(tt.pod, tt.enforceBodies)
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+
+			if evaluation == nil {
+				if tt.wantBlocking || tt.wantAudits > 0 {
+					t.Fatalf("expected evaluation, got nil")
+				}
+
+				return
+			}
+
+			if blocking := evaluation.Blocking != nil; blocking != tt.wantBlocking {
+				t.Fatalf("expected blocking %v, got %#v", tt.wantBlocking, evaluation.Blocking)
+			}
+
+			if len(evaluation.Audits) != tt.wantAudits {
+				t.Fatalf("expected %d audit decisions, got %d", tt.wantAudits, len(evaluation.Audits))
+			}
+
+			if tt.wantBlocking && evaluation.Blocking.EventReason != events.ReasonForbiddenSecurityContext {
+				t.Fatalf("expected event reason %q, got %q", events.ReasonForbiddenSecurityContext, evaluation.Blocking.EventReason)
+			}
+
+			if tt.wantMessage != "" && !strings.Contains(evaluation.Blocking.Message, tt.wantMessage) {
+				t.Fatalf("expected message %q to contain %q", evaluation.Blocking.Message, tt.wantMessage)
+			}
+		})
+	}
+}
+
+func securityContextPodForTest(sc *corev1.SecurityContext) *corev1.Pod {
+	return &corev1.Pod{
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{
+					Name:            "app",
+					Image:           "registry.example.com/app:1.0.0",
+					SecurityContext: sc,
+				},
+			},
+		},
+	}
+}
+
+func securityContextEnforceForTest(
+	action apirules.ActionType,
+	targets []apirules.WorkloadValidationTarget,
+	rules *apirules.WorkloadSecurityContextRules,
+) *apirules.NamespaceRuleEnforceBody {
+	return &apirules.NamespaceRuleEnforceBody{
+		Action: action,
+		Workloads: apirules.NamespaceRuleEnforceWorkloadsBody{
+			Targets:         targets,
+			SecurityContext: rules,
+		},
+	}
+}
@@ -0,0 +1,300 @@
+// Copyright 2020-2026 Project Capsule Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package validation
+
+import (
+	"fmt"
+	"path"
+	"reflect"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	apirules "github.com/projectcapsule/capsule/pkg/api/rules"
+	"github.com/projectcapsule/capsule/pkg/api/runtime"
+	ruleengine "github.com/projectcapsule/capsule/pkg/ruleengine"
+	"github.com/projectcapsule/capsule/pkg/runtime/events"
+)
+
+// volumeReference is one Pod volume with the attributes matched by volume rules.
+type volumeReference struct {
+	Path      string
+	Type      string
+	HostPath  string
+	ReadOnly  bool
+	CSIDriver string
+}
+
+func (h *podRules) validateVolumes(
+	pod *corev1.Pod,
+	enforceBodies []*apirules.NamespaceRuleEnforceBody,
+) (*ruleengine.Evaluation, error) {
+	if pod == nil || !hasVolumeRules(enforceBodies) {
+		return nil, nil
+	}
+
+	out := &ruleengine.Evaluation{}
+
+	for _, ref := range volumeReferencesFromPod(pod) {
+		for _, evaluate := range []func(volumeReference, []*apirules.NamespaceRuleEnforceBody) (*ruleengine.Evaluation, error){
+			h.evaluateVolumeType,
+			h.evaluateVolumeHostPath,
+			h.evaluateVolumeCSIDriver,
+		} {
+			evaluation, err := evaluate(ref, enforceBodies)
+			if err != nil {
+				return out, err
+			}
+
+			out.Append(evaluation)
+
+			if evaluation != nil && evaluation.Blocking != nil {
+				return out, nil
+			}
+		}
+	}
+
+	return out, nil
+}
+
+func (h *podRules) evaluateVolumeType(
+	ref volumeReference,
+	enforceBodies []*apirules.NamespaceRuleEnforceBody,
+) (*ruleengine.Evaluation, error) {
+	return ruleengine.EvaluateEnforce[runtime.ExpressionMatch](
+		ref,
+		enforceBodies,
+		ruleengine.Set[runtime.ExpressionMatch, volumeReference]{
+			Name:        "volume type",
+			EventReason: events.ReasonForbiddenVolume,
+			Values: func(ref volumeReference) []ruleengine.Value {
+				return []ruleengine.Value{
+					{
+						Value: ref.Type,
+						Path:  ref.Path,
+					},
+				}
+			},
+			Rules: func(enforce *apirules.NamespaceRuleEnforceBody) []runtime.ExpressionMatch {
+				rules := volumeRules(enforce)
+				if rules == nil {
+					return nil
+				}
+
+				return rules.Types
+			},
+			Matches:            h.matchExpression,
+			RuleDescription:    runtime.DescribeExpressionMatch,
+			AllowedDescription: "Allowed volume types",
+		},
+	)
+}
+
+func (h *podRules) evaluateVolumeHostPath(
+	ref volumeReference,
+	enforceBodies []*apirules.NamespaceRuleEnforceBody,
+) (*ruleengine.Evaluation, error) {
+	return ruleengine.EvaluateEnforce[apirules.WorkloadHostPathRule](
+		ref,
+		enforceBodies,
+		ruleengine.Set[apirules.WorkloadHostPathRule, volumeReference]{
+			Name:        "hostPath",
+			EventReason: events.ReasonForbiddenVolume,
+			Values: func(ref volumeReference) []ruleengine.Value {
+				if ref.HostPath == "" {
+					return nil
+				}
+
+				return []ruleengine.Value{
+					{
+						Value: ref.HostPath,
+						Path:  ref.Path,
+					},
+				}
+			},
+			Rules: func(enforce *apirules.NamespaceRuleEnforceBody) []apirules.WorkloadHostPathRule {
+				rules := volumeRules(enforce)
+				if rules == nil {
+					return nil
+				}
+
+				return rules.HostPaths
+			},
+			Matches: func(rule apirules.WorkloadHostPathRule, value ruleengine.Value) (ruleengine.Match, error) {
+				if rule.ReadOnly && !ref.ReadOnly {
+					return ruleengine.Match{}, nil
+				}
+
+				return ruleengine.Match{
+					Matched: hostPathHasPrefix(value.Value, rule.PathPrefix),
+				}, nil
+			},
+			RuleDescription:    describeHostPathRule,
+			AllowedDescription: "Allowed host paths",
+		},
+	)
+}
+
+func (h *podRules) evaluateVolumeCSIDriver(
+	ref volumeReference,
+	enforceBodies []*apirules.NamespaceRuleEnforceBody,
+) (*ruleengine.Evaluation, error) {
+	return ruleengine.EvaluateEnforce[runtime.ExpressionMatch](
+		ref,
+		enforceBodies,
+		ruleengine.Set[runtime.ExpressionMatch, volumeReference]{
+			Name:        "CSI driver",
+			EventReason: events.ReasonForbiddenVolume,
+			Values: func(ref volumeReference) []ruleengine.Value {
+				if ref.CSIDriver == "" {
+					return nil
+				}
+
+				return []ruleengine.Value{
+					{
+						Value: ref.CSIDriver,
+						Path:  ref.Path,
+					},
+				}
+			},
+			Rules: func(enforce *apirules.NamespaceRuleEnforceBody) []runtime.ExpressionMatch {
+				rules := volumeRules(enforce)
+				if rules == nil {
+					return nil
+				}
+
+				return rules.CSIDrivers
+			},
+			Matches:            h.matchExpression,
+			RuleDescription:    runtime.DescribeExpressionMatch,
+			AllowedDescription: "Allowed CSI drivers",
+		},
+	)
+}
+
+func (h *podRules) matchExpression(match runtime.ExpressionMatch, value ruleengine.Value) (ruleengine.Match, error) {
+	matched, err := match.MatchesWithExpressionMatcher(h.regexCache, value.Value)
+	if err != nil {
+		return ruleengine.Match{}, err
+	}
+
+	return ruleengine.Match{
+		Matched: matched,
+	}, nil
+}
+
+func hasVolumeRules(enforceBodies []*apirules.NamespaceRuleEnforceBody) bool {
+	for _, enforce := range enforceBodies {
+		if volumeRules(enforce) != nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+func volumeRules(enforce *apirules.NamespaceRuleEnforceBody) *apirules.WorkloadVolumeRules {
+	if enforce == nil || enforce.Workloads.Volumes == nil {
+		return nil
+	}
+
+	if !enforce.GetWorkloadTargets(apirules.ValidateVolumes) {
+		return nil
+	}
+
+	return enforce.Workloads.Volumes
+}
+
+func describeHostPathRule(rule apirules.WorkloadHostPathRule) string {
+	if rule.ReadOnly {
+		return rule.PathPrefix + " (read-only)"
+	}
+
+	return rule.PathPrefix
+}
+
+// hostPathHasPrefix reports whether the host path equals the prefix or is
+// located below it.
+func hostPathHasPrefix(hostPath string, prefix string) bool {
+	hostPath = path.Clean("/" + hostPath)
+	prefix = path.Clean("/" + prefix)
+
+	if prefix == "/" || hostPath == prefix {
+		return true
+	}
+
+	return strings.HasPrefix(hostPath, prefix+"/")
+}
+
+func volumeReferencesFromPod(pod *corev1.Pod) []volumeReference {
+	refs := make([]volumeReference, 0, len(pod.Spec.Volumes))
+
+	for i := range pod.Spec.Volumes {
+		v := &pod.Spec.Volumes[i]
+
+		ref := volumeReference{
+			Path: fmt.Sprintf("volumes[%d](%s)", i, v.Name),
+			Type: volumeSourceType(v.VolumeSource),
+		}
+
+		if v.HostPath != nil {
+			ref.HostPath = v.HostPath.Path
+			ref.ReadOnly = volumeMountedReadOnly(pod, v.Name)
+		}
+
+		if v.CSI != nil {
+			ref.CSIDriver = v.CSI.Driver
+		}
+
+		refs = append(refs, ref)
+	}
+
+	return refs
+}
+
+// volumeSourceType returns the JSON field name of the populated volume source.
+func volumeSourceType(source corev1.VolumeSource) string {
+	value := reflect.ValueOf(source)
+
+	for i := range value.NumField() {
+		field := value.Field(i)
+		if field.Kind() != reflect.Pointer || field.IsNil() {
+			continue
+		}
+
+		name, _, _ := strings.Cut(value.Type().Field(i).Tag.Get("json"), ",")
+
+		return name
+	}
+
+	return ""
+}
+
+// volumeMountedReadOnly reports whether every mount of the named volume is
+// read-only. A volume which is not mounted is considered read-only.
+func volumeMountedReadOnly(pod *corev1.Pod, name string) bool {
+	mounts := make([][]corev1.VolumeMount, 0, len(pod.Spec.InitContainers)+len(pod.Spec.Containers)+len(pod.Spec.EphemeralContainers))
+
+	for i := range pod.Spec.InitContainers {
+		mounts = append(mounts, pod.Spec.InitContainers[i].VolumeMounts)
+	}
+
+	for i := range pod.Spec.Containers {
+		mounts = append(mounts, pod.Spec.Containers[i].VolumeMounts)
+	}
+
+	for i := range pod.Spec.EphemeralContainers {
+		mounts = append(mounts, pod.Spec.EphemeralContainers[i].VolumeMounts)
+	}
+
+	for _, containerMounts := range mounts {
+		for _, mount := range containerMounts {
+			if mount.Name == name && !mount.ReadOnly {
+				return false
+			}
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,240 @@
+// Copyright 2020-2026 Project Capsule Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package validation
+
+import (
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+
+	apirules "github.com/projectcapsule/capsule/pkg/api/rules"
+	"github.com/projectcapsule/capsule/pkg/api/runtime"
+	"github.com/projectcapsule/capsule/pkg/runtime/events"
+)
+
+func TestPodRulesValidateVolumes(t *testing.T) {
+	t.Parallel()
+
+	hostPath := func(path string) corev1.Volume {
+		return corev1.Volume{
+			Name:         "host",
+			VolumeSource: corev1.VolumeSource{HostPath: &corev1.HostPathVolumeSource{Path: path}},
+		}
+	}
+
+	tests := []struct {
+		name          string
+		pod           *corev1.Pod
+		enforceBodies []*apirules.NamespaceRuleEnforceBody
+		wantBlocking  bool
+		wantAudits    int
+		wantMessage   string
+	}{
+		{
+			name: "no volume rules returns nil",
+			pod:  volumePodForTest(false, hostPath("/var/log")),
+			enforceBodies: []*apirules.NamespaceRuleEnforceBody{
+				{Action: apirules.ActionTypeDeny},
+			},
+		},
+		{
+			name: "deny hostPath volume type",
+			pod:  volumePodForTest(false, hostPath("/var/log")),
+			enforceBodies: []*apirules.NamespaceRuleEnforceBody{
+				volumeEnforceForTest(apirules.ActionTypeDeny, nil, &apirules.WorkloadVolumeRules{
+					Types: []runtime.ExpressionMatch{{Exact: []string{"hostPath"}}},
+				}),
+			},
+			wantBlocking: true,
+			wantMessage:  `volume type "hostPath" at volumes[0](host) is denied by namespace rule`,
+		},
+		{
+			name: "allowed volume types block other sources",
+			pod: volumePodForTest(false, corev1.Volume{
+				Name:         "data",
+				VolumeSource: corev1.VolumeSource{NFS: &corev1.NFSVolumeSource{Server: "nfs", Path: "/"}},
+			}),
+			enforceBodies: []*apirules.NamespaceRuleEnforceBody{
+				volumeEnforceForTest(apirules.ActionTypeAllow, nil, &apirules.WorkloadVolumeRules{
+					Types: []runtime.ExpressionMatch{{Exact: []string{"emptyDir", "configMap"}}},
+				}),
+			},
+			wantBlocking: true,
+			wantMessage:  `volume type "nfs" at volumes[0](data) is not allowed by namespace rule`,
+		},
+		{
+			name: "hostPath below allowed prefix is admitted",
+			pod:  volumePodForTest(false, hostPath("/var/log/app")),
+			enforceBodies: []*apirules.NamespaceRuleEnforceBody{
+				volumeEnforceForTest(apirules.ActionTypeAllow, nil, &apirules.WorkloadVolumeRules{
+					HostPaths: []apirules.WorkloadHostPathRule{{PathPrefix: "/var/log"}},
+				}),
+			},
+		},
+		{
+			name: "hostPath sharing a name prefix is not below the prefix",
+			pod:  volumePodForTest(false, hostPath("/var/logs")),
+			enforceBodies: []*apirules.NamespaceRuleEnforceBody{
+				volumeEnforceForTest(apirules.ActionTypeAllow, nil, &apirules.WorkloadVolumeRules{
+					HostPaths: []apirules.WorkloadHostPathRule{{PathPrefix: "/var/log"}},
+				}),
+			},
+			wantBlocking: true,
+			wantMessage:  "Allowed host paths: /var/log",
+		},
+		{
+			name: "read-only hostPath rule rejects writable mount",
+			pod:  volumePodForTest(false, hostPath("/var/log")),
+			enforceBodies: []*apirules.NamespaceRuleEnforceBody{
+				volumeEnforceForTest(apirules.ActionTypeAllow, nil, &apirules.WorkloadVolumeRules{
+					HostPaths: []apirules.WorkloadHostPathRule{{PathPrefix: "/var/log", ReadOnly: true}},
+				}),
+			},
+			wantBlocking: true,
+			wantMessage:  "/var/log (read-only)",
+		},
+		{
+			name: "read-only hostPath rule admits read-only mount",
+			pod:  volumePodForTest(true, hostPath("/var/log")),
+			enforceBodies: []*apirules.NamespaceRuleEnforceBody{
+				volumeEnforceForTest(apirules.ActionTypeAllow, nil, &apirules.WorkloadVolumeRules{
+					HostPaths: []apirules.WorkloadHostPathRule{{PathPrefix: "/var/log", ReadOnly: true}},
+				}),
+			},
+		},
+		{
+			name: "CSI driver allowlist",
+			pod: volumePodForTest(false, corev1.Volume{
+				Name:         "secrets",
+				VolumeSource: corev1.VolumeSource{CSI: &corev1.CSIVolumeSource{Driver: "secrets-store.csi.k8s.io"}},
+			}),
+			enforceBodies: []*apirules.NamespaceRuleEnforceBody{
+				volumeEnforceForTest(apirules.ActionTypeAllow, nil, &apirules.WorkloadVolumeRules{
+					CSIDrivers: []runtime.ExpressionMatch{
+						{ExpressionRegex: runtime.ExpressionRegex{Expression: `^ebs\.csi\.aws\.com$`}},
+					},
+				}),
+			},
+			wantBlocking: true,
+			wantMessage:  `CSI driver "secrets-store.csi.k8s.io" at volumes[0](secrets) is not allowed by namespace rule`,
+		},
+		{
+			name: "targets without pod/volumes skip volume rules",
+			pod:  volumePodForTest(false, hostPath("/var/log")),
+			enforceBodies: []*apirules.NamespaceRuleEnforceBody{
+				volumeEnforceForTest(
+					apirules.ActionTypeDeny,
+					[]apirules.WorkloadValidationTarget{apirules.ValidateContainers},
+					&apirules.WorkloadVolumeRules{
+						Types: []runtime.ExpressionMatch{{Exact: []string{"hostPath"}}},
+					},
+				),
+			},
+		},
+		{
+			name: "audit hostPath does not block",
+			pod:  volumePodForTest(false, hostPath("/var/log")),
+			enforceBodies: []*apirules.NamespaceRuleEnforceBody{
+				volumeEnforceForTest(apirules.ActionTypeAudit, nil, &apirules.WorkloadVolumeRules{
+					HostPaths: []apirules.WorkloadHostPathRule{{PathPrefix: "/"}},
+				}),
+			},
+			wantAudits: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			evaluation, err := podRulesForTest().validateVolumes(tt.pod, tt.enforceBodies)
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+
+			if evaluation == nil {
+				if tt.wantBlocking || tt.wantAudits > 0 {
+					t.Fatalf("expected evaluation, got nil")
+				}
+
+				return
+			}
+
+			if blocking := evaluation.Blocking != nil; blocking != tt.wantBlocking {
+				t.Fatalf("expected blocking %v, got %#v", tt.wantBlocking, evaluation.Blocking)
+			}
+
+			if len(evaluation.Audits) != tt.wantAudits {
+				t.Fatalf("expected %d audit decisions, got %d", tt.wantAudits, len(evaluation.Audits))
+			}
+
+			if tt.wantBlocking && evaluation.Blocking.EventReason != events.ReasonForbiddenVolume {
+				t.Fatalf("expected event reason %q, got %q", events.ReasonForbiddenVolume, evaluation.Blocking.EventReason)
+			}
+
+			if tt.wantMessage != "" && !strings.Contains(evaluation.Blocking.Message, tt.wantMessage) {
+				t.Fatalf("expected message %q to contain %q", evaluation.Blocking.Message, tt.wantMessage)
+			}
+		})
+	}
+}
+
+func TestVolumeSourceType(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		source corev1.VolumeSource
+		want   string
+	}{
+		{source: corev1.VolumeSource{HostPath: &corev1.HostPathVolumeSource{}}, want: "hostPath"},
+		{source: corev1.VolumeSource{PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{}}, want: "persistentVolumeClaim"},
+		{source: corev1.VolumeSource{Ephemeral: &corev1.EphemeralVolumeSource{}}, want: "ephemeral"},
+		{source: corev1.VolumeSource{}, want: ""},
+	}
+
+	for _, tt := range tests {
+		if got := volumeSourceType(tt.source); got != tt.want {
+			t.Fatalf("volumeSourceType() = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func volumePodForTest(readOnly bool, volumes ...corev1.Volume) *corev1.Pod {
+	mounts := make([]corev1.VolumeMount, 0, len(volumes))
+	for _, volume := range volumes {
+		mounts = append(mounts, corev1.VolumeMount{
+			Name:      volume.Name,
+			MountPath: "/mnt/" + volume.Name,
+			ReadOnly:  readOnly,
+		})
+	}
+
+	return &corev1.Pod{
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{
+					Name:         "app",
+					Image:        "registry.example.com/app:1.0.0",
+					VolumeMounts: mounts,
+				},
+			},
+			Volumes: volumes,
+		},
+	}
+}
+
+func volumeEnforceForTest(
+	action apirules.ActionType,
+	targets []apirules.WorkloadValidationTarget,
+	rules *apirules.WorkloadVolumeRules,
+) *apirules.NamespaceRuleEnforceBody {
+	return &apirules.NamespaceRuleEnforceBody{
+		Action: action,
+		Workloads: apirules.NamespaceRuleEnforceWorkloadsBody{
+			Targets: targets,
+			Volumes: rules,
+		},
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright 2020-2026 Project Capsule Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package validation
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	"github.com/projectcapsule/capsule/internal/cache"
+	apirules "github.com/projectcapsule/capsule/pkg/api/rules"
+	"github.com/projectcapsule/capsule/pkg/ruleengine"
+)
+
+type serviceEvaluator struct {
+	rules *serviceRules
+}
+
+// ServiceEvaluator evaluates the service rules against existing Services.
+func ServiceEvaluator(regexCache *cache.RegexCache) ruleengine.ObjectEvaluator {
+	return &serviceEvaluator{rules: newServiceRules(regexCache)}
+}
+
+func (*serviceEvaluator) Supports(gvk schema.GroupVersionKind) bool {
+	return gvk.GroupKind() == corev1.SchemeGroupVersion.WithKind("Service").GroupKind()
+}
+
+func (*serviceEvaluator) NotEvaluable([]*apirules.NamespaceRuleEnforceBody) []string {
+	return nil
+}
+
+func (e *serviceEvaluator) Evaluate(
+	_ context.Context,
+	obj *unstructured.Unstructured,
+	_ *capsulev1beta2.Tenant,
+	enforceBodies []*apirules.NamespaceRuleEnforceBody,
+) (*ruleengine.Evaluation, error) {
+	svc := &corev1.Service{}
+	if err := k8sruntime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, svc); err != nil {
+		return nil, fmt.Errorf("convert service %s: %w", obj.GetName(), err)
+	}
+
+	out := &ruleengine.Evaluation{}
+
+	for _, evaluate := range e.rules.rules {
+		evaluation, err := evaluate(svc, enforceBodies)
+		if err != nil {
+			return out, err
+		}
+
+		out.Append(evaluation)
+
+		if out.Blocking != nil {
+			break
+		}
+	}
+
+	return out, nil
+}
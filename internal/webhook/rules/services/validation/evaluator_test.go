@@ -0,0 +1,77 @@
+// Copyright 2020-2026 Project Capsule Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package validation
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+
+	apirules "github.com/projectcapsule/capsule/pkg/api/rules"
+)
+
+func TestServiceEvaluator(t *testing.T) {
+	t.Parallel()
+
+	evaluator := ServiceEvaluator(nil)
+
+	if !evaluator.Supports(corev1.SchemeGroupVersion.WithKind("Service")) {
+		t.Fatalf("Supports(Service) = false, want true")
+	}
+
+	if evaluator.Supports(corev1.SchemeGroupVersion.WithKind("Pod")) {
+		t.Fatalf("Supports(Pod) = true, want false")
+	}
+
+	tests := []struct {
+		name         string
+		serviceType  corev1.ServiceType
+		wantBlocking bool
+	}{
+		{
+			name:         "service type outside of allowed types is blocked",
+			serviceType:  corev1.ServiceTypeNodePort,
+			wantBlocking: true,
+		},
+		{
+			name:        "allowed service type is not blocked",
+			serviceType: corev1.ServiceTypeClusterIP,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			content, err := k8sruntime.DefaultUnstructuredConverter.ToUnstructured(
+				serviceTypeServiceForTest("web", tt.serviceType),
+			)
+			if err != nil {
+				t.Fatalf("ToUnstructured() error = %v", err)
+			}
+
+			obj := &unstructured.Unstructured{Object: content}
+			obj.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("Service"))
+
+			evaluation, err := evaluator.Evaluate(
+				context.Background(),
+				obj,
+				nil,
+				[]*apirules.NamespaceRuleEnforceBody{
+					serviceTypeEnforceForTest(apirules.ActionTypeAllow, apirules.ServiceTypeClusterIP),
+				},
+			)
+			if err != nil {
+				t.Fatalf("Evaluate() error = %v", err)
+			}
+
+			if blocking := evaluation.Blocking != nil; blocking != tt.wantBlocking {
+				t.Fatalf("Evaluate() blocking = %v, want %v", blocking, tt.wantBlocking)
+			}
+		})
+	}
+}
@@ -51,6 +51,10 @@ type serviceRules struct {
 func ServiceRules(
 	regexCache *cache.RegexCache,
 ) handlers.TypedHandlerWithTenantWithRuleset[*corev1.Service] {
+	return newServiceRules(regexCache)
+}
+
+func newServiceRules(regexCache *cache.RegexCache) *serviceRules {
 	if regexCache == nil {
 		regexCache = cache.NewRegexCache()
 	}
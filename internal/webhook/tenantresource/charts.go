@@ -0,0 +1,95 @@
+// Copyright 2020-2026 Project Capsule Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tenantresource
+
+import (
+	"context"
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	ad "github.com/projectcapsule/capsule/pkg/runtime/admission"
+	"github.com/projectcapsule/capsule/pkg/runtime/configuration"
+	"github.com/projectcapsule/capsule/pkg/runtime/events"
+	"github.com/projectcapsule/capsule/pkg/runtime/handlers"
+	"github.com/projectcapsule/capsule/pkg/runtime/helm"
+)
+
+type chartsHandler struct {
+	cfg configuration.Configuration
+}
+
+// ChartsHandler rejects TenantResources replicating charts from repositories which are not
+// allowed by the Capsule configuration.
+func ChartsHandler(cfg configuration.Configuration) handlers.Handler {
+	return &chartsHandler{cfg: cfg}
+}
+
+func (h *chartsHandler) OnCreate(
+	_ client.Client,
+	_ client.Reader,
+	decoder admission.Decoder,
+	_ events.EventRecorder,
+) handlers.Func {
+	return func(_ context.Context, req admission.Request) *admission.Response {
+		return h.validate(decoder, req)
+	}
+}
+
+func (h *chartsHandler) OnDelete(
+	client.Client,
+	client.Reader,
+	admission.Decoder,
+	events.EventRecorder,
+) handlers.Func {
+	return func(context.Context, admission.Request) *admission.Response {
+		return nil
+	}
+}
+
+func (h *chartsHandler) OnUpdate(
+	_ client.Client,
+	_ client.Reader,
+	decoder admission.Decoder,
+	_ events.EventRecorder,
+) handlers.Func {
+	return func(_ context.Context, req admission.Request) *admission.Response {
+		return h.validate(decoder, req)
+	}
+}
+
+func (h *chartsHandler) validate(decoder admission.Decoder, req admission.Request) *admission.Response {
+	tntResource := &capsulev1beta2.TenantResource{}
+	if err := decoder.Decode(req, tntResource); err != nil {
+		return ad.ErroredResponse(fmt.Errorf("failed to decode new object: %w", err))
+	}
+
+	var repositories []string
+
+	if charts := h.cfg.Charts(); charts != nil {
+		repositories = charts.AllowedRepositories
+	}
+
+	allowList, err := helm.NewAllowList(repositories...)
+	if err != nil {
+		return ad.ErroredResponse(err)
+	}
+
+	for i, resource := range tntResource.Spec.Resources {
+		if resource.Chart == nil || allowList.Allows(resource.Chart.Repository) {
+			continue
+		}
+
+		return ad.Denyf(
+			"spec.resources[%d].chart: repository %s is not allowed, allowed repositories: %v",
+			i,
+			resource.Chart.Repository,
+			repositories,
+		)
+	}
+
+	return nil
+}
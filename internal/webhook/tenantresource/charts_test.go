@@ -0,0 +1,112 @@
+// Copyright 2020-2026 Project Capsule Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tenantresource
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	"github.com/projectcapsule/capsule/pkg/runtime/configuration"
+)
+
+func TestChartsHandler(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	if err := capsulev1beta2.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		charts     *capsulev1beta2.ChartsConfiguration
+		repository string
+		wantDenied string
+	}{
+		{
+			name:       "allowed repository",
+			charts:     &capsulev1beta2.ChartsConfiguration{AllowedRepositories: []string{"oci://registry.example.com/charts"}},
+			repository: "oci://registry.example.com/charts/addons",
+		},
+		{
+			name:       "repository not allowed",
+			charts:     &capsulev1beta2.ChartsConfiguration{AllowedRepositories: []string{"oci://registry.example.com/charts"}},
+			repository: "http://169.254.169.254/latest",
+			wantDenied: "repository http://169.254.169.254/latest is not allowed",
+		},
+		{
+			name:       "no allowed repositories",
+			repository: "oci://registry.example.com/charts",
+			wantDenied: "is not allowed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+
+			cl := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(&capsulev1beta2.CapsuleConfiguration{
+					ObjectMeta: metav1.ObjectMeta{Name: "capsule"},
+					Spec:       capsulev1beta2.CapsuleConfigurationSpec{Charts: tt.charts},
+				}).
+				Build()
+
+			tntResource := &capsulev1beta2.TenantResource{
+				ObjectMeta: metav1.ObjectMeta{Name: "addons", Namespace: "solar-system"},
+				Spec: capsulev1beta2.TenantResourceSpec{
+					TenantResourceCommonSpec: capsulev1beta2.TenantResourceCommonSpec{
+						Resources: []capsulev1beta2.ResourceSpec{
+							{},
+							{Chart: &capsulev1beta2.ChartItemSpec{Repository: tt.repository, Name: "addon"}},
+						},
+					},
+				},
+			}
+
+			raw, err := json.Marshal(tntResource)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			response := ChartsHandler(configuration.NewCapsuleConfiguration(ctx, cl, cl, nil, "capsule")).OnCreate(
+				cl,
+				cl,
+				admission.NewDecoder(scheme),
+				nil,
+			)(ctx, admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+				Operation: admissionv1.Create,
+				Object:    runtime.RawExtension{Raw: raw},
+			}})
+
+			if tt.wantDenied == "" {
+				if response != nil {
+					t.Fatalf("expected the TenantResource to be admitted, got %#v", response)
+				}
+
+				return
+			}
+
+			if response == nil || response.Allowed || !strings.Contains(response.Result.Message, tt.wantDenied) {
+				t.Fatalf("expected denial containing %q, got %#v", tt.wantDenied, response)
+			}
+
+			if !strings.Contains(response.Result.Message, "spec.resources[1].chart") {
+				t.Fatalf("expected the denied resource index, got %q", response.Result.Message)
+			}
+		})
+	}
+}
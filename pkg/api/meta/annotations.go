@@ -22,6 +22,11 @@ const (
 
 	ReconcileAnnotation = "reconcile.projectcapsule.dev/requestedAt"
 
+	// NetworkPolicyHashAnnotation stores the hash of the spec Capsule rendered for a NetworkPolicy.
+	NetworkPolicyHashAnnotation = "projectcapsule.dev/network-policy-hash"
+	// DriftDetectedAnnotation records when a managed object was last found modified outside of Capsule.
+	DriftDetectedAnnotation = "projectcapsule.dev/drift-detected"
+
 	AvailableIngressClassesAnnotation       = "capsule.clastix.io/ingress-classes"
 	AvailableIngressClassesRegexpAnnotation = "capsule.clastix.io/ingress-classes-regexp"
 	AvailableStorageClassesAnnotation       = "capsule.clastix.io/storage-classes"
@@ -18,6 +18,23 @@ const (
 	AssignedCondition  string = "Assigned"
 	BoundCondition     string = "Bound"
 	ExhaustedCondition string = "Exhausted"
+	// PreemptionCondition reports a claim preempted by, or preempting, claims with another priority.
+	PreemptionCondition string = "Preemption"
+	// ExpiredCondition reports a claim which passed its expiry.
+	ExpiredCondition string = "Expired"
+
+	// DryRunCompliantCondition reports whether existing objects comply with dry-run rules.
+	DryRunCompliantCondition string = "DryRunCompliant"
+	// AuditCompliantCondition reports whether existing objects comply with enforced rules.
+	AuditCompliantCondition string = "AuditCompliant"
+	// DriftedCondition reports whether managed objects were modified outside of Capsule.
+	DriftedCondition string = "Drifted"
+	// NearLimitCondition reports whether the usage of a quota reached its warning threshold or exceeded its soft limit.
+	NearLimitCondition string = "NearLimit"
+	// LedgerConsistentCondition reports whether a QuantityLedger matched the live usage at its last audit.
+	LedgerConsistentCondition string = "LedgerConsistent"
+	// HealthyCondition reports whether all replicated objects reached their desired state.
+	HealthyCondition string = "Healthy"
 
 	// FailedReason indicates a condition or event observed a failure (Claim Rejected).
 	SucceededReason               string = "Succeeded"
@@ -33,6 +50,23 @@ const (
 	InUseReason                   string = "InUse"
 	UnusedReason                  string = "Unused"
 	PendingUnmanagedContentReason string = "PendingUnmanagedContent"
+	CompliantReason               string = "Compliant"
+	ViolationsReason              string = "Violations"
+	DriftDetectedReason           string = "DriftDetected"
+	InSyncReason                  string = "InSync"
+	PreemptedReason               string = "Preempted"
+	PreemptingReason              string = "Preempting"
+	ReclaimedReason               string = "Reclaimed"
+	ExpiredReason                 string = "Expired"
+	ExpiryGracePeriodReason       string = "ExpiryGracePeriod"
+	BelowThresholdReason          string = "BelowThreshold"
+	ThresholdReachedReason        string = "ThresholdReached"
+	SoftLimitExceededReason       string = "SoftLimitExceeded"
+	ConsistentReason              string = "Consistent"
+	DriftRepairedReason           string = "DriftRepaired"
+	HealthyReason                 string = "Healthy"
+	ProgressingReason             string = "Progressing"
+	DegradedReason                string = "Degraded"
 )
 
 func IsStatusConditionTrue(conditions ConditionList, conditionType string) bool {
@@ -144,6 +178,69 @@ func NewBoundCondition(obj client.Object) Condition {
 	}
 }
 
+func NewPreemptionCondition(obj client.Object) Condition {
+	return Condition{
+		Type:               PreemptionCondition,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: obj.GetGeneration(),
+		LastTransitionTime: metav1.Now(),
+	}
+}
+
+func NewExpiredCondition(obj client.Object) Condition {
+	return Condition{
+		Type:               ExpiredCondition,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: obj.GetGeneration(),
+		LastTransitionTime: metav1.Now(),
+		Reason:             ExpiredReason,
+	}
+}
+
+func NewNearLimitCondition(obj client.Object) Condition {
+	return Condition{
+		Type:               NearLimitCondition,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: obj.GetGeneration(),
+		LastTransitionTime: metav1.Now(),
+		Reason:             BelowThresholdReason,
+		Message:            "usage below thresholds",
+	}
+}
+
+func NewLedgerConsistentCondition(obj client.Object) Condition {
+	return Condition{
+		Type:               LedgerConsistentCondition,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: obj.GetGeneration(),
+		LastTransitionTime: metav1.Now(),
+		Reason:             ConsistentReason,
+		Message:            "ledger matches live usage",
+	}
+}
+
+func NewHealthyCondition(obj client.Object) Condition {
+	return Condition{
+		Type:               HealthyCondition,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: obj.GetGeneration(),
+		LastTransitionTime: metav1.Now(),
+		Reason:             HealthyReason,
+		Message:            "all items healthy",
+	}
+}
+
+func NewDriftedCondition(obj client.Object) Condition {
+	return Condition{
+		Type:               DriftedCondition,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: obj.GetGeneration(),
+		LastTransitionTime: metav1.Now(),
+		Reason:             InSyncReason,
+		Message:            "all items in sync",
+	}
+}
+
 func NewAssignedCondition(obj client.Object) Condition {
 	return Condition{
 		Type:               AssignedCondition,
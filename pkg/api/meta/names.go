@@ -16,3 +16,7 @@ func NameForManagedRoleBindings(hash string) string {
 func NameForManagedPoolResourceQuota(name string) string {
 	return fmt.Sprintf("capsule-pool-%s", name)
 }
+
+func NameForManagedPoolScopedResourceQuota(name string, scope string) string {
+	return fmt.Sprintf("capsule-pool-%s-%s", name, scope)
+}
@@ -4,11 +4,19 @@
 package meta
 
 import (
+	"fmt"
 	"sort"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/projectcapsule/capsule/pkg/runtime/gvk"
 )
 
+// Maximum amount of items listed by the messages of the aggregated conditions.
+const maxItemsInMessage = 5
+
 type ProcessedItems []ObjectReferenceStatus
 
 // Adds a condition by type.
@@ -120,6 +128,131 @@ func (p *ProcessedItems) GetItem(ref gvk.ResourceID) *ObjectReferenceStatus {
 	return nil
 }
 
+// Settling reports whether any item is still progressing or its health could not be determined,
+// so that it must be assessed again.
+func (p ProcessedItems) Settling() bool {
+	for _, item := range p {
+		if item.Health == HealthStatusProgressing || item.Health == HealthStatusUnknown {
+			return true
+		}
+	}
+
+	return false
+}
+
+// HealthyCondition aggregates the health of the items. Items whose health was not assessed,
+// eg. because their apply failed, are not considered.
+func (p ProcessedItems) HealthyCondition(obj client.Object) Condition {
+	condition := NewHealthyCondition(obj)
+
+	assessed := 0
+	degraded := false
+	unhealthy := make([]string, 0)
+
+	for _, item := range p {
+		if item.Health == "" {
+			continue
+		}
+
+		assessed++
+
+		if item.Health == HealthStatusHealthy {
+			continue
+		}
+
+		if item.Health == HealthStatusDegraded {
+			degraded = true
+		}
+
+		description := fmt.Sprintf("%s %s/%s is %s", item.Kind, item.Namespace, item.Name, item.Health)
+		if item.HealthMessage != "" {
+			description += ": " + item.HealthMessage
+		}
+
+		unhealthy = append(unhealthy, description)
+	}
+
+	if len(unhealthy) == 0 {
+		return condition
+	}
+
+	condition.Status = metav1.ConditionFalse
+	condition.Reason = ProgressingReason
+
+	if degraded {
+		condition.Reason = DegradedReason
+	}
+
+	listed := unhealthy
+	if len(listed) > maxItemsInMessage {
+		listed = listed[:maxItemsInMessage]
+	}
+
+	condition.Message = fmt.Sprintf("%d of %d items not healthy: %s", len(unhealthy), assessed, strings.Join(listed, "; "))
+	if len(unhealthy) > len(listed) {
+		condition.Message += fmt.Sprintf("; and %d more", len(unhealthy)-len(listed))
+	}
+
+	return condition
+}
+
+// DriftedCondition aggregates the drift of the items whose changes are observed. Nil is
+// returned when no item is observed, as the condition would not carry any information.
+func (p ProcessedItems) DriftedCondition(obj client.Object) *Condition {
+	condition := NewDriftedCondition(obj)
+
+	observed := 0
+	corrected := 0
+	drifted := make([]string, 0)
+
+	for _, item := range p {
+		if !item.DriftPolicy.Observed() {
+			continue
+		}
+
+		observed++
+
+		switch item.Drift {
+		case DriftStateCorrected:
+			corrected++
+		case DriftStateDrifted:
+			description := fmt.Sprintf("%s %s/%s", item.Kind, item.Namespace, item.Name)
+			if item.DriftMessage != "" {
+				description += ": " + item.DriftMessage
+			}
+
+			drifted = append(drifted, description)
+		}
+	}
+
+	if observed == 0 {
+		return nil
+	}
+
+	if len(drifted) == 0 {
+		if corrected > 0 {
+			condition.Message = fmt.Sprintf("%d drifted items have been restored", corrected)
+		}
+
+		return &condition
+	}
+
+	condition.Status = metav1.ConditionTrue
+	condition.Reason = DriftDetectedReason
+
+	listed := drifted
+	if len(listed) > maxItemsInMessage {
+		listed = listed[:maxItemsInMessage]
+	}
+
+	condition.Message = fmt.Sprintf("%d of %d items drifted: %s", len(drifted), observed, strings.Join(listed, "; "))
+	if len(drifted) > len(listed) {
+		condition.Message += fmt.Sprintf("; and %d more", len(drifted)-len(listed))
+	}
+
+	return &condition
+}
+
 func (p ProcessedItems) SortDeterministic() {
 	sort.Slice(p, func(i, j int) bool {
 		a, b := p[i], p[j]
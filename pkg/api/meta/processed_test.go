@@ -275,3 +275,186 @@ func TestProcessedItems_ReplaceScope_EmptyDropsScopeOnly(t *testing.T) {
 		t.Fatalf("expected only the out of scope item to survive, got %+v", p)
 	}
 }
+
+func TestProcessedItems_HealthyCondition(t *testing.T) {
+	t.Parallel()
+
+	now := metav1.NewTime(time.Now())
+
+	withHealth := func(name string, health meta.HealthStatus, message string) meta.ObjectReferenceStatus {
+		item := mkItem("tenant-a", "ns-a", name, "Deployment", metav1.ConditionTrue, "Ready", "", true, now)
+		item.Health = health
+		item.HealthMessage = message
+
+		return item
+	}
+
+	tests := []struct {
+		name        string
+		items       meta.ProcessedItems
+		wantStatus  metav1.ConditionStatus
+		wantReason  string
+		wantMessage string
+	}{
+		{
+			name:        "no items",
+			wantStatus:  metav1.ConditionTrue,
+			wantReason:  meta.HealthyReason,
+			wantMessage: "all items healthy",
+		},
+		{
+			name: "unassessed items are ignored",
+			items: meta.ProcessedItems{
+				withHealth("a", meta.HealthStatusHealthy, ""),
+				withHealth("b", "", ""),
+			},
+			wantStatus:  metav1.ConditionTrue,
+			wantReason:  meta.HealthyReason,
+			wantMessage: "all items healthy",
+		},
+		{
+			name: "progressing items",
+			items: meta.ProcessedItems{
+				withHealth("a", meta.HealthStatusHealthy, ""),
+				withHealth("b", meta.HealthStatusProgressing, "Replicas: 0/1"),
+			},
+			wantStatus:  metav1.ConditionFalse,
+			wantReason:  meta.ProgressingReason,
+			wantMessage: "1 of 2 items not healthy: Deployment ns-a/b is Progressing: Replicas: 0/1",
+		},
+		{
+			name: "degraded items take precedence",
+			items: meta.ProcessedItems{
+				withHealth("a", meta.HealthStatusProgressing, ""),
+				withHealth("b", meta.HealthStatusDegraded, "Job failed"),
+			},
+			wantStatus:  metav1.ConditionFalse,
+			wantReason:  meta.DegradedReason,
+			wantMessage: "2 of 2 items not healthy: Deployment ns-a/a is Progressing; Deployment ns-a/b is Degraded: Job failed",
+		},
+		{
+			name: "message is truncated",
+			items: meta.ProcessedItems{
+				withHealth("a", meta.HealthStatusUnknown, ""),
+				withHealth("b", meta.HealthStatusUnknown, ""),
+				withHealth("c", meta.HealthStatusUnknown, ""),
+				withHealth("d", meta.HealthStatusUnknown, ""),
+				withHealth("e", meta.HealthStatusUnknown, ""),
+				withHealth("f", meta.HealthStatusUnknown, ""),
+			},
+			wantStatus: metav1.ConditionFalse,
+			wantReason: meta.ProgressingReason,
+			wantMessage: "6 of 6 items not healthy: Deployment ns-a/a is Unknown; Deployment ns-a/b is Unknown; " +
+				"Deployment ns-a/c is Unknown; Deployment ns-a/d is Unknown; Deployment ns-a/e is Unknown; and 1 more",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			condition := tt.items.HealthyCondition(&metav1.PartialObjectMetadata{})
+
+			if condition.Type != meta.HealthyCondition {
+				t.Fatalf("expected condition type %s, got %s", meta.HealthyCondition, condition.Type)
+			}
+			if condition.Status != tt.wantStatus || condition.Reason != tt.wantReason {
+				t.Fatalf("expected %s/%s, got %s/%s", tt.wantStatus, tt.wantReason, condition.Status, condition.Reason)
+			}
+			if condition.Message != tt.wantMessage {
+				t.Fatalf("expected message %q, got %q", tt.wantMessage, condition.Message)
+			}
+		})
+	}
+}
+
+func TestProcessedItems_DriftedCondition(t *testing.T) {
+	t.Parallel()
+
+	now := metav1.NewTime(time.Now())
+
+	withDrift := func(name string, policy meta.DriftPolicy, drift meta.DriftState, message string) meta.ObjectReferenceStatus {
+		item := mkItem("tenant-a", "ns-a", name, "ConfigMap", metav1.ConditionTrue, "Ready", "", true, now)
+		item.DriftPolicy = policy
+		item.Drift = drift
+		item.DriftMessage = message
+
+		return item
+	}
+
+	tests := []struct {
+		name        string
+		items       meta.ProcessedItems
+		wantNil     bool
+		wantStatus  metav1.ConditionStatus
+		wantReason  string
+		wantMessage string
+	}{
+		{
+			name: "no observed items",
+			items: meta.ProcessedItems{
+				withDrift("a", meta.DriftPolicyIgnore, "", ""),
+				withDrift("b", "", "", ""),
+			},
+			wantNil: true,
+		},
+		{
+			name: "observed items in sync",
+			items: meta.ProcessedItems{
+				withDrift("a", meta.DriftPolicyReport, meta.DriftStateInSync, ""),
+				withDrift("b", meta.DriftPolicyIgnore, "", ""),
+			},
+			wantStatus:  metav1.ConditionFalse,
+			wantReason:  meta.InSyncReason,
+			wantMessage: "all items in sync",
+		},
+		{
+			name: "corrected items",
+			items: meta.ProcessedItems{
+				withDrift("a", meta.DriftPolicyCorrect, meta.DriftStateCorrected, "modified .data.a"),
+				withDrift("b", meta.DriftPolicyCorrect, meta.DriftStateInSync, ""),
+			},
+			wantStatus:  metav1.ConditionFalse,
+			wantReason:  meta.InSyncReason,
+			wantMessage: "1 drifted items have been restored",
+		},
+		{
+			name: "drifted items",
+			items: meta.ProcessedItems{
+				withDrift("a", meta.DriftPolicyReport, meta.DriftStateDrifted, "modified .data.a by kubectl-edit"),
+				withDrift("b", meta.DriftPolicyReport, meta.DriftStateInSync, ""),
+			},
+			wantStatus:  metav1.ConditionTrue,
+			wantReason:  meta.DriftDetectedReason,
+			wantMessage: "1 of 2 items drifted: ConfigMap ns-a/a: modified .data.a by kubectl-edit",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			condition := tt.items.DriftedCondition(&metav1.PartialObjectMetadata{})
+			if tt.wantNil {
+				if condition != nil {
+					t.Fatalf("expected no condition, got %#v", condition)
+				}
+
+				return
+			}
+
+			if condition == nil {
+				t.Fatalf("expected a condition, got nil")
+			}
+			if condition.Type != meta.DriftedCondition {
+				t.Fatalf("expected condition type %s, got %s", meta.DriftedCondition, condition.Type)
+			}
+			if condition.Status != tt.wantStatus || condition.Reason != tt.wantReason {
+				t.Fatalf("expected %s/%s, got %s/%s", tt.wantStatus, tt.wantReason, condition.Status, condition.Reason)
+			}
+			if condition.Message != tt.wantMessage {
+				t.Fatalf("expected message %q, got %q", tt.wantMessage, condition.Message)
+			}
+		})
+	}
+}
@@ -189,4 +189,70 @@ type ObjectReferenceStatusCondition struct {
 
 	// Indicates whether the referenced resource is cluster-scoped.
 	ClusterScoped bool `json:"clusterScoped,omitempty"`
+
+	// Health of the referenced resource, assessed once it was applied.
+	// +optional
+	Health HealthStatus `json:"health,omitempty"`
+
+	// Human readable message indicating details about the health.
+	// +optional
+	HealthMessage string `json:"healthMessage,omitempty"`
+
+	// Drift policy the referenced resource was applied with.
+	// +optional
+	DriftPolicy DriftPolicy `json:"driftPolicy,omitempty"`
+
+	// Drift of the referenced resource, observed when its drift policy is Report or Correct.
+	// +optional
+	Drift DriftState `json:"drift,omitempty"`
+
+	// Human readable message indicating details about the drift.
+	// +optional
+	DriftMessage string `json:"driftMessage,omitempty"`
 }
+
+// HealthStatus of a replicated resource, derived from its status.
+// +kubebuilder:validation:Enum=Healthy;Progressing;Degraded;Unknown
+type HealthStatus string
+
+const (
+	// The resource reached its desired state.
+	HealthStatusHealthy HealthStatus = "Healthy"
+	// The resource is reconciling towards its desired state.
+	HealthStatusProgressing HealthStatus = "Progressing"
+	// The resource failed to reach its desired state.
+	HealthStatusDegraded HealthStatus = "Degraded"
+	// The health of the resource could not be assessed.
+	HealthStatusUnknown HealthStatus = "Unknown"
+)
+
+// DriftPolicy defines how changes made to replicated resources outside of Capsule are handled.
+// +kubebuilder:validation:Enum=Ignore;Report;Correct
+type DriftPolicy string
+
+const (
+	// Changes are not observed, they are overwritten on the next resync.
+	DriftPolicyIgnore DriftPolicy = "Ignore"
+	// Changes are observed and reported, the resource is left untouched.
+	DriftPolicyReport DriftPolicy = "Report"
+	// Changes are observed and corrected immediately.
+	DriftPolicyCorrect DriftPolicy = "Correct"
+)
+
+// Observed states whether changes to the resources are watched for.
+func (p DriftPolicy) Observed() bool {
+	return p == DriftPolicyReport || p == DriftPolicyCorrect
+}
+
+// DriftState of a replicated resource, compared to the state Capsule applied.
+// +kubebuilder:validation:Enum=InSync;Drifted;Corrected
+type DriftState string
+
+const (
+	// The resource matches the state Capsule applied.
+	DriftStateInSync DriftState = "InSync"
+	// The resource was modified outside of Capsule and left untouched.
+	DriftStateDrifted DriftState = "Drifted"
+	// The resource was modified outside of Capsule and has been restored.
+	DriftStateCorrected DriftState = "Corrected"
+)
@@ -6,6 +6,7 @@ package processor
 import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 
+	"github.com/projectcapsule/capsule/pkg/api/meta"
 	"github.com/projectcapsule/capsule/pkg/runtime/gvk"
 )
 
@@ -22,6 +23,8 @@ type AccumulatorItem struct {
 type AccumulatorObject struct {
 	Origin gvk.TenantResourceIDWithOrigin
 	Object *unstructured.Unstructured
+	// How changes made to the object outside of Capsule are handled.
+	DriftPolicy meta.DriftPolicy
 }
 
 func AccumulatorAdd(
@@ -0,0 +1,228 @@
+// Copyright 2020-2026 Project Capsule Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package processor
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/structured-merge-diff/v6/fieldpath"
+
+	"github.com/projectcapsule/capsule/pkg/api/meta"
+)
+
+// Maximum amount of drifted fields listed by the message of an item.
+const maxDriftedFieldsInMessage = 5
+
+// Drift of an applied object, compared to the state its field owner applied.
+type objectDrift struct {
+	// The object was deleted outside of Capsule.
+	deleted bool
+	// Fields the field owner applied which were modified outside of Capsule.
+	fields []string
+	// Managers which modified the fields.
+	managers []string
+}
+
+func (d objectDrift) detected() bool {
+	return d.deleted || len(d.fields) > 0
+}
+
+func (d objectDrift) message() string {
+	if d.deleted {
+		return "object was deleted"
+	}
+
+	listed := d.fields
+	if len(listed) > maxDriftedFieldsInMessage {
+		listed = listed[:maxDriftedFieldsInMessage]
+	}
+
+	message := "modified " + strings.Join(listed, ", ")
+	if len(d.fields) > len(listed) {
+		message += fmt.Sprintf(" and %d more", len(d.fields)-len(listed))
+	}
+
+	if len(d.managers) > 0 {
+		message += " by " + strings.Join(d.managers, ", ")
+	}
+
+	return message
+}
+
+// Detects whether an applied object drifted from the state its field owner applied. The desired
+// state is obtained through a server-side apply dry-run: fields the field owner would take back
+// from other managers were modified outside of Capsule. Fields which are not managed by anyone
+// are new to the desired state, unless another manager modified the object since the last apply,
+// in which case they were removed outside of Capsule.
+func detectDrift(
+	ctx context.Context,
+	c client.Client,
+	obj *unstructured.Unstructured,
+	fieldOwner string,
+) (objectDrift, error) {
+	live := &unstructured.Unstructured{}
+	live.SetGroupVersionKind(obj.GroupVersionKind())
+
+	if err := c.Get(ctx, client.ObjectKeyFromObject(obj), live); err != nil {
+		if apierrors.IsNotFound(err) {
+			return objectDrift{deleted: true}, nil
+		}
+
+		return objectDrift{}, err
+	}
+
+	desired := obj.DeepCopy()
+
+	//nolint:staticcheck
+	if err := c.Patch(ctx, desired, client.Apply, client.FieldOwner(fieldOwner), client.ForceOwnership, client.DryRunAll); err != nil {
+		return objectDrift{}, fmt.Errorf("dry-run apply failed: %w", err)
+	}
+
+	return driftFromManagedFields(live.GetManagedFields(), desired.GetManagedFields(), fieldOwner)
+}
+
+// Compares the managed fields of the live object with the ones of its dry-run apply.
+func driftFromManagedFields(
+	live []metav1.ManagedFieldsEntry,
+	desired []metav1.ManagedFieldsEntry,
+	fieldOwner string,
+) (objectDrift, error) {
+	owned, lastApply, err := appliedFieldSet(live, fieldOwner)
+	if err != nil {
+		return objectDrift{}, err
+	}
+
+	applied, _, err := appliedFieldSet(desired, fieldOwner)
+	if err != nil {
+		return objectDrift{}, err
+	}
+
+	missing := applied.Difference(owned)
+	if missing.Empty() {
+		return objectDrift{}, nil
+	}
+
+	drift := objectDrift{}
+	modifiedSinceApply := false
+
+	for _, entry := range live {
+		if !foreignManager(entry, fieldOwner) {
+			continue
+		}
+
+		set, err := entryFieldSet(entry)
+		if err != nil {
+			return objectDrift{}, err
+		}
+
+		if !set.Intersection(missing).Empty() {
+			drift.managers = append(drift.managers, entry.Manager)
+		}
+
+		if entry.Time != nil && (lastApply == nil || entry.Time.After(lastApply.Time)) {
+			modifiedSinceApply = true
+		}
+	}
+
+	// Missing fields nobody else manages were added to the desired state since the last apply.
+	if len(drift.managers) == 0 && !modifiedSinceApply {
+		return objectDrift{}, nil
+	}
+
+	missing.Leaves().Iterate(func(path fieldpath.Path) {
+		drift.fields = append(drift.fields, path.String())
+	})
+
+	slices.Sort(drift.fields)
+	slices.Sort(drift.managers)
+	drift.managers = slices.Compact(drift.managers)
+
+	return drift, nil
+}
+
+// Returns the fields applied by the given field owner, along with the time of its last apply.
+func appliedFieldSet(entries []metav1.ManagedFieldsEntry, fieldOwner string) (*fieldpath.Set, *metav1.Time, error) {
+	for _, entry := range entries {
+		if entry.Manager != fieldOwner || entry.Operation != metav1.ManagedFieldsOperationApply || entry.Subresource != "" {
+			continue
+		}
+
+		set, err := entryFieldSet(entry)
+
+		return set, entry.Time, err
+	}
+
+	return fieldpath.NewSet(), nil, nil
+}
+
+func entryFieldSet(entry metav1.ManagedFieldsEntry) (*fieldpath.Set, error) {
+	set := fieldpath.NewSet()
+	if entry.FieldsV1 == nil {
+		return set, nil
+	}
+
+	if err := set.FromJSON(bytes.NewReader(entry.FieldsV1.Raw)); err != nil {
+		return nil, fmt.Errorf("decoding managed fields of %s: %w", entry.Manager, err)
+	}
+
+	return set, nil
+}
+
+// States whether the managed fields entry belongs to a manager outside of Capsule. Status
+// subresources are reconciled by the owning controllers and never drift the applied state.
+func foreignManager(entry metav1.ManagedFieldsEntry, fieldOwner string) bool {
+	if entry.Subresource != "" || entry.Manager == fieldOwner {
+		return false
+	}
+
+	return !strings.HasPrefix(entry.Manager, meta.FieldManagerCapsulePrefix)
+}
+
+// Reports whether the drift state b is worse than the drift state a.
+func worseDrift(a, b meta.DriftState) bool {
+	return driftSeverity(b) > driftSeverity(a)
+}
+
+func driftSeverity(state meta.DriftState) int {
+	switch state {
+	case meta.DriftStateInSync:
+		return 1
+	case meta.DriftStateCorrected:
+		return 2
+	case meta.DriftStateDrifted:
+		return 3
+	default:
+		return 0
+	}
+}
+
+// Returns the stricter of the given drift policies.
+func stricterDriftPolicy(a, b meta.DriftPolicy) meta.DriftPolicy {
+	if driftPolicySeverity(b) > driftPolicySeverity(a) {
+		return b
+	}
+
+	return a
+}
+
+func driftPolicySeverity(policy meta.DriftPolicy) int {
+	switch policy {
+	case meta.DriftPolicyIgnore:
+		return 1
+	case meta.DriftPolicyReport:
+		return 2
+	case meta.DriftPolicyCorrect:
+		return 3
+	default:
+		return 0
+	}
+}
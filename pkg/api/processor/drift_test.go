@@ -0,0 +1,172 @@
+// Copyright 2020-2026 Project Capsule Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package processor
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/projectcapsule/capsule/pkg/api/meta"
+)
+
+func TestDriftFromManagedFields(t *testing.T) {
+	t.Parallel()
+
+	const fieldOwner = "owner/ns/tenant/0/"
+
+	applied := func(fields string, at int64) metav1.ManagedFieldsEntry {
+		return metav1.ManagedFieldsEntry{
+			Manager:   fieldOwner,
+			Operation: metav1.ManagedFieldsOperationApply,
+			Time:      &metav1.Time{Time: time.Unix(at, 0)},
+			FieldsV1:  &metav1.FieldsV1{Raw: []byte(fields)},
+		}
+	}
+	updated := func(manager, fields string, at int64) metav1.ManagedFieldsEntry {
+		return metav1.ManagedFieldsEntry{
+			Manager:   manager,
+			Operation: metav1.ManagedFieldsOperationUpdate,
+			Time:      &metav1.Time{Time: time.Unix(at, 0)},
+			FieldsV1:  &metav1.FieldsV1{Raw: []byte(fields)},
+		}
+	}
+
+	desired := []metav1.ManagedFieldsEntry{applied(`{"f:data":{".":{},"f:a":{},"f:b":{}}}`, 300)}
+
+	tests := []struct {
+		name         string
+		live         []metav1.ManagedFieldsEntry
+		desired      []metav1.ManagedFieldsEntry
+		wantFields   []string
+		wantManagers []string
+	}{
+		{
+			name:    "in sync",
+			live:    []metav1.ManagedFieldsEntry{applied(`{"f:data":{".":{},"f:a":{},"f:b":{}}}`, 100)},
+			desired: desired,
+		},
+		{
+			name: "fields taken over by another manager",
+			live: []metav1.ManagedFieldsEntry{
+				applied(`{"f:data":{".":{},"f:a":{}}}`, 100),
+				updated("kubectl-edit", `{"f:data":{"f:b":{}}}`, 200),
+			},
+			desired:      desired,
+			wantFields:   []string{".data.b"},
+			wantManagers: []string{"kubectl-edit"},
+		},
+		{
+			name: "fields added to the desired state",
+			live: []metav1.ManagedFieldsEntry{
+				updated("kubectl-create", `{"f:metadata":{"f:labels":{}}}`, 50),
+				applied(`{"f:data":{".":{},"f:a":{}}}`, 100),
+			},
+			desired: desired,
+		},
+		{
+			name: "fields removed by another manager",
+			live: []metav1.ManagedFieldsEntry{
+				applied(`{"f:data":{".":{},"f:a":{}}}`, 100),
+				updated("kubectl-edit", `{"f:metadata":{"f:annotations":{}}}`, 200),
+			},
+			desired:    desired,
+			wantFields: []string{".data.b"},
+		},
+		{
+			name: "capsule managers and status updates do not drift",
+			live: []metav1.ManagedFieldsEntry{
+				applied(`{"f:data":{".":{},"f:a":{}}}`, 100),
+				updated(meta.ResourceControllerFieldOwnerPrefix(), `{"f:metadata":{"f:labels":{}}}`, 200),
+				{
+					Manager:     "kube-controller-manager",
+					Operation:   metav1.ManagedFieldsOperationUpdate,
+					Subresource: "status",
+					Time:        &metav1.Time{Time: time.Unix(200, 0)},
+					FieldsV1:    &metav1.FieldsV1{Raw: []byte(`{"f:status":{}}`)},
+				},
+			},
+			desired: desired,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			drift, err := driftFromManagedFields(tt.live, tt.desired, fieldOwner)
+			if err != nil {
+				t.Fatalf("driftFromManagedFields() error = %v", err)
+			}
+
+			if !slices.Equal(drift.fields, tt.wantFields) {
+				t.Fatalf("fields = %v, want %v", drift.fields, tt.wantFields)
+			}
+			if !slices.Equal(drift.managers, tt.wantManagers) {
+				t.Fatalf("managers = %v, want %v", drift.managers, tt.wantManagers)
+			}
+			if drift.detected() != (len(tt.wantFields) > 0) {
+				t.Fatalf("detected = %t, want %t", drift.detected(), len(tt.wantFields) > 0)
+			}
+		})
+	}
+}
+
+func TestObjectDriftMessage(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		drift objectDrift
+		want  string
+	}{
+		{
+			name:  "deleted",
+			drift: objectDrift{deleted: true},
+			want:  "object was deleted",
+		},
+		{
+			name:  "modified by managers",
+			drift: objectDrift{fields: []string{".data.a", ".data.b"}, managers: []string{"kubectl-edit"}},
+			want:  "modified .data.a, .data.b by kubectl-edit",
+		},
+		{
+			name:  "truncated fields",
+			drift: objectDrift{fields: []string{".a", ".b", ".c", ".d", ".e", ".f", ".g"}},
+			want:  "modified .a, .b, .c, .d, .e and 2 more",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := tt.drift.message(); got != tt.want {
+				t.Fatalf("message() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStricterDriftPolicy(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		a, b meta.DriftPolicy
+		want meta.DriftPolicy
+	}{
+		{a: "", b: meta.DriftPolicyIgnore, want: meta.DriftPolicyIgnore},
+		{a: meta.DriftPolicyIgnore, b: meta.DriftPolicyReport, want: meta.DriftPolicyReport},
+		{a: meta.DriftPolicyCorrect, b: meta.DriftPolicyReport, want: meta.DriftPolicyCorrect},
+		{a: meta.DriftPolicyReport, b: "", want: meta.DriftPolicyReport},
+	}
+
+	for _, tt := range tests {
+		if got := stricterDriftPolicy(tt.a, tt.b); got != tt.want {
+			t.Errorf("stricterDriftPolicy(%q, %q) = %q, want %q", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+// Copyright 2020-2026 Project Capsule Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package processor
+
+import (
+	"context"
+
+	"github.com/fluxcd/cli-utils/pkg/kstatus/status"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/projectcapsule/capsule/pkg/api/meta"
+)
+
+// Assesses the health of an applied object from its live status. The status is read again
+// after the apply, as the apply itself may have moved the object away from its desired state.
+func assessHealth(ctx context.Context, c client.Client, obj *unstructured.Unstructured) (meta.HealthStatus, string) {
+	actual := &unstructured.Unstructured{}
+	actual.SetGroupVersionKind(obj.GroupVersionKind())
+
+	if err := c.Get(ctx, client.ObjectKeyFromObject(obj), actual); err != nil {
+		if apierrors.IsNotFound(err) {
+			return meta.HealthStatusUnknown, "object not found"
+		}
+
+		return meta.HealthStatusUnknown, "reading object failed: " + err.Error()
+	}
+
+	return objectHealth(actual)
+}
+
+// Derives the health of an object from its status following the kstatus conventions: workloads
+// must be rolled out (eg. Deployment available, Job complete) and generic resources must report
+// their observed generation and a Ready condition, if they expose any. Healthy objects carry no
+// message, keeping the status of large replications compact.
+func objectHealth(obj *unstructured.Unstructured) (meta.HealthStatus, string) {
+	result, err := status.Compute(obj)
+	if err != nil {
+		return meta.HealthStatusUnknown, "computing status failed: " + err.Error()
+	}
+
+	switch result.Status {
+	case status.CurrentStatus:
+		return meta.HealthStatusHealthy, ""
+	case status.InProgressStatus, status.TerminatingStatus:
+		return meta.HealthStatusProgressing, result.Message
+	case status.FailedStatus:
+		return meta.HealthStatusDegraded, result.Message
+	default:
+		return meta.HealthStatusUnknown, result.Message
+	}
+}
+
+// Reports whether the health b is worse than the health a.
+func worseHealth(a, b meta.HealthStatus) bool {
+	return healthSeverity(b) > healthSeverity(a)
+}
+
+func healthSeverity(health meta.HealthStatus) int {
+	switch health {
+	case meta.HealthStatusHealthy:
+		return 1
+	case meta.HealthStatusProgressing:
+		return 2
+	case meta.HealthStatusUnknown:
+		return 3
+	case meta.HealthStatusDegraded:
+		return 4
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,139 @@
+// Copyright 2020-2026 Project Capsule Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package processor
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/projectcapsule/capsule/pkg/api/meta"
+)
+
+func TestObjectHealth(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		obj  map[string]any
+		want meta.HealthStatus
+	}{
+		{
+			name: "configmap without status",
+			obj: map[string]any{
+				"apiVersion": "v1",
+				"kind":       "ConfigMap",
+				"metadata":   map[string]any{"name": "cm", "namespace": "ns"},
+			},
+			want: meta.HealthStatusHealthy,
+		},
+		{
+			name: "deployment rolled out",
+			obj: map[string]any{
+				"apiVersion": "apps/v1",
+				"kind":       "Deployment",
+				"metadata":   map[string]any{"name": "app", "namespace": "ns", "generation": int64(2)},
+				"spec":       map[string]any{"replicas": int64(1)},
+				"status": map[string]any{
+					"observedGeneration":  int64(2),
+					"replicas":            int64(1),
+					"updatedReplicas":     int64(1),
+					"readyReplicas":       int64(1),
+					"availableReplicas":   int64(1),
+					"unavailableReplicas": int64(0),
+					"conditions": []any{
+						map[string]any{"type": "Available", "status": "True"},
+						map[string]any{"type": "Progressing", "status": "True", "reason": "NewReplicaSetAvailable"},
+					},
+				},
+			},
+			want: meta.HealthStatusHealthy,
+		},
+		{
+			name: "deployment with lagging observed generation",
+			obj: map[string]any{
+				"apiVersion": "apps/v1",
+				"kind":       "Deployment",
+				"metadata":   map[string]any{"name": "app", "namespace": "ns", "generation": int64(3)},
+				"spec":       map[string]any{"replicas": int64(1)},
+				"status":     map[string]any{"observedGeneration": int64(2)},
+			},
+			want: meta.HealthStatusProgressing,
+		},
+		{
+			name: "job complete",
+			obj: map[string]any{
+				"apiVersion": "batch/v1",
+				"kind":       "Job",
+				"metadata":   map[string]any{"name": "job", "namespace": "ns"},
+				"status": map[string]any{
+					"succeeded":  int64(1),
+					"conditions": []any{map[string]any{"type": "Complete", "status": "True"}},
+				},
+			},
+			want: meta.HealthStatusHealthy,
+		},
+		{
+			name: "job failed",
+			obj: map[string]any{
+				"apiVersion": "batch/v1",
+				"kind":       "Job",
+				"metadata":   map[string]any{"name": "job", "namespace": "ns"},
+				"status": map[string]any{
+					"failed":     int64(1),
+					"conditions": []any{map[string]any{"type": "Failed", "status": "True", "message": "BackoffLimitExceeded"}},
+				},
+			},
+			want: meta.HealthStatusDegraded,
+		},
+		{
+			name: "custom resource not ready",
+			obj: map[string]any{
+				"apiVersion": "example.com/v1",
+				"kind":       "Database",
+				"metadata":   map[string]any{"name": "db", "namespace": "ns", "generation": int64(1)},
+				"status": map[string]any{
+					"observedGeneration": int64(1),
+					"conditions":         []any{map[string]any{"type": "Ready", "status": "False", "reason": "Provisioning"}},
+				},
+			},
+			want: meta.HealthStatusProgressing,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, message := objectHealth(&unstructured.Unstructured{Object: tt.obj})
+			if got != tt.want {
+				t.Fatalf("health = %s (%s), want %s", got, message, tt.want)
+			}
+			if got == meta.HealthStatusHealthy && message != "" {
+				t.Fatalf("healthy objects must not carry a message, got %q", message)
+			}
+		})
+	}
+}
+
+func TestWorseHealth(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		a, b meta.HealthStatus
+		want bool
+	}{
+		{a: "", b: meta.HealthStatusHealthy, want: true},
+		{a: meta.HealthStatusHealthy, b: meta.HealthStatusProgressing, want: true},
+		{a: meta.HealthStatusProgressing, b: meta.HealthStatusDegraded, want: true},
+		{a: meta.HealthStatusDegraded, b: meta.HealthStatusUnknown, want: false},
+		{a: meta.HealthStatusHealthy, b: meta.HealthStatusHealthy, want: false},
+	}
+
+	for _, tt := range tests {
+		if got := worseHealth(tt.a, tt.b); got != tt.want {
+			t.Errorf("worseHealth(%q, %q) = %t, want %t", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
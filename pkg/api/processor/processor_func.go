@@ -43,8 +43,6 @@ func (p *Processor) Reconcile(
 		return fmt.Errorf("applying of %d resources failed", itemErrors)
 	}
 
-	// Running Healthchecks
-
 	log.V(4).Info("processing completed")
 
 	return nil
@@ -322,15 +320,46 @@ func (p *Processor) applyAccumulatorObject(
 		return false
 	}
 
+	current := processed.GetItem(item.Resource)
+
+	or.DriftPolicy = stricterDriftPolicy(or.DriftPolicy, obj.DriftPolicy)
+
+	skip, correct, err := p.observeDrift(ctx, c, obj, fieldOwner, current, or)
+	if err != nil {
+		or.Status = metav1.ConditionFalse
+		or.Message = "detecting drift failed for item " + obj.Origin.Origin + ": " + err.Error()
+
+		processed.UpdateItem(*or)
+
+		return true
+	}
+
+	if skip {
+		log.V(4).Info("skipping apply because item drifted", "item", obj.Origin.Origin, "drift", or.DriftMessage)
+
+		or.Status = metav1.ConditionTrue
+		or.Created = current.Created
+		or.LastApply = current.LastApply
+
+		if health, message := assessHealth(ctx, c, obj.Object); worseHealth(or.Health, health) {
+			or.Health = health
+			or.HealthMessage = message
+		}
+
+		processed.UpdateItem(*or)
+
+		return false
+	}
+
 	ver, created, err := p.Apply(
 		ctx,
 		c,
 		obj.Object,
 		fieldOwner,
-		opts.Force,
+		opts.Force || correct,
 		opts.Adopt,
 		opts.Owner,
-		processed.GetItem(item.Resource),
+		current,
 	)
 
 	or.Created = created
@@ -348,6 +377,12 @@ func (p *Processor) applyAccumulatorObject(
 		or.Status = metav1.ConditionTrue
 
 		log.V(4).Info("successfully applied item", "item", obj.Origin.Origin, "version", ver)
+
+		// An item may consist of several objects, the item is as healthy as the least healthy one.
+		if health, message := assessHealth(ctx, c, obj.Object); worseHealth(or.Health, health) {
+			or.Health = health
+			or.HealthMessage = message
+		}
 	}
 
 	processed.UpdateItem(*or)
@@ -355,6 +390,51 @@ func (p *Processor) applyAccumulatorObject(
 	return err != nil
 }
 
+// Observes whether an object applied before drifted, when its drift policy asks for it. Drifted
+// objects whose drift is only reported must be skipped, whereas the ones whose drift is corrected
+// must be applied forcing the ownership of the fields modified outside of Capsule.
+func (p *Processor) observeDrift(
+	ctx context.Context,
+	c client.Client,
+	obj AccumulatorObject,
+	fieldOwner string,
+	current *meta.ObjectReferenceStatus,
+	or *meta.ObjectReferenceStatus,
+) (skip bool, correct bool, err error) {
+	if !obj.DriftPolicy.Observed() {
+		return false, false, nil
+	}
+
+	state := meta.DriftStateInSync
+	message := ""
+
+	// Objects which were never applied cannot drift.
+	if current != nil && !current.LastApply.IsZero() {
+		drift, err := detectDrift(ctx, c, obj.Object, fieldOwner)
+		if err != nil {
+			return false, false, err
+		}
+
+		if drift.detected() {
+			message = drift.message()
+
+			switch obj.DriftPolicy {
+			case meta.DriftPolicyReport:
+				state, skip = meta.DriftStateDrifted, true
+			default:
+				state, correct = meta.DriftStateCorrected, true
+			}
+		}
+	}
+
+	if worseDrift(or.Drift, state) {
+		or.Drift = state
+		or.DriftMessage = message
+	}
+
+	return skip, correct, nil
+}
+
 func (p *Processor) objectForProcessedItem(item meta.ObjectReferenceStatus) (*unstructured.Unstructured, error) {
 	obj := &unstructured.Unstructured{}
 	obj.SetGroupVersionKind(item.GetGVK())
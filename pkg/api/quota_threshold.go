@@ -0,0 +1,86 @@
+// Copyright 2020-2026 Project Capsule Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// QuotaThreshold is either a percentage of a limit (eg. 80%) or an absolute quantity (eg. 8Gi).
+type QuotaThreshold string
+
+func (t QuotaThreshold) String() string {
+	return string(t)
+}
+
+// Returns true if the threshold is expressed as a percentage of the limit.
+func (t QuotaThreshold) IsPercentage() bool {
+	return strings.HasSuffix(string(t), "%")
+}
+
+// Validates the threshold. Percentages must be whole numbers between 1 and 100.
+func (t QuotaThreshold) Validate() error {
+	if t == "" {
+		return nil
+	}
+
+	if t.IsPercentage() {
+		_, err := t.percentage()
+
+		return err
+	}
+
+	quantity, err := resource.ParseQuantity(string(t))
+	if err != nil {
+		return fmt.Errorf("threshold %q is neither a percentage nor a quantity: %w", t, err)
+	}
+
+	if quantity.Sign() < 0 {
+		return fmt.Errorf("threshold %q must not be negative", t)
+	}
+
+	return nil
+}
+
+// Resolves the threshold against the given limit. Percentages are rounded up.
+func (t QuotaThreshold) Resolve(limit resource.Quantity) (resource.Quantity, error) {
+	if !t.IsPercentage() {
+		return resource.ParseQuantity(string(t))
+	}
+
+	percentage, err := t.percentage()
+	if err != nil {
+		return resource.Quantity{}, err
+	}
+
+	// Milli units keep the precision of small limits (eg. cpu), large limits are resolved in units
+	// to avoid overflows.
+	if limit.CmpInt64(math.MaxInt64/100/1000) < 0 {
+		return *resource.NewMilliQuantity(ceilDiv(limit.MilliValue()*percentage, 100), limit.Format), nil
+	}
+
+	return *resource.NewQuantity(ceilDiv(limit.Value(), 100)*percentage, limit.Format), nil
+}
+
+func (t QuotaThreshold) percentage() (int64, error) {
+	percentage, err := strconv.ParseInt(strings.TrimSuffix(string(t), "%"), 10, 64)
+	if err != nil || percentage < 1 || percentage > 100 {
+		return 0, fmt.Errorf("threshold %q must be a whole percentage between 1%% and 100%%", t)
+	}
+
+	return percentage, nil
+}
+
+func ceilDiv(a, b int64) int64 {
+	if a <= 0 {
+		return a / b
+	}
+
+	return (a + b - 1) / b
+}
@@ -0,0 +1,66 @@
+// Copyright 2020-2026 Project Capsule Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package api_test
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/projectcapsule/capsule/pkg/api"
+)
+
+func TestQuotaThresholdValidate(t *testing.T) {
+	t.Parallel()
+
+	for _, tt := range []struct {
+		threshold api.QuotaThreshold
+		wantErr   bool
+	}{
+		{threshold: ""},
+		{threshold: "80%"},
+		{threshold: "100%"},
+		{threshold: "8Gi"},
+		{threshold: "500m"},
+		{threshold: "0%", wantErr: true},
+		{threshold: "101%", wantErr: true},
+		{threshold: "12.5%", wantErr: true},
+		{threshold: "-1", wantErr: true},
+		{threshold: "eighty", wantErr: true},
+	} {
+		if err := tt.threshold.Validate(); (err != nil) != tt.wantErr {
+			t.Fatalf("QuotaThreshold(%q).Validate() error = %v, wantErr %v", tt.threshold, err, tt.wantErr)
+		}
+	}
+}
+
+func TestQuotaThresholdResolve(t *testing.T) {
+	t.Parallel()
+
+	for _, tt := range []struct {
+		threshold api.QuotaThreshold
+		limit     string
+		want      string
+	}{
+		{threshold: "80%", limit: "10", want: "8"},
+		{threshold: "80%", limit: "10Gi", want: "8Gi"},
+		{threshold: "50%", limit: "1", want: "500m"},
+		{threshold: "33%", limit: "1", want: "330m"},
+		{threshold: "90%", limit: "1E", want: "900P"},
+		{threshold: "8Gi", limit: "10Gi", want: "8Gi"},
+	} {
+		got, err := tt.threshold.Resolve(resource.MustParse(tt.limit))
+		if err != nil {
+			t.Fatalf("QuotaThreshold(%q).Resolve(%s) unexpected error: %v", tt.threshold, tt.limit, err)
+		}
+
+		if got.Cmp(resource.MustParse(tt.want)) != 0 {
+			t.Fatalf("QuotaThreshold(%q).Resolve(%s) = %s, want %s", tt.threshold, tt.limit, got.String(), tt.want)
+		}
+	}
+
+	if _, err := api.QuotaThreshold("0%").Resolve(resource.MustParse("1")); err == nil {
+		t.Fatal("QuotaThreshold(\"0%\").Resolve() expected error")
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright 2020-2026 Project Capsule Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package rules
+
+import (
+	"strings"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/projectcapsule/capsule/pkg/api/runtime"
+)
+
+// CELRule defines a CEL expression evaluated against admitted namespaced
+// resources. The expression must return a boolean: true means the rule
+// matches and the enclosing allow, deny, or audit action applies.
+//
+// The following variables are available:
+// - object: the admitted object.
+// - oldObject: the previous object on updates, null otherwise.
+// - tenant: the Tenant owning the namespace.
+// - userInfo: the requesting user (username, uid, groups, extra).
+//
+// +kubebuilder:object:generate=true
+type CELRule struct {
+	runtime.VersionKinds `json:",inline"`
+
+	// Name is a human-readable identifier used in admission messages and events.
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// Expression is the CEL expression evaluated for matching objects.
+	//
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=4096
+	Expression string `json:"expression"`
+
+	// Message replaces the expression in admission messages and events.
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// FailurePolicy defines how errors evaluating the expression are handled,
+	// eg. when it reads a field the object does not have. With Fail the request
+	// is denied, naming the rule, with Ignore the expression evaluates to false.
+	// +kubebuilder:validation:Enum=Fail;Ignore
+	// +kubebuilder:default=Fail
+	// +optional
+	FailurePolicy admissionregistrationv1.FailurePolicyType `json:"failurePolicy,omitempty"`
+}
+
+// MatchesGroupVersionKind matches CEL targets. Namespaces are never targeted,
+// since namespace rules only apply to resources within a namespace.
+func (r CELRule) MatchesGroupVersionKind(gvk schema.GroupVersionKind) bool {
+	if gvk.Group == "" && gvk.Kind == "Namespace" {
+		return false
+	}
+
+	return r.VersionKinds.MatchesGroupVersionKind(gvk)
+}
+
+// Description returns the most specific human-readable representation of the rule.
+func (r CELRule) Description() string {
+	if message := strings.TrimSpace(r.Message); message != "" {
+		return message
+	}
+
+	if name := strings.TrimSpace(r.Name); name != "" {
+		return name
+	}
+
+	return "expression " + strings.TrimSpace(r.Expression)
+}
+
+// IgnoresFailures reports whether evaluation errors of the rule are ignored.
+func (r CELRule) IgnoresFailures() bool {
+	return r.FailurePolicy == admissionregistrationv1.Ignore
+}
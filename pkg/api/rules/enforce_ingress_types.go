@@ -3,7 +3,11 @@
 
 package rules
 
-import "github.com/projectcapsule/capsule/pkg/api/runtime"
+import (
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/projectcapsule/capsule/pkg/api/runtime"
+)
 
 // +kubebuilder:validation:Enum=Ingress;Route;ListenerSet;HTTPRoute;Gateway;TLSRoute;GRPCRoute
 type IngressType string
@@ -18,6 +22,24 @@ const (
 	IngressTypeGRPCRoute   IngressType = "GRPCRoute"
 )
 
+// GroupVersionKind returns the kind enforced for the ingress type.
+func (t IngressType) GroupVersionKind() schema.GroupVersionKind {
+	switch t {
+	case IngressTypeIngress:
+		return schema.GroupVersionKind{Group: "networking.k8s.io", Version: "v1", Kind: string(t)}
+	case IngressTypeRoute:
+		return schema.GroupVersionKind{Group: "route.openshift.io", Version: "v1", Kind: string(t)}
+	case IngressTypeListenerSet,
+		IngressTypeHTTPRoute,
+		IngressTypeGateway,
+		IngressTypeTLSRoute,
+		IngressTypeGRPCRoute:
+		return schema.GroupVersionKind{Group: "gateway.networking.k8s.io", Version: "v1", Kind: string(t)}
+	}
+
+	return schema.GroupVersionKind{}
+}
+
 // NamespaceRuleEnforceIngressBody defines hostname enforcement for Kubernetes
 // Ingress and Gateway API resources.
 //
@@ -52,4 +52,9 @@ type NamespaceRuleEnforceBody struct {
 	// Enforcement for Ingress and Gateway API resource hostnames.
 	// +optional
 	Ingress NamespaceRuleEnforceIngressBody `json:"ingress,omitempty"`
+
+	// CEL expressions evaluated against admitted namespaced resources.
+	// Use them when none of the typed enforcement blocks covers a policy.
+	// +optional
+	CEL []CELRule `json:"cel,omitempty"`
 }
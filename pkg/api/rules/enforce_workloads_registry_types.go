@@ -24,4 +24,56 @@ type OCIRegistry struct {
 	// +optional
 	// +kubebuilder:validation:Items:Enum=Always;Never;IfNotPresent
 	Policy []corev1.PullPolicy `json:"policy,omitempty"`
+
+	// RequireDigest requires references of the given registry to be pinned by
+	// a sha256 or sha512 digest (@sha256:<64 hex characters> or
+	// @sha512:<128 hex characters>).
+	// +optional
+	RequireDigest bool `json:"requireDigest,omitempty"`
+
+	// ForbiddenTags matches tags which must not be used for the given registry,
+	// for example latest. References without a tag are evaluated as latest.
+	// References pinned by digest are immutable and therefore not evaluated.
+	// +optional
+	ForbiddenTags []runtime.ExpressionMatch `json:"forbiddenTags,omitempty"`
+
+	// Digests restricts references of the given registry to the listed digests.
+	// References must be pinned by digest, tags are not resolved against the
+	// registry. An entry with a tag additionally requires a tagged reference to
+	// use that tag for the digest.
+	// +optional
+	Digests []OCIDigest `json:"digests,omitempty"`
+
+	// Rewrite replaces the registry of references when a Pod is admitted, for
+	// example to pull through a mirror. It applies to every reference of the
+	// selected workload targets starting with From, the expression of this
+	// registry and all validation are evaluated against the rewritten reference.
+	// +optional
+	Rewrite *OCIRegistryRewrite `json:"rewrite,omitempty"`
+}
+
+// OCIRegistryRewrite replaces a registry or repository prefix of image references.
+// +kubebuilder:object:generate=true
+type OCIRegistryRewrite struct {
+	// From is the registry or repository prefix which is replaced, for example
+	// docker.io. References without a registry are evaluated as docker.io.
+	// +kubebuilder:validation:MinLength=1
+	From string `json:"from"`
+
+	// To is the registry or repository prefix used instead, for example
+	// mirror.internal/dockerhub.
+	// +kubebuilder:validation:MinLength=1
+	To string `json:"to"`
+}
+
+// OCIDigest is an allowed image digest, optionally bound to a tag.
+// +kubebuilder:object:generate=true
+type OCIDigest struct {
+	// Digest of the image.
+	// +kubebuilder:validation:Pattern=`^(sha256:[a-f0-9]{64}|sha512:[a-f0-9]{128})$`
+	Digest string `json:"digest"`
+
+	// Tag the digest is published with.
+	// +optional
+	Tag string `json:"tag,omitempty"`
 }
@@ -0,0 +1,60 @@
+// Copyright 2020-2026 Project Capsule Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package rules
+
+import (
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/projectcapsule/capsule/pkg/api/runtime"
+)
+
+// WorkloadSchedulingRules defines node placement matchers for Pod admission.
+//
+// The rules are evaluated on the pod workload target. Combined with the
+// namespace selectors of the enclosing rule, they dedicate node pools to
+// selected namespaces of a tenant.
+//
+// +kubebuilder:object:generate=true
+type WorkloadSchedulingRules struct {
+	// NodeAffinity matches the required node affinity of Pods. Every required
+	// node selector term of a Pod, combined with spec.nodeSelector, is matched
+	// against the rule terms. A Pod term matches a rule term when it contains
+	// each of its requirements, or a narrower one. Pods without node
+	// constraints do not match any term.
+	// With the allow action, Pods created without required node affinity get
+	// the terms of the last allow rule injected by mutation.
+	// +optional
+	NodeAffinity *corev1.NodeSelector `json:"nodeAffinity,omitempty"`
+
+	// Tolerations matches the tolerations of Pods. The bounded
+	// node.kubernetes.io/not-ready and node.kubernetes.io/unreachable NoExecute
+	// tolerations, injected by the DefaultTolerationSeconds admission plugin,
+	// are always allowed.
+	// +optional
+	Tolerations []WorkloadTolerationRule `json:"tolerations,omitempty"`
+
+	// DefaultTolerations are added by mutation to Pods which are created
+	// without an equal toleration. They are injected regardless of the rule
+	// action and are still evaluated against the toleration rules.
+	// +optional
+	DefaultTolerations []corev1.Toleration `json:"defaultTolerations,omitempty"`
+}
+
+// WorkloadTolerationRule matches Pod tolerations by key and effect.
+//
+// A toleration with an empty key tolerates every taint and is evaluated with
+// the key "*". A toleration with an empty effect tolerates every effect and is
+// evaluated with the effect "*".
+//
+// +kubebuilder:object:generate=true
+// +kubebuilder:validation:XValidation:rule="has(self.key) || has(self.effect)",message="at least one of key or effect must be set"
+type WorkloadTolerationRule struct {
+	// Key matches the toleration key.
+	// +optional
+	Key *runtime.ExpressionMatch `json:"key,omitempty"`
+
+	// Effect matches the toleration effect.
+	// +optional
+	Effect *runtime.ExpressionMatch `json:"effect,omitempty"`
+}
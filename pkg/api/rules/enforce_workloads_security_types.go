@@ -0,0 +1,82 @@
+// Copyright 2020-2026 Project Capsule Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package rules
+
+import (
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/projectcapsule/capsule/pkg/api/runtime"
+)
+
+// WorkloadSecurityContextRules defines security context matchers for Pod admission.
+//
+// Each field is evaluated on its own against the effective value of every
+// selected location: a container value overrides the Pod value and unset
+// values are evaluated with the Kubernetes default. Boolean fields match when
+// the effective value equals the given value, so an allow rule with
+// runAsNonRoot: true only admits containers running as non-root while a deny
+// rule with privileged: true rejects privileged containers.
+//
+// Container fields honor the workload targets pod/containers,
+// pod/initcontainers and pod/ephemeralcontainers. Host namespace fields are
+// evaluated on the Pod and honor the pod target.
+//
+// +kubebuilder:object:generate=true
+type WorkloadSecurityContextRules struct {
+	// Capabilities matches Linux capabilities added to containers.
+	// Capability names are compared in upper case without the CAP_ prefix.
+	// +optional
+	Capabilities []runtime.ExpressionMatch `json:"capabilities,omitempty"`
+
+	// RequiredDropCapabilities matches containers which drop every listed
+	// capability, either by name or by dropping ALL. Capability names are
+	// compared in upper case without the CAP_ prefix. An allow rule with
+	// requiredDropCapabilities: ["ALL"] only admits containers dropping ALL.
+	// +optional
+	RequiredDropCapabilities []corev1.Capability `json:"requiredDropCapabilities,omitempty"`
+
+	// RunAsNonRoot matches the effective runAsNonRoot of containers.
+	// Unset is evaluated as false, and so is an effective runAsUser of 0.
+	// +optional
+	RunAsNonRoot *bool `json:"runAsNonRoot,omitempty"`
+
+	// Privileged matches the privileged flag of containers.
+	// Unset is evaluated as false.
+	// +optional
+	Privileged *bool `json:"privileged,omitempty"`
+
+	// AllowPrivilegeEscalation matches the allowPrivilegeEscalation flag of containers.
+	// Unset is evaluated as true.
+	// +optional
+	AllowPrivilegeEscalation *bool `json:"allowPrivilegeEscalation,omitempty"`
+
+	// ReadOnlyRootFilesystem matches the readOnlyRootFilesystem flag of containers.
+	// Unset is evaluated as false.
+	// +optional
+	ReadOnlyRootFilesystem *bool `json:"readOnlyRootFilesystem,omitempty"`
+
+	// HostNetwork matches pod.spec.hostNetwork.
+	// +optional
+	HostNetwork *bool `json:"hostNetwork,omitempty"`
+
+	// HostPID matches pod.spec.hostPID.
+	// +optional
+	HostPID *bool `json:"hostPID,omitempty"`
+
+	// HostIPC matches pod.spec.hostIPC.
+	// +optional
+	HostIPC *bool `json:"hostIPC,omitempty"`
+
+	// SeccompProfiles matches the effective seccomp profile of containers.
+	// The profile is evaluated as RuntimeDefault, Unconfined or
+	// Localhost/<localhostProfile>. Unset is evaluated as Unconfined.
+	// +optional
+	SeccompProfiles []runtime.ExpressionMatch `json:"seccompProfiles,omitempty"`
+
+	// AppArmorProfiles matches the effective AppArmor profile of containers.
+	// The profile is evaluated as RuntimeDefault, Unconfined or
+	// Localhost/<localhostProfile>. Unset is evaluated as RuntimeDefault.
+	// +optional
+	AppArmorProfiles []runtime.ExpressionMatch `json:"appArmorProfiles,omitempty"`
+}
@@ -61,6 +61,22 @@ type NamespaceRuleEnforceWorkloadsBody struct {
 	//
 	// +optional
 	Schedulers []runtime.ExpressionMatch `json:"schedulers,omitempty"`
+
+	// Scheduling defines node affinity and toleration matchers for Pod
+	// admission, including tolerations injected by mutation.
+	//
+	// +optional
+	Scheduling *WorkloadSchedulingRules `json:"scheduling,omitempty"`
+
+	// SecurityContext defines security context matchers for Pod admission.
+	//
+	// +optional
+	SecurityContext *WorkloadSecurityContextRules `json:"securityContext,omitempty"`
+
+	// Volumes defines volume matchers for Pod admission.
+	//
+	// +optional
+	Volumes *WorkloadVolumeRules `json:"volumes,omitempty"`
 }
 
 type WorkloadResourceRequestPolicyType string
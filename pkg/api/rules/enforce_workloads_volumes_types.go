@@ -0,0 +1,47 @@
+// Copyright 2020-2026 Project Capsule Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package rules
+
+import (
+	"github.com/projectcapsule/capsule/pkg/api/runtime"
+)
+
+// WorkloadVolumeRules defines volume matchers for Pod admission.
+//
+// Each field is evaluated on its own against every Pod volume. The rules honor
+// the pod/volumes workload target.
+//
+// +kubebuilder:object:generate=true
+type WorkloadVolumeRules struct {
+	// Types matches the source type of Pod volumes. The type is the field name
+	// of the volume source, for example hostPath, nfs, csi, ephemeral, emptyDir,
+	// configMap, secret or persistentVolumeClaim.
+	// +optional
+	Types []runtime.ExpressionMatch `json:"types,omitempty"`
+
+	// HostPaths matches hostPath volumes by path prefix.
+	// +optional
+	HostPaths []WorkloadHostPathRule `json:"hostPaths,omitempty"`
+
+	// CSIDrivers matches the driver name of inline CSI volumes.
+	// +optional
+	CSIDrivers []runtime.ExpressionMatch `json:"csiDrivers,omitempty"`
+}
+
+// WorkloadHostPathRule matches hostPath volumes.
+//
+// +kubebuilder:object:generate=true
+type WorkloadHostPathRule struct {
+	// PathPrefix matches host paths equal to or below the given path.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:Pattern=`^/`
+	PathPrefix string `json:"pathPrefix"`
+
+	// ReadOnly restricts the match to volumes which are mounted read-only by
+	// every container. Combined with an allow rule, the path prefix is only
+	// admitted for read-only mounts.
+	// +kubebuilder:default:=false
+	// +optional
+	ReadOnly bool `json:"readOnly,omitempty"`
+}
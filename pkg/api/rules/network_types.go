@@ -0,0 +1,53 @@
+// Copyright 2020-2026 Project Capsule Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package rules
+
+import networkingv1 "k8s.io/api/networking/v1"
+
+// NamespaceRuleNetworkBody is rendered into a NetworkPolicy selecting all Pods
+// in every namespace selected by the rule.
+//
+// Following NetworkPolicy semantics, any allowance isolates the Pods for its
+// direction: traffic which is not allowed by this or another NetworkPolicy is
+// dropped.
+//
+// Changes to the generated NetworkPolicy are reverted, each with a
+// DriftDetected event. The drift stays reported by the drift annotation of
+// the NetworkPolicy and the Tenant Drifted condition, also once reverted,
+// until the annotation is removed or the rule changes.
+//
+// +kubebuilder:object:generate=true
+type NamespaceRuleNetworkBody struct {
+	// DefaultDeny isolates the Pods for ingress and egress, even when no
+	// allowance is declared for a direction.
+	// +kubebuilder:default:=false
+	// +optional
+	DefaultDeny bool `json:"defaultDeny,omitempty"`
+
+	// IngressFromTenant allows ingress from all namespaces of the same Tenant.
+	// +kubebuilder:default:=false
+	// +optional
+	IngressFromTenant bool `json:"ingressFromTenant,omitempty"`
+
+	// Egress lists the CIDRs the Pods are allowed to connect to.
+	// +optional
+	Egress []NetworkEgressRule `json:"egress,omitempty"`
+}
+
+// NetworkEgressRule allows egress to a CIDR.
+//
+// +kubebuilder:object:generate=true
+type NetworkEgressRule struct {
+	// CIDR is the allowed destination, for example 10.0.0.0/8 or 2001:db8::/64.
+	// +kubebuilder:validation:MinLength=1
+	CIDR string `json:"cidr"`
+
+	// Except lists CIDRs within CIDR which are not allowed.
+	// +optional
+	Except []string `json:"except,omitempty"`
+
+	// Ports restricts the allowed destination ports. Empty allows all ports.
+	// +optional
+	Ports []networkingv1.NetworkPolicyPort `json:"ports,omitempty"`
+}
@@ -0,0 +1,46 @@
+// Copyright 2020-2026 Project Capsule Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package rules
+
+import "time"
+
+// EffectiveAction returns the action enforced at now according to the
+// activation window. False is returned when the rule is not enforced at now.
+func (in *NamespaceRuleBodyNamespace) EffectiveAction(now time.Time) (ActionType, bool) {
+	if in == nil || in.Enforce == nil {
+		return "", false
+	}
+
+	if in.NotBefore != nil && now.Before(in.NotBefore.Time) {
+		return "", false
+	}
+
+	if in.NotAfter != nil && !now.Before(in.NotAfter.Time) {
+		if in.EscalateTo == "" {
+			return "", false
+		}
+
+		return in.EscalateTo, true
+	}
+
+	return in.Enforce.Action.OrDefault(), true
+}
+
+// NextActivationChange returns the next boundary of the activation window
+// after now. False is returned when the effective action no longer changes.
+func (in *NamespaceRuleBodyNamespace) NextActivationChange(now time.Time) (time.Time, bool) {
+	if in == nil || in.Enforce == nil {
+		return time.Time{}, false
+	}
+
+	if in.NotBefore != nil && in.NotBefore.After(now) {
+		return in.NotBefore.Time, true
+	}
+
+	if in.NotAfter != nil && in.NotAfter.After(now) {
+		return in.NotAfter.Time, true
+	}
+
+	return time.Time{}, false
+}
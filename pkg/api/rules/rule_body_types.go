@@ -24,6 +24,31 @@ type NamespaceRuleBodyNamespace struct {
 	// Enforcement for given rule
 	//+optional
 	Enforce *NamespaceRuleEnforceBody `json:"enforce,omitzero"`
+
+	// DryRun evaluates the enforcement against objects already present in the
+	// selected namespaces and reports violations in the RuleStatus instead of
+	// enforcing it at admission. Disable it once no violations are reported.
+	// CEL expressions reading oldObject or userInfo can't be evaluated outside
+	// of admission, they are listed as not evaluable.
+	// +optional
+	// +kubebuilder:default:=false
+	DryRun bool `json:"dryRun,omitempty"`
+
+	// NotBefore activates the enforcement at the given time. The rule is not
+	// enforced before.
+	// +optional
+	NotBefore *metav1.Time `json:"notBefore,omitempty"`
+
+	// NotAfter ends the enforcement with the configured action at the given
+	// time. Afterwards the rule is enforced with EscalateTo, if set, otherwise
+	// it is no longer enforced.
+	// +optional
+	NotAfter *metav1.Time `json:"notAfter,omitempty"`
+
+	// EscalateTo is the action enforced once NotAfter has passed, for example
+	// to deny objects a rule has been auditing.
+	// +optional
+	EscalateTo ActionType `json:"escalateTo,omitempty"`
 }
 
 // Rules Distributed via Tenants
@@ -37,4 +62,10 @@ type NamespaceRuleBodyTenant struct {
 	// Permissions for given rule
 	//+optional
 	Permissions NamespaceRulePermissionBody `json:"permissions,omitempty"`
+
+	// Network is rendered into a NetworkPolicy in each selected namespace.
+	// Changes to the generated NetworkPolicy are reverted and reported in the
+	// Tenant status.
+	//+optional
+	Network *NamespaceRuleNetworkBody `json:"network,omitempty"`
 }
@@ -11,6 +11,7 @@ import (
 	"github.com/projectcapsule/capsule/pkg/api/rbac"
 	"github.com/projectcapsule/capsule/pkg/api/runtime"
 	"k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -29,6 +30,22 @@ func (in *Audience) DeepCopy() *Audience {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CELRule) DeepCopyInto(out *CELRule) {
+	*out = *in
+	in.VersionKinds.DeepCopyInto(&out.VersionKinds)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CELRule.
+func (in *CELRule) DeepCopy() *CELRule {
+	if in == nil {
+		return nil
+	}
+	out := new(CELRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MetadataRule) DeepCopyInto(out *MetadataRule) {
 	*out = *in
@@ -111,6 +128,14 @@ func (in *NamespaceRuleBodyNamespace) DeepCopyInto(out *NamespaceRuleBodyNamespa
 		*out = new(NamespaceRuleEnforceBody)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.NotBefore != nil {
+		in, out := &in.NotBefore, &out.NotBefore
+		*out = (*in).DeepCopy()
+	}
+	if in.NotAfter != nil {
+		in, out := &in.NotAfter, &out.NotAfter
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceRuleBodyNamespace.
@@ -137,6 +162,11 @@ func (in *NamespaceRuleBodyTenant) DeepCopyInto(out *NamespaceRuleBodyTenant) {
 		(*in).DeepCopyInto(*out)
 	}
 	in.Permissions.DeepCopyInto(&out.Permissions)
+	if in.Network != nil {
+		in, out := &in.Network, &out.Network
+		*out = new(NamespaceRuleNetworkBody)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceRuleBodyTenant.
@@ -162,6 +192,13 @@ func (in *NamespaceRuleEnforceBody) DeepCopyInto(out *NamespaceRuleEnforceBody)
 		}
 	}
 	in.Ingress.DeepCopyInto(&out.Ingress)
+	if in.CEL != nil {
+		in, out := &in.CEL, &out.CEL
+		*out = make([]CELRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceRuleEnforceBody.
@@ -268,6 +305,21 @@ func (in *NamespaceRuleEnforceWorkloadsBody) DeepCopyInto(out *NamespaceRuleEnfo
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Scheduling != nil {
+		in, out := &in.Scheduling, &out.Scheduling
+		*out = new(WorkloadSchedulingRules)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SecurityContext != nil {
+		in, out := &in.SecurityContext, &out.SecurityContext
+		*out = new(WorkloadSecurityContextRules)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Volumes != nil {
+		in, out := &in.Volumes, &out.Volumes
+		*out = new(WorkloadVolumeRules)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceRuleEnforceWorkloadsBody.
@@ -280,6 +332,28 @@ func (in *NamespaceRuleEnforceWorkloadsBody) DeepCopy() *NamespaceRuleEnforceWor
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespaceRuleNetworkBody) DeepCopyInto(out *NamespaceRuleNetworkBody) {
+	*out = *in
+	if in.Egress != nil {
+		in, out := &in.Egress, &out.Egress
+		*out = make([]NetworkEgressRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceRuleNetworkBody.
+func (in *NamespaceRuleNetworkBody) DeepCopy() *NamespaceRuleNetworkBody {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespaceRuleNetworkBody)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NamespaceRulePermissionBody) DeepCopyInto(out *NamespaceRulePermissionBody) {
 	*out = *in
@@ -338,6 +412,48 @@ func (in *NamespaceRulePromotionRule) DeepCopy() *NamespaceRulePromotionRule {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkEgressRule) DeepCopyInto(out *NetworkEgressRule) {
+	*out = *in
+	if in.Except != nil {
+		in, out := &in.Except, &out.Except
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Ports != nil {
+		in, out := &in.Ports, &out.Ports
+		*out = make([]networkingv1.NetworkPolicyPort, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkEgressRule.
+func (in *NetworkEgressRule) DeepCopy() *NetworkEgressRule {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkEgressRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OCIDigest) DeepCopyInto(out *OCIDigest) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OCIDigest.
+func (in *OCIDigest) DeepCopy() *OCIDigest {
+	if in == nil {
+		return nil
+	}
+	out := new(OCIDigest)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OCIRegistry) DeepCopyInto(out *OCIRegistry) {
 	*out = *in
@@ -347,6 +463,23 @@ func (in *OCIRegistry) DeepCopyInto(out *OCIRegistry) {
 		*out = make([]v1.PullPolicy, len(*in))
 		copy(*out, *in)
 	}
+	if in.ForbiddenTags != nil {
+		in, out := &in.ForbiddenTags, &out.ForbiddenTags
+		*out = make([]runtime.ExpressionMatch, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Digests != nil {
+		in, out := &in.Digests, &out.Digests
+		*out = make([]OCIDigest, len(*in))
+		copy(*out, *in)
+	}
+	if in.Rewrite != nil {
+		in, out := &in.Rewrite, &out.Rewrite
+		*out = new(OCIRegistryRewrite)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OCIRegistry.
@@ -359,6 +492,21 @@ func (in *OCIRegistry) DeepCopy() *OCIRegistry {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OCIRegistryRewrite) DeepCopyInto(out *OCIRegistryRewrite) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OCIRegistryRewrite.
+func (in *OCIRegistryRewrite) DeepCopy() *OCIRegistryRewrite {
+	if in == nil {
+		return nil
+	}
+	out := new(OCIRegistryRewrite)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ResourceQuotaRule) DeepCopyInto(out *ResourceQuotaRule) {
 	*out = *in
@@ -452,6 +600,21 @@ func (in *ServiceNodePortRule) DeepCopy() *ServiceNodePortRule {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkloadHostPathRule) DeepCopyInto(out *WorkloadHostPathRule) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadHostPathRule.
+func (in *WorkloadHostPathRule) DeepCopy() *WorkloadHostPathRule {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkloadHostPathRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WorkloadResourceLimitPolicy) DeepCopyInto(out *WorkloadResourceLimitPolicy) {
 	*out = *in
@@ -520,3 +683,172 @@ func (in *WorkloadResourceRules) DeepCopy() *WorkloadResourceRules {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkloadSchedulingRules) DeepCopyInto(out *WorkloadSchedulingRules) {
+	*out = *in
+	if in.NodeAffinity != nil {
+		in, out := &in.NodeAffinity, &out.NodeAffinity
+		*out = new(v1.NodeSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]WorkloadTolerationRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DefaultTolerations != nil {
+		in, out := &in.DefaultTolerations, &out.DefaultTolerations
+		*out = make([]v1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadSchedulingRules.
+func (in *WorkloadSchedulingRules) DeepCopy() *WorkloadSchedulingRules {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkloadSchedulingRules)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkloadSecurityContextRules) DeepCopyInto(out *WorkloadSecurityContextRules) {
+	*out = *in
+	if in.Capabilities != nil {
+		in, out := &in.Capabilities, &out.Capabilities
+		*out = make([]runtime.ExpressionMatch, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RequiredDropCapabilities != nil {
+		in, out := &in.RequiredDropCapabilities, &out.RequiredDropCapabilities
+		*out = make([]v1.Capability, len(*in))
+		copy(*out, *in)
+	}
+	if in.RunAsNonRoot != nil {
+		in, out := &in.RunAsNonRoot, &out.RunAsNonRoot
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Privileged != nil {
+		in, out := &in.Privileged, &out.Privileged
+		*out = new(bool)
+		**out = **in
+	}
+	if in.AllowPrivilegeEscalation != nil {
+		in, out := &in.AllowPrivilegeEscalation, &out.AllowPrivilegeEscalation
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ReadOnlyRootFilesystem != nil {
+		in, out := &in.ReadOnlyRootFilesystem, &out.ReadOnlyRootFilesystem
+		*out = new(bool)
+		**out = **in
+	}
+	if in.HostNetwork != nil {
+		in, out := &in.HostNetwork, &out.HostNetwork
+		*out = new(bool)
+		**out = **in
+	}
+	if in.HostPID != nil {
+		in, out := &in.HostPID, &out.HostPID
+		*out = new(bool)
+		**out = **in
+	}
+	if in.HostIPC != nil {
+		in, out := &in.HostIPC, &out.HostIPC
+		*out = new(bool)
+		**out = **in
+	}
+	if in.SeccompProfiles != nil {
+		in, out := &in.SeccompProfiles, &out.SeccompProfiles
+		*out = make([]runtime.ExpressionMatch, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.AppArmorProfiles != nil {
+		in, out := &in.AppArmorProfiles, &out.AppArmorProfiles
+		*out = make([]runtime.ExpressionMatch, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadSecurityContextRules.
+func (in *WorkloadSecurityContextRules) DeepCopy() *WorkloadSecurityContextRules {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkloadSecurityContextRules)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkloadTolerationRule) DeepCopyInto(out *WorkloadTolerationRule) {
+	*out = *in
+	if in.Key != nil {
+		in, out := &in.Key, &out.Key
+		*out = new(runtime.ExpressionMatch)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Effect != nil {
+		in, out := &in.Effect, &out.Effect
+		*out = new(runtime.ExpressionMatch)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadTolerationRule.
+func (in *WorkloadTolerationRule) DeepCopy() *WorkloadTolerationRule {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkloadTolerationRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkloadVolumeRules) DeepCopyInto(out *WorkloadVolumeRules) {
+	*out = *in
+	if in.Types != nil {
+		in, out := &in.Types, &out.Types
+		*out = make([]runtime.ExpressionMatch, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.HostPaths != nil {
+		in, out := &in.HostPaths, &out.HostPaths
+		*out = make([]WorkloadHostPathRule, len(*in))
+		copy(*out, *in)
+	}
+	if in.CSIDrivers != nil {
+		in, out := &in.CSIDrivers, &out.CSIDrivers
+		*out = make([]runtime.ExpressionMatch, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadVolumeRules.
+func (in *WorkloadVolumeRules) DeepCopy() *WorkloadVolumeRules {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkloadVolumeRules)
+	in.DeepCopyInto(out)
+	return out
+}
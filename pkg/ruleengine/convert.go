@@ -3,7 +3,11 @@
 
 package ruleengine
 
-import api "github.com/projectcapsule/capsule/pkg/api/rules"
+import (
+	"time"
+
+	api "github.com/projectcapsule/capsule/pkg/api/rules"
+)
 
 func EnforceBodiesFromNamespaceRules(
 	bodies []*api.NamespaceRuleBodyNamespace,
@@ -24,3 +28,94 @@ func EnforceBodiesFromNamespaceRules(
 
 	return out
 }
+
+// EnforcedNamespaceRules returns the rules which are enforced at admission,
+// dropping rules marked as dry-run. The order of bodies is preserved.
+func EnforcedNamespaceRules(
+	bodies []*api.NamespaceRuleBodyNamespace,
+) []*api.NamespaceRuleBodyNamespace {
+	if !HasDryRunNamespaceRules(bodies) {
+		return bodies
+	}
+
+	out := make([]*api.NamespaceRuleBodyNamespace, 0, len(bodies))
+
+	for _, body := range bodies {
+		if body == nil || body.DryRun {
+			continue
+		}
+
+		out = append(out, body)
+	}
+
+	return out
+}
+
+// HasDryRunNamespaceRules reports whether any of bodies is marked as dry-run.
+func HasDryRunNamespaceRules(bodies []*api.NamespaceRuleBodyNamespace) bool {
+	for _, body := range bodies {
+		if body != nil && body.DryRun {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ActiveNamespaceRules returns the rules enforced at now according to their
+// activation window, with the action resolved to the effective one. Rules
+// before notBefore are dropped, as are rules after notAfter unless they
+// escalate, in which case they are enforced with the escalated action. The
+// order of bodies is preserved.
+func ActiveNamespaceRules(
+	bodies []*api.NamespaceRuleBodyNamespace,
+	now time.Time,
+) []*api.NamespaceRuleBodyNamespace {
+	out := make([]*api.NamespaceRuleBodyNamespace, 0, len(bodies))
+
+	for _, body := range bodies {
+		action, active := body.EffectiveAction(now)
+		if !active {
+			continue
+		}
+
+		if action != body.Enforce.Action.OrDefault() {
+			body = body.DeepCopy()
+			body.Enforce.Action = action
+		}
+
+		out = append(out, body)
+	}
+
+	return out
+}
+
+// AdmissionNamespaceRules returns the rules enforced at admission at now:
+// dry-run rules are dropped and the activation windows are applied as by
+// ActiveNamespaceRules.
+func AdmissionNamespaceRules(
+	bodies []*api.NamespaceRuleBodyNamespace,
+	now time.Time,
+) []*api.NamespaceRuleBodyNamespace {
+	return EnforcedNamespaceRules(ActiveNamespaceRules(bodies, now))
+}
+
+// NextNamespaceRuleActivationChange returns the duration until the effective
+// action of any of bodies changes, zero if none will.
+func NextNamespaceRuleActivationChange(
+	bodies []*api.NamespaceRuleBodyNamespace,
+	now time.Time,
+) (next time.Duration) {
+	for _, body := range bodies {
+		boundary, ok := body.NextActivationChange(now)
+		if !ok {
+			continue
+		}
+
+		if until := boundary.Sub(now); next == 0 || until < next {
+			next = until
+		}
+	}
+
+	return next
+}
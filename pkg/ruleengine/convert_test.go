@@ -5,6 +5,9 @@ package ruleengine
 
 import (
 	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	api "github.com/projectcapsule/capsule/pkg/api/rules"
 )
@@ -192,3 +195,136 @@ func TestEnforceBodiesFromNamespaceRulesReturnsOriginalPointers(t *testing.T) {
 		t.Fatalf("expected second returned enforce body to be the original pointer")
 	}
 }
+
+func TestEnforcedNamespaceRules(t *testing.T) {
+	t.Parallel()
+
+	enforced := &api.NamespaceRuleBodyNamespace{Enforce: &api.NamespaceRuleEnforceBody{Action: api.ActionTypeDeny}}
+	dryRun := &api.NamespaceRuleBodyNamespace{DryRun: true, Enforce: &api.NamespaceRuleEnforceBody{Action: api.ActionTypeAllow}}
+
+	tests := []struct {
+		name  string
+		input []*api.NamespaceRuleBodyNamespace
+		want  []*api.NamespaceRuleBodyNamespace
+	}{
+		{
+			name:  "nil input returns nil",
+			input: nil,
+			want:  nil,
+		},
+		{
+			name:  "without dry-run rules input is returned as is",
+			input: []*api.NamespaceRuleBodyNamespace{enforced, nil},
+			want:  []*api.NamespaceRuleBodyNamespace{enforced, nil},
+		},
+		{
+			name:  "dry-run rules are dropped",
+			input: []*api.NamespaceRuleBodyNamespace{dryRun, enforced, dryRun},
+			want:  []*api.NamespaceRuleBodyNamespace{enforced},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := EnforcedNamespaceRules(tt.input)
+			if len(got) != len(tt.want) {
+				t.Fatalf("EnforcedNamespaceRules() len = %d, want %d", len(got), len(tt.want))
+			}
+
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("EnforcedNamespaceRules()[%d] = %#v, want %#v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestActiveNamespaceRules(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	past := metav1.NewTime(now.Add(-time.Hour))
+	future := metav1.NewTime(now.Add(time.Hour))
+
+	unbounded := &api.NamespaceRuleBodyNamespace{Enforce: &api.NamespaceRuleEnforceBody{Action: api.ActionTypeDeny}}
+	pending := &api.NamespaceRuleBodyNamespace{
+		NotBefore: &future,
+		Enforce:   &api.NamespaceRuleEnforceBody{Action: api.ActionTypeDeny},
+	}
+	expired := &api.NamespaceRuleBodyNamespace{
+		NotAfter: &past,
+		Enforce:  &api.NamespaceRuleEnforceBody{Action: api.ActionTypeAudit},
+	}
+	escalated := &api.NamespaceRuleBodyNamespace{
+		NotAfter:   &past,
+		EscalateTo: api.ActionTypeDeny,
+		Enforce:    &api.NamespaceRuleEnforceBody{Action: api.ActionTypeAudit},
+	}
+	auditing := &api.NamespaceRuleBodyNamespace{
+		NotBefore:  &past,
+		NotAfter:   &future,
+		EscalateTo: api.ActionTypeDeny,
+		Enforce:    &api.NamespaceRuleEnforceBody{Action: api.ActionTypeAudit},
+	}
+
+	got := ActiveNamespaceRules(
+		[]*api.NamespaceRuleBodyNamespace{unbounded, pending, expired, escalated, auditing, nil},
+		now,
+	)
+
+	if len(got) != 3 {
+		t.Fatalf("ActiveNamespaceRules() len = %d, want 3", len(got))
+	}
+
+	if got[0] != unbounded {
+		t.Fatalf("ActiveNamespaceRules()[0] = %#v, want the unbounded rule as is", got[0])
+	}
+
+	if got[1].Enforce.Action != api.ActionTypeDeny {
+		t.Fatalf("escalated action = %q, want deny", got[1].Enforce.Action)
+	}
+
+	if escalated.Enforce.Action != api.ActionTypeAudit {
+		t.Fatal("escalation modified the input rule")
+	}
+
+	if got[2] != auditing || got[2].Enforce.Action != api.ActionTypeAudit {
+		t.Fatalf("ActiveNamespaceRules()[2] = %#v, want the auditing rule as is", got[2])
+	}
+
+	if next := NextNamespaceRuleActivationChange(
+		[]*api.NamespaceRuleBodyNamespace{unbounded, expired, auditing, pending},
+		now,
+	); next != time.Hour {
+		t.Fatalf("NextNamespaceRuleActivationChange() = %s, want 1h", next)
+	}
+
+	if next := NextNamespaceRuleActivationChange([]*api.NamespaceRuleBodyNamespace{unbounded, expired}, now); next != 0 {
+		t.Fatalf("NextNamespaceRuleActivationChange() = %s, want 0", next)
+	}
+}
+
+func TestAdmissionNamespaceRules(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	past := metav1.NewTime(now.Add(-time.Hour))
+
+	enforced := &api.NamespaceRuleBodyNamespace{Enforce: &api.NamespaceRuleEnforceBody{Action: api.ActionTypeDeny}}
+	dryRun := &api.NamespaceRuleBodyNamespace{
+		DryRun:  true,
+		Enforce: &api.NamespaceRuleEnforceBody{Action: api.ActionTypeDeny},
+	}
+	expired := &api.NamespaceRuleBodyNamespace{
+		NotAfter: &past,
+		Enforce:  &api.NamespaceRuleEnforceBody{Action: api.ActionTypeDeny},
+	}
+
+	got := AdmissionNamespaceRules([]*api.NamespaceRuleBodyNamespace{enforced, dryRun, expired}, now)
+	if len(got) != 1 || got[0] != enforced {
+		t.Fatalf("AdmissionNamespaceRules() = %#v, want the enforced rule only", got)
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright 2020-2026 Project Capsule Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ruleengine
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	api "github.com/projectcapsule/capsule/pkg/api/rules"
+)
+
+// ObjectEvaluator evaluates namespace rules against an object which already
+// exists in the cluster, outside of an admission request. Implementations
+// must reach the same decision admission would for a create of obj.
+type ObjectEvaluator interface {
+	// Supports reports whether objects of the given kind are evaluated.
+	Supports(gvk schema.GroupVersionKind) bool
+
+	Evaluate(
+		ctx context.Context,
+		obj *unstructured.Unstructured,
+		tnt *capsulev1beta2.Tenant,
+		enforceBodies []*api.NamespaceRuleEnforceBody,
+	) (*Evaluation, error)
+
+	// NotEvaluable returns the descriptions of the rules which depend on the
+	// admission request, such as the previous object or the requesting user.
+	// Evaluate skips them, since an existing object has no such request.
+	NotEvaluable(enforceBodies []*api.NamespaceRuleEnforceBody) []string
+}
+
+// ObjectEvaluators evaluates an object with every evaluator supporting its
+// kind. Like admission, evaluation stops at the first blocking decision.
+type ObjectEvaluators []ObjectEvaluator
+
+func (e ObjectEvaluators) Supports(gvk schema.GroupVersionKind) bool {
+	for _, evaluator := range e {
+		if evaluator != nil && evaluator.Supports(gvk) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (e ObjectEvaluators) NotEvaluable(enforceBodies []*api.NamespaceRuleEnforceBody) []string {
+	var out []string
+
+	for _, evaluator := range e {
+		if evaluator != nil {
+			out = append(out, evaluator.NotEvaluable(enforceBodies)...)
+		}
+	}
+
+	return out
+}
+
+func (e ObjectEvaluators) Evaluate(
+	ctx context.Context,
+	obj *unstructured.Unstructured,
+	tnt *capsulev1beta2.Tenant,
+	enforceBodies []*api.NamespaceRuleEnforceBody,
+) (*Evaluation, error) {
+	out := &Evaluation{}
+
+	if obj == nil || len(enforceBodies) == 0 {
+		return out, nil
+	}
+
+	gvk := obj.GroupVersionKind()
+
+	for _, evaluator := range e {
+		if evaluator == nil || !evaluator.Supports(gvk) {
+			continue
+		}
+
+		evaluation, err := evaluator.Evaluate(ctx, obj, tnt, enforceBodies)
+		if err != nil {
+			return out, err
+		}
+
+		out.Append(evaluation)
+
+		if out.Blocking != nil {
+			return out, nil
+		}
+	}
+
+	return out, nil
+}
@@ -0,0 +1,112 @@
+// Copyright 2020-2026 Project Capsule Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ruleengine
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	api "github.com/projectcapsule/capsule/pkg/api/rules"
+)
+
+type fakeObjectEvaluator struct {
+	kind       string
+	evaluation *Evaluation
+	err        error
+	calls      int
+}
+
+func (f *fakeObjectEvaluator) Supports(gvk schema.GroupVersionKind) bool {
+	return gvk.Kind == f.kind
+}
+
+func (f *fakeObjectEvaluator) Evaluate(
+	context.Context,
+	*unstructured.Unstructured,
+	*capsulev1beta2.Tenant,
+	[]*api.NamespaceRuleEnforceBody,
+) (*Evaluation, error) {
+	f.calls++
+
+	return f.evaluation, f.err
+}
+
+func (*fakeObjectEvaluator) NotEvaluable([]*api.NamespaceRuleEnforceBody) []string { return nil }
+
+func TestObjectEvaluators(t *testing.T) {
+	t.Parallel()
+
+	bodies := []*api.NamespaceRuleEnforceBody{{Action: api.ActionTypeDeny}}
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(schema.GroupVersionKind{Version: "v1", Kind: "Pod"})
+
+	t.Run("skips unsupported kinds", func(t *testing.T) {
+		t.Parallel()
+
+		service := &fakeObjectEvaluator{kind: "Service", evaluation: &Evaluation{Blocking: &Decision{}}}
+
+		evaluation, err := ObjectEvaluators{service}.Evaluate(context.Background(), obj, nil, bodies)
+		if err != nil {
+			t.Fatalf("Evaluate() error = %v", err)
+		}
+
+		if evaluation.Blocking != nil || service.calls != 0 {
+			t.Fatalf("Evaluate() used unsupported evaluator: %#v", evaluation)
+		}
+	})
+
+	t.Run("stops at first blocking decision", func(t *testing.T) {
+		t.Parallel()
+
+		audit := &fakeObjectEvaluator{kind: "Pod", evaluation: &Evaluation{Audits: []*Decision{{Message: "audit"}}}}
+		deny := &fakeObjectEvaluator{kind: "Pod", evaluation: &Evaluation{Blocking: &Decision{Message: "denied"}}}
+		after := &fakeObjectEvaluator{kind: "Pod"}
+
+		evaluation, err := ObjectEvaluators{audit, deny, after}.Evaluate(context.Background(), obj, nil, bodies)
+		if err != nil {
+			t.Fatalf("Evaluate() error = %v", err)
+		}
+
+		if evaluation.Blocking == nil || evaluation.Blocking.Message != "denied" {
+			t.Fatalf("Evaluate() blocking = %#v, want denied", evaluation.Blocking)
+		}
+
+		if len(evaluation.Audits) != 1 {
+			t.Fatalf("Evaluate() audits = %d, want 1", len(evaluation.Audits))
+		}
+
+		if after.calls != 0 {
+			t.Fatalf("Evaluate() called evaluator after blocking decision")
+		}
+	})
+
+	t.Run("returns evaluator errors", func(t *testing.T) {
+		t.Parallel()
+
+		failing := &fakeObjectEvaluator{kind: "Pod", err: errors.New("boom")}
+
+		if _, err := (ObjectEvaluators{failing}).Evaluate(context.Background(), obj, nil, bodies); err == nil {
+			t.Fatalf("Evaluate() error = nil, want error")
+		}
+	})
+
+	t.Run("supports any kind of its evaluators", func(t *testing.T) {
+		t.Parallel()
+
+		evaluators := ObjectEvaluators{nil, &fakeObjectEvaluator{kind: "Service"}}
+
+		if !evaluators.Supports(schema.GroupVersionKind{Version: "v1", Kind: "Service"}) {
+			t.Fatalf("Supports(Service) = false, want true")
+		}
+
+		if evaluators.Supports(obj.GroupVersionKind()) {
+			t.Fatalf("Supports(Pod) = true, want false")
+		}
+	})
+}
@@ -8,15 +8,18 @@ import (
 	"net"
 	"regexp"
 	"strings"
+	"sync"
 
 	corev1 "k8s.io/api/core/v1"
 	k8smeta "k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	k8svalidation "k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apiserver/pkg/cel/environment"
 
 	"github.com/projectcapsule/capsule/pkg/api/rules"
 	"github.com/projectcapsule/capsule/pkg/api/runtime"
+	celruntime "github.com/projectcapsule/capsule/pkg/runtime/cel"
 	workloadruntime "github.com/projectcapsule/capsule/pkg/runtime/workloads"
 )
 
@@ -39,6 +42,10 @@ func ValidateRuleStatusBody(
 			return err
 		}
 
+		if err := validateActivationWindow(i, rule); err != nil {
+			return err
+		}
+
 		if rule.Enforce == nil {
 			continue
 		}
@@ -58,6 +65,22 @@ func ValidateRuleStatusBody(
 		if err := validateMetadataRules(i, rule.Enforce.Metadata, mapper); err != nil {
 			return err
 		}
+
+		if err := validateCELRules(i, rule.Enforce.CEL, mapper); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func validateActivationWindow(ruleIndex int, rule *rules.NamespaceRuleBodyNamespace) error {
+	if rule.EscalateTo != "" && rule.NotAfter == nil {
+		return fmt.Errorf("rules[%d].escalateTo is invalid: notAfter must be configured", ruleIndex)
+	}
+
+	if rule.NotBefore != nil && rule.NotAfter != nil && !rule.NotBefore.Before(rule.NotAfter) {
+		return fmt.Errorf("rules[%d].notAfter is invalid: must be after notBefore", ruleIndex)
 	}
 
 	return nil
@@ -628,6 +651,50 @@ func validateNodePortRange(portRange rules.ServiceNodePortRange) error {
 	return nil
 }
 
+// ruleCompiler is shared by rule validation, compiling is only used to
+// surface syntax and type errors before a rule is persisted.
+var ruleCompiler = sync.OnceValues(celruntime.NewCompiler)
+
+func validateCELRules(
+	ruleIndex int,
+	celRules []rules.CELRule,
+	mapper k8smeta.RESTMapper,
+) error {
+	if len(celRules) == 0 {
+		return nil
+	}
+
+	compiler, err := ruleCompiler()
+	if err != nil {
+		return err
+	}
+
+	for j, rule := range celRules {
+		fieldPath := fmt.Sprintf("rules[%d].enforce.cel[%d]", ruleIndex, j)
+
+		if len(rule.Kinds) == 0 {
+			return fmt.Errorf("%s.kinds is invalid: at least one kind must be configured", fieldPath)
+		}
+
+		if mapper != nil {
+			if err := rule.ValidateKnownKindsWithScope(mapper, fieldPath, func(
+				_ schema.GroupVersionKind,
+				scope k8smeta.RESTScope,
+			) bool {
+				return scope.Name() == k8smeta.RESTScopeNameNamespace
+			}); err != nil {
+				return err
+			}
+		}
+
+		if _, err := compiler.CompileRule(rule.Expression, environment.NewExpressions); err != nil {
+			return fmt.Errorf("%s.expression is invalid: %w", fieldPath, err)
+		}
+	}
+
+	return nil
+}
+
 func validateMetadataTargets(
 	fieldPath string,
 	rule rules.MetadataRule,
@@ -6,10 +6,12 @@ package ruleengine
 import (
 	"strings"
 	"testing"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/utils/ptr"
 
@@ -21,6 +23,8 @@ func TestValidateRuleStatusBody(t *testing.T) {
 	t.Parallel()
 
 	mapper := newRuleValidationRESTMapper()
+	windowStart := metav1.NewTime(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	windowEnd := metav1.NewTime(windowStart.Add(24 * time.Hour))
 
 	tests := []struct {
 		name    string
@@ -39,6 +43,35 @@ func TestValidateRuleStatusBody(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:   "valid activation window",
+			mapper: mapper,
+			bodies: []*rules.NamespaceRuleBodyNamespace{{
+				NotBefore:  &windowStart,
+				NotAfter:   &windowEnd,
+				EscalateTo: rules.ActionTypeDeny,
+				Enforce:    &rules.NamespaceRuleEnforceBody{Action: rules.ActionTypeAudit},
+			}},
+		},
+		{
+			name:   "escalation requires notAfter",
+			mapper: mapper,
+			bodies: []*rules.NamespaceRuleBodyNamespace{{
+				EscalateTo: rules.ActionTypeDeny,
+				Enforce:    &rules.NamespaceRuleEnforceBody{Action: rules.ActionTypeAudit},
+			}},
+			wantErr: "rules[0].escalateTo is invalid",
+		},
+		{
+			name:   "notAfter must be after notBefore",
+			mapper: mapper,
+			bodies: []*rules.NamespaceRuleBodyNamespace{{
+				NotBefore: &windowEnd,
+				NotAfter:  &windowStart,
+				Enforce:   &rules.NamespaceRuleEnforceBody{Action: rules.ActionTypeAudit},
+			}},
+			wantErr: "rules[0].notAfter is invalid",
+		},
 		{
 			name:   "valid quota-only rule",
 			mapper: mapper,
@@ -788,6 +821,67 @@ func TestValidateRuleStatusBody(t *testing.T) {
 			},
 			wantErr: `rules[1].enforce.services.externalNames.hostnames[1].exp "[" is invalid`,
 		},
+		{
+			name:   "valid cel rule",
+			mapper: mapper,
+			bodies: []*rules.NamespaceRuleBodyNamespace{{
+				Enforce: &rules.NamespaceRuleEnforceBody{
+					CEL: []rules.CELRule{{
+						VersionKinds: runtime.VersionKinds{APIGroups: []string{"apps"}, Kinds: []string{"Deployment"}},
+						Expression:   `object.spec.replicas <= 3 || userInfo.username == "admin"`,
+					}},
+				},
+			}},
+		},
+		{
+			name:   "cel rule requires kinds",
+			mapper: mapper,
+			bodies: []*rules.NamespaceRuleBodyNamespace{{
+				Enforce: &rules.NamespaceRuleEnforceBody{
+					CEL: []rules.CELRule{{Expression: `true`}},
+				},
+			}},
+			wantErr: "rules[0].enforce.cel[0].kinds is invalid",
+		},
+		{
+			name:   "cel rule must compile",
+			mapper: mapper,
+			bodies: []*rules.NamespaceRuleBodyNamespace{{
+				Enforce: &rules.NamespaceRuleEnforceBody{
+					CEL: []rules.CELRule{{
+						VersionKinds: runtime.VersionKinds{Kinds: []string{"ConfigMap"}},
+						Expression:   `object.metadata.name`,
+					}},
+				},
+			}},
+			wantErr: "rules[0].enforce.cel[0].expression is invalid",
+		},
+		{
+			name:   "cel rule rejects unknown variables",
+			mapper: mapper,
+			bodies: []*rules.NamespaceRuleBodyNamespace{{
+				Enforce: &rules.NamespaceRuleEnforceBody{
+					CEL: []rules.CELRule{{
+						VersionKinds: runtime.VersionKinds{Kinds: []string{"ConfigMap"}},
+						Expression:   `request.name == "x"`,
+					}},
+				},
+			}},
+			wantErr: "rules[0].enforce.cel[0].expression is invalid",
+		},
+		{
+			name:   "cel rule rejects cluster scoped kinds",
+			mapper: mapper,
+			bodies: []*rules.NamespaceRuleBodyNamespace{{
+				Enforce: &rules.NamespaceRuleEnforceBody{
+					CEL: []rules.CELRule{{
+						VersionKinds: runtime.VersionKinds{Kinds: []string{"Namespace"}},
+						Expression:   `true`,
+					}},
+				},
+			}},
+			wantErr: "rules[0].enforce.cel[0]",
+		},
 	}
 
 	for _, tt := range tests {
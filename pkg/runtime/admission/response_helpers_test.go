@@ -38,6 +38,15 @@ func TestAdmissionResponseHelpers(t *testing.T) {
 		t.Fatalf("Allowf() = %#v", allowed)
 	}
 
+	if warned := AllowWithWarnings(); warned != nil {
+		t.Fatalf("AllowWithWarnings() = %#v, want nil without warnings", warned)
+	}
+
+	warned := AllowWithWarnings("near limit")
+	if warned == nil || !warned.Allowed || len(warned.Warnings) != 1 || warned.Warnings[0] != "near limit" {
+		t.Fatalf("AllowWithWarnings() = %#v", warned)
+	}
+
 	errResponse := ErroredResponse(errors.New("boom"))
 	if errResponse == nil || errResponse.Allowed || errResponse.Result == nil {
 		t.Fatalf("ErroredResponse() = %#v", errResponse)
@@ -27,6 +27,15 @@ func Allow(message string) *admission.Response {
 	return new(admission.Allowed(message))
 }
 
+// Allows the request with the given warnings, nil if there are no warnings.
+func AllowWithWarnings(warnings ...string) *admission.Response {
+	if len(warnings) == 0 {
+		return nil
+	}
+
+	return new(admission.Allowed("").WithWarnings(warnings...))
+}
+
 func normalizePath(p string) string {
 	if p == "" {
 		return ""
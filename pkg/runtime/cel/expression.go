@@ -21,6 +21,9 @@ import (
 
 const (
 	ObjectVariable      = "object"
+	OldObjectVariable   = "oldObject"
+	TenantVariable      = "tenant"
+	UserInfoVariable    = "userInfo"
 	MaxExpressionLength = 4096
 )
 
@@ -29,16 +32,31 @@ type ResultType string
 const (
 	ResultTypeBoolean  ResultType = "boolean"
 	ResultTypeQuantity ResultType = "quantity"
+	// ResultTypeRule is a boolean expression compiled for namespace rules. It
+	// can reference the admitted object, the previous object, the tenant and
+	// the requesting user.
+	ResultTypeRule ResultType = "rule"
 )
 
 type Compiler struct {
-	envSet *environment.EnvSet
+	envSet     *environment.EnvSet
+	ruleEnvSet *environment.EnvSet
+}
+
+// RuleActivation contains the variables available to namespace rule
+// expressions. Missing values are exposed as null.
+type RuleActivation struct {
+	Object    map[string]any
+	OldObject map[string]any
+	Tenant    map[string]any
+	UserInfo  map[string]any
 }
 
 type CompiledExpression struct {
 	expression string
 	program    celgo.Program
 	resultType ResultType
+	variables  map[string]struct{}
 }
 
 func NewCompiler() (*Compiler, error) {
@@ -57,7 +75,23 @@ func NewCompiler() (*Compiler, error) {
 		return nil, fmt.Errorf("build Kubernetes CEL environment: %w", err)
 	}
 
-	return &Compiler{envSet: envSet}, nil
+	ruleEnvSet, err := base.Extend(
+		environment.VersionedOptions{
+			IntroducedVersion: version.MajorMinor(1, 0),
+			EnvOptions: []celgo.EnvOption{
+				celgo.Variable(ObjectVariable, celgo.DynType),
+				celgo.Variable(OldObjectVariable, celgo.DynType),
+				celgo.Variable(TenantVariable, celgo.DynType),
+				celgo.Variable(UserInfoVariable, celgo.DynType),
+			},
+		},
+		environment.StrictCostOpt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("build Kubernetes CEL rule environment: %w", err)
+	}
+
+	return &Compiler{envSet: envSet, ruleEnvSet: ruleEnvSet}, nil
 }
 
 func (c *Compiler) CompileBoolean(expression string, mode environment.Type) (*CompiledExpression, error) {
@@ -68,6 +102,10 @@ func (c *Compiler) CompileQuantity(expression string, mode environment.Type) (*C
 	return c.compile(expression, mode, ResultTypeQuantity)
 }
 
+func (c *Compiler) CompileRule(expression string, mode environment.Type) (*CompiledExpression, error) {
+	return c.compile(expression, mode, ResultTypeRule)
+}
+
 func (c *Compiler) compile(
 	expression string,
 	mode environment.Type,
@@ -77,6 +115,15 @@ func (c *Compiler) compile(
 		return nil, fmt.Errorf("CEL compiler is nil")
 	}
 
+	envSet := c.envSet
+	if resultType == ResultTypeRule {
+		envSet = c.ruleEnvSet
+	}
+
+	if envSet == nil {
+		return nil, fmt.Errorf("CEL compiler is nil")
+	}
+
 	expression = strings.TrimSpace(expression)
 	if expression == "" {
 		return nil, fmt.Errorf("CEL expression must not be empty")
@@ -86,7 +133,7 @@ func (c *Compiler) compile(
 		return nil, fmt.Errorf("CEL expression exceeds max length of %d", MaxExpressionLength)
 	}
 
-	env, err := c.envSet.Env(mode)
+	env, err := envSet.Env(mode)
 	if err != nil {
 		return nil, fmt.Errorf("load Kubernetes CEL %s environment: %w", mode, err)
 	}
@@ -108,16 +155,25 @@ func (c *Compiler) compile(
 		return nil, fmt.Errorf("create CEL program for %q: %w", expression, err)
 	}
 
+	variables := make(map[string]struct{})
+
+	for _, reference := range ast.NativeRep().ReferenceMap() {
+		if reference != nil && reference.Name != "" {
+			variables[reference.Name] = struct{}{}
+		}
+	}
+
 	return &CompiledExpression{
 		expression: expression,
 		program:    program,
 		resultType: resultType,
+		variables:  variables,
 	}, nil
 }
 
 func validateOutputType(output *celgo.Type, resultType ResultType) error {
 	switch resultType {
-	case ResultTypeBoolean:
+	case ResultTypeBoolean, ResultTypeRule:
 		if !output.IsExactType(celgo.BoolType) {
 			return fmt.Errorf("expression must evaluate to bool, got %s", output)
 		}
@@ -146,6 +202,17 @@ func (c *CompiledExpression) Expression() string {
 	return c.expression
 }
 
+// References reports whether the expression reads the given variable.
+func (c *CompiledExpression) References(variable string) bool {
+	if c == nil {
+		return false
+	}
+
+	_, ok := c.variables[variable]
+
+	return ok
+}
+
 func (c *CompiledExpression) EvaluateBoolean(
 	ctx context.Context,
 	object unstructured.Unstructured,
@@ -173,16 +240,75 @@ func (c *CompiledExpression) EvaluateBoolean(
 	return bool(result), nil
 }
 
+// EvaluateRule evaluates a namespace rule expression. A null variable is
+// passed for every activation field which is not set.
+func (c *CompiledExpression) EvaluateRule(
+	ctx context.Context,
+	activation RuleActivation,
+) (bool, error) {
+	if c == nil || c.program == nil {
+		return false, fmt.Errorf("compiled CEL expression is nil")
+	}
+
+	if c.resultType != ResultTypeRule {
+		return false, fmt.Errorf("compiled CEL expression %q is not a rule expression", c.expression)
+	}
+
+	value, _, err := c.program.ContextEval(ctx, map[string]any{
+		ObjectVariable:    activationValue(activation.Object),
+		OldObjectVariable: activationValue(activation.OldObject),
+		TenantVariable:    activationValue(activation.Tenant),
+		UserInfoVariable:  activationValue(activation.UserInfo),
+	})
+	if err != nil {
+		return false, fmt.Errorf("evaluate CEL expression %q: %w", c.expression, err)
+	}
+
+	result, ok := value.(types.Bool)
+	if !ok {
+		return false, fmt.Errorf("CEL expression %q returned %T, expected bool", c.expression, value)
+	}
+
+	return bool(result), nil
+}
+
+func activationValue(value map[string]any) any {
+	if value == nil {
+		return types.NullValue
+	}
+
+	return value
+}
+
 func (c *CompiledExpression) EvaluateQuantity(
 	ctx context.Context,
 	object unstructured.Unstructured,
 ) (resource.Quantity, error) {
+	quantities, err := c.EvaluateQuantities(ctx, object)
+	if err != nil {
+		return resource.Quantity{}, err
+	}
+
+	total := resource.Quantity{}
+	for _, quantity := range quantities {
+		total.Add(quantity)
+	}
+
+	return total, nil
+}
+
+// EvaluateQuantities evaluates the expression and returns each quantity without summing them.
+// A single quantity is returned as list with one element.
+func (c *CompiledExpression) EvaluateQuantities(
+	ctx context.Context,
+	object unstructured.Unstructured,
+) ([]resource.Quantity, error) {
 	if c == nil || c.program == nil {
-		return resource.Quantity{}, fmt.Errorf("compiled CEL expression is nil")
+		return nil, fmt.Errorf("compiled CEL expression is nil")
 	}
 
 	if c.resultType != ResultTypeQuantity {
-		return resource.Quantity{}, fmt.Errorf(
+		return nil, fmt.Errorf(
 			"compiled CEL expression %q does not return a quantity",
 			c.expression,
 		)
@@ -192,28 +318,27 @@ func (c *CompiledExpression) EvaluateQuantity(
 		ObjectVariable: object.Object,
 	})
 	if err != nil {
-		return resource.Quantity{}, fmt.Errorf("evaluate CEL expression %q: %w", c.expression, err)
+		return nil, fmt.Errorf("evaluate CEL expression %q: %w", c.expression, err)
 	}
 
 	if quantity, ok := value.(apiservercel.Quantity); ok {
 		if quantity.Quantity == nil {
-			return resource.Quantity{}, fmt.Errorf("CEL expression %q returned a nil quantity", c.expression)
+			return nil, fmt.Errorf("CEL expression %q returned a nil quantity", c.expression)
 		}
 
-		return quantity.DeepCopy(), nil
+		return []resource.Quantity{quantity.DeepCopy()}, nil
 	}
 
 	list, ok := value.(traits.Lister)
 	if !ok {
-		return resource.Quantity{}, fmt.Errorf(
+		return nil, fmt.Errorf(
 			"CEL expression %q returned %T, expected kubernetes.Quantity or list<kubernetes.Quantity>",
 			c.expression,
 			value,
 		)
 	}
 
-	total := resource.Quantity{}
-	count := 0
+	quantities := make([]resource.Quantity, 0)
 	iterator := list.Iterator()
 
 	for iterator.HasNext() == types.True {
@@ -221,21 +346,19 @@ func (c *CompiledExpression) EvaluateQuantity(
 
 		quantity, ok := item.(apiservercel.Quantity)
 		if !ok || quantity.Quantity == nil {
-			return resource.Quantity{}, fmt.Errorf(
+			return nil, fmt.Errorf(
 				"CEL expression %q returned a list containing %T, expected kubernetes.Quantity",
 				c.expression,
 				item,
 			)
 		}
 
-		total.Add(quantity.DeepCopy())
-
-		count++
+		quantities = append(quantities, quantity.DeepCopy())
 	}
 
-	if count == 0 {
-		return resource.Quantity{}, fmt.Errorf("CEL expression %q returned an empty quantity list", c.expression)
+	if len(quantities) == 0 {
+		return nil, fmt.Errorf("CEL expression %q returned an empty quantity list", c.expression)
 	}
 
-	return total, nil
+	return quantities, nil
 }
@@ -180,3 +180,112 @@ func TestCompileQuantityRejectsStringResult(t *testing.T) {
 		t.Fatalf("CompileQuantity() error = %v, want quantity result type error", err)
 	}
 }
+
+func TestCompiledExpressionEvaluateRule(t *testing.T) {
+	t.Parallel()
+
+	compiler, err := NewCompiler()
+	if err != nil {
+		t.Fatalf("NewCompiler() error = %v", err)
+	}
+
+	compiled, err := compiler.CompileRule(
+		`oldObject == null && tenant.metadata.name == "solar" && "admins" in userInfo.groups && object.kind == "ConfigMap"`,
+		environment.StoredExpressions,
+	)
+	if err != nil {
+		t.Fatalf("CompileRule() error = %v", err)
+	}
+
+	matched, err := compiled.EvaluateRule(context.Background(), RuleActivation{
+		Object:   map[string]any{"kind": "ConfigMap"},
+		Tenant:   map[string]any{"metadata": map[string]any{"name": "solar"}},
+		UserInfo: map[string]any{"username": "alice", "groups": []any{"admins"}},
+	})
+	if err != nil {
+		t.Fatalf("EvaluateRule() error = %v", err)
+	}
+	if !matched {
+		t.Fatal("EvaluateRule() = false, want true")
+	}
+
+	if _, err := compiled.EvaluateBoolean(context.Background(), unstructured.Unstructured{}); err == nil {
+		t.Fatal("EvaluateBoolean() on rule expression error = nil, want result type error")
+	}
+}
+
+func TestCompiledExpressionReferences(t *testing.T) {
+	t.Parallel()
+
+	compiler, err := NewCompiler()
+	if err != nil {
+		t.Fatalf("NewCompiler() error = %v", err)
+	}
+
+	compiled, err := compiler.CompileRule(
+		`object.metadata.labels.exists(k, k == "env") && tenant.metadata.name == "solar"`,
+		environment.StoredExpressions,
+	)
+	if err != nil {
+		t.Fatalf("CompileRule() error = %v", err)
+	}
+
+	for _, variable := range []string{ObjectVariable, TenantVariable} {
+		if !compiled.References(variable) {
+			t.Fatalf("References(%q) = false, want true", variable)
+		}
+	}
+
+	for _, variable := range []string{OldObjectVariable, UserInfoVariable} {
+		if compiled.References(variable) {
+			t.Fatalf("References(%q) = true, want false", variable)
+		}
+	}
+}
+
+func TestCompileBooleanRejectsRuleVariables(t *testing.T) {
+	t.Parallel()
+
+	compiler, err := NewCompiler()
+	if err != nil {
+		t.Fatalf("NewCompiler() error = %v", err)
+	}
+
+	if _, err := compiler.CompileBoolean(`tenant.metadata.name == "solar"`, environment.StoredExpressions); err == nil {
+		t.Fatal("CompileBoolean() error = nil, want undeclared reference error")
+	}
+}
+
+func TestCompiledExpressionEvaluateQuantitiesKeepsListItems(t *testing.T) {
+	t.Parallel()
+
+	compiler, err := NewCompiler()
+	if err != nil {
+		t.Fatalf("NewCompiler() error = %v", err)
+	}
+
+	compiled, err := compiler.CompileQuantity(
+		`object.spec.containers.map(c, quantity(c.cpu))`,
+		environment.StoredExpressions,
+	)
+	if err != nil {
+		t.Fatalf("CompileQuantity() error = %v", err)
+	}
+
+	object := unstructured.Unstructured{Object: map[string]any{
+		"spec": map[string]any{
+			"containers": []any{
+				map[string]any{"cpu": "250m"},
+				map[string]any{"cpu": "1"},
+			},
+		},
+	}}
+
+	got, err := compiled.EvaluateQuantities(context.Background(), object)
+	if err != nil {
+		t.Fatalf("EvaluateQuantities() error = %v", err)
+	}
+	if len(got) != 2 || got[0].Cmp(resource.MustParse("250m")) != 0 || got[1].Cmp(resource.MustParse("1")) != 0 {
+		t.Fatalf("EvaluateQuantities() = %v, want [250m 1]", got)
+	}
+}
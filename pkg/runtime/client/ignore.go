@@ -33,14 +33,18 @@ func (i *IgnoreRule) Matches(obj *unstructured.Unstructured) bool {
 		return true
 	}
 
+	return selectorMatches(i.Target, obj)
+}
+
+func selectorMatches(target *kustomize.Selector, obj *unstructured.Unstructured) bool {
 	sr, err := jsondiff.NewSelectorRegex(&jsondiff.Selector{
-		Group:              i.Target.Group,
-		Version:            i.Target.Version,
-		Kind:               i.Target.Kind,
-		Namespace:          i.Target.Namespace,
-		Name:               i.Target.Name,
-		LabelSelector:      i.Target.LabelSelector,
-		AnnotationSelector: i.Target.AnnotationSelector,
+		Group:              target.Group,
+		Version:            target.Version,
+		Kind:               target.Kind,
+		Namespace:          target.Namespace,
+		Name:               target.Name,
+		LabelSelector:      target.LabelSelector,
+		AnnotationSelector: target.AnnotationSelector,
 	})
 	if err != nil {
 		return false
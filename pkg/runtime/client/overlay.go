@@ -0,0 +1,129 @@
+// Copyright 2020-2026 Project Capsule Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"bytes"
+	"fmt"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	"github.com/fluxcd/pkg/apis/kustomize"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/json"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/yaml"
+)
+
+// Overlay is a parsed kustomize patch, either a strategic merge or a JSON6902 patch.
+type Overlay struct {
+	target *kustomize.Selector
+	// Document of the patch, converted to JSON.
+	document []byte
+	// JSON6902 operations, nil for strategic merge patches.
+	operations jsonpatch.Patch
+}
+
+// NewOverlay parses the document of the given patch, which may be YAML or JSON. Documents holding
+// a list are JSON6902 patches and require a target, any other document is a strategic merge patch.
+func NewOverlay(patch kustomize.Patch) (*Overlay, error) {
+	document, err := yaml.YAMLToJSON([]byte(patch.Patch))
+	if err != nil {
+		return nil, fmt.Errorf("decoding patch: %w", err)
+	}
+
+	overlay := &Overlay{target: patch.Target, document: document}
+
+	trimmed := bytes.TrimSpace(document)
+	if len(trimmed) == 0 || bytes.Equal(trimmed, []byte("null")) {
+		return nil, fmt.Errorf("patch is empty")
+	}
+
+	if trimmed[0] != '[' {
+		return overlay, nil
+	}
+
+	if patch.Target == nil {
+		return nil, fmt.Errorf("JSON6902 patches require a target")
+	}
+
+	if overlay.operations, err = jsonpatch.DecodePatch(document); err != nil {
+		return nil, fmt.Errorf("decoding JSON6902 patch: %w", err)
+	}
+
+	return overlay, nil
+}
+
+// Matches states whether the overlay targets the given object. Strategic merge patches without
+// a target select the objects matching the kind and the name of their document.
+func (o *Overlay) Matches(obj *unstructured.Unstructured) bool {
+	if o.target != nil {
+		return selectorMatches(o.target, obj)
+	}
+
+	document := &unstructured.Unstructured{}
+	if err := document.UnmarshalJSON(o.document); err != nil {
+		// Documents without apiVersion and kind cannot be decoded, they select nothing.
+		return false
+	}
+
+	documentGVK := document.GroupVersionKind()
+	objGVK := obj.GroupVersionKind()
+
+	if documentGVK.Group != objGVK.Group || documentGVK.Kind != objGVK.Kind {
+		return false
+	}
+
+	if name := document.GetName(); name != "" && name != obj.GetName() {
+		return false
+	}
+
+	if namespace := document.GetNamespace(); namespace != "" && namespace != obj.GetNamespace() {
+		return false
+	}
+
+	return true
+}
+
+// Apply patches the given object in place. Strategic merge patches honor the patch strategies
+// of the built-in kinds, other kinds are patched as JSON merge patches.
+func (o *Overlay) Apply(obj *unstructured.Unstructured) error {
+	original, err := obj.MarshalJSON()
+	if err != nil {
+		return err
+	}
+
+	var patched []byte
+
+	switch {
+	case o.operations != nil:
+		patched, err = o.operations.Apply(original)
+	default:
+		patched, err = strategicMerge(obj.GroupVersionKind(), original, o.document)
+	}
+
+	if err != nil {
+		return fmt.Errorf("applying patch: %w", err)
+	}
+
+	// Numbers are decoded as int64, as in any unstructured content.
+	content := map[string]any{}
+	if err := json.Unmarshal(patched, &content); err != nil {
+		return fmt.Errorf("decoding patched object: %w", err)
+	}
+
+	obj.SetUnstructuredContent(content)
+
+	return nil
+}
+
+func strategicMerge(gvk schema.GroupVersionKind, original []byte, patch []byte) ([]byte, error) {
+	typed, err := scheme.Scheme.New(gvk)
+	if err != nil {
+		return jsonpatch.MergePatch(original, patch)
+	}
+
+	return strategicpatch.StrategicMergePatch(original, patch, typed)
+}
@@ -0,0 +1,309 @@
+// Copyright 2020-2026 Project Capsule Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package client_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/fluxcd/pkg/apis/kustomize"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/projectcapsule/capsule/pkg/runtime/client"
+)
+
+func newDeployment() *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata":   map[string]any{"name": "app", "namespace": "ns1"},
+		"spec": map[string]any{
+			"replicas": int64(1),
+			"template": map[string]any{
+				"spec": map[string]any{
+					"containers": []any{
+						map[string]any{"name": "app", "image": "app:1"},
+						map[string]any{"name": "sidecar", "image": "sidecar:1"},
+					},
+				},
+			},
+		},
+	}}
+}
+
+func newCustomResource() *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "example.com/v1",
+		"kind":       "Database",
+		"metadata":   map[string]any{"name": "db", "namespace": "ns1"},
+		"spec": map[string]any{
+			"size":  "small",
+			"users": []any{"admin"},
+		},
+	}}
+}
+
+func TestNewOverlay(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		patch   kustomize.Patch
+		wantErr string
+	}{
+		{
+			name:  "strategic merge patch",
+			patch: kustomize.Patch{Patch: "apiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: app\n"},
+		},
+		{
+			name: "JSON6902 patch with target",
+			patch: kustomize.Patch{
+				Patch:  "- op: replace\n  path: /spec/replicas\n  value: 2\n",
+				Target: &kustomize.Selector{Kind: "Deployment"},
+			},
+		},
+		{
+			name:    "JSON6902 patch without target",
+			patch:   kustomize.Patch{Patch: "- op: replace\n  path: /spec/replicas\n  value: 2\n"},
+			wantErr: "require a target",
+		},
+		{
+			name: "invalid JSON6902 operation",
+			patch: kustomize.Patch{
+				Patch:  "- path: /spec/replicas\n",
+				Target: &kustomize.Selector{Kind: "Deployment"},
+			},
+			wantErr: "decoding JSON6902 patch",
+		},
+		{
+			name:    "empty patch",
+			patch:   kustomize.Patch{Patch: "  \n"},
+			wantErr: "patch is empty",
+		},
+		{
+			name:    "invalid YAML",
+			patch:   kustomize.Patch{Patch: "spec: [\n"},
+			wantErr: "decoding patch",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := client.NewOverlay(tt.patch)
+
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Fatalf("unexpected error: %v", err)
+			case tt.wantErr != "" && err == nil:
+				t.Fatalf("expected error containing %q, got nil", tt.wantErr)
+			case tt.wantErr != "" && !strings.Contains(err.Error(), tt.wantErr):
+				t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestOverlay_Matches(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		patch kustomize.Patch
+		obj   *unstructured.Unstructured
+		want  bool
+	}{
+		{
+			name:  "document kind and name",
+			patch: kustomize.Patch{Patch: "apiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: app\n"},
+			obj:   newDeployment(),
+			want:  true,
+		},
+		{
+			name:  "document without name selects the kind",
+			patch: kustomize.Patch{Patch: "apiVersion: apps/v1\nkind: Deployment\n"},
+			obj:   newDeployment(),
+			want:  true,
+		},
+		{
+			name:  "document name mismatch",
+			patch: kustomize.Patch{Patch: "apiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: other\n"},
+			obj:   newDeployment(),
+			want:  false,
+		},
+		{
+			name:  "document namespace mismatch",
+			patch: kustomize.Patch{Patch: "apiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: app\n  namespace: ns2\n"},
+			obj:   newDeployment(),
+			want:  false,
+		},
+		{
+			name:  "document kind mismatch",
+			patch: kustomize.Patch{Patch: "apiVersion: example.com/v1\nkind: Database\n"},
+			obj:   newDeployment(),
+			want:  false,
+		},
+		{
+			name:  "document without kind selects nothing",
+			patch: kustomize.Patch{Patch: "spec:\n  replicas: 2\n"},
+			obj:   newDeployment(),
+			want:  false,
+		},
+		{
+			name: "target selector",
+			patch: kustomize.Patch{
+				Patch:  "spec:\n  replicas: 2\n",
+				Target: &kustomize.Selector{Group: "apps", Kind: "Deployment", Name: "app"},
+			},
+			obj:  newDeployment(),
+			want: true,
+		},
+		{
+			name: "target selector mismatch",
+			patch: kustomize.Patch{
+				Patch:  "spec:\n  replicas: 2\n",
+				Target: &kustomize.Selector{Kind: "Deployment", Namespace: "ns2"},
+			},
+			obj:  newDeployment(),
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			overlay, err := client.NewOverlay(tt.patch)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got := overlay.Matches(tt.obj); got != tt.want {
+				t.Fatalf("Matches() = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOverlay_Apply(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		patch   kustomize.Patch
+		obj     *unstructured.Unstructured
+		path    []string
+		want    any
+		wantErr bool
+	}{
+		{
+			name: "strategic merge of containers by name",
+			patch: kustomize.Patch{Patch: `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+spec:
+  template:
+    spec:
+      containers:
+      - name: sidecar
+        image: sidecar:2
+`},
+			obj:  newDeployment(),
+			path: []string{"spec", "template", "spec", "containers"},
+			want: []any{
+				map[string]any{"name": "app", "image": "app:1"},
+				map[string]any{"name": "sidecar", "image": "sidecar:2"},
+			},
+		},
+		{
+			name: "merge patch of custom resources replaces lists",
+			patch: kustomize.Patch{Patch: `apiVersion: example.com/v1
+kind: Database
+spec:
+  users:
+  - tenant
+`},
+			obj:  newCustomResource(),
+			path: []string{"spec", "users"},
+			want: []any{"tenant"},
+		},
+		{
+			name: "merge patch keeps untouched fields",
+			patch: kustomize.Patch{Patch: `apiVersion: example.com/v1
+kind: Database
+spec:
+  users:
+  - tenant
+`},
+			obj:  newCustomResource(),
+			path: []string{"spec", "size"},
+			want: "small",
+		},
+		{
+			name: "JSON6902 replace",
+			patch: kustomize.Patch{
+				Patch:  "- op: replace\n  path: /spec/replicas\n  value: 3\n",
+				Target: &kustomize.Selector{Kind: "Deployment"},
+			},
+			obj:  newDeployment(),
+			path: []string{"spec", "replicas"},
+			want: int64(3),
+		},
+		{
+			name: "JSON6902 add to list",
+			patch: kustomize.Patch{
+				Patch:  `[{"op": "add", "path": "/spec/users/-", "value": "reader"}]`,
+				Target: &kustomize.Selector{Kind: "Database"},
+			},
+			obj:  newCustomResource(),
+			path: []string{"spec", "users"},
+			want: []any{"admin", "reader"},
+		},
+		{
+			name: "JSON6902 test failure",
+			patch: kustomize.Patch{
+				Patch:  "- op: test\n  path: /spec/size\n  value: large\n",
+				Target: &kustomize.Selector{Kind: "Database"},
+			},
+			obj:     newCustomResource(),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			overlay, err := client.NewOverlay(tt.patch)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			err = overlay.Apply(tt.obj)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got nil")
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			got, found, err := unstructured.NestedFieldNoCopy(tt.obj.Object, tt.path...)
+			if err != nil || !found {
+				t.Fatalf("field %v not found: %v", tt.path, err)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("field %v = %#v, want %#v", tt.path, got, tt.want)
+			}
+		})
+	}
+}
@@ -256,6 +256,14 @@ func (c *capsuleConfiguration) CacheInvalidation() metav1.Duration {
 	return c.retrievalFn().Spec.CacheInvalidation
 }
 
+func (c *capsuleConfiguration) Chargeback() *capsulev1beta2.ChargebackConfiguration {
+	return c.retrievalFn().Spec.Chargeback
+}
+
+func (c *capsuleConfiguration) Charts() *capsulev1beta2.ChartsConfiguration {
+	return c.retrievalFn().Spec.Charts
+}
+
 func (c *capsuleConfiguration) ServiceAccountClientProperties() capsulev1beta2.ServiceAccountClient {
 	return c.retrievalFn().Spec.Impersonation
 }
@@ -46,4 +46,6 @@ type Configuration interface {
 	Events() capsulev1beta2.EventsConfiguration
 	RBAC() *capsulev1beta2.RBACConfiguration
 	CacheInvalidation() metav1.Duration
+	Chargeback() *capsulev1beta2.ChargebackConfiguration
+	Charts() *capsulev1beta2.ChartsConfiguration
 }
@@ -12,4 +12,5 @@ const (
 	ActionMutated          string = "Mutated"
 	ActionValidationDenied string = "ValidationDenied"
 	ActionRuleAudit        string = "RuleAudit"
+	ActionQuotaAudit       string = "QuotaAudit"
 )
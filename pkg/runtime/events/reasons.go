@@ -19,6 +19,7 @@ const (
 
 	// RuleStatus.
 	ReasonNamespaceRuleAudit string = "NamespaceRuleAudit"
+	ReasonForbiddenCELRule   string = "ForbiddenCELRule"
 	// Namespace.
 	ReasonNamespaceHijack string = "ReasonNamespacePatch"
 
@@ -47,9 +48,13 @@ const (
 	ReasonMissingFQCI                string = "MissingFQCI"
 	ReasonForbiddenContainerRegistry string = "ForbiddenContainerRegistry"
 	ReasonForbiddenPullPolicy        string = "ForbiddenPullPolicy"
+	ReasonForbiddenImageReference    string = "ForbiddenImageReference"
 	ReasonForbiddenPodQoSClass       string = "ForbiddenQoSClass"
 	ReasonForbiddenPodScheduler      string = "ForbiddenScheduler"
+	ReasonForbiddenPodScheduling     string = "ForbiddenScheduling"
 	ReasonForbiddenPodResources      string = "ForbiddenPodResources"
+	ReasonForbiddenSecurityContext   string = "ForbiddenSecurityContext"
+	ReasonForbiddenVolume            string = "ForbiddenVolume"
 
 	// Ingress.
 	ReasonWildcardDenied           string = "WildcardDenied"
@@ -71,8 +76,15 @@ const (
 
 	// ResourcePools.
 	ReasonDisassociated string = "Disassociated"
+	ReasonScaled        string = "Scaled"
+
+	// TenantResources.
+	ReasonDriftDetected  string = "DriftDetected"
+	ReasonDriftCorrected string = "DriftCorrected"
 
 	// CustomQuotas.
 	ReasonUsageCalculationFailed = "UsageCalculationFailed"
 	ReasonQuotaExceeded          = "QuotaExceeded"
+	ReasonQuotaNearLimit         = "QuotaNearLimit"
+	ReasonQuotaSoftLimitExceeded = "QuotaSoftLimitExceeded"
 )
@@ -5,6 +5,7 @@ package handlers
 
 import (
 	"context"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -233,8 +234,9 @@ func (h *TypedTenantWithRulesetHandler[T]) resolveRuleset(
 		return nil, result.err
 	}
 
+	// Rules may have entered or left their activation window since the status was published.
 	if result.found {
-		return result.rules, nil
+		return ruleengine.AdmissionNamespaceRules(result.rules, time.Now()), nil
 	}
 
 	ns := &corev1.Namespace{}
@@ -242,5 +244,11 @@ func (h *TypedTenantWithRulesetHandler[T]) resolveRuleset(
 		return nil, err
 	}
 
-	return tenant.BuildNamespaceRuleBodyStatus(c.Scheme(), ns, tnt)
+	bodies, err := tenant.BuildNamespaceRuleBodyStatus(c.Scheme(), ns, tnt)
+	if err != nil {
+		return nil, err
+	}
+
+	// Dry-run rules are reported by the RuleStatus controller only.
+	return ruleengine.AdmissionNamespaceRules(bodies, time.Now()), nil
 }
@@ -5,12 +5,23 @@ package handlers_test
 
 import (
 	"context"
+	"encoding/json"
+	"reflect"
 	"testing"
+	"time"
 
 	admissionv1 "k8s.io/api/admission/v1"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	"github.com/projectcapsule/capsule/pkg/api/meta"
+	"github.com/projectcapsule/capsule/pkg/api/rules"
+	"github.com/projectcapsule/capsule/pkg/runtime/events"
 	"github.com/projectcapsule/capsule/pkg/runtime/handlers"
 )
 
@@ -29,3 +40,158 @@ func TestTypedTenantWithRulesetHandlerSkipsDelete(t *testing.T) {
 		t.Fatalf("OnDelete() response = %#v, want nil", response)
 	}
 }
+
+type recordingRulesetHandler struct {
+	ruleBlocks []*rules.NamespaceRuleBodyNamespace
+}
+
+func (h *recordingRulesetHandler) OnCreate(
+	_ client.Client,
+	_ client.Reader,
+	_ *corev1.ConfigMap,
+	_ admission.Decoder,
+	_ events.EventRecorder,
+	_ *capsulev1beta2.Tenant,
+	ruleBlocks []*rules.NamespaceRuleBodyNamespace,
+) handlers.Func {
+	return func(context.Context, admission.Request) *admission.Response {
+		h.ruleBlocks = ruleBlocks
+
+		return nil
+	}
+}
+
+func (h *recordingRulesetHandler) OnUpdate(
+	client.Client,
+	client.Reader,
+	*corev1.ConfigMap,
+	*corev1.ConfigMap,
+	admission.Decoder,
+	events.EventRecorder,
+	*capsulev1beta2.Tenant,
+	[]*rules.NamespaceRuleBodyNamespace,
+) handlers.Func {
+	return func(context.Context, admission.Request) *admission.Response {
+		return nil
+	}
+}
+
+func (h *recordingRulesetHandler) OnDelete(
+	client.Client,
+	client.Reader,
+	*corev1.ConfigMap,
+	admission.Decoder,
+	events.EventRecorder,
+	*capsulev1beta2.Tenant,
+	[]*rules.NamespaceRuleBodyNamespace,
+) handlers.Func {
+	return func(context.Context, admission.Request) *admission.Response {
+		return nil
+	}
+}
+
+func TestTypedTenantWithRulesetHandlerAppliesActivationWindows(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	if err := corev1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := capsulev1beta2.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	past := metav1.NewTime(time.Now().Add(-time.Hour))
+	future := metav1.NewTime(time.Now().Add(time.Hour))
+
+	windowed := []*rules.NamespaceRuleBodyNamespace{
+		{
+			Enforce:   &rules.NamespaceRuleEnforceBody{Action: rules.ActionTypeDeny},
+			NotBefore: &future,
+		},
+		{
+			Enforce:  &rules.NamespaceRuleEnforceBody{Action: rules.ActionTypeDeny},
+			NotAfter: &past,
+		},
+		{
+			Enforce:    &rules.NamespaceRuleEnforceBody{Action: rules.ActionTypeAudit},
+			NotAfter:   &past,
+			EscalateTo: rules.ActionTypeDeny,
+		},
+		{
+			Enforce: &rules.NamespaceRuleEnforceBody{Action: rules.ActionTypeAudit},
+		},
+	}
+
+	tnt := &capsulev1beta2.Tenant{ObjectMeta: metav1.ObjectMeta{Name: "solar", UID: "solar-uid"}}
+	for _, body := range windowed {
+		tnt.Spec.Rules = append(tnt.Spec.Rules, &rules.NamespaceRuleBodyTenant{NamespaceRuleBodyNamespace: body.DeepCopy()})
+	}
+
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+		Name: "solar-prod",
+		OwnerReferences: []metav1.OwnerReference{{
+			APIVersion: capsulev1beta2.GroupVersion.String(),
+			Kind:       "Tenant",
+			Name:       tnt.Name,
+			UID:        tnt.UID,
+		}},
+	}}
+
+	raw, err := json.Marshal(&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: ns.Name}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		objects []client.Object
+	}{
+		{
+			name:    "computed ruleset",
+			objects: []client.Object{tnt, ns},
+		},
+		{
+			name: "published ruleset",
+			objects: []client.Object{tnt, ns, &capsulev1beta2.RuleStatus{
+				ObjectMeta: metav1.ObjectMeta{Name: meta.NameForManagedRuleStatus(), Namespace: ns.Name},
+				Status:     capsulev1beta2.RuleStatusStatus{Rules: windowed},
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(tt.objects...).Build()
+			recorder := &recordingRulesetHandler{}
+			handler := &handlers.TypedTenantWithRulesetHandler[*corev1.ConfigMap]{
+				Factory:  func() *corev1.ConfigMap { return &corev1.ConfigMap{} },
+				Handlers: []handlers.TypedHandlerWithTenantWithRuleset[*corev1.ConfigMap]{recorder},
+			}
+
+			response := handler.OnCreate(cl, cl, admission.NewDecoder(scheme), nil)(context.Background(), admission.Request{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					Operation: admissionv1.Create,
+					Namespace: ns.Name,
+					Object:    runtime.RawExtension{Raw: raw},
+				},
+			})
+			if response != nil {
+				t.Fatalf("OnCreate() response = %#v, want nil", response)
+			}
+
+			got := make([]rules.ActionType, 0, len(recorder.ruleBlocks))
+			for _, body := range recorder.ruleBlocks {
+				got = append(got, body.Enforce.Action)
+			}
+
+			// Pending and expired rules are dropped, the escalated one is enforced with its escalation.
+			want := []rules.ActionType{rules.ActionTypeDeny, rules.ActionTypeAudit}
+			if !reflect.DeepEqual(got, want) {
+				t.Fatalf("enforced actions = %v, want %v", got, want)
+			}
+		})
+	}
+}
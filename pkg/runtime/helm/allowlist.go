@@ -0,0 +1,83 @@
+// Copyright 2020-2026 Project Capsule Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package helm
+
+import (
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+)
+
+// AllowList restricts the locations charts are pulled from: the repositories, the download URLs
+// published by their index and the redirects followed while downloading. Entries are matched
+// regardless of their scheme, on the host and on the path at a segment boundary, so that
+// registry.example.com/charts allows oci://registry.example.com/charts/addon and
+// https://registry.example.com/charts/index.yaml, but not registry.example.com/charts-private.
+//
+// A nil AllowList allows any location, while an empty one allows none.
+type AllowList struct {
+	entries []*url.URL
+}
+
+func NewAllowList(entries ...string) (*AllowList, error) {
+	list := &AllowList{entries: make([]*url.URL, 0, len(entries))}
+
+	for _, entry := range entries {
+		u, err := parseLocation(entry)
+		if err != nil || u.Host == "" || u.User != nil {
+			return nil, fmt.Errorf("invalid allowed repository %q", entry)
+		}
+
+		list.entries = append(list.entries, u)
+	}
+
+	return list, nil
+}
+
+// Allows reports whether the location is below one of the entries.
+func (a *AllowList) Allows(location string) bool {
+	if a == nil {
+		return true
+	}
+
+	u, err := parseLocation(location)
+	if err != nil || u.User != nil {
+		return false
+	}
+
+	for _, entry := range a.entries {
+		if !strings.EqualFold(u.Host, entry.Host) {
+			continue
+		}
+
+		prefix := strings.TrimSuffix(entry.Path, "/")
+		if prefix == "" || u.Path == prefix ||
+			strings.HasPrefix(u.Path, prefix+"/") ||
+			// References of OCI charts carry their tag after the repository.
+			strings.HasPrefix(u.Path, prefix+":") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Parses a location, with or without its http, https or oci scheme.
+func parseLocation(location string) (*url.URL, error) {
+	for _, scheme := range []string{ociScheme, "http://", "https://"} {
+		location = strings.TrimPrefix(location, scheme)
+	}
+
+	u, err := url.Parse("//" + location)
+	if err != nil {
+		return nil, err
+	}
+
+	if u.Path != "" {
+		u.Path = path.Clean(u.Path)
+	}
+
+	return u, nil
+}
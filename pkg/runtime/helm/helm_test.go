@@ -0,0 +1,472 @@
+// Copyright 2020-2026 Project Capsule Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package helm_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"helm.sh/helm/v4/pkg/chart/common"
+	chart "helm.sh/helm/v4/pkg/chart/v2"
+	chartutil "helm.sh/helm/v4/pkg/chart/v2/util"
+	"helm.sh/helm/v4/pkg/registry"
+
+	"github.com/projectcapsule/capsule/pkg/runtime/helm"
+)
+
+const configMapTemplate = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: {{ include "addon.fullname" . }}
+  namespace: {{ .Release.Namespace }}
+data:
+  message: {{ .Values.message | quote }}
+  version: {{ .Chart.Version | quote }}
+`
+
+func newTestChart(version string) *chart.Chart {
+	return &chart.Chart{
+		Metadata: &chart.Metadata{APIVersion: chart.APIVersionV2, Name: "addon", Version: version},
+		Values:   map[string]any{"message": "default"},
+		Templates: []*common.File{
+			{Name: "templates/_helpers.tpl", Data: []byte(`{{- define "addon.fullname" -}}{{ .Release.Name }}-addon{{- end -}}`)},
+			{Name: "templates/configmap.yaml", Data: []byte(configMapTemplate)},
+			{Name: "templates/serviceaccount.yaml", Data: []byte("apiVersion: v1\nkind: ServiceAccount\nmetadata:\n  name: addon\n")},
+			{Name: "templates/test.yaml", Data: []byte("apiVersion: v1\nkind: Pod\nmetadata:\n  name: addon-test\n  annotations:\n    helm.sh/hook: test\n")},
+			{Name: "templates/empty.yaml", Data: []byte("{{- if false }}\nkind: Secret\n{{- end }}\n")},
+			{Name: "templates/NOTES.txt", Data: []byte("Installed {{ .Release.Name }}")},
+		},
+	}
+}
+
+func packageChart(t *testing.T, chrt *chart.Chart) []byte {
+	t.Helper()
+
+	path, err := chartutil.Save(chrt, t.TempDir())
+	if err != nil {
+		t.Fatalf("saving chart: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading chart: %v", err)
+	}
+
+	return data
+}
+
+func digestOf(data []byte) string {
+	sum := sha256.Sum256(data)
+
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+func TestRender(t *testing.T) {
+	t.Parallel()
+
+	objs, err := helm.Render(newTestChart("1.0.0"), "solar", "solar-prod", map[string]any{"message": "hello"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(objs) != 2 {
+		t.Fatalf("expected 2 manifests without hooks, notes and empty files, got %d", len(objs))
+	}
+
+	// Manifests are returned in install order.
+	if objs[0].GetKind() != "ServiceAccount" || objs[1].GetKind() != "ConfigMap" {
+		t.Fatalf("unexpected order: %s, %s", objs[0].GetKind(), objs[1].GetKind())
+	}
+
+	cm := objs[1]
+	if cm.GetName() != "solar-addon" || cm.GetNamespace() != "solar-prod" {
+		t.Fatalf("unexpected identity %s/%s", cm.GetNamespace(), cm.GetName())
+	}
+
+	data := cm.Object["data"].(map[string]any) //nolint:forcetypeassert
+	if data["message"] != "hello" || data["version"] != "1.0.0" {
+		t.Fatalf("unexpected data %v", data)
+	}
+}
+
+func TestRender_DefaultValues(t *testing.T) {
+	t.Parallel()
+
+	objs, err := helm.Render(newTestChart("1.0.0"), "solar", "solar-prod", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	data := objs[1].Object["data"].(map[string]any) //nolint:forcetypeassert
+	if data["message"] != "default" {
+		t.Fatalf("expected chart default values, got %v", data)
+	}
+}
+
+// Serves a Helm repository index publishing the given archives, along with the served archives,
+// counting the downloads.
+func newIndexRepository(t *testing.T, published, served map[string][]byte) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+
+	downloads := &atomic.Int32{}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/index.yaml", func(w http.ResponseWriter, _ *http.Request) {
+		var entries strings.Builder
+
+		for version, archive := range published {
+			fmt.Fprintf(&entries, "  - apiVersion: v2\n    name: addon\n    version: %s\n    digest: %s\n    urls:\n    - charts/addon-%s.tgz\n",
+				version, strings.TrimPrefix(digestOf(archive), "sha256:"), version)
+		}
+
+		fmt.Fprintf(w, "apiVersion: v1\nentries:\n  addon:\n%s", entries.String())
+	})
+	mux.HandleFunc("/charts/", func(w http.ResponseWriter, r *http.Request) {
+		version := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/charts/addon-"), ".tgz")
+
+		archive, ok := served[version]
+		if !ok {
+			http.NotFound(w, r)
+
+			return
+		}
+
+		downloads.Add(1)
+
+		_, _ = w.Write(archive)
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	return server, downloads
+}
+
+// Serves the charts from a minimal OCI distribution registry, as published by helm push.
+func newOCIRegistry(t *testing.T, repository string, archives map[string][]byte) *httptest.Server {
+	t.Helper()
+
+	blobs := map[string][]byte{}
+	manifests := map[string][]byte{}
+	tags := []string{}
+
+	for version, archive := range archives {
+		config, _ := json.Marshal(map[string]any{"apiVersion": "v2", "name": "addon", "version": version})
+		manifest, _ := json.Marshal(map[string]any{
+			"schemaVersion": 2,
+			"mediaType":     "application/vnd.oci.image.manifest.v1+json",
+			"config":        map[string]any{"mediaType": registry.ConfigMediaType, "digest": digestOf(config), "size": len(config)},
+			"layers": []any{
+				map[string]any{"mediaType": registry.ChartLayerMediaType, "digest": digestOf(archive), "size": len(archive)},
+			},
+		})
+
+		blobs[digestOf(config)] = config
+		blobs[digestOf(archive)] = archive
+		manifests[version] = manifest
+		manifests[digestOf(manifest)] = manifest
+		tags = append(tags, version)
+	}
+
+	prefix := "/v2/" + repository
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch path := r.URL.Path; {
+		case path == "/v2/":
+			w.WriteHeader(http.StatusOK)
+		case path == prefix+"/tags/list":
+			_ = json.NewEncoder(w).Encode(map[string]any{"name": repository, "tags": tags})
+		case strings.HasPrefix(path, prefix+"/manifests/"):
+			manifest, ok := manifests[strings.TrimPrefix(path, prefix+"/manifests/")]
+			if !ok {
+				http.NotFound(w, r)
+
+				return
+			}
+
+			w.Header().Set("Content-Type", "application/vnd.oci.image.manifest.v1+json")
+			w.Header().Set("Docker-Content-Digest", digestOf(manifest))
+			w.Header().Set("Content-Length", fmt.Sprint(len(manifest)))
+
+			if r.Method != http.MethodHead {
+				_, _ = w.Write(manifest)
+			}
+		case strings.HasPrefix(path, prefix+"/blobs/"):
+			blob, ok := blobs[strings.TrimPrefix(path, prefix+"/blobs/")]
+			if !ok {
+				http.NotFound(w, r)
+
+				return
+			}
+
+			w.Header().Set("Content-Length", fmt.Sprint(len(blob)))
+
+			if r.Method != http.MethodHead {
+				_, _ = w.Write(blob)
+			}
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestLoader_Load(t *testing.T) {
+	t.Parallel()
+
+	archives := map[string][]byte{}
+	for _, version := range []string{"1.0.0", "1.1.0", "2.0.0-rc.1"} {
+		archives[version] = packageChart(t, newTestChart(version))
+	}
+
+	index, _ := newIndexRepository(t, archives, archives)
+	oci := newOCIRegistry(t, "charts/addon", archives)
+	ociRepository := "oci://" + strings.TrimPrefix(oci.URL, "http://") + "/charts"
+
+	tests := []struct {
+		name    string
+		ref     helm.Reference
+		want    string
+		wantErr string
+	}{
+		{
+			name: "index latest stable version",
+			ref:  helm.Reference{Repository: index.URL, Name: "addon"},
+			want: "1.1.0",
+		},
+		{
+			name: "index exact version",
+			ref:  helm.Reference{Repository: index.URL + "/", Name: "addon", Version: "1.0.0"},
+			want: "1.0.0",
+		},
+		{
+			name: "index version constraint",
+			ref:  helm.Reference{Repository: index.URL, Name: "addon", Version: ">=2.0.0-0"},
+			want: "2.0.0-rc.1",
+		},
+		{
+			name:    "index unknown chart",
+			ref:     helm.Reference{Repository: index.URL, Name: "missing"},
+			wantErr: "resolving chart missing",
+		},
+		{
+			name: "oci latest version",
+			ref:  helm.Reference{Repository: ociRepository, Name: "addon", Version: "^1", PlainHTTP: true},
+			want: "1.1.0",
+		},
+		{
+			name: "oci exact version",
+			ref:  helm.Reference{Repository: ociRepository, Name: "addon", Version: "1.0.0", PlainHTTP: true},
+			want: "1.0.0",
+		},
+		{
+			name:    "oci unknown version",
+			ref:     helm.Reference{Repository: ociRepository, Name: "addon", Version: "3.0.0", PlainHTTP: true},
+			wantErr: "could not locate a version",
+		},
+		{
+			name:    "unsupported scheme",
+			ref:     helm.Reference{Repository: "file:///charts", Name: "addon"},
+			wantErr: "unsupported repository scheme",
+		},
+	}
+
+	loader := helm.NewLoader(helm.DefaultResolveInterval)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			chrt, err := loader.Load(context.Background(), tt.ref)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if chrt.Metadata.Version != tt.want {
+				t.Fatalf("loaded version %s, want %s", chrt.Metadata.Version, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoader_Caching(t *testing.T) {
+	t.Parallel()
+
+	archives := map[string][]byte{"1.0.0": packageChart(t, newTestChart("1.0.0"))}
+	index, downloads := newIndexRepository(t, archives, archives)
+
+	loader := helm.NewLoader(time.Hour)
+	ref := helm.Reference{Repository: index.URL, Name: "addon"}
+
+	for range 3 {
+		chrt, err := loader.Load(context.Background(), ref)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		// Each load returns its own chart, rendering must not alter the cached one.
+		if _, err := helm.Render(chrt, "solar", "solar-prod", nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if got := downloads.Load(); got != 1 {
+		t.Fatalf("expected the archive to be downloaded once, got %d", got)
+	}
+}
+
+func TestLoader_DigestMismatch(t *testing.T) {
+	t.Parallel()
+
+	published := map[string][]byte{"1.0.0": packageChart(t, newTestChart("1.0.0"))}
+	served := map[string][]byte{"1.0.0": append(packageChart(t, newTestChart("1.0.0")), 0)}
+
+	index, _ := newIndexRepository(t, published, served)
+
+	_, err := helm.NewLoader(time.Hour).Load(context.Background(), helm.Reference{Repository: index.URL, Name: "addon"})
+	if err == nil || !strings.Contains(err.Error(), "digest mismatch") {
+		t.Fatalf("expected digest mismatch, got %v", err)
+	}
+}
+
+func TestAllowList(t *testing.T) {
+	t.Parallel()
+
+	allowList, err := helm.NewAllowList("oci://registry.example.com/charts", "https://charts.example.com/", "mirror.example.com:8443/stable")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		location string
+		want     bool
+	}{
+		{location: "oci://registry.example.com/charts", want: true},
+		{location: "registry.example.com/charts/addon:1.0.0", want: true},
+		{location: "https://registry.example.com/charts/addon-1.0.0.tgz", want: true},
+		{location: "https://charts.example.com/index.yaml", want: true},
+		{location: "https://CHARTS.example.com/addon-1.0.0.tgz", want: true},
+		{location: "https://mirror.example.com:8443/stable/index.yaml", want: true},
+		{location: "oci://registry.example.com/charts-private/addon"},
+		{location: "oci://registry.example.com/charts/../private/addon"},
+		{location: "oci://registry.example.com"},
+		{location: "https://mirror.example.com/stable/index.yaml"},
+		{location: "https://charts.example.com@169.254.169.254/latest"},
+		{location: "https://charts.example.com.evil.io/index.yaml"},
+		{location: "http://169.254.169.254/latest/meta-data"},
+	}
+
+	for _, tt := range tests {
+		if got := allowList.Allows(tt.location); got != tt.want {
+			t.Errorf("Allows(%q) = %t, want %t", tt.location, got, tt.want)
+		}
+	}
+
+	var unrestricted *helm.AllowList
+	if !unrestricted.Allows("http://169.254.169.254/latest/meta-data") {
+		t.Error("expected a nil allow list to allow any location")
+	}
+
+	if _, err := helm.NewAllowList("/charts"); err == nil {
+		t.Error("expected error for an entry without host, got nil")
+	}
+}
+
+func TestLoader_AllowList(t *testing.T) {
+	t.Parallel()
+
+	archives := map[string][]byte{"1.0.0": packageChart(t, newTestChart("1.0.0"))}
+	index, _ := newIndexRepository(t, archives, archives)
+
+	// Publishes the archives of the index repository as absolute URLs.
+	external := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprintf(w, "apiVersion: v1\nentries:\n  addon:\n  - apiVersion: v2\n    name: addon\n    version: 1.0.0\n    urls:\n    - %s/charts/addon-1.0.0.tgz\n", index.URL)
+	}))
+	t.Cleanup(external.Close)
+
+	// Redirects the downloads to the index repository.
+	redirecting := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, index.URL+r.URL.Path, http.StatusFound)
+	}))
+	t.Cleanup(redirecting.Close)
+
+	tests := []struct {
+		name       string
+		repository string
+		allowed    string
+		wantErr    string
+	}{
+		{
+			name:       "allowed repository",
+			repository: index.URL,
+			allowed:    index.URL,
+		},
+		{
+			name:       "repository not allowed",
+			repository: index.URL,
+			allowed:    "https://charts.example.com",
+			wantErr:    "is not allowed",
+		},
+		{
+			name:       "archive published outside of the allowed repositories",
+			repository: external.URL,
+			allowed:    external.URL,
+			wantErr:    "which is not allowed",
+		},
+		{
+			name:       "redirect outside of the allowed repositories",
+			repository: redirecting.URL,
+			allowed:    redirecting.URL,
+			wantErr:    "redirect to",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			allowList, err := helm.NewAllowList(tt.allowed)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			_, err = helm.NewLoader(time.Hour).Load(context.Background(), helm.Reference{
+				Repository: tt.repository,
+				Name:       "addon",
+				AllowList:  allowList,
+			})
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+
+				return
+			}
+
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,315 @@
+// Copyright 2020-2026 Project Capsule Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package helm
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	chart "helm.sh/helm/v4/pkg/chart/v2"
+	"helm.sh/helm/v4/pkg/chart/v2/loader"
+	"helm.sh/helm/v4/pkg/registry"
+	repo "helm.sh/helm/v4/pkg/repo/v1"
+	"k8s.io/apimachinery/pkg/util/cache"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// Maximum size of a downloaded index or chart archive.
+	maxDownloadSize = 32 << 20
+	// Maximum amount of redirects followed, as the default of net/http.
+	maxRedirects = 10
+	// Maximum amount of chart archives kept in memory.
+	maxCachedCharts = 128
+	// Chart archives are immutable for a given version, they are only evicted to bound memory.
+	chartCacheTTL = 24 * time.Hour
+	// Default interval upon which the versions of a chart are resolved again.
+	DefaultResolveInterval = 5 * time.Minute
+)
+
+const ociScheme = "oci://"
+
+// Reference locates a chart in a Helm repository serving an index, or in an OCI registry.
+type Reference struct {
+	// URL of the repository, either http(s):// or oci://.
+	Repository string
+	// Name of the chart within the repository.
+	Name string
+	// Version or semver constraint, the latest stable version when empty.
+	Version string
+	// Connect to the OCI registry over plain HTTP.
+	PlainHTTP bool
+	// Locations the chart may be pulled from, any when nil.
+	AllowList *AllowList
+}
+
+func (r Reference) isOCI() bool {
+	return strings.HasPrefix(r.Repository, ociScheme)
+}
+
+func (r Reference) key() string {
+	return fmt.Sprintf("%s\x1f%s\x1f%s\x1f%t", r.Repository, r.Name, r.Version, r.PlainHTTP)
+}
+
+// Version of a chart resolved from a reference.
+type resolution struct {
+	version string
+	// Location of the chart archive: an URL for Helm repositories, a reference for OCI registries.
+	location string
+	// Expected sha256 digest of the archive, when published by the repository index.
+	digest string
+}
+
+// Loader pulls charts from Helm repositories and OCI registries. Resolved versions are cached
+// for the resolve interval, while the archives are cached by version, so that replicating a
+// chart across many Namespaces downloads it once.
+type Loader struct {
+	httpClient      *http.Client
+	resolveInterval time.Duration
+
+	resolved *cache.LRUExpireCache
+	archives *cache.LRUExpireCache
+}
+
+func NewLoader(resolveInterval time.Duration) *Loader {
+	return &Loader{
+		httpClient:      &http.Client{Timeout: time.Minute},
+		resolveInterval: resolveInterval,
+		resolved:        cache.NewLRUExpireCache(maxCachedCharts),
+		archives:        cache.NewLRUExpireCache(maxCachedCharts),
+	}
+}
+
+// Load returns the chart matching the given reference. A fresh chart is loaded on each call,
+// since rendering mutates the chart while processing its dependencies.
+func (l *Loader) Load(ctx context.Context, ref Reference) (*chart.Chart, error) {
+	if ref.Name == "" {
+		return nil, fmt.Errorf("chart name is required")
+	}
+
+	if !ref.AllowList.Allows(ref.Repository) {
+		return nil, fmt.Errorf("repository %s is not allowed", ref.Repository)
+	}
+
+	res, err := l.resolve(ctx, ref)
+	if err != nil {
+		return nil, fmt.Errorf("resolving chart %s in %s: %w", ref.Name, ref.Repository, err)
+	}
+
+	// The index of a repository may publish archives anywhere.
+	if !ref.AllowList.Allows(res.location) {
+		return nil, fmt.Errorf("chart %s-%s is located at %s, which is not allowed", ref.Name, res.version, res.location)
+	}
+
+	archive, err := l.archive(ctx, ref, res)
+	if err != nil {
+		return nil, fmt.Errorf("pulling chart %s-%s: %w", ref.Name, res.version, err)
+	}
+
+	chrt, err := loader.LoadArchive(bytes.NewReader(archive))
+	if err != nil {
+		return nil, fmt.Errorf("loading chart %s-%s: %w", ref.Name, res.version, err)
+	}
+
+	return chrt, nil
+}
+
+func (l *Loader) resolve(ctx context.Context, ref Reference) (res resolution, err error) {
+	if cached, ok := l.resolved.Get(ref.key()); ok {
+		return cached.(resolution), nil //nolint:forcetypeassert
+	}
+
+	switch {
+	case ref.isOCI():
+		res, err = l.resolveOCI(ref)
+	case strings.HasPrefix(ref.Repository, "http://"), strings.HasPrefix(ref.Repository, "https://"):
+		res, err = l.resolveIndex(ctx, ref)
+	default:
+		err = fmt.Errorf("unsupported repository scheme, expected http, https or oci")
+	}
+
+	if err != nil {
+		return res, err
+	}
+
+	l.resolved.Add(ref.key(), res, l.resolveInterval)
+
+	return res, nil
+}
+
+func (l *Loader) resolveIndex(ctx context.Context, ref Reference) (resolution, error) {
+	data, err := l.download(ctx, ref, strings.TrimSuffix(ref.Repository, "/")+"/index.yaml")
+	if err != nil {
+		return resolution{}, err
+	}
+
+	index := repo.NewIndexFile()
+	if err := yaml.Unmarshal(data, index); err != nil {
+		return resolution{}, fmt.Errorf("decoding index: %w", err)
+	}
+
+	index.SortEntries()
+
+	version, err := index.Get(ref.Name, ref.Version)
+	if err != nil {
+		return resolution{}, err
+	}
+
+	if len(version.URLs) == 0 {
+		return resolution{}, fmt.Errorf("chart %s-%s has no download URL", ref.Name, version.Version)
+	}
+
+	location, err := repo.ResolveReferenceURL(ref.Repository, version.URLs[0])
+	if err != nil {
+		return resolution{}, err
+	}
+
+	return resolution{version: version.Version, location: location, digest: version.Digest}, nil
+}
+
+func (l *Loader) resolveOCI(ref Reference) (resolution, error) {
+	client, err := l.registryClient(ref)
+	if err != nil {
+		return resolution{}, err
+	}
+
+	repository := strings.TrimSuffix(strings.TrimPrefix(ref.Repository, ociScheme), "/") + "/" + ref.Name
+
+	tags, err := client.Tags(repository)
+	if err != nil {
+		return resolution{}, fmt.Errorf("listing tags: %w", err)
+	}
+
+	version, err := registry.GetTagMatchingVersionOrConstraint(tags, ref.Version)
+	if err != nil {
+		return resolution{}, err
+	}
+
+	// Tags cannot hold the build metadata separator of semantic versions.
+	return resolution{version: version, location: repository + ":" + strings.ReplaceAll(version, "+", "_")}, nil
+}
+
+func (l *Loader) archive(ctx context.Context, ref Reference, res resolution) (data []byte, err error) {
+	key := ref.Repository + "\x1f" + res.location
+
+	if cached, ok := l.archives.Get(key); ok {
+		return cached.([]byte), nil //nolint:forcetypeassert
+	}
+
+	if ref.isOCI() {
+		data, err = l.pullOCI(ref, res.location)
+	} else {
+		data, err = l.download(ctx, ref, res.location)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if res.digest != "" {
+		sum := sha256.Sum256(data)
+		if actual := hex.EncodeToString(sum[:]); actual != strings.TrimPrefix(res.digest, "sha256:") {
+			return nil, fmt.Errorf("digest mismatch, expected %s, got %s", res.digest, actual)
+		}
+	}
+
+	l.archives.Add(key, data, chartCacheTTL)
+
+	return data, nil
+}
+
+func (l *Loader) pullOCI(ref Reference, location string) ([]byte, error) {
+	client, err := l.registryClient(ref)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := client.Pull(location, registry.PullOptWithChart(true))
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Chart.Data, nil
+}
+
+func (l *Loader) registryClient(ref Reference) (*registry.Client, error) {
+	opts := []registry.ClientOption{
+		registry.ClientOptHTTPClient(l.client(ref)),
+		registry.ClientOptWriter(io.Discard),
+	}
+
+	if ref.PlainHTTP {
+		opts = append(opts, registry.ClientOptPlainHTTP())
+	}
+
+	return registry.NewClient(opts...)
+}
+
+// Returns the HTTP client for the reference, refusing to follow redirects outside of its
+// allowed locations.
+func (l *Loader) client(ref Reference) *http.Client {
+	if ref.AllowList == nil {
+		return l.httpClient
+	}
+
+	client := *l.httpClient
+	client.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		if len(via) >= maxRedirects {
+			return fmt.Errorf("stopped after %d redirects", maxRedirects)
+		}
+
+		if !ref.AllowList.Allows(req.URL.String()) {
+			return fmt.Errorf("redirect to %s is not allowed", req.URL.Redacted())
+		}
+
+		return nil
+	}
+
+	return &client
+}
+
+func (l *Loader) download(ctx context.Context, ref Reference, location string) ([]byte, error) {
+	u, err := url.Parse(location)
+	if err != nil {
+		return nil, err
+	}
+
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("unsupported scheme %q for %s", u.Scheme, location)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := l.client(ref).Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("downloading %s: unexpected status %s", location, resp.Status)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxDownloadSize+1))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) > maxDownloadSize {
+		return nil, fmt.Errorf("downloading %s: exceeds %d bytes", location, maxDownloadSize)
+	}
+
+	return data, nil
+}
@@ -0,0 +1,79 @@
+// Copyright 2020-2026 Project Capsule Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package helm
+
+import (
+	"fmt"
+	"strings"
+
+	"helm.sh/helm/v4/pkg/chart/common"
+	chartutil "helm.sh/helm/v4/pkg/chart/common/util"
+	chart "helm.sh/helm/v4/pkg/chart/v2"
+	chartv2util "helm.sh/helm/v4/pkg/chart/v2/util"
+	"helm.sh/helm/v4/pkg/engine"
+	releaseutil "helm.sh/helm/v4/pkg/release/v1/util"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+)
+
+const notesFileSuffix = "NOTES.txt"
+
+// Render renders the templates of the chart as an install of the given release, returning the
+// manifests in install order. Hooks are not part of the rendered manifests, since the objects
+// are replicated without any release lifecycle, and so are the CRDs of the chart.
+func Render(
+	chrt *chart.Chart,
+	releaseName string,
+	namespace string,
+	values map[string]any,
+) ([]*unstructured.Unstructured, error) {
+	if err := chartv2util.ProcessDependencies(chrt, values); err != nil {
+		return nil, fmt.Errorf("processing dependencies: %w", err)
+	}
+
+	renderValues, err := chartutil.ToRenderValues(chrt, values, common.ReleaseOptions{
+		Name:      releaseName,
+		Namespace: namespace,
+		Revision:  1,
+		IsInstall: true,
+	}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("computing values: %w", err)
+	}
+
+	files, err := engine.Render(chrt, renderValues)
+	if err != nil {
+		return nil, fmt.Errorf("rendering templates: %w", err)
+	}
+
+	for name := range files {
+		if strings.HasSuffix(name, notesFileSuffix) {
+			delete(files, name)
+		}
+	}
+
+	// Partials and empty files are skipped, hooks are returned apart.
+	_, manifests, err := releaseutil.SortManifests(files, nil, releaseutil.InstallOrder)
+	if err != nil {
+		return nil, fmt.Errorf("sorting manifests: %w", err)
+	}
+
+	objs := make([]*unstructured.Unstructured, 0, len(manifests))
+
+	for _, manifest := range manifests {
+		content := map[string]any{}
+		if err := yaml.Unmarshal([]byte(manifest.Content), &content); err != nil {
+			return nil, fmt.Errorf("decoding %s: %w", manifest.Name, err)
+		}
+
+		obj := &unstructured.Unstructured{Object: content}
+		if obj.GetAPIVersion() == "" && obj.GetKind() == "" {
+			continue
+		}
+
+		objs = append(objs, obj)
+	}
+
+	return objs, nil
+}
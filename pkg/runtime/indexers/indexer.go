@@ -49,6 +49,7 @@ func AddToManager(ctx context.Context, log logr.Logger, mgr manager.Manager) err
 		customquota.GlobalTargetReference{},
 		customquota.GlobalObjectUIDReference{},
 		resourcepool.NamespacesReference{Obj: &capsulev1beta2.ResourcePool{}},
+		resourcepool.CohortReference{Obj: &capsulev1beta2.ResourcePool{}},
 		resourcepool.PoolUIDReference{Obj: &capsulev1beta2.ResourcePoolClaim{}},
 		tenant.OwnerReference{},
 		namespace.OwnerReference{},
@@ -35,7 +35,7 @@ func TestAddToManagerRegistersIndexers(t *testing.T) {
 		t.Fatalf("AddToManager() unexpected error: %v", err)
 	}
 
-	if got, want := len(mgr.indexer.calls), 22; got != want {
+	if got, want := len(mgr.indexer.calls), 23; got != want {
 		t.Fatalf("registered indexers = %d, want %d", got, want)
 	}
 
@@ -60,6 +60,7 @@ func TestAddToManagerRegistersIndexers(t *testing.T) {
 		"hostnamePathPair",
 		".spec.dependsOn.global",
 		".spec.dependsOn.namespaced",
+		".spec.cohort",
 	} {
 		if !fields[field] {
 			t.Fatalf("expected field %q to be registered; got %#v", field, fields)
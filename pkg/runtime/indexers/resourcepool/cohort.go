@@ -0,0 +1,34 @@
+// Copyright 2020-2026 Project Capsule Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package resourcepool
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+)
+
+// CohortReference defines the indexer logic for ResourcePool cohorts.
+type CohortReference struct {
+	Obj client.Object
+}
+
+func (o CohortReference) Object() client.Object {
+	return o.Obj
+}
+
+func (o CohortReference) Field() string {
+	return ".spec.cohort"
+}
+
+func (o CohortReference) Func() client.IndexerFunc {
+	return func(object client.Object) []string {
+		rp, ok := object.(*capsulev1beta2.ResourcePool)
+		if !ok || rp.Spec.Cohort == "" {
+			return nil
+		}
+
+		return []string{rp.Spec.Cohort}
+	}
+}
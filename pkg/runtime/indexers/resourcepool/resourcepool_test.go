@@ -24,6 +24,17 @@ func TestResourcePoolIndexers(t *testing.T) {
 		t.Fatalf("NamespacesReference.Func() = %#v", got)
 	}
 
+	cohort := resourcepool.CohortReference{Obj: &capsulev1beta2.ResourcePool{}}
+	if cohort.Object() == nil || cohort.Field() != ".spec.cohort" {
+		t.Fatalf("unexpected cohort indexer object/field")
+	}
+	if got := cohort.Func()(&capsulev1beta2.ResourcePool{Spec: capsulev1beta2.ResourcePoolSpec{Cohort: "department"}}); !reflect.DeepEqual(got, []string{"department"}) {
+		t.Fatalf("CohortReference.Func() = %#v", got)
+	}
+	if got := cohort.Func()(&capsulev1beta2.ResourcePool{}); got != nil {
+		t.Fatalf("CohortReference.Func() without cohort = %#v", got)
+	}
+
 	poolUID := resourcepool.PoolUIDReference{Obj: &capsulev1beta2.ResourcePoolClaim{}}
 	if poolUID.Object() == nil || poolUID.Field() != ".status.pool.uid" {
 		t.Fatalf("unexpected pool UID indexer object/field")
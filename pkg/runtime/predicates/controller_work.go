@@ -216,6 +216,61 @@ func (ResourcePoolNamespacesChangedPredicate) Update(e event.UpdateEvent) bool {
 		(oldPool.DeletionTimestamp == nil) != (newPool.DeletionTimestamp == nil)
 }
 
+// ResourcePoolCohortChangedPredicate admits pools of a cohort, whose allocation
+// or exhaustions changed, as they affect the resources lent within the cohort.
+type ResourcePoolCohortChangedPredicate struct{ predicate.Funcs }
+
+func (ResourcePoolCohortChangedPredicate) Create(e event.CreateEvent) bool {
+	pool, ok := e.Object.(*capsulev1beta2.ResourcePool)
+
+	return ok && pool.Spec.Cohort != ""
+}
+
+func (ResourcePoolCohortChangedPredicate) Delete(e event.DeleteEvent) bool {
+	pool, ok := e.Object.(*capsulev1beta2.ResourcePool)
+
+	return ok && pool.Spec.Cohort != ""
+}
+
+func (ResourcePoolCohortChangedPredicate) Generic(event.GenericEvent) bool { return false }
+func (ResourcePoolCohortChangedPredicate) Update(e event.UpdateEvent) bool {
+	oldPool, oldOK := e.ObjectOld.(*capsulev1beta2.ResourcePool)
+
+	newPool, newOK := e.ObjectNew.(*capsulev1beta2.ResourcePool)
+
+	if !oldOK || !newOK {
+		return false
+	}
+
+	if oldPool.Spec.Cohort == "" && newPool.Spec.Cohort == "" {
+		return false
+	}
+
+	return oldPool.Spec.Cohort != newPool.Spec.Cohort ||
+		!reflect.DeepEqual(oldPool.Status.Allocation, newPool.Status.Allocation) ||
+		!reflect.DeepEqual(oldPool.Status.Exhaustions, newPool.Status.Exhaustions)
+}
+
+// ResourcePoolClaimUnassignedPredicate admits claims whose pool assignment was
+// removed by the pool controller, for example on preemption, so they are
+// assigned again.
+type ResourcePoolClaimUnassignedPredicate struct{ predicate.Funcs }
+
+func (ResourcePoolClaimUnassignedPredicate) Create(event.CreateEvent) bool   { return false }
+func (ResourcePoolClaimUnassignedPredicate) Delete(event.DeleteEvent) bool   { return false }
+func (ResourcePoolClaimUnassignedPredicate) Generic(event.GenericEvent) bool { return false }
+func (ResourcePoolClaimUnassignedPredicate) Update(e event.UpdateEvent) bool {
+	oldClaim, oldOK := e.ObjectOld.(*capsulev1beta2.ResourcePoolClaim)
+
+	newClaim, newOK := e.ObjectNew.(*capsulev1beta2.ResourcePoolClaim)
+
+	if !oldOK || !newOK {
+		return false
+	}
+
+	return oldClaim.Status.Pool.UID != "" && newClaim.Status.Pool.UID == ""
+}
+
 // ResourceQuotaUsageChangedPredicate admits only usage changes from the quota
 // controller. ResourcePool claim Bound conditions are derived from status.used,
 // while status.hard and other status updates do not affect claim scheduling.
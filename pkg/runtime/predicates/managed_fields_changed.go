@@ -0,0 +1,45 @@
+// Copyright 2020-2026 Project Capsule Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package predicates
+
+import (
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+)
+
+// ManagedFieldsChangedPredicate admits deletions and updates changing the managed fields of an
+// object outside of its subresources. Any write to the object, including the ones which hand the
+// ownership of fields over to another manager, is reflected by its managed fields, whereas status
+// updates are filtered.
+type ManagedFieldsChangedPredicate struct{}
+
+func (ManagedFieldsChangedPredicate) Create(event.CreateEvent) bool   { return false }
+func (ManagedFieldsChangedPredicate) Delete(event.DeleteEvent) bool   { return true }
+func (ManagedFieldsChangedPredicate) Generic(event.GenericEvent) bool { return false }
+
+func (ManagedFieldsChangedPredicate) Update(e event.UpdateEvent) bool {
+	if e.ObjectOld == nil || e.ObjectNew == nil {
+		return false
+	}
+
+	return !equality.Semantic.DeepEqual(
+		objectManagedFields(e.ObjectOld.GetManagedFields()),
+		objectManagedFields(e.ObjectNew.GetManagedFields()),
+	)
+}
+
+func objectManagedFields(entries []metav1.ManagedFieldsEntry) []metav1.ManagedFieldsEntry {
+	filtered := make([]metav1.ManagedFieldsEntry, 0, len(entries))
+
+	for _, entry := range entries {
+		if entry.Subresource != "" {
+			continue
+		}
+
+		filtered = append(filtered, entry)
+	}
+
+	return filtered
+}
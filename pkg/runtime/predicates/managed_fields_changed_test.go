@@ -0,0 +1,98 @@
+// Copyright 2020-2026 Project Capsule Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package predicates_test
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	"github.com/projectcapsule/capsule/pkg/runtime/predicates"
+)
+
+func TestManagedFieldsChangedPredicate_StaticFuncs(t *testing.T) {
+	t.Parallel()
+
+	p := predicates.ManagedFieldsChangedPredicate{}
+
+	if got := p.Create(event.CreateEvent{}); got {
+		t.Fatalf("Create() = %v, want false", got)
+	}
+	if got := p.Delete(event.DeleteEvent{}); !got {
+		t.Fatalf("Delete() = %v, want true", got)
+	}
+	if got := p.Generic(event.GenericEvent{}); got {
+		t.Fatalf("Generic() = %v, want false", got)
+	}
+}
+
+func TestManagedFieldsChangedPredicate_Update(t *testing.T) {
+	t.Parallel()
+
+	applied := metav1.ManagedFieldsEntry{
+		Manager:   "capsule",
+		Operation: metav1.ManagedFieldsOperationApply,
+		Time:      &metav1.Time{Time: time.Unix(100, 0)},
+		FieldsV1:  &metav1.FieldsV1{Raw: []byte(`{"f:data":{"f:key":{}}}`)},
+	}
+	status := metav1.ManagedFieldsEntry{
+		Manager:     "kube-controller-manager",
+		Operation:   metav1.ManagedFieldsOperationUpdate,
+		Subresource: "status",
+		Time:        &metav1.Time{Time: time.Unix(100, 0)},
+	}
+	statusLater := *status.DeepCopy()
+	statusLater.Time = &metav1.Time{Time: time.Unix(200, 0)}
+	edit := metav1.ManagedFieldsEntry{
+		Manager:   "kubectl-edit",
+		Operation: metav1.ManagedFieldsOperationUpdate,
+		Time:      &metav1.Time{Time: time.Unix(200, 0)},
+		FieldsV1:  &metav1.FieldsV1{Raw: []byte(`{"f:data":{"f:key":{}}}`)},
+	}
+
+	tests := []struct {
+		name string
+		old  []metav1.ManagedFieldsEntry
+		new  []metav1.ManagedFieldsEntry
+		want bool
+	}{
+		{
+			name: "unchanged managed fields",
+			old:  []metav1.ManagedFieldsEntry{applied},
+			new:  []metav1.ManagedFieldsEntry{applied},
+			want: false,
+		},
+		{
+			name: "status subresource update is filtered",
+			old:  []metav1.ManagedFieldsEntry{applied, status},
+			new:  []metav1.ManagedFieldsEntry{applied, statusLater},
+			want: false,
+		},
+		{
+			name: "foreign manager took over fields",
+			old:  []metav1.ManagedFieldsEntry{applied},
+			new:  []metav1.ManagedFieldsEntry{applied, edit},
+			want: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			oldObj := &metav1.PartialObjectMetadata{ObjectMeta: metav1.ObjectMeta{Name: "x", ManagedFields: tt.old}}
+			newObj := &metav1.PartialObjectMetadata{ObjectMeta: metav1.ObjectMeta{Name: "x", ManagedFields: tt.new}}
+
+			if got := (predicates.ManagedFieldsChangedPredicate{}).Update(event.UpdateEvent{ObjectOld: oldObj, ObjectNew: newObj}); got != tt.want {
+				t.Fatalf("Update() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if got := (predicates.ManagedFieldsChangedPredicate{}).Update(event.UpdateEvent{}); got {
+		t.Fatalf("Update() with nil objects = %v, want false", got)
+	}
+}
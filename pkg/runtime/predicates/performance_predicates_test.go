@@ -16,6 +16,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/event"
 
 	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	"github.com/projectcapsule/capsule/pkg/api/meta"
 	"github.com/projectcapsule/capsule/pkg/runtime/predicates"
 )
 
@@ -291,6 +292,70 @@ func TestResourcePoolNamespacesChangedPredicate(t *testing.T) {
 	}
 }
 
+func TestResourcePoolCohortChangedPredicate(t *testing.T) {
+	t.Parallel()
+
+	p := predicates.ResourcePoolCohortChangedPredicate{}
+	standalone := &capsulev1beta2.ResourcePool{}
+	if p.Create(event.CreateEvent{Object: standalone}) {
+		t.Fatal("pool without cohort must be filtered")
+	}
+
+	allocated := standalone.DeepCopy()
+	allocated.Status.Allocation.Claimed = corev1.ResourceList{corev1.ResourceRequestsCPU: resource.MustParse("1")}
+	if p.Update(event.UpdateEvent{ObjectOld: standalone, ObjectNew: allocated}) {
+		t.Fatal("allocation update of pool without cohort must be filtered")
+	}
+
+	oldPool := &capsulev1beta2.ResourcePool{}
+	oldPool.Spec.Cohort = "department"
+	if !p.Create(event.CreateEvent{Object: oldPool}) {
+		t.Fatal("pool with cohort must be admitted")
+	}
+
+	statusOnly := oldPool.DeepCopy()
+	statusOnly.Status.ClaimSize = 1
+	if p.Update(event.UpdateEvent{ObjectOld: oldPool, ObjectNew: statusOnly}) {
+		t.Fatal("unrelated pool status update must be filtered")
+	}
+
+	allocationChanged := oldPool.DeepCopy()
+	allocationChanged.Status.Allocation.Claimed = corev1.ResourceList{corev1.ResourceRequestsCPU: resource.MustParse("1")}
+	if !p.Update(event.UpdateEvent{ObjectOld: oldPool, ObjectNew: allocationChanged}) {
+		t.Fatal("allocation update must be admitted")
+	}
+
+	left := oldPool.DeepCopy()
+	left.Spec.Cohort = ""
+	if !p.Update(event.UpdateEvent{ObjectOld: oldPool, ObjectNew: left}) {
+		t.Fatal("leaving the cohort must be admitted")
+	}
+}
+
+func TestResourcePoolClaimUnassignedPredicate(t *testing.T) {
+	t.Parallel()
+
+	p := predicates.ResourcePoolClaimUnassignedPredicate{}
+	assigned := &capsulev1beta2.ResourcePoolClaim{}
+	assigned.Status.Pool.UID = "pool-uid"
+
+	conditionOnly := assigned.DeepCopy()
+	conditionOnly.Status.Conditions = meta.ConditionList{{Type: meta.BoundCondition}}
+	if p.Update(event.UpdateEvent{ObjectOld: assigned, ObjectNew: conditionOnly}) {
+		t.Fatal("claim condition update must be filtered")
+	}
+
+	unassigned := assigned.DeepCopy()
+	unassigned.Status.Pool = meta.LocalRFC1123ObjectReferenceWithUID{}
+	if !p.Update(event.UpdateEvent{ObjectOld: assigned, ObjectNew: unassigned}) {
+		t.Fatal("removed pool assignment must be admitted")
+	}
+
+	if p.Update(event.UpdateEvent{ObjectOld: unassigned, ObjectNew: assigned}) {
+		t.Fatal("new pool assignment must be filtered")
+	}
+}
+
 func TestResourceQuotaUsageChangedPredicate(t *testing.T) {
 	t.Parallel()
 
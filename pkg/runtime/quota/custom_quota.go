@@ -4,6 +4,8 @@
 package quota
 
 import (
+	"time"
+
 	"k8s.io/apimachinery/pkg/api/resource"
 
 	celruntime "github.com/projectcapsule/capsule/pkg/runtime/cel"
@@ -22,8 +24,16 @@ type MatchedQuota struct {
 	Operation    Operation
 	Limit        resource.Quantity
 	Used         resource.Quantity
-	IsGlobal     bool
-	SourceRank   int
+	// Usage from which admissions are warned about, nil if the quota has no threshold.
+	WarnAt *resource.Quantity
+	// Soft limit which admissions may overrun with a warning, nil if the quota has no soft limit.
+	SoftLimit *resource.Quantity
+	// Distinct values already counted by the quota.
+	Values     []string
+	IsGlobal   bool
+	SourceRank int
+	// Rolling window in which admissions are counted, zero if the quota is not windowed.
+	Window time.Duration
 }
 
 func MakeCustomQuotaCacheKey(namespace, name string) string {
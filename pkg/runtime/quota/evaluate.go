@@ -0,0 +1,56 @@
+// Copyright 2020-2026 Project Capsule Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package quota
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	celruntime "github.com/projectcapsule/capsule/pkg/runtime/cel"
+	"github.com/projectcapsule/capsule/pkg/runtime/jsonpath"
+)
+
+// EvaluateQuantity evaluates the usage of an object using either the compiled CEL expression or
+// the compiled path. The max operation evaluates to the largest single value, all other operations
+// to the sum of the values.
+func EvaluateQuantity(
+	ctx context.Context,
+	u unstructured.Unstructured,
+	op Operation,
+	compiledPath *jsonpath.CompiledJSONPath,
+	compiledCEL *celruntime.CompiledExpression,
+) (resource.Quantity, error) {
+	switch {
+	case compiledCEL != nil && op == OpMax:
+		quantities, err := compiledCEL.EvaluateQuantities(ctx, u)
+		if err != nil {
+			return resource.Quantity{}, err
+		}
+
+		return MaxQuantity(quantities), nil
+	case compiledCEL != nil:
+		return compiledCEL.EvaluateQuantity(ctx, u)
+	case compiledPath != nil && op == OpMax:
+		return ParseMaxQuantityFromUnstructured(u, compiledPath)
+	case compiledPath != nil:
+		return ParseQuantityFromUnstructured(u, compiledPath)
+	default:
+		return resource.Quantity{}, fmt.Errorf("compiled usage expression is missing")
+	}
+}
+
+// EvaluateDistinctValues evaluates the values of an object counted by the distinct operation.
+func EvaluateDistinctValues(
+	u unstructured.Unstructured,
+	compiledPath *jsonpath.CompiledJSONPath,
+) ([]string, error) {
+	if compiledPath == nil {
+		return nil, fmt.Errorf("compiled usage path is missing")
+	}
+
+	return ParseDistinctValuesFromUnstructured(u, compiledPath)
+}
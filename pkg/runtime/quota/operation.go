@@ -3,11 +3,29 @@
 
 package quota
 
-// +kubebuilder:validation:Enum=add;sub;count
+// +kubebuilder:validation:Enum=add;sub;count;max;distinct;perObject
 type Operation string
 
 const (
 	OpAdd   Operation = "add"
 	OpSub   Operation = "sub"
 	OpCount Operation = "count"
+	// The largest single value (eg. of a list of containers) must stay within the limit.
+	OpMax Operation = "max"
+	// Counts the distinct values across all objects (eg. images or hostnames).
+	OpDistinct Operation = "distinct"
+	// The value of each object must stay within the limit, values are not aggregated across objects.
+	OpPerObject Operation = "perObject"
 )
+
+// Capping operations limit the value of each object instead of aggregating the values of all objects.
+// The usage of the quota is the largest observed value, nothing is reserved on the QuantityLedger.
+func (o Operation) Capping() bool {
+	return o == OpMax || o == OpPerObject
+}
+
+// Exclusive operations cannot be combined with other operations within the same quota,
+// as their usage is not additive.
+func (o Operation) Exclusive() bool {
+	return o.Capping() || o == OpDistinct
+}
@@ -49,23 +49,51 @@ func ConditionsMatch(u unstructured.Unstructured, conditions []*jsonpath.Compile
 }
 
 func ParseQuantities(value string) (resource.Quantity, error) {
+	quantities, err := ParseQuantityList(value)
+	if err != nil {
+		return resource.Quantity{}, err
+	}
+
+	total := resource.Quantity{}
+	for _, q := range quantities {
+		total.Add(q)
+	}
+
+	return total, nil
+}
+
+// ParseQuantityList parses each whitespace separated quantity of the value.
+func ParseQuantityList(value string) ([]resource.Quantity, error) {
 	fields := strings.Fields(value)
 	if len(fields) == 0 {
-		return resource.Quantity{}, fmt.Errorf("no quantity values found")
+		return nil, fmt.Errorf("no quantity values found")
 	}
 
-	total := resource.Quantity{}
+	quantities := make([]resource.Quantity, 0, len(fields))
 
 	for _, v := range fields {
 		q, err := resource.ParseQuantity(v)
 		if err != nil {
-			return total, fmt.Errorf("invalid quantity %q: %w", v, err)
+			return nil, fmt.Errorf("invalid quantity %q: %w", v, err)
 		}
 
-		total.Add(q)
+		quantities = append(quantities, q)
 	}
 
-	return total, nil
+	return quantities, nil
+}
+
+// MaxQuantity returns the largest of the quantities, zero if there are none.
+func MaxQuantity(quantities []resource.Quantity) resource.Quantity {
+	largest := resource.MustParse("0")
+
+	for i, q := range quantities {
+		if i == 0 || q.Cmp(largest) > 0 {
+			largest = q.DeepCopy()
+		}
+	}
+
+	return largest
 }
 
 // GetUsageFromUnstructured extracts a value from an unstructured object using a JSONPath source path.
@@ -87,3 +115,29 @@ func ParseQuantityFromUnstructured(u unstructured.Unstructured, compiled *jsonpa
 
 	return ParseQuantities(usage)
 }
+
+// ParseMaxQuantityFromUnstructured returns the largest single quantity the path resolves to.
+func ParseMaxQuantityFromUnstructured(u unstructured.Unstructured, compiled *jsonpath.CompiledJSONPath) (resource.Quantity, error) {
+	usage, err := ParseUsageFromUnstructured(u, compiled)
+	if err != nil {
+		return resource.Quantity{}, err
+	}
+
+	quantities, err := ParseQuantityList(usage)
+	if err != nil {
+		return resource.Quantity{}, err
+	}
+
+	return MaxQuantity(quantities), nil
+}
+
+// ParseDistinctValuesFromUnstructured returns the sorted distinct values the path resolves to.
+// Objects without values at the path have no values.
+func ParseDistinctValuesFromUnstructured(u unstructured.Unstructured, compiled *jsonpath.CompiledJSONPath) ([]string, error) {
+	usage, err := ParseUsageFromUnstructured(u, compiled)
+	if err != nil {
+		return nil, err
+	}
+
+	return DistinctValues(strings.Fields(usage)), nil
+}
@@ -322,3 +322,64 @@ func TestParseUsageFromUnstructured_RejectsControlWhitespace(t *testing.T) {
 		t.Fatal("expected compile error, got nil")
 	}
 }
+
+func TestParseMaxQuantityFromUnstructured(t *testing.T) {
+	t.Parallel()
+
+	u := unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"spec": map[string]interface{}{
+				"containers": []interface{}{
+					map[string]interface{}{"cpu": "250m"},
+					map[string]interface{}{"cpu": "1"},
+					map[string]interface{}{"cpu": "500m"},
+				},
+			},
+		},
+	}
+
+	jp, err := jsonpath.CompileJSONPath(".spec.containers[*].cpu")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	got, err := quota.ParseMaxQuantityFromUnstructured(u, jp)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	want := resource.MustParse("1")
+	if got.Cmp(want) != 0 {
+		t.Fatalf("expected quantity %q, got %q", want.String(), got.String())
+	}
+}
+
+func TestParseDistinctValuesFromUnstructured(t *testing.T) {
+	t.Parallel()
+
+	u := unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"spec": map[string]interface{}{
+				"containers": []interface{}{
+					map[string]interface{}{"image": "nginx:1.27"},
+					map[string]interface{}{"image": "busybox:1.36"},
+					map[string]interface{}{"image": "nginx:1.27"},
+				},
+			},
+		},
+	}
+
+	jp, err := jsonpath.CompileJSONPath(".spec.containers[*].image")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	got, err := quota.ParseDistinctValuesFromUnstructured(u, jp)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(got) != 2 || got[0] != "busybox:1.36" || got[1] != "nginx:1.27" {
+		t.Fatalf("expected [busybox:1.36 nginx:1.27], got %v", got)
+	}
+}
@@ -0,0 +1,31 @@
+// Copyright 2020-2026 Project Capsule Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package quota
+
+import (
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// Threshold passed by the usage of a quota.
+type Threshold string
+
+const (
+	ThresholdNone      Threshold = ""
+	ThresholdWarnAt    Threshold = "WarnAt"
+	ThresholdSoftLimit Threshold = "SoftLimit"
+)
+
+// Returns the threshold passed by the usage. The usage passes the warning threshold once it reaches it
+// and the soft limit once it exceeds it. An exceeded soft limit takes precedence over the warning threshold.
+func PassedThreshold(used resource.Quantity, warnAt, softLimit *resource.Quantity) Threshold {
+	if softLimit != nil && used.Cmp(*softLimit) > 0 {
+		return ThresholdSoftLimit
+	}
+
+	if warnAt != nil && used.Cmp(*warnAt) >= 0 {
+		return ThresholdWarnAt
+	}
+
+	return ThresholdNone
+}
@@ -5,6 +5,7 @@ package quota
 
 import (
 	"fmt"
+	"slices"
 
 	"k8s.io/apimachinery/pkg/api/resource"
 )
@@ -38,3 +39,30 @@ func NegateQuantity(in resource.Quantity) resource.Quantity {
 func QuantityEqual(a, b resource.Quantity) bool {
 	return a.Cmp(b) == 0
 }
+
+// DistinctValues returns the sorted values without duplicates.
+func DistinctValues(values []string) []string {
+	if len(values) == 0 {
+		return nil
+	}
+
+	out := slices.Clone(values)
+	slices.Sort(out)
+
+	return slices.Compact(out)
+}
+
+// UnknownValues returns the distinct values which are not part of the sorted known values.
+func UnknownValues(known []string, values []string) []string {
+	var out []string
+
+	for _, value := range DistinctValues(values) {
+		if _, found := slices.BinarySearch(known, value); found {
+			continue
+		}
+
+		out = append(out, value)
+	}
+
+	return out
+}
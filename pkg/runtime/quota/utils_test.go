@@ -331,3 +331,93 @@ func TestNegateQuantityReturnsIndependentCopy(t *testing.T) {
 		t.Fatalf("expected input to remain unchanged after mutating output, got %q", in.String())
 	}
 }
+
+func TestUnknownValues(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		known  []string
+		values []string
+		want   []string
+	}{
+		{
+			name:   "no known values",
+			values: []string{"b", "a", "b"},
+			want:   []string{"a", "b"},
+		},
+		{
+			name:   "known values are skipped",
+			known:  []string{"a", "c"},
+			values: []string{"c", "b", "a"},
+			want:   []string{"b"},
+		},
+		{
+			name:   "all values known",
+			known:  []string{"a"},
+			values: []string{"a", "a"},
+			want:   nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := UnknownValues(tt.known, tt.values)
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Fatalf("UnknownValues() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMaxQuantity(t *testing.T) {
+	t.Parallel()
+
+	got := MaxQuantity([]resource.Quantity{
+		resource.MustParse("500m"),
+		resource.MustParse("2"),
+		resource.MustParse("1"),
+	})
+	if got.Cmp(resource.MustParse("2")) != 0 {
+		t.Fatalf("MaxQuantity() = %s, want 2", got.String())
+	}
+
+	empty := MaxQuantity(nil)
+	if !empty.IsZero() {
+		t.Fatalf("MaxQuantity(nil) = %s, want 0", empty.String())
+	}
+}
+
+func TestPassedThreshold(t *testing.T) {
+	t.Parallel()
+
+	warnAt := resource.MustParse("8")
+	softLimit := resource.MustParse("9")
+
+	tests := []struct {
+		name      string
+		used      string
+		warnAt    *resource.Quantity
+		softLimit *resource.Quantity
+		want      Threshold
+	}{
+		{name: "no thresholds", used: "10", want: ThresholdNone},
+		{name: "below threshold", used: "7", warnAt: &warnAt, softLimit: &softLimit, want: ThresholdNone},
+		{name: "reaches threshold", used: "8", warnAt: &warnAt, softLimit: &softLimit, want: ThresholdWarnAt},
+		{name: "reaches soft limit", used: "9", warnAt: &warnAt, softLimit: &softLimit, want: ThresholdWarnAt},
+		{name: "exceeds soft limit", used: "9500m", warnAt: &warnAt, softLimit: &softLimit, want: ThresholdSoftLimit},
+		{name: "exceeds soft limit without threshold", used: "10", softLimit: &softLimit, want: ThresholdSoftLimit},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := PassedThreshold(resource.MustParse(tt.used), tt.warnAt, tt.softLimit); got != tt.want {
+				t.Fatalf("PassedThreshold() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
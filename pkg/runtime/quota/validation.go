@@ -79,3 +79,21 @@ func ValidateHardLimitScopeChange(
 
 	return nil
 }
+
+// ValidateOperations rejects exclusive operations (max, distinct, perObject) being combined
+// with other operations, as their usage can not be added to the usage of other operations.
+func ValidateOperations(operations []Operation) error {
+	for _, op := range operations {
+		if !op.Exclusive() {
+			continue
+		}
+
+		for _, other := range operations {
+			if other != op {
+				return fmt.Errorf("operation %q cannot be combined with operation %q", op, other)
+			}
+		}
+	}
+
+	return nil
+}
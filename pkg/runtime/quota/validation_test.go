@@ -84,3 +84,51 @@ func TestValidateHardLimitScopeChange(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateOperations(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		operations []Operation
+		wantErr    string
+	}{
+		{
+			name:       "aggregating operations can be combined",
+			operations: []Operation{OpAdd, OpSub, OpCount},
+		},
+		{
+			name:       "exclusive operation with itself",
+			operations: []Operation{OpMax, OpMax},
+		},
+		{
+			name:       "max cannot be combined with add",
+			operations: []Operation{OpAdd, OpMax},
+			wantErr:    `operation "max" cannot be combined with operation "add"`,
+		},
+		{
+			name:       "distinct cannot be combined with perObject",
+			operations: []Operation{OpDistinct, OpPerObject},
+			wantErr:    `operation "distinct" cannot be combined with operation "perObject"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := ValidateOperations(tt.operations)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("ValidateOperations() error = %v", err)
+				}
+
+				return
+			}
+
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("ValidateOperations() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
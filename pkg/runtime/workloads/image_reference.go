@@ -0,0 +1,80 @@
+// Copyright 2020-2026 Project Capsule Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package workloads
+
+import (
+	"strings"
+)
+
+// digestLengths are the lengths of the hex encoded digests of the supported algorithms.
+var digestLengths = map[string]int{
+	"sha256": 64,
+	"sha512": 128,
+}
+
+// ImageReference is an OCI image reference split into its parts.
+type ImageReference struct {
+	// Repository is the reference without tag and digest, including the registry.
+	Repository string
+	// Tag is empty when the reference has no tag.
+	Tag string
+	// Digest is empty when the reference is not pinned by digest.
+	Digest string
+}
+
+// ParseImageReference splits an image reference into repository, tag and digest.
+// The reference is not normalized, an empty tag is not defaulted to latest.
+func ParseImageReference(reference string) ImageReference {
+	reference = strings.TrimSpace(reference)
+
+	var out ImageReference
+
+	if name, digest, ok := strings.Cut(reference, "@"); ok {
+		reference = name
+		out.Digest = digest
+	}
+
+	// A colon after the last slash separates the tag, a colon before it
+	// belongs to the registry port.
+	if i := strings.LastIndex(reference, ":"); i > strings.LastIndex(reference, "/") {
+		out.Tag = reference[i+1:]
+		reference = reference[:i]
+	}
+
+	out.Repository = reference
+
+	return out
+}
+
+// String joins the parts into an image reference.
+func (r ImageReference) String() string {
+	out := r.Repository
+
+	if r.Tag != "" {
+		out += ":" + r.Tag
+	}
+
+	if r.Digest != "" {
+		out += "@" + r.Digest
+	}
+
+	return out
+}
+
+// HasValidDigest reports whether the reference is pinned by a well-formed
+// sha256 or sha512 digest.
+func (r ImageReference) HasValidDigest() bool {
+	algorithm, encoded, ok := strings.Cut(r.Digest, ":")
+	if !ok || len(encoded) != digestLengths[algorithm] {
+		return false
+	}
+
+	for _, c := range encoded {
+		if (c < '0' || c > '9') && (c < 'a' || c > 'f') {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,86 @@
+// Copyright 2020-2026 Project Capsule Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package workloads
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseImageReference(t *testing.T) {
+	t.Parallel()
+
+	digest := "sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+
+	tests := []struct {
+		reference string
+		want      ImageReference
+	}{
+		{
+			reference: "nginx",
+			want:      ImageReference{Repository: "nginx"},
+		},
+		{
+			reference: "docker.io/library/nginx:1.27",
+			want:      ImageReference{Repository: "docker.io/library/nginx", Tag: "1.27"},
+		},
+		{
+			reference: "registry.example.com:5000/team/app",
+			want:      ImageReference{Repository: "registry.example.com:5000/team/app"},
+		},
+		{
+			reference: "registry.example.com:5000/team/app:v1@" + digest,
+			want:      ImageReference{Repository: "registry.example.com:5000/team/app", Tag: "v1", Digest: digest},
+		},
+		{
+			reference: "ghcr.io/org/app@" + digest,
+			want:      ImageReference{Repository: "ghcr.io/org/app", Digest: digest},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.reference, func(t *testing.T) {
+			t.Parallel()
+
+			got := ParseImageReference(tt.reference)
+			if got != tt.want {
+				t.Fatalf("ParseImageReference() = %#v, want %#v", got, tt.want)
+			}
+
+			if got.String() != tt.reference {
+				t.Fatalf("String() = %q, want %q", got.String(), tt.reference)
+			}
+		})
+	}
+}
+
+func TestImageReferenceHasValidDigest(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		digest string
+		want   bool
+	}{
+		{name: "sha256", digest: "sha256:" + strings.Repeat("a", 64), want: true},
+		{name: "sha512", digest: "sha512:" + strings.Repeat("0", 128), want: true},
+		{name: "empty"},
+		{name: "missing algorithm", digest: strings.Repeat("a", 64)},
+		{name: "short sha256", digest: "sha256:abc"},
+		{name: "sha512 length for sha256", digest: "sha256:" + strings.Repeat("a", 128)},
+		{name: "uppercase hex", digest: "sha256:" + strings.Repeat("A", 64)},
+		{name: "non hex", digest: "sha256:" + strings.Repeat("g", 64)},
+		{name: "unsupported algorithm", digest: "md5:" + strings.Repeat("a", 32)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := (ImageReference{Digest: tt.digest}).HasValidDigest(); got != tt.want {
+				t.Fatalf("HasValidDigest() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright 2020-2026 Project Capsule Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tenant
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	"github.com/projectcapsule/capsule/pkg/api/meta"
+	"github.com/projectcapsule/capsule/pkg/api/rules"
+)
+
+// RuleNetworkPolicyKey is the NetworkPolicy label value for a network rule. It
+// cannot collide with the numeric keys of spec.networkPolicies.
+func RuleNetworkPolicyKey(ruleIndex int) string {
+	return "rule-" + strconv.Itoa(ruleIndex)
+}
+
+func RuleNetworkPolicyName(tnt *capsulev1beta2.Tenant, ruleIndex int) string {
+	return fmt.Sprintf("capsule-%s-%s", tnt.Name, RuleNetworkPolicyKey(ruleIndex))
+}
+
+// RuleNetworkPolicySpec renders the network rule body. The boolean is false when
+// the body does not isolate any direction and no NetworkPolicy is required.
+func RuleNetworkPolicySpec(tnt *capsulev1beta2.Tenant, body *rules.NamespaceRuleNetworkBody) (networkingv1.NetworkPolicySpec, bool) {
+	spec := networkingv1.NetworkPolicySpec{}
+
+	if body == nil {
+		return spec, false
+	}
+
+	if body.DefaultDeny || body.IngressFromTenant {
+		spec.PolicyTypes = append(spec.PolicyTypes, networkingv1.PolicyTypeIngress)
+	}
+
+	if body.IngressFromTenant {
+		spec.Ingress = []networkingv1.NetworkPolicyIngressRule{{
+			From: []networkingv1.NetworkPolicyPeer{{
+				NamespaceSelector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{meta.TenantLabel: tnt.Name},
+				},
+			}},
+		}}
+	}
+
+	if body.DefaultDeny || len(body.Egress) > 0 {
+		spec.PolicyTypes = append(spec.PolicyTypes, networkingv1.PolicyTypeEgress)
+	}
+
+	for _, egress := range body.Egress {
+		rule := networkingv1.NetworkPolicyEgressRule{
+			To: []networkingv1.NetworkPolicyPeer{{
+				IPBlock: &networkingv1.IPBlock{
+					CIDR:   egress.CIDR,
+					Except: append([]string(nil), egress.Except...),
+				},
+			}},
+		}
+
+		for _, port := range egress.Ports {
+			port := *port.DeepCopy()
+
+			// The API server defaults the protocol, rendering it avoids reporting
+			// the defaulted field as drift.
+			if port.Protocol == nil {
+				protocol := corev1.ProtocolTCP
+				port.Protocol = &protocol
+			}
+
+			rule.Ports = append(rule.Ports, port)
+		}
+
+		spec.Egress = append(spec.Egress, rule)
+	}
+
+	return spec, len(spec.PolicyTypes) > 0
+}
+
+// NetworkPolicySpecHash identifies a rendered NetworkPolicy spec. A live object
+// carrying the hash of the desired spec but a different spec has been modified
+// outside of Capsule.
+func NetworkPolicySpecHash(spec networkingv1.NetworkPolicySpec) string {
+	data, _ := json.Marshal(spec)
+
+	h := fnv.New64a()
+	_, _ = h.Write(data)
+
+	return fmt.Sprintf("%x", h.Sum64())
+}
@@ -0,0 +1,114 @@
+// Copyright 2020-2026 Project Capsule Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tenant
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	capsulev1beta2 "github.com/projectcapsule/capsule/api/v1beta2"
+	"github.com/projectcapsule/capsule/pkg/api/meta"
+	"github.com/projectcapsule/capsule/pkg/api/rules"
+)
+
+func TestRuleNetworkPolicySpec(t *testing.T) {
+	t.Parallel()
+
+	tnt := &capsulev1beta2.Tenant{ObjectMeta: metav1.ObjectMeta{Name: "tenant-a"}}
+	port := intstr.FromInt32(443)
+
+	tests := []struct {
+		name        string
+		body        *rules.NamespaceRuleNetworkBody
+		wantOK      bool
+		wantTypes   []networkingv1.PolicyType
+		wantIngress int
+		wantEgress  int
+	}{
+		{name: "nil body", body: nil},
+		{name: "empty body", body: &rules.NamespaceRuleNetworkBody{}},
+		{
+			name:      "default deny",
+			body:      &rules.NamespaceRuleNetworkBody{DefaultDeny: true},
+			wantOK:    true,
+			wantTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress, networkingv1.PolicyTypeEgress},
+		},
+		{
+			name:        "ingress from tenant",
+			body:        &rules.NamespaceRuleNetworkBody{IngressFromTenant: true},
+			wantOK:      true,
+			wantTypes:   []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+			wantIngress: 1,
+		},
+		{
+			name: "egress allow-list",
+			body: &rules.NamespaceRuleNetworkBody{Egress: []rules.NetworkEgressRule{
+				{CIDR: "10.0.0.0/8", Except: []string{"10.1.0.0/16"}},
+				{CIDR: "0.0.0.0/0", Ports: []networkingv1.NetworkPolicyPort{{Port: &port}}},
+			}},
+			wantOK:     true,
+			wantTypes:  []networkingv1.PolicyType{networkingv1.PolicyTypeEgress},
+			wantEgress: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			spec, ok := RuleNetworkPolicySpec(tnt, tt.body)
+			if ok != tt.wantOK {
+				t.Fatalf("ok = %v, want %v", ok, tt.wantOK)
+			}
+			if len(spec.PolicyTypes) != len(tt.wantTypes) {
+				t.Fatalf("policy types = %v, want %v", spec.PolicyTypes, tt.wantTypes)
+			}
+			for i := range tt.wantTypes {
+				if spec.PolicyTypes[i] != tt.wantTypes[i] {
+					t.Fatalf("policy types = %v, want %v", spec.PolicyTypes, tt.wantTypes)
+				}
+			}
+			if len(spec.Ingress) != tt.wantIngress || len(spec.Egress) != tt.wantEgress {
+				t.Fatalf("ingress = %d egress = %d, want %d and %d", len(spec.Ingress), len(spec.Egress), tt.wantIngress, tt.wantEgress)
+			}
+		})
+	}
+}
+
+func TestRuleNetworkPolicySpecDetails(t *testing.T) {
+	t.Parallel()
+
+	tnt := &capsulev1beta2.Tenant{ObjectMeta: metav1.ObjectMeta{Name: "tenant-a"}}
+	port := intstr.FromInt32(443)
+
+	spec, _ := RuleNetworkPolicySpec(tnt, &rules.NamespaceRuleNetworkBody{
+		IngressFromTenant: true,
+		Egress: []rules.NetworkEgressRule{{
+			CIDR:  "0.0.0.0/0",
+			Ports: []networkingv1.NetworkPolicyPort{{Port: &port}},
+		}},
+	})
+
+	selector := spec.Ingress[0].From[0].NamespaceSelector
+	if selector == nil || selector.MatchLabels[meta.TenantLabel] != tnt.Name {
+		t.Fatalf("ingress namespace selector = %#v, want tenant label", selector)
+	}
+
+	protocol := spec.Egress[0].Ports[0].Protocol
+	if protocol == nil || *protocol != corev1.ProtocolTCP {
+		t.Fatalf("egress port protocol = %v, want TCP default", protocol)
+	}
+
+	if RuleNetworkPolicyName(tnt, 2) != "capsule-tenant-a-rule-2" {
+		t.Fatalf("name = %q", RuleNetworkPolicyName(tnt, 2))
+	}
+
+	if NetworkPolicySpecHash(spec) != NetworkPolicySpecHash(*spec.DeepCopy()) {
+		t.Fatal("hash is not deterministic")
+	}
+}